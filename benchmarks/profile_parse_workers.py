@@ -0,0 +1,127 @@
+"""Benchmark: cold index_folder time, sequential vs. concurrent parsing.
+
+Generates a synthetic mixed Go / Python / TypeScript tree (or indexes an
+existing folder) and times a full, non-incremental index at each
+``parse_workers`` setting.  The parse cache is cleared before every run so
+each one is a genuine cold parse.
+
+Usage:
+    python benchmarks/profile_parse_workers.py [--files N] [--iterations N]
+        [--workers 1,4,0] [--folder PATH]
+
+``--workers 0`` means one worker per CPU; ``1`` is the default setting.
+"""
+
+import argparse
+import os
+import shutil
+import statistics
+import sys
+import tempfile
+import time
+from pathlib import Path
+
+PROJECT_ROOT = str(Path(__file__).resolve().parent.parent)
+sys.path.insert(0, os.path.join(PROJECT_ROOT, "src"))
+
+
+def _create_tree(base_dir: str, n_files: int) -> str:
+    """Write ``n_files`` moderately sized source files across a few packages."""
+    root = Path(base_dir) / "parse_bench_project"
+    for i in range(n_files):
+        pkg = root / f"pkg{i % 16}"
+        pkg.mkdir(parents=True, exist_ok=True)
+        kind = i % 3
+        if kind == 0:
+            body = "".join(
+                f"// Handler{j} handles request {j}.\n"
+                f"func (s *Service{i}) Handler{j}(ctx context.Context, n int) (int, error) {{\n"
+                f"\tif n > {j} {{\n\t\treturn n * {j}, nil\n\t}}\n"
+                f"\tfor k := 0; k < n; k++ {{\n\t\tn += k\n\t}}\n\treturn n, nil\n}}\n\n"
+                for j in range(25)
+            )
+            (pkg / f"service{i}.go").write_text(
+                f"package pkg{i % 16}\n\nimport \"context\"\n\n"
+                f"type Service{i} struct {{\n\tname string\n}}\n\n" + body,
+                encoding="utf-8",
+            )
+        elif kind == 1:
+            body = "".join(
+                f"    def method_{j}(self, value: int) -> int:\n"
+                f"        \"\"\"Return value scaled by {j}.\"\"\"\n"
+                f"        if value > {j}:\n            return value * {j}\n"
+                f"        return sum(range(value))\n\n"
+                for j in range(25)
+            )
+            (pkg / f"module_{i}.py").write_text(
+                f"import os\n\n\nclass Model{i}:\n" + body, encoding="utf-8",
+            )
+        else:
+            body = "".join(
+                f"  handle{j}(input: number): number {{\n"
+                f"    if (input > {j}) {{ return input * {j}; }}\n"
+                f"    return [...Array(input).keys()].reduce((a, b) => a + b, 0);\n  }}\n\n"
+                for j in range(25)
+            )
+            (pkg / f"widget{i}.ts").write_text(
+                f"export class Widget{i} {{\n" + body + "}\n", encoding="utf-8",
+            )
+    return str(root)
+
+
+def _run_once(folder: str, workers: int, storage: str) -> tuple[float, int]:
+    from jcodemunch_mcp import config as config_module
+    from jcodemunch_mcp.parser import parse_cache
+    from jcodemunch_mcp.tools.index_folder import index_folder
+
+    config_module._GLOBAL_CONFIG["parse_workers"] = workers
+    config_module._GLOBAL_CONFIG["max_folder_files"] = 100_000
+    parse_cache.clear()
+    shutil.rmtree(storage, ignore_errors=True)
+    t0 = time.perf_counter()
+    result = index_folder(
+        folder, use_ai_summaries=False, storage_path=storage,
+        incremental=False, context_providers=False,
+    )
+    elapsed = time.perf_counter() - t0
+    if not result.get("success"):
+        raise SystemExit(f"index_folder failed: {result.get('error')}")
+    return elapsed, result.get("symbol_count", 0)
+
+
+def main() -> None:
+    parser = argparse.ArgumentParser(description=__doc__.splitlines()[0])
+    parser.add_argument("--files", type=int, default=600)
+    parser.add_argument("--iterations", type=int, default=3)
+    parser.add_argument("--workers", default="1,4,0")
+    parser.add_argument("--folder", default=None, help="Index this folder instead of a synthetic tree")
+    args = parser.parse_args()
+
+    from jcodemunch_mcp.parser.parse_pool import resolve_workers
+
+    tmpdir = tempfile.mkdtemp(prefix="jcm_parsebench_")
+    try:
+        folder = args.folder or _create_tree(tmpdir, args.files)
+        storage = os.path.join(tmpdir, "store")
+        print(f"Folder: {folder}")
+        print(f"CPUs: {os.cpu_count()}  iterations: {args.iterations}\n")
+        print(f"{'workers':>8} {'median_s':>10} {'min_s':>8} {'speedup':>8} {'symbols':>8}")
+
+        baseline = None
+        for raw in args.workers.split(","):
+            workers = int(raw)
+            timings = []
+            symbols = 0
+            for _ in range(args.iterations):
+                elapsed, symbols = _run_once(folder, workers, storage)
+                timings.append(elapsed)
+            median = statistics.median(timings)
+            baseline = baseline or median
+            label = f"{resolve_workers(workers)}" + (" (auto)" if workers == 0 else "")
+            print(f"{label:>8} {median:>10.2f} {min(timings):>8.2f} {baseline / median:>7.2f}x {symbols:>8}")
+    finally:
+        shutil.rmtree(tmpdir, ignore_errors=True)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,197 @@
+"""Withhold the bodies of unexported functions and methods.
+
+With ``withhold_unexported_bodies`` on, unexported functions and methods
+still appear in outlines, search results and the call graph, but no tool
+returns their implementation: the body is replaced by a single marker line
+and only the signature (plus a Python docstring) is kept.  The policy is
+applied where source text leaves the store (``IndexStore.get_file_content``
+/ ``get_symbol_content``), so every tool built on those reads inherits it;
+the few tools that read the content cache directly call ``withhold_file``.
+Analysis that needs the real bodies (call graph, dead code, complexity)
+reads with ``raw=True`` and returns no source text.
+
+Visibility comes from ``parser.visibility``, the same rule ``get_file_outline``
+reports as ``exported``.  Symbols in languages without a rule are never
+withheld.  Line numbers are preserved in file views: hidden lines become
+blank, so search and outline line numbers still point at the right place.
+
+Controlled by the ``withhold_unexported_bodies`` config key (default:
+``false``) or the ``JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES`` env var.
+"""
+
+from __future__ import annotations
+
+import os
+import re
+from typing import Optional
+
+BODY_WITHHELD = "body withheld by policy"
+
+_BODY_KINDS = ("function", "method")
+_PY_DOC_OPEN_RE = re.compile(r"""^[rRbBuUfF]{0,2}("\"\"|''')""")
+
+
+def is_enabled() -> bool:
+    """Check whether unexported bodies are withheld.
+
+    Controlled by config key ``withhold_unexported_bodies`` (default: False)
+    or env var ``JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES``.
+    """
+    env = os.environ.get("JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES")
+    if env is not None:
+        return env.lower() in ("1", "true", "yes", "on")
+    try:
+        from . import config as _cfg
+        val = _cfg.get("withhold_unexported_bodies", False)
+        if isinstance(val, str):
+            return val.lower() in ("1", "true", "yes", "on")
+        return bool(val)
+    except Exception:
+        return False
+
+
+def withheld_ids(index, file_path: str, content: str) -> frozenset[str]:
+    """IDs of the unexported functions/methods in *file_path* (cached per index).
+
+    *content* is the file's raw text; Python ``__all__`` and JS/TS export
+    lists are read from it.
+    """
+    cached = index._withheld_ids.get(file_path)
+    if cached is not None:
+        return cached
+    ids: frozenset[str] = frozenset()
+    symbols = index.get_file_symbols(file_path)
+    if symbols:
+        from .parser import build_symbol_tree
+        from .parser.visibility import js_exports, python_all_names
+        from .tools.get_file_outline import _dict_to_symbol, _flatten_tree_with_parents
+
+        language = index.file_languages.get(file_path, "") or symbols[0].get("language", "")
+        module_all = python_all_names(content) if language == "python" else None
+        module_exports = js_exports(content) if language in ("javascript", "typescript", "tsx") else None
+        flat = _flatten_tree_with_parents(
+            build_symbol_tree([_dict_to_symbol(s) for s in symbols]),
+            language=language, module_all=module_all, module_exports=module_exports,
+        )
+        ids = frozenset(d["id"] for d in flat if d.get("exported") is False and d["kind"] in _BODY_KINDS)
+    index._withheld_ids[file_path] = ids
+    return ids
+
+
+def _body_span(lines: list[str], language: str) -> Optional[tuple[int, int]]:
+    """``(start, end)`` indexes of the body lines to hide, or None for a one-liner.
+
+    The header runs to the first line ending in the block opener (``:`` for
+    Python, ``{`` elsewhere).  A leading Python docstring and a closing
+    ``}`` line stay visible.
+    """
+    opener = ":" if language == "python" else "{"
+    head = next((i for i, line in enumerate(lines) if line.rstrip().endswith(opener)), None)
+    if head is None or head == len(lines) - 1:
+        return None
+    start, end = head + 1, len(lines)
+    if language != "python":
+        if lines[-1].strip().startswith("}"):
+            end -= 1
+    else:
+        doc = _PY_DOC_OPEN_RE.match(lines[start].strip())
+        if doc:
+            quote = doc.group(1)
+            first = lines[start].strip()[doc.end():]
+            close = start if quote in first else next(
+                (i for i in range(start + 1, end) if quote in lines[i]), end - 1,
+            )
+            start = close + 1
+    return (start, end) if start < end else (end, end)
+
+
+def _marker(language: str, indent: str, hidden: int) -> str:
+    note = f"{BODY_WITHHELD} ({hidden} line{'s' if hidden != 1 else ''})"
+    # ``...`` keeps a Python body syntactically valid.
+    return f"{indent}...  # {note}" if language == "python" else f"{indent}// {note}"
+
+
+def _withhold_lines(lines: list[str], symbol: dict) -> list[Optional[str]]:
+    """*lines* of one symbol with its body replaced; None marks a hidden line."""
+    language = symbol.get("language", "")
+    span = _body_span(lines, language)
+    if span is None:
+        indent = lines[0][: len(lines[0]) - len(lines[0].lstrip())] if lines else ""
+        header = indent + symbol.get("signature", "").split("\n")[0].rstrip().rstrip(":{").rstrip()
+        joiner = ": " if language == "python" else " "
+        return [header + joiner + _marker(language, "", 1)] + [None] * (len(lines) - 1)
+    start, end = span
+    if start == end:
+        return list(lines)
+    body = next((line for line in lines[start:end] if line.strip()), lines[start])
+    indent = body[: len(body) - len(body.lstrip())]
+    out: list[Optional[str]] = list(lines)
+    out[start] = _marker(language, indent, end - start)
+    for i in range(start + 1, end):
+        out[i] = None
+    return out
+
+
+def withhold_source(source: str, symbol: dict) -> str:
+    """One symbol's source with its body replaced by the marker line."""
+    return "\n".join(line for line in _withhold_lines(source.split("\n"), symbol) if line is not None)
+
+
+def withhold_file(content: str, index, file_path: str) -> str:
+    """*content* with every withheld body blanked out; line count is unchanged."""
+    ids = withheld_ids(index, file_path, content)
+    if not ids:
+        return content
+    lines = content.split("\n")
+    covered = 0
+    for sym in sorted((index.get_symbol(i) for i in ids), key=lambda s: s["line"]):
+        first, last = sym["line"] - 1, sym["end_line"]
+        if first < covered or last > len(lines):
+            continue  # nested in a body already hidden, or stale offsets
+        for i, new in enumerate(_withhold_lines(lines[first:last], sym), start=first):
+            cr = "\r" if lines[i].endswith("\r") else ""
+            lines[i] = (new.rstrip("\r") + cr) if new is not None else cr
+        covered = last
+    return "\n".join(lines)
+
+
+def withheld_lines(index, file_path: str, content: str) -> set[int]:
+    """1-based line numbers of *file_path* hidden by the policy."""
+    before = content.split("\n")
+    after = withhold_file(content, index, file_path).split("\n")
+    return {i + 1 for i, (a, b) in enumerate(zip(before, after)) if a != b}
+
+
+def mask_snippets(snippets: list[dict], index, file_path: str, content: str, key: str = "text") -> None:
+    """Replace ``snippet[key]`` with the marker for snippets touching hidden lines.
+
+    For analysis that scans raw content (so relationships inside withheld
+    bodies still count) but returns matching text.  A snippet covers
+    ``line`` through ``end_line`` (when present).  No-op when the policy
+    is off.
+    """
+    if not snippets or not is_enabled():
+        return
+    hidden = withheld_lines(index, file_path, content)
+    if not hidden:
+        return
+    for snippet in snippets:
+        first = snippet.get("line") or 0
+        last = snippet.get("end_line") or first
+        if any(n in hidden for n in range(first, last + 1)):
+            snippet[key] = BODY_WITHHELD
+
+
+def blank_hidden_lines(lines: list[str], index, file_path: str, keepends: bool = False) -> list[str]:
+    """*lines* of a file with the hidden ones emptied, for text search.
+
+    Search then neither matches nor quotes withheld code.  *keepends* says
+    the lines carry their ``\\n`` (``readlines()``), which is kept.
+    """
+    hidden = withheld_lines(index, file_path, ("" if keepends else "\n").join(lines))
+    if not hidden:
+        return lines
+    return [
+        ("\n" if keepends and line.endswith("\n") else "") if i in hidden else line
+        for i, line in enumerate(lines, start=1)
+    ]
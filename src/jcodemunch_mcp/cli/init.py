@@ -10,6 +10,8 @@ import sys
 from pathlib import Path
 from typing import Any, Optional
 
+from ..config import default_config_dir
+
 # ---------------------------------------------------------------------------
 # Constants
 # ---------------------------------------------------------------------------
@@ -689,7 +691,7 @@ def install_hooks(*, dry_run: bool = False, backup: bool = True) -> str:
 
 def _install_version_path() -> Path:
     """Path to the file recording the jcodemunch-mcp version that last ran ``init``."""
-    base = Path(os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index")))
+    base = default_config_dir()
     return base / "last_init_version.txt"
 
 
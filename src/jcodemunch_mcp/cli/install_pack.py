@@ -11,6 +11,8 @@ from typing import Optional
 
 import httpx
 
+from ..config import default_storage_path
+
 STARTER_PACK_API = "https://j.gravelle.us/jCodeMunch/starter-packs-system/api/index.php"
 
 # ANSI helpers
@@ -35,7 +37,7 @@ except (UnicodeEncodeError, LookupError):
 
 def _storage_path() -> Path:
     """Return the global index directory."""
-    return Path(os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index")))
+    return default_storage_path()
 
 
 def _mask_license(key: str) -> str:
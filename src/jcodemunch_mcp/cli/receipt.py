@@ -34,30 +34,40 @@ _TOOL_MULTIPLIERS: dict[str, float] = {
     # Pure retrieval — narrow query against indexed corpus.
     "search_symbols": 20.0,
     "search_text": 12.0,
+    "search_content": 12.0,
+    "list_todos": 8.0,
     "search_columns": 15.0,
     "search_ast": 18.0,
     "get_ranked_context": 18.0,
     "winnow_symbols": 15.0,
     # Targeted symbol/file fetch — surgical vs whole-file Read.
     "get_symbol_source": 8.0,
+    "summarize_symbol": 8.0,
     "get_context_bundle": 10.0,
     "get_file_outline": 6.0,
     "get_file_content": 2.0,  # nearly 1:1, only saves on filtering
+    "read_file_range": 2.0,
     # Repo structure / orientation.
     "get_repo_outline": 8.0,
+    "project_stats": 8.0,
     "get_file_tree": 4.0,
+    "describe_package": 10.0,
     "get_project_intel": 12.0,
     "get_session_context": 6.0,
     "get_session_snapshot": 6.0,
     # Graph queries — the structurally hardest things to do with grep.
     "find_importers": 25.0,
     "find_references": 25.0,
+    "locate_definition": 12.0,
     "check_references": 15.0,
     "get_call_hierarchy": 30.0,
+    "get_call_graph": 30.0,
     "get_dependency_graph": 25.0,
+    "get_dependencies": 20.0,
     "get_dependency_cycles": 25.0,
     "get_blast_radius": 35.0,
     "get_class_hierarchy": 20.0,
+    "get_type_hierarchy": 20.0,
     "get_layer_violations": 20.0,
     "get_extraction_candidates": 25.0,
     "get_signal_chains": 30.0,
@@ -69,8 +79,13 @@ _TOOL_MULTIPLIERS: dict[str, float] = {
     "get_repo_health": 35.0,
     "get_hotspots": 25.0,
     "get_symbol_complexity": 12.0,
+    "get_complexity": 12.0,
+    "get_parse_errors": 10.0,
+    "format_check": 5.0,
+    "get_test_coverage_map": 10.0,
     "get_churn_rate": 6.0,
     "get_symbol_provenance": 15.0,
+    "git_blame": 8.0,
     "get_untested_symbols": 30.0,
     "find_dead_code": 35.0,
     "get_dead_code_v2": 35.0,
@@ -80,12 +95,14 @@ _TOOL_MULTIPLIERS: dict[str, float] = {
     # Refactoring / maintenance.
     "plan_refactoring": 25.0,
     "check_rename_safe": 15.0,
+    "rename_preview": 15.0,
     "get_symbol_diff": 12.0,
     "get_changed_symbols": 12.0,
     "audit_agent_config": 8.0,
     # Indexing / repo management.
     "resolve_repo": 3.0,
     "list_repos": 2.0,
+    "server_info": 1.0,
     "index_folder": 2.0,
     "index_repo": 2.0,
     "index_file": 2.0,
@@ -25,14 +25,18 @@ ENV_VAR_MAPPING = {
     "JCODEMUNCH_TRUSTED_FOLDERS_WHITELIST_MODE": "trusted_folders_whitelist_mode",
     "JCODEMUNCH_MAX_FOLDER_FILES": "max_folder_files",
     "JCODEMUNCH_MAX_INDEX_FILES": "max_index_files",
+    "JCODEMUNCH_MAX_FILE_SIZE": "max_file_size",
     "JCODEMUNCH_STALENESS_DAYS": "staleness_days",
     "JCODEMUNCH_MAX_RESULTS": "max_results",
     "JCODEMUNCH_FILE_TREE_MAX_FILES": "file_tree_max_files",
     "JCODEMUNCH_GITIGNORE_WARN_THRESHOLD": "gitignore_warn_threshold",
     "JCODEMUNCH_EXTRA_IGNORE_PATTERNS": "extra_ignore_patterns",
+    "JCODEMUNCH_EXCLUDE_GENERATED": "exclude_generated",
+    "JCODEMUNCH_FOLLOW_SYMLINKS": "follow_symlinks",
     "JCODEMUNCH_EXTRA_EXTENSIONS": "extra_extensions",
     "JCODEMUNCH_CONTEXT_PROVIDERS": "context_providers",
     "JCODEMUNCH_REDACT_SOURCE_ROOT": "redact_source_root",
+    "JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES": "withhold_unexported_bodies",
     "JCODEMUNCH_GIT_ROOT_IDENTITY": "git_root_identity",
     "JCODEMUNCH_GIT_BLAME_ENABLED": "git_blame_enabled",
     "JCODEMUNCH_STATS_FILE_INTERVAL": "stats_file_interval",
@@ -44,6 +48,9 @@ ENV_VAR_MAPPING = {
     "JCODEMUNCH_RUNTIME_INGEST_ENABLED": "runtime_ingest_enabled",
     "JCODEMUNCH_RUNTIME_INGEST_MAX_BODY_BYTES": "runtime_ingest_max_body_bytes",
     "JCODEMUNCH_SUMMARIZER_CONCURRENCY": "summarizer_concurrency",
+    "JCODEMUNCH_PARSE_CACHE_MAX_BYTES": "parse_cache_max_bytes",
+    "JCODEMUNCH_PARSE_WORKERS": "parse_workers",
+    "JCODEMUNCH_TOOL_TIMEOUT_SECONDS": "tool_timeout_seconds",
     "JCODEMUNCH_SUMMARIZER_MAX_FAILURES": "summarizer_max_failures",
     "JCODEMUNCH_ALLOW_REMOTE_SUMMARIZER": "allow_remote_summarizer",
     "JCODEMUNCH_RATE_LIMIT": "rate_limit",
@@ -52,6 +59,7 @@ ENV_VAR_MAPPING = {
     "JCODEMUNCH_PORT": "port",
     "JCODEMUNCH_WATCH": "watch",
     "JCODEMUNCH_WATCH_DEBOUNCE_MS": "watch_debounce_ms",
+    "JCODEMUNCH_WATCH_NOTIFICATIONS": "watch_notifications",
     "JCODEMUNCH_WATCH_EXTRA_IGNORE": "watch_extra_ignore",
     "JCODEMUNCH_WATCH_FOLLOW_SYMLINKS": "watch_follow_symlinks",
     "JCODEMUNCH_WATCH_IDLE_TIMEOUT": "watch_idle_timeout",
@@ -87,15 +95,66 @@ def _normalize_server_output(value: str) -> str | None:
     return _SERVER_OUTPUT_ALIASES.get(value.strip().lower())
 
 
+# What an existing install left in ~/.code-index, per role.  Only real
+# content counts: other helpers create the bare directory (and stamps such as
+# ``last_seen_version``) on first run, and that must not flip the answer.
+_LEGACY_MARKERS = {
+    "XDG_CACHE_HOME": ("*.db",),
+    "XDG_CONFIG_HOME": ("config.jsonc", "tuning.jsonc", "_session_state.json"),
+}
+
+
+def _has_legacy_content(legacy: Path, xdg_var: str) -> bool:
+    try:
+        return any(next(legacy.glob(pattern), None) is not None for pattern in _LEGACY_MARKERS[xdg_var])
+    except OSError:
+        return False
+
+
+def _xdg_or_legacy(xdg_var: str) -> Path:
+    """``CODE_INDEX_PATH``, else ``~/.code-index`` if it holds this role's files, else ``$<xdg_var>/jcodemunch-mcp``."""
+    env = os.environ.get("CODE_INDEX_PATH")
+    if env:
+        return Path(env)
+    legacy = Path.home() / ".code-index"
+    xdg = os.environ.get(xdg_var, "").strip()
+    # The XDG spec says relative values are invalid and must be ignored.
+    if xdg and os.path.isabs(xdg) and not _has_legacy_content(legacy, xdg_var):
+        return Path(xdg) / "jcodemunch-mcp"
+    return legacy
+
+
+def default_storage_path() -> Path:
+    """Return the directory holding indexes and other rebuildable cache data.
+
+    ``CODE_INDEX_PATH`` always wins.  Otherwise ``~/.code-index`` is used if
+    it already holds ``*.db`` indexes (upgrades keep finding them) or if
+    ``XDG_CACHE_HOME`` is unset; otherwise an absolute ``XDG_CACHE_HOME``
+    stores under ``$XDG_CACHE_HOME/jcodemunch-mcp``.
+    """
+    return _xdg_or_legacy("XDG_CACHE_HOME")
+
+
+def default_config_dir() -> Path:
+    """Return the directory holding config.jsonc and other user state.
+
+    Same precedence as :func:`default_storage_path`, but the legacy
+    directory wins only if it holds ``config.jsonc``, ``tuning.jsonc`` or
+    session state, and otherwise ``$XDG_CONFIG_HOME/jcodemunch-mcp`` is
+    used: configuration, tuning, session state, and install stamps must not
+    live in a cache directory the user may wipe.
+    """
+    return _xdg_or_legacy("XDG_CONFIG_HOME")
+
+
 def _global_config_path() -> Path:
     """Return the path to the global config.jsonc."""
-    storage = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
-    return Path(storage) / "config.jsonc"
+    return default_config_dir() / "config.jsonc"
 
 
 def _global_storage_path() -> Path:
     """Return the global storage directory path."""
-    return Path(os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index")))
+    return default_storage_path()
 
 
 _LANG_BLOCK_RE = re.compile(
@@ -281,11 +340,18 @@ DEFAULTS = {
     "trusted_folders_whitelist_mode": True,
     "max_folder_files": 2000,
     "max_index_files": 10000,
+    "max_file_size": 512000,
     "staleness_days": 7,
     "max_results": 500,
     "file_tree_max_files": 500,
+    "parse_cache_max_entries": 4096,
+    "parse_cache_max_bytes": 268435456,
+    "parse_workers": 1,
+    "tool_timeout_seconds": 300,
     "gitignore_warn_threshold": 500,
     "extra_ignore_patterns": [],
+    "exclude_generated": False,
+    "follow_symlinks": True,
     "exclude_secret_patterns": [],
     "exclude_skip_directories": [],
     "extra_extensions": {},
@@ -313,8 +379,8 @@ DEFAULTS = {
             "search_text", "get_context_bundle", "get_ranked_context",
             "assemble_task_context",
             "find_importers", "find_references",
-            "summarize_repo", "embed_repo", "suggest_queries",
-            "search_columns", "check_references",
+            "summarize_repo", "embed_repo", "server_info", "suggest_queries", "summarize_symbol",
+            "search_columns", "project_stats", "check_references",
             "get_dependency_graph", "get_class_hierarchy",
             "get_related_symbols", "get_call_hierarchy",
             "get_blast_radius", "check_rename_safe", "check_delete_safe",
@@ -364,6 +430,7 @@ DEFAULTS = {
     "rate_limit": 0,
     "watch": False,
     "watch_debounce_ms": 2000,
+    "watch_notifications": True,
     "watch_extra_ignore": [],
     "watch_follow_symlinks": False,
     "watch_idle_timeout": None,
@@ -379,6 +446,7 @@ DEFAULTS = {
     "log_level": "WARNING",
     "log_file": None,
     "redact_source_root": False,
+    "withhold_unexported_bodies": False,
     "git_root_identity": True,
     "git_blame_enabled": True,
     "stats_file_interval": 3,
@@ -421,11 +489,18 @@ CONFIG_TYPES = {
     "trusted_folders_whitelist_mode": bool,
     "max_folder_files": int,
     "max_index_files": int,
+    "max_file_size": int,
     "staleness_days": int,
     "max_results": int,
     "file_tree_max_files": int,
+    "parse_cache_max_entries": int,
+    "parse_cache_max_bytes": int,
+    "parse_workers": int,
+    "tool_timeout_seconds": int,
     "gitignore_warn_threshold": int,
     "extra_ignore_patterns": list,
+    "exclude_generated": bool,
+    "follow_symlinks": bool,
     "exclude_secret_patterns": list,
     "exclude_skip_directories": list,
     "extra_extensions": dict,
@@ -449,6 +524,7 @@ CONFIG_TYPES = {
     "rate_limit": int,
     "watch": bool,
     "watch_debounce_ms": int,
+    "watch_notifications": bool,
     "watch_extra_ignore": list,
     "watch_follow_symlinks": bool,
     "watch_idle_timeout": (int, type(None)),
@@ -464,6 +540,7 @@ CONFIG_TYPES = {
     "log_level": str,
     "log_file": (str, type(None)),
     "redact_source_root": bool,
+    "withhold_unexported_bodies": bool,
     "git_root_identity": bool,
     "git_blame_enabled": bool,
     "stats_file_interval": int,
@@ -968,7 +1045,7 @@ def _list_repos_for_config() -> list[dict]:
     Deferred import to avoid circular dependency at module load time.
     """
     from .storage.index_store import IndexStore
-    storage_path = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
+    storage_path = str(default_storage_path())
     store = IndexStore(base_path=storage_path)
     return store.list_repos()
 
@@ -1252,10 +1329,26 @@ def generate_template() -> str:
         "announce_model",
         "audit_agent_config",
         "check_embedding_drift",
+        "format_check",
+        "get_complexity",
+        "get_dependencies",
+        "get_parse_errors",
+        "get_test_coverage_map",
+        "get_type_hierarchy",
+        "git_blame",
+        "list_todos",
+        "locate_definition",
+        "project_stats",
+        "read_file_range",
+        "rename_preview",
+        "search_content",
+        "server_info",
+        "summarize_symbol",
         "tune_weights",
         "check_delete_safe",
         "check_references",
         "check_rename_safe",
+        "describe_package",
         "diff_health_radar",
         "digest",
         "embed_repo",
@@ -1266,6 +1359,7 @@ def generate_template() -> str:
         "find_references",
         "find_unused_paths",
         "get_blast_radius",
+        "get_call_graph",
         "get_call_hierarchy",
         "get_changed_symbols",
         "get_churn_rate",
@@ -1381,6 +1475,12 @@ def generate_template() -> str:
   //   Maximum number of files to index when indexing a GitHub repo.
   //   Separate cap from max_folder_files for different use cases.
 
+  // "max_file_size": 512000,
+  //   Largest file (in bytes) that is indexed; bigger files are skipped and
+  //   listed in the index warnings. Catches minified bundles and huge
+  //   single-file vendored libraries. Files containing NUL bytes are always
+  //   skipped as binary, regardless of size.
+
   // "staleness_days": 7,
   //   Days before an index is considered stale (warning only, no blocking).
 
@@ -1393,10 +1493,51 @@ def generate_template() -> str:
   //   includes a hint to use path_prefix when this cap is hit.
   //   Can also be overridden per-call via the max_files tool parameter.
 
+  // "parse_cache_max_entries": 4096,
+  //   In-memory cache of parse results keyed by file path + content hash.
+  //   Re-indexing unchanged content (full rebuilds, index_file, sibling
+  //   worktrees) skips tree-sitter. Least-recently-used entries are
+  //   evicted past this count. Set 0 to disable.
+
+  // "parse_cache_max_bytes": 268435456,
+  //   Memory budget for the parse cache (estimated bytes, default 256 MiB).
+  //   Entries are evicted least-recently-used to stay under it, whichever
+  //   of the two caps is hit first. Set 0 to disable the cache.
+  //   server_info reports the cache's size and hit/miss/eviction counters.
+
+  // "parse_workers": 1,
+  //   Threads used to parse files while indexing. 1 = sequential (default);
+  //   0 = one per CPU (max 32). Symbol extraction holds the GIL, so measure
+  //   with benchmarks/profile_parse_workers.py before raising it. Files are
+  //   still read and merged in order on the calling thread, so the resulting
+  //   index is identical either way.
+
+  // "tool_timeout_seconds": 300,
+  //   Time limit for one tool call. Walking and parsing stop at the limit and
+  //   the tool returns what it finished with "timed_out": true. A call that
+  //   overruns by more than a few seconds gets a timed-out error instead.
+  //   Override per call with the "timeout_seconds" argument. 0 = no limit.
+  //   The indexing tools (index_repo, index_folder, index_file,
+  //   summarize_repo, embed_repo) ignore this key and only stop early when
+  //   called with "timeout_seconds"; index_folder then saves the files
+  //   parsed so far and a re-run continues.
+
   // "extra_ignore_patterns": [],
   //   Additional gitignore-style patterns to exclude from indexing.
   //   Merged with JCODEMUNCH_EXTRA_IGNORE_PATTERNS env var.
 
+  // "exclude_generated": false,
+  //   Skip files whose header carries a "Code generated ... DO NOT EDIT."
+  //   marker (protoc .pb.go, mockgen, stringer, sqlc, ...). Counted under
+  //   discovery_skip_counts.generated. index_folder's exclude_generated
+  //   argument overrides this per call.
+
+  // "follow_symlinks": true,
+  //   Follow symlinked files and directories during discovery. Each real
+  //   directory is walked once, so symlink cycles cannot loop; symlinks
+  //   that resolve outside the indexed root are skipped and reported.
+  //   index_folder's follow_symlinks argument overrides this per call.
+
   // "exclude_secret_patterns": [],
   //   Glob patterns to exclude from *secret* detection.
   //   Use when *secret* has false positives on specific paths.
@@ -1536,8 +1677,8 @@ def generate_template() -> str:
       "search_text", "get_context_bundle", "get_ranked_context",
       "assemble_task_context",
       "find_importers", "find_references",
-      "summarize_repo", "embed_repo", "suggest_queries",
-      "search_columns", "check_references",
+      "summarize_repo", "embed_repo", "server_info", "suggest_queries", "summarize_symbol",
+      "search_columns", "project_stats", "check_references",
       "get_dependency_graph", "get_class_hierarchy",
       "get_related_symbols", "get_call_hierarchy",
       "get_blast_radius", "check_rename_safe", "check_delete_safe",
@@ -1627,6 +1768,10 @@ def generate_template() -> str:
   // "watch_debounce_ms": 2000,
   //   Milliseconds to wait after a file change before reindexing.
   //   Higher values reduce CPU usage but slower detection.
+  // "watch_notifications": true,
+  //   With "serve --watcher", notify connected clients (MCP log message,
+  //   logger "jcodemunch.watch", plus resources/updated and
+  //   resources/list_changed) each time a re-index changes the index.
   // "freshness_mode": "relaxed",
   //   relaxed - Default. Index remains queryable during reindex.
   //             Best for interactive use (IDE, chat).
@@ -1675,6 +1820,11 @@ def generate_template() -> str:
   // "redact_source_root": false,
   //   Replace absolute source_root paths with display_name in responses.
   //   Set true to hide project paths from clients.
+  // "withhold_unexported_bodies": false,
+  //   Hide the implementation of unexported functions and methods. They
+  //   still appear in outlines, search and the call graph, but every tool
+  //   that returns source shows their signature and doc only, with a
+  //   "body withheld" marker in place of the body. Read at startup.
   // "stats_file_interval": 3,
   //   Write session_stats.json every N tool calls. 0 = disable writes.
   //   Lower values = more disk I/O but faster stats for external consumers.
@@ -0,0 +1,64 @@
+"""Per-call deadlines for tool calls.
+
+``call_tool`` opens a :func:`deadline_scope` around every call
+(``tool_timeout_seconds`` config key, overridable per call with the
+cross-cutting ``timeout_seconds`` argument).  The active deadline lives in a
+context variable, so it follows the call into ``asyncio.to_thread`` workers
+without being threaded through every tool signature.
+
+Long-running code reads it once at its entry point with
+:func:`current_deadline` and passes it on explicitly, then checks
+:meth:`Deadline.expired` between units of work (directories walked, files
+parsed).  Once it has passed, work stops and the tool returns what it
+finished, flagged ``timed_out``.  Code that never checks is bounded by the
+hard stop in ``call_tool`` instead, which answers with an error while the
+abandoned worker thread runs to completion in the background.
+"""
+
+from __future__ import annotations
+
+import time
+from contextlib import contextmanager
+from contextvars import ContextVar
+from typing import Iterator, Optional
+
+
+class Deadline:
+    """A point in time (monotonic clock) after which work should stop."""
+
+    __slots__ = ("seconds", "_expires_at")
+
+    def __init__(self, seconds: float) -> None:
+        self.seconds = float(seconds)
+        self._expires_at = time.monotonic() + self.seconds
+
+    def remaining(self) -> float:
+        """Seconds left, never negative."""
+        return max(0.0, self._expires_at - time.monotonic())
+
+    def expired(self) -> bool:
+        return time.monotonic() >= self._expires_at
+
+
+_current: ContextVar[Optional[Deadline]] = ContextVar("jcodemunch_deadline", default=None)
+
+
+def current_deadline() -> Optional[Deadline]:
+    """The deadline of the tool call in progress, or None outside one."""
+    return _current.get()
+
+
+def expired(deadline: Optional[Deadline]) -> bool:
+    """``deadline.expired()``, False for no deadline."""
+    return deadline is not None and deadline.expired()
+
+
+@contextmanager
+def deadline_scope(seconds: Optional[float]) -> Iterator[Optional[Deadline]]:
+    """Make a *seconds* deadline current for the block; None or <= 0 means no limit."""
+    deadline = Deadline(seconds) if seconds is not None and seconds > 0 else None
+    token = _current.set(deadline)
+    try:
+        yield deadline
+    finally:
+        _current.reset(token)
@@ -22,6 +22,8 @@ import unicodedata
 from pathlib import Path
 from typing import Optional
 
+from ..config import default_storage_path
+
 logger = logging.getLogger(__name__)
 
 # ── Constants ──────────────────────────────────────────────────────────────
@@ -39,7 +41,7 @@ _HF_BASE_URL = "https://huggingface.co"
 
 def _default_models_dir() -> Path:
     """Return ``~/.code-index/models/<model_name>/``."""
-    root = Path(os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index")))
+    root = default_storage_path()
     return root / "models" / MODEL_NAME
 
 
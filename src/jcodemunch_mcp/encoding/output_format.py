@@ -0,0 +1,171 @@
+"""Text serialization for the cross-cutting ``output_format`` argument.
+
+Separate from the MUNCH encoder: MUNCH (the ``format`` argument) saves
+tokens for agents, while ``output_format`` picks how a response reads for
+the client on the other end of the pipe:
+
+- ``compact`` — JSON without whitespace (what the server emits today for
+  uncompressed responses).  ``json`` is accepted as an alias.
+- ``pretty`` — JSON indented by two spaces.
+- ``markdown`` — headings, bullet lists and tables.  Lists of symbols
+  (dicts with ``name`` and ``kind``) become a ``name | kind | line |
+  summary`` table, with a ``file`` column when they span several files;
+  outline trees are flattened with ``Parent.child`` names.
+
+Every tool response is a plain dict, so the same renderer serves all of
+them.  Setting ``output_format`` bypasses MUNCH encoding.
+"""
+
+from __future__ import annotations
+
+import json
+from typing import Any, Optional
+
+OUTPUT_FORMATS = ("compact", "pretty", "markdown")
+_ALIASES = {"json": "compact"}
+
+_MAX_TABLE_COLUMNS = 8
+_MAX_CELL_CHARS = 120
+
+
+def normalize_output_format(raw: Any) -> Optional[str]:
+    """Canonical format name, or None when *raw* is not one of OUTPUT_FORMATS (or an alias)."""
+    if not isinstance(raw, str):
+        return None
+    fmt = raw.strip().lower()
+    fmt = _ALIASES.get(fmt, fmt)
+    return fmt if fmt in OUTPUT_FORMATS else None
+
+
+def render(tool_name: str, response: Any, output_format: str) -> str:
+    """Serialize *response* in *output_format* (one of OUTPUT_FORMATS)."""
+    if output_format == "pretty":
+        return json.dumps(response, indent=2, default=str)
+    if output_format == "markdown":
+        return to_markdown(tool_name, response)
+    return json.dumps(response, separators=(",", ":"), default=str)
+
+
+# ---------------------------------------------------------------------------
+# Markdown
+# ---------------------------------------------------------------------------
+
+def _cell(value: Any) -> str:
+    if value is None:
+        return ""
+    if isinstance(value, bool):
+        text = "yes" if value else "no"
+    elif isinstance(value, (dict, list)):
+        text = json.dumps(value, separators=(",", ":"), default=str)
+    else:
+        text = str(value)
+    text = " ".join(text.split())
+    if len(text) > _MAX_CELL_CHARS:
+        text = text[: _MAX_CELL_CHARS - 1] + "…"
+    return text.replace("|", "\\|")
+
+
+def _table(headers: list[str], rows: list[list[Any]]) -> list[str]:
+    out = [
+        "| " + " | ".join(headers) + " |",
+        "|" + "|".join("---" for _ in headers) + "|",
+    ]
+    out.extend("| " + " | ".join(_cell(v) for v in row) + " |" for row in rows)
+    return out
+
+
+def _is_symbol(item: Any) -> bool:
+    return isinstance(item, dict) and "name" in item and "kind" in item
+
+
+def _flatten_symbols(items: list[dict], prefix: str = "") -> list[tuple[str, dict]]:
+    """``(display_name, symbol)`` pairs, children after their parent."""
+    out: list[tuple[str, dict]] = []
+    for item in items:
+        name = f"{prefix}{item.get('name', '')}"
+        out.append((name, item))
+        children = item.get("children")
+        if isinstance(children, list) and children and all(_is_symbol(c) for c in children):
+            out.extend(_flatten_symbols(children, prefix=name + "."))
+    return out
+
+
+def _summary(sym: dict) -> str:
+    summary = sym.get("summary") or ""
+    if not summary and sym.get("docstring"):
+        summary = str(sym["docstring"]).strip().split("\n")[0]
+    return summary
+
+
+def _symbol_table(items: list[dict]) -> list[str]:
+    flat = _flatten_symbols(items)
+    files = {sym.get("file") for _, sym in flat if sym.get("file")}
+    with_file = len(files) > 1
+    headers = ["name", "kind"] + (["file"] if with_file else []) + ["line", "summary"]
+    rows = [
+        [name, sym.get("kind", "")]
+        + ([sym.get("file", "")] if with_file else [])
+        + [sym.get("line", ""), _summary(sym)]
+        for name, sym in flat
+    ]
+    return _table(headers, rows)
+
+
+def _dict_table(items: list[dict]) -> list[str]:
+    """Generic table: the scalar keys shared by the rows, in first-seen order."""
+    headers: list[str] = []
+    for item in items:
+        for key, value in item.items():
+            if key not in headers and not isinstance(value, (dict, list)):
+                headers.append(key)
+    headers = headers[:_MAX_TABLE_COLUMNS]
+    if not headers:
+        return ["- " + _cell(item) for item in items]
+    return _table(headers, [[item.get(h) for h in headers] for item in items])
+
+
+def _list_block(items: list) -> list[str]:
+    if not items:
+        return ["_(none)_"]
+    if all(_is_symbol(i) for i in items):
+        return _symbol_table(items)
+    if all(isinstance(i, dict) for i in items):
+        return _dict_table(items)
+    return ["- " + _cell(i) for i in items]
+
+
+def _section(key: str, value: Any, level: int) -> list[str]:
+    heading = "#" * min(level, 6) + f" {key}"
+    if isinstance(value, list):
+        return [heading, "", *_list_block(value), ""]
+    lines = [heading, ""]
+    scalars = {k: v for k, v in value.items() if not isinstance(v, (dict, list))}
+    lines.extend(f"- **{k}:** {_cell(v)}" for k, v in scalars.items())
+    if scalars:
+        lines.append("")
+    for k, v in value.items():
+        if isinstance(v, (dict, list)):
+            lines.extend(_section(k, v, level + 1))
+    return lines
+
+
+def to_markdown(tool_name: str, response: Any) -> str:
+    """Render a tool response as Markdown."""
+    if not isinstance(response, dict):
+        body = _list_block(response) if isinstance(response, list) else [_cell(response)]
+        return "\n".join([f"## {tool_name}", "", *body]).rstrip() + "\n"
+    if set(response) <= {"error", "_meta"} and "error" in response:
+        return f"**Error:** {response['error']}\n"
+
+    lines = [f"## {tool_name}", ""]
+    scalars = {k: v for k, v in response.items() if k != "_meta" and not isinstance(v, (dict, list))}
+    lines.extend(f"- **{k}:** {_cell(v)}" for k, v in scalars.items())
+    if scalars:
+        lines.append("")
+    for key, value in response.items():
+        if key != "_meta" and isinstance(value, (dict, list)):
+            lines.extend(_section(key, value, 3))
+    meta = response.get("_meta")
+    if isinstance(meta, dict) and meta:
+        lines.append("_meta: " + ", ".join(f"{k}={_cell(v)}" for k, v in meta.items()))
+    return "\n".join(lines).rstrip() + "\n"
@@ -15,8 +15,15 @@ _TABLES = [
         cols=["file", "specifier", "match_type"],
         intern=["file", "specifier"],
     ),
+    sd.TableSpec(
+        key="usages",
+        tag="u",
+        cols=["file", "line", "role", "symbol", "text"],
+        intern=["file", "symbol"],
+        types={"line": "int"},
+    ),
 ]
-_SCALARS = ("repo", "identifier", "reference_count", "note")
+_SCALARS = ("repo", "identifier", "reference_count", "usage_count", "note")
 _META = ("timing_ms", "truncated", "tokens_saved", "total_tokens_saved")
 _JSON = ("results", _EMPTY_GROUPS_KEY)
 
@@ -84,6 +91,9 @@ def encode(tool: str, response: dict) -> tuple[str, str]:
 
 def decode(payload: str) -> dict:
     decoded = sd.decode(payload, _TABLES, _SCALARS, meta_keys=_META, json_blobs=_JSON)
+    # usages is opt-in (include_usages); don't invent an empty list when absent.
+    if not decoded.get("usages") and "usage_count" not in decoded:
+        decoded.pop("usages", None)
     if _ROWS_KEY in decoded:
         return _regroup(decoded)
     return decoded
@@ -9,9 +9,9 @@ _TABLES = [
     sd.TableSpec(
         key="symbols",
         tag="s",
-        cols=["id", "name", "kind", "signature", "line", "end_line", "parent", "summary"],
+        cols=["id", "name", "kind", "signature", "line", "end_line", "parent", "summary", "exported", "exported_as"],
         intern=["id", "parent"],
-        types={"line": "int", "end_line": "int"},
+        types={"line": "int", "end_line": "int", "exported": "bool"},
     ),
     sd.TableSpec(
         key="results",
@@ -21,7 +21,7 @@ _TABLES = [
         types={"symbol_count": "int"},
     ),
 ]
-_SCALARS = ("repo", "file", "symbol_count", "language")
+_SCALARS = ("repo", "file", "symbol_count", "language", "total_count", "has_more")
 _META = ("timing_ms", "tokens_saved", "total_tokens_saved")
 
 
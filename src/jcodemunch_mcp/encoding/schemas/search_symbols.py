@@ -9,12 +9,12 @@ _TABLES = [
     sd.TableSpec(
         key="results",
         tag="s",
-        cols=["id", "name", "kind", "file", "line", "score", "signature", "summary"],
+        cols=["id", "name", "kind", "file", "line", "score", "match_type", "signature", "summary"],
         intern=["file", "id"],
         types={"line": "int", "score": "float"},
     ),
 ]
-_SCALARS = ("result_count", "query", "repo")
+_SCALARS = ("result_count", "total_count", "has_more", "query", "repo")
 _META = (
     "timing_ms", "truncated", "total_symbols", "tokens_saved",
     "total_tokens_saved", "fusion", "channels",
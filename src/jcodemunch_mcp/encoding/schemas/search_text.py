@@ -4,6 +4,9 @@ search_text returns a nested shape — matches grouped by file:
 
     {
       "result_count": int,
+      "total_count": int,
+      "total_count_exact": bool,
+      "has_more": bool,
       "results": [
         {"file": str, "matches": [{"line": int, "text": str, "before"?: [str], "after"?: [str]}]}
       ],
@@ -40,7 +43,7 @@ _TABLES = [
         types={"line": "int"},
     ),
 ]
-_SCALARS = ("result_count", "query", "repo")
+_SCALARS = ("result_count", "total_count", "total_count_exact", "has_more", "query", "repo")
 _META = (
     "timing_ms",
     "files_searched",
@@ -52,6 +55,9 @@ _META = (
 # instead of raw strings.
 _SCALAR_TYPES: dict[str, str] = {
     "result_count": "int",
+    "total_count": "int",
+    "total_count_exact": "bool",
+    "has_more": "bool",
     "_meta.timing_ms": "float",
     "_meta.files_searched": "int",
     "_meta.truncated": "bool",
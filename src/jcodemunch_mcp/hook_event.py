@@ -2,18 +2,18 @@
 git worktrees, and records state to a JSONL manifest."""
 
 import json
-import os
 import shutil
 import subprocess
 import sys
 from datetime import datetime, timezone
 from pathlib import Path
 
+from .config import default_config_dir
+
 
 def default_manifest_path() -> Path:
     """Return manifest path, respecting CODE_INDEX_PATH."""
-    storage = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
-    return Path(storage) / "jcodemunch-worktrees.jsonl"
+    return default_config_dir() / "jcodemunch-worktrees.jsonl"
 
 
 # Legacy location — checked for migration.
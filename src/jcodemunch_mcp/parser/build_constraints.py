@@ -0,0 +1,117 @@
+"""Go build constraints and test-file detection.
+
+A Go file is conditionally compiled by two mechanisms, both of which apply:
+
+    header lines    ``//go:build linux && amd64`` (or the legacy
+                    ``// +build linux,amd64`` form) before the package clause
+    file name       ``_GOOS``, ``_GOARCH`` or ``_GOOS_GOARCH`` suffixes, e.g.
+                    ``poll_linux.go`` or ``asm_windows_arm64.go``
+
+``go_build_constraint`` folds both into one ``//go:build``-style expression
+(``""`` when the file is unconstrained).  Files named ``*_test.go`` are only
+compiled by ``go test``; ``is_go_test_file`` flags them.
+"""
+
+from __future__ import annotations
+
+import posixpath
+
+# From go/build/syslist.go.
+_KNOWN_OS = frozenset({
+    "aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos",
+    "ios", "js", "linux", "nacl", "netbsd", "openbsd", "plan9", "solaris",
+    "wasip1", "windows", "zos",
+})
+_KNOWN_ARCH = frozenset({
+    "386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be",
+    "loong64", "mips", "mipsle", "mips64", "mips64le", "mips64p32",
+    "mips64p32le", "ppc", "ppc64", "ppc64le", "riscv", "riscv64", "s390",
+    "s390x", "sparc", "sparc64", "wasm",
+})
+
+
+def is_go_test_file(file_path: str) -> bool:
+    return posixpath.basename(file_path.replace("\\", "/")).endswith("_test.go")
+
+
+def _filename_constraint(file_path: str) -> str:
+    """Implicit constraint from a ``_GOOS`` / ``_GOARCH`` file-name suffix."""
+    stem = posixpath.basename(file_path.replace("\\", "/"))
+    if stem.endswith(".go"):
+        stem = stem[:-3]
+    if stem.endswith("_test"):
+        stem = stem[:-5]
+    parts = stem.split("_")
+    # As in go/build: the first element is the name proper, never a tag,
+    # so "linux.go" is unconstrained.
+    if len(parts) >= 3 and parts[-2] in _KNOWN_OS and parts[-1] in _KNOWN_ARCH:
+        return f"{parts[-2]} && {parts[-1]}"
+    if len(parts) >= 2 and (parts[-1] in _KNOWN_OS or parts[-1] in _KNOWN_ARCH):
+        return parts[-1]
+    return ""
+
+
+def _plus_build_expr(lines: list[str]) -> str:
+    """Translate legacy ``// +build`` lines into a ``//go:build`` expression.
+
+    Space-separated options OR, comma-separated terms AND, and separate
+    lines AND together.
+    """
+    clauses = []
+    for line in lines:
+        options = [" && ".join(opt.split(",")) for opt in line.split()]
+        if not options:
+            continue
+        clauses.append(options[0] if len(options) == 1 else "(" + " || ".join(options) + ")")
+    if len(clauses) == 1 and clauses[0].startswith("("):
+        return clauses[0][1:-1]
+    return " && ".join(clauses)
+
+
+def _header_constraint(source: str) -> str:
+    """The ``//go:build`` (or ``// +build``) expression before the package clause."""
+    go_build = ""
+    plus_build: list[str] = []
+    in_block = False
+    for raw in source.splitlines():
+        line = raw.strip()
+        if in_block:
+            if "*/" in line:
+                in_block = False
+            continue
+        if not line:
+            continue
+        if line.startswith("/*"):
+            in_block = "*/" not in line[2:]
+            continue
+        if not line.startswith("//"):
+            break  # package clause (or anything else) ends the header
+        if line.startswith("//go:build "):
+            go_build = line[len("//go:build "):].strip()
+        elif line.startswith("// +build "):
+            plus_build.append(line[len("// +build "):].strip())
+    # //go:build wins when both are present, as in the go command.
+    return go_build or _plus_build_expr(plus_build)
+
+
+def _has_top_level_or(expr: str) -> bool:
+    depth = 0
+    for i, ch in enumerate(expr):
+        if ch == "(":
+            depth += 1
+        elif ch == ")":
+            depth -= 1
+        elif depth == 0 and expr.startswith("||", i):
+            return True
+    return False
+
+
+def go_build_constraint(file_path: str, source: str) -> str:
+    """Effective build constraint of a Go file, or ``""`` when unconstrained."""
+    header = _header_constraint(source)
+    implicit = _filename_constraint(file_path)
+    if header and implicit:
+        if _has_top_level_or(header):
+            header = f"({header})"
+        return f"{header} && {implicit}"
+    return header or implicit
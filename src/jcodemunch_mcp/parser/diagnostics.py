@@ -0,0 +1,90 @@
+"""Syntax diagnostics from tree-sitter error recovery.
+
+Tree-sitter never fails outright: unparseable input becomes ``ERROR`` nodes
+and tokens it had to invent become zero-width ``MISSING`` nodes, with the
+rest of the tree intact.  This module turns those nodes into positioned
+diagnostics so a file that parsed badly can be told apart from a file that
+simply declares nothing.
+"""
+
+from __future__ import annotations
+
+from typing import Optional
+
+from tree_sitter_language_pack import get_parser
+
+from .languages import LANGUAGE_REGISTRY
+
+_SNIPPET_CHARS = 40
+_DEFAULT_LIMIT = 50
+
+
+def _snippet(source_bytes: bytes, start: int, end: int) -> str:
+    text = source_bytes[start:end].decode("utf-8", errors="replace")
+    first = text.strip().split("\n", 1)[0]
+    return first if len(first) <= _SNIPPET_CHARS else first[: _SNIPPET_CHARS - 3] + "..."
+
+
+def _diagnostic(node, source_bytes: bytes) -> dict:
+    if node.is_missing:
+        kind = "missing"
+        message = f"syntax error: missing {node.type!r}"
+    else:
+        kind = "error"
+        snippet = _snippet(source_bytes, node.start_byte, node.end_byte)
+        message = f"syntax error: unexpected {snippet!r}" if snippet else "syntax error"
+    parent = node.parent
+    diag = {
+        "line": node.start_point[0] + 1,
+        "column": node.start_point[1] + 1,
+        "end_line": node.end_point[0] + 1,
+        "end_column": node.end_point[1] + 1,
+        "kind": kind,
+        "message": message,
+    }
+    if parent is not None and parent.type not in ("ERROR", "source_file", "module", "program"):
+        diag["context"] = parent.type
+    return diag
+
+
+def collect_syntax_errors(root_node, source_bytes: bytes, limit: int = _DEFAULT_LIMIT) -> list[dict]:
+    """Return up to *limit* diagnostics for ERROR / MISSING nodes, in source order.
+
+    Only subtrees flagged ``has_error`` are visited, so clean files cost one
+    attribute check.  An ERROR node is reported once; its children are not.
+    """
+    if root_node is None or not root_node.has_error:
+        return []
+    out: list[dict] = []
+    stack = [root_node]
+    while stack and len(out) < limit:
+        node = stack.pop()
+        if node.type == "ERROR" or node.is_missing:
+            out.append(_diagnostic(node, source_bytes))
+            continue
+        # Reverse so the leftmost child is popped first (source order).
+        stack.extend(c for c in reversed(node.children) if c.has_error or c.is_missing)
+    return out
+
+
+def parse_diagnostics(
+    content: str,
+    language: str,
+    limit: int = _DEFAULT_LIMIT,
+) -> Optional[list[dict]]:
+    """Parse *content* and return its syntax diagnostics.
+
+    Returns None when the language has no tree-sitter grammar here (custom
+    regex parsers, text-only languages), so callers can say "not checked"
+    rather than "clean".
+    """
+    spec = LANGUAGE_REGISTRY.get(language)
+    if spec is None or not getattr(spec, "ts_language", None):
+        return None
+    try:
+        parser = get_parser(spec.ts_language)
+    except Exception:
+        return None
+    source_bytes = content.encode("utf-8")
+    tree = parser.parse(source_bytes)
+    return collect_syntax_errors(tree.root_node, source_bytes, limit=limit)
@@ -0,0 +1,173 @@
+"""Split a cleaned docstring into structured sections.
+
+The index stores each symbol's doc comment verbatim (comment markers
+removed, see ``extractor._clean_comment_markers``).  ``parse_docstring``
+breaks that text into the parts an agent usually wants on its own:
+
+    summary     first sentence of the first paragraph
+    body        remaining prose, with deprecation and example blocks removed
+    deprecated  deprecation notice text, or None
+    examples    code/example blocks, verbatim
+
+Recognised conventions:
+    deprecation  Go ``Deprecated:`` paragraphs, JSDoc/Javadoc/PHPDoc
+                 ``@deprecated``, Sphinx ``.. deprecated::``, and
+                 ``@Deprecated`` / ``#[deprecated]`` / ``@deprecated(...)``
+                 decorators when ``decorators`` is passed
+    examples     fenced ``` blocks, indented (tab / 4-space) blocks, doctest
+                 ``>>>`` runs, and JSDoc ``@example`` blocks
+
+Pure text processing — no parser or index access — so it works on any
+stored docstring regardless of language.
+"""
+
+from __future__ import annotations
+
+import re
+from typing import Optional
+
+_SENTENCE_END_RE = re.compile(r"(?<=[.!?])\s+(?=[A-Z0-9`\"'(\[])")
+_DEPRECATED_PARA_RE = re.compile(r"^Deprecated:\s*(.*)", re.DOTALL)
+_DEPRECATED_TAG_RE = re.compile(r"^@deprecated\b\s*(.*)", re.IGNORECASE)
+_SPHINX_DEPRECATED_RE = re.compile(r"^\.\.\s+deprecated::\s*(.*)")
+_DEPRECATED_DECORATOR_RE = re.compile(r"^(?:@|#\[)\s*(?:[\w.]+\.)?deprecated\b", re.IGNORECASE)
+_DECORATOR_MESSAGE_RE = re.compile(r"""["']([^"']+)["']""")
+_TAG_RE = re.compile(r"^@\w+")
+
+
+def _first_sentence(paragraph: str) -> str:
+    flat = " ".join(paragraph.split())
+    parts = _SENTENCE_END_RE.split(flat, maxsplit=1)
+    return parts[0]
+
+
+def _is_indented_code(line: str) -> bool:
+    return line.startswith("\t") or line.startswith("    ")
+
+
+def _split_blocks(text: str) -> tuple[list[str], list[str], Optional[str]]:
+    """Walk *text* line by line, separating prose, examples and deprecation."""
+    prose: list[str] = []
+    examples: list[str] = []
+    deprecated: Optional[str] = None
+
+    lines = text.split("\n")
+    i = 0
+    prev_blank = True
+    while i < len(lines):
+        line = lines[i]
+        stripped = line.strip()
+
+        # Fenced code block
+        if stripped.startswith("```"):
+            block: list[str] = []
+            i += 1
+            while i < len(lines) and not lines[i].strip().startswith("```"):
+                block.append(lines[i])
+                i += 1
+            examples.append("\n".join(block).strip("\n"))
+            i += 1
+            prev_blank = False
+            continue
+
+        # Doctest run
+        if stripped.startswith(">>>"):
+            block = []
+            while i < len(lines) and lines[i].strip():
+                block.append(lines[i].strip())
+                i += 1
+            examples.append("\n".join(block))
+            prev_blank = False
+            continue
+
+        # JSDoc @example: everything up to the next tag or blank line
+        if stripped.lower().startswith("@example"):
+            block = []
+            rest = stripped[len("@example"):].strip()
+            if rest:
+                block.append(rest)
+            i += 1
+            while i < len(lines) and lines[i].strip() and not _TAG_RE.match(lines[i].strip()):
+                block.append(lines[i])
+                i += 1
+            examples.append(_dedent("\n".join(block)))
+            prev_blank = False
+            continue
+
+        # Indented block after a blank line (Go / Markdown convention)
+        if prev_blank and stripped and _is_indented_code(line):
+            block = []
+            while i < len(lines) and (not lines[i].strip() or _is_indented_code(lines[i])):
+                block.append(lines[i])
+                i += 1
+            examples.append(_dedent("\n".join(block).rstrip("\n")))
+            prev_blank = True
+            continue
+
+        # Deprecation notices (paragraph-scoped)
+        match = (
+            _DEPRECATED_PARA_RE.match(stripped)
+            or _DEPRECATED_TAG_RE.match(stripped)
+            or _SPHINX_DEPRECATED_RE.match(stripped)
+        )
+        if match and deprecated is None:
+            notice = [match.group(1).strip()]
+            i += 1
+            while i < len(lines) and lines[i].strip() and not _TAG_RE.match(lines[i].strip()):
+                notice.append(lines[i].strip())
+                i += 1
+            deprecated = " ".join(p for p in notice if p)
+            prev_blank = False
+            continue
+
+        prose.append(line)
+        prev_blank = not stripped
+        i += 1
+
+    return prose, examples, deprecated
+
+
+def _dedent(text: str) -> str:
+    lines = text.split("\n")
+    indents = [len(l) - len(l.lstrip()) for l in lines if l.strip()]
+    cut = min(indents) if indents else 0
+    return "\n".join(l[cut:] for l in lines)
+
+
+def _deprecated_from_decorators(decorators: list[str]) -> Optional[str]:
+    for deco in decorators or []:
+        text = deco.strip()
+        if _DEPRECATED_DECORATOR_RE.match(text):
+            msg = _DECORATOR_MESSAGE_RE.search(text)
+            return msg.group(1) if msg else ""
+    return None
+
+
+def parse_docstring(raw: str, decorators: Optional[list[str]] = None) -> dict:
+    """Return ``{summary, body, deprecated, examples}`` for a stored docstring.
+
+    ``deprecated`` is None when no notice is found, or a (possibly empty)
+    string when the symbol is deprecated.  ``raw`` is not echoed back;
+    callers already hold it.
+    """
+    text = (raw or "").strip()
+    prose, examples, deprecated = _split_blocks(text) if text else ([], [], None)
+    if deprecated is None and decorators:
+        deprecated = _deprecated_from_decorators(decorators)
+
+    paragraphs = [p.strip() for p in re.split(r"\n\s*\n", "\n".join(prose)) if p.strip()]
+    summary = ""
+    body = ""
+    if paragraphs:
+        first = paragraphs[0]
+        summary = _first_sentence(first)
+        rest_of_first = " ".join(first.split())[len(summary):].strip()
+        remaining = ([rest_of_first] if rest_of_first else []) + paragraphs[1:]
+        body = "\n\n".join(remaining)
+
+    return {
+        "summary": summary,
+        "body": body,
+        "deprecated": deprecated,
+        "examples": examples,
+    }
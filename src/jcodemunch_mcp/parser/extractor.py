@@ -8,6 +8,9 @@ from tree_sitter_language_pack import get_parser
 from .symbols import Symbol, make_symbol_id, compute_content_hash
 from .languages import LanguageSpec, LANGUAGE_REGISTRY
 from .complexity import compute_complexity
+from .build_constraints import go_build_constraint, is_go_test_file
+from .fqn import assign_fqns
+from . import parse_cache as _parse_cache
 
 
 # Node types that represent function/call expressions per language.
@@ -208,7 +211,8 @@ def parse_file(content: str, filename: str, language: str, source_bytes: Optiona
         source_bytes: Optional pre-encoded UTF-8 bytes. If provided, avoids
             a redundant encode() call when the caller has already encoded content.
         repo: Optional folder path used to consult per-project .jcodemunch.jsonc
-            when checking whether the language is enabled.
+            when checking whether the language is enabled, and to find the
+            go.mod that qualifies Go ``fqn`` values.
 
     Returns:
         List of Symbol objects
@@ -229,6 +233,15 @@ def parse_file(content: str, filename: str, language: str, source_bytes: Optiona
     if source_bytes is None:
         source_bytes = content.encode("utf-8")
 
+    # Identical content at the same path parses to identical symbols — serve
+    # full rebuilds, index_file re-runs, and sibling worktrees from memory.
+    cache_key = _parse_cache.make_key(language, filename, source_bytes)
+    cached = _parse_cache.get(cache_key)
+    if cached is not None:
+        # fqn depends on go.mod, not just the file, so it is never cached.
+        assign_fqns(cached, filename, language, content, repo)
+        return cached
+
     # Track the tree for call reference extraction (custom parsers may return it)
     root_node: Any = None
 
@@ -341,6 +354,15 @@ def parse_file(content: str, filename: str, language: str, source_bytes: Optiona
     # Disambiguate overloaded symbols + compute complexity in a single pass
     symbols = _disambiguate_and_compute_complexity(symbols, source_bytes)
 
+    if language == "go" and symbols:
+        build_tags = go_build_constraint(filename, content)
+        is_test = is_go_test_file(filename)
+        for sym in symbols:
+            sym.build_tags = build_tags
+            sym.is_test = is_test
+
+    _parse_cache.put(cache_key, symbols)
+    assign_fqns(symbols, filename, language, content, repo)
     return symbols
 
 
@@ -468,7 +490,8 @@ def _walk_tree(
     if is_cpp and node.type == "namespace_definition":
         ns_name = _extract_cpp_namespace_name(node, source_bytes)
         if ns_name:
-            local_scope_parts = [*local_scope_parts, ns_name]
+            # C++17 `namespace a::b {` opens both scopes.
+            local_scope_parts = [*local_scope_parts, *ns_name.replace(" ", "").split("::")]
 
     # Collect call sites during the same walk (when enabled)
     if call_types is not None and calls is not None and node.type in call_types:
@@ -478,8 +501,14 @@ def _walk_tree(
 
     # Check if this node is a symbol
     if node.type in spec.symbol_node_types:
-        # C++ declarations include non-function declarations. Filter those out.
-        if not (is_cpp and node.type in {"declaration", "field_declaration"} and not _is_cpp_function_declaration(node)):
+        # C/C++ declarations include non-function declarations. Filter those
+        # out, along with C prototypes local to a function body.
+        skip_declaration = (
+            node.type in {"declaration", "field_declaration"}
+            and language in ("c", "cpp", "arduino")
+            and (not _is_cpp_function_declaration(node) or (language == "c" and parent_symbol is not None))
+        )
+        if not skip_declaration:
             symbol = _extract_symbol(
                 node,
                 spec,
@@ -507,12 +536,30 @@ def _walk_tree(
         if var_func:
             symbols.append(var_func)
 
+    # Anonymous `export default function () {}` / `class {}` / arrow in JS/TS
+    if node.type == "export_statement" and parent_symbol is None and language in ("javascript", "typescript", "tsx"):
+        default_sym = _extract_default_export(node, spec, source_bytes, filename, language)
+        if default_sym:
+            symbols.append(default_sym)
+            if default_sym.kind == "class":
+                next_parent = default_sym
+
     # Check for constant patterns (top-level assignments with UPPER_CASE names)
     if node.type in spec.constant_patterns and parent_symbol is None:
         const_symbol = _extract_constant(node, spec, source_bytes, filename, language)
         if const_symbol:
             symbols.append(const_symbol)
 
+    # Java has no top-level fields: constants are `static final` members
+    # (or any interface field) of the enclosing type.
+    if (
+        language == "java"
+        and node.type in spec.constant_patterns
+        and parent_symbol is not None
+        and parent_symbol.kind in ("class", "type")
+    ):
+        symbols.extend(_java_constants(node, spec, source_bytes, filename, parent_symbol))
+
     # Recurse into children
     for child in node.children:
         _walk_tree(
@@ -530,6 +577,310 @@ def _walk_tree(
         )
 
 
+def _go_tag_value(tag_node, source_bytes: bytes) -> str:
+    """Struct tag without its quotes: `json:"id"` -> json:"id"."""
+    text = _node_text(tag_node, source_bytes)
+    if len(text) >= 2 and text[0] == text[-1] and text[0] in "`\"":
+        return text[1:-1]
+    return text
+
+
+def _go_embedded_name(type_text: str) -> str:
+    """Field name Go gives an embedded type: ``*pkg.Base[T]`` -> ``Base``."""
+    base = type_text.lstrip("*").split("[", 1)[0].strip()
+    return base.rsplit(".", 1)[-1]
+
+
+def _go_struct_fields(struct_node, source_bytes: bytes) -> list[dict]:
+    """Fields of a Go ``struct_type`` node, in declaration order.
+
+    Each entry has ``name``, ``type`` (verbatim source text, e.g.
+    ``map[string][]*Foo``), ``tag`` (without quotes; "" when absent),
+    ``embedded``, and ``line``.  ``X, Y int`` yields one entry per name.
+    Anonymous struct types nest their own ``fields``.
+    """
+    field_list = next((c for c in struct_node.named_children if c.type == "field_declaration_list"), None)
+    if field_list is None:
+        return []
+    out: list[dict] = []
+    for decl in field_list.named_children:
+        if decl.type != "field_declaration":
+            continue
+        names = decl.children_by_field_name("name")
+        type_node = decl.child_by_field_name("type")
+        tag_node = decl.child_by_field_name("tag")
+        tag = _go_tag_value(tag_node, source_bytes) if tag_node is not None else ""
+        if names:
+            type_text = _node_text(type_node, source_bytes) if type_node is not None else ""
+        else:
+            # Embedded: the type is everything before the tag, so `*Base`
+            # keeps its pointer whatever node shape the grammar uses.
+            end = tag_node.start_byte if tag_node is not None else decl.end_byte
+            type_text = source_bytes[decl.start_byte:end].decode("utf-8", errors="replace").strip()
+        nested = (
+            _go_struct_fields(type_node, source_bytes)
+            if type_node is not None and type_node.type == "struct_type" else None
+        )
+        entry_names = [_node_text(n, source_bytes) for n in names] or [_go_embedded_name(type_text)]
+        for field_name in entry_names:
+            entry = {
+                "name": field_name,
+                "type": type_text,
+                "tag": tag,
+                "embedded": not names,
+                "line": decl.start_point[0] + 1,
+            }
+            if nested:
+                entry["fields"] = nested
+            out.append(entry)
+    return out
+
+
+_ANNOTATION_NODE_TYPES = ("marker_annotation", "annotation")
+_JAVA_FIELD_OWNERS = frozenset({"class_declaration", "record_declaration", "enum_declaration"})
+
+
+def _java_modifiers(decl, source_bytes: bytes) -> tuple[list[str], list[str]]:
+    """``(keywords, annotations)`` of a Java declaration's ``modifiers`` node."""
+    mods = next((c for c in decl.children if c.type == "modifiers"), None)
+    if mods is None:
+        return [], []
+    keywords, annotations = [], []
+    for child in mods.children:
+        text = _node_text(child, source_bytes)
+        if child.type in _ANNOTATION_NODE_TYPES:
+            annotations.append(text)
+        else:
+            keywords.append(text)
+    return keywords, annotations
+
+
+def _java_field_decls(class_node) -> list:
+    """``field_declaration`` nodes directly in a Java class, record, or enum body."""
+    body = class_node.child_by_field_name("body")
+    if body is None:
+        return []
+    children = list(body.named_children)
+    # Enum fields follow the constants, after the `;`.
+    for child in body.named_children:
+        if child.type == "enum_body_declarations":
+            children.extend(child.named_children)
+    return [c for c in children if c.type == "field_declaration"]
+
+
+def _java_fields(class_node, source_bytes: bytes) -> list[dict]:
+    """Fields of a Java type, in declaration order.
+
+    Each entry has ``name``, ``type`` (verbatim source text), ``modifiers``
+    (keywords in source order, e.g. ``private static final``), and ``line``;
+    ``annotations`` is added when the field has any.  ``int x, y;`` yields
+    one entry per name.
+    """
+    out: list[dict] = []
+    for decl in _java_field_decls(class_node):
+        keywords, annotations = _java_modifiers(decl, source_bytes)
+        type_node = decl.child_by_field_name("type")
+        type_text = _node_text(type_node, source_bytes) if type_node is not None else ""
+        for declarator in decl.children_by_field_name("declarator"):
+            name_node = declarator.child_by_field_name("name")
+            if name_node is None:
+                continue
+            entry = {
+                "name": _node_text(name_node, source_bytes),
+                "type": type_text,
+                "modifiers": " ".join(keywords),
+                "line": decl.start_point[0] + 1,
+            }
+            if annotations:
+                entry["annotations"] = annotations
+            out.append(entry)
+    return out
+
+
+def _java_constants(
+    node, spec: LanguageSpec, source_bytes: bytes, filename: str, parent_symbol: Symbol
+) -> list[Symbol]:
+    """Constant symbols for a Java ``static final`` field or interface field."""
+    keywords, annotations = _java_modifiers(node, source_bytes)
+    if node.type == "field_declaration" and not {"static", "final"} <= set(keywords):
+        return []
+    sig = source_bytes[node.start_byte:node.end_byte].decode("utf-8").strip()
+    docstring = _extract_docstring(node, spec, source_bytes)
+    out = []
+    for declarator in node.children_by_field_name("declarator"):
+        name_node = declarator.child_by_field_name("name")
+        if name_node is None:
+            continue
+        name = _node_text(name_node, source_bytes)
+        qualified_name = f"{parent_symbol.name}.{name}"
+        out.append(Symbol(
+            id=make_symbol_id(filename, qualified_name, "constant"),
+            file=filename,
+            name=name,
+            qualified_name=qualified_name,
+            kind="constant",
+            language="java",
+            signature=sig[:200],
+            docstring=docstring,
+            decorators=annotations,
+            parent=parent_symbol.id,
+            line=node.start_point[0] + 1,
+            end_line=node.end_point[0] + 1,
+            byte_offset=node.start_byte,
+            byte_length=node.end_byte - node.start_byte,
+            content_hash=compute_content_hash(source_bytes[node.start_byte:node.end_byte]),
+        ))
+    return out
+
+
+def _extract_struct_fields(node, language: str, source_bytes: bytes) -> list[dict]:
+    """Field list for struct (Go) and class (Java) declarations; [] for everything else."""
+    if language == "go" and node.type == "type_declaration":
+        for child in node.children:
+            if child.type == "type_spec":
+                type_node = child.child_by_field_name("type")
+                if type_node is not None and type_node.type == "struct_type":
+                    return _go_struct_fields(type_node, source_bytes)
+                return []
+    if language == "java" and node.type in _JAVA_FIELD_OWNERS:
+        return _java_fields(node, source_bytes)
+    return []
+
+
+def _go_receiver(node, source_bytes: bytes) -> tuple[str, bool]:
+    """Receiver base type and pointer flag of a Go method: ``(l *List[T])`` -> ("List", True)."""
+    receiver = node.child_by_field_name("receiver") if node.type == "method_declaration" else None
+    if receiver is None:
+        return "", False
+    decl = next((c for c in receiver.named_children if c.type == "parameter_declaration"), None)
+    type_node = decl.child_by_field_name("type") if decl is not None else None
+    pointer = False
+    while type_node is not None and type_node.type in ("pointer_type", "parenthesized_type", "generic_type"):
+        if type_node.type == "pointer_type":
+            pointer = True
+        if type_node.type == "generic_type":
+            type_node = type_node.child_by_field_name("type")
+        else:
+            type_node = type_node.named_children[0] if type_node.named_children else None
+    if type_node is None or type_node.type != "type_identifier":
+        return "", False
+    return _node_text(type_node, source_bytes), pointer
+
+
+def _go_param_list(list_node, source_bytes: bytes) -> list[dict]:
+    """Entries of a Go ``parameter_list``: ``a, b int`` yields one per name.
+
+    Unnamed parameters have ``name`` ""; the blank identifier stays ``_``.
+    A ``...T`` parameter has ``type`` ``T`` and ``variadic: True``.
+    """
+    out: list[dict] = []
+    for decl in list_node.named_children:
+        if decl.type not in ("parameter_declaration", "variadic_parameter_declaration"):
+            continue
+        type_node = decl.child_by_field_name("type")
+        type_text = _node_text(type_node, source_bytes) if type_node is not None else ""
+        names = [_node_text(n, source_bytes) for n in decl.children_by_field_name("name")] or [""]
+        for param_name in names:
+            entry = {"name": param_name, "type": type_text}
+            if decl.type == "variadic_parameter_declaration":
+                entry["variadic"] = True
+            out.append(entry)
+    return out
+
+
+def _go_signature_parts(node, source_bytes: bytes) -> tuple[list[dict], list[dict]]:
+    """``(params, returns)`` of a Go function or method declaration."""
+    params_node = node.child_by_field_name("parameters")
+    params = _go_param_list(params_node, source_bytes) if params_node is not None else []
+    result = node.child_by_field_name("result")
+    if result is None:
+        returns: list[dict] = []
+    elif result.type == "parameter_list":
+        returns = _go_param_list(result, source_bytes)
+    else:
+        returns = [{"name": "", "type": _node_text(result, source_bytes)}]
+    return params, returns
+
+
+def _go_type_params(node, source_bytes: bytes) -> list[dict]:
+    """Type parameters of a generic Go function or type: ``[K comparable, V any]``.
+
+    One ``{name, constraint}`` entry per name, so ``[T, U any]`` gives two;
+    the constraint is source text (``~int | ~string``, ``cmp.Ordered``).
+    Methods declare none of their own; their receiver's type does.
+    """
+    if node.type == "type_declaration":
+        node = next((c for c in node.named_children if c.type in ("type_spec", "type_alias")), None)
+        if node is None:
+            return []
+    list_node = node.child_by_field_name("type_parameters")
+    if list_node is None:
+        return []
+    out: list[dict] = []
+    for decl in list_node.named_children:
+        if decl.type not in ("type_parameter_declaration", "parameter_declaration"):
+            continue
+        type_node = decl.child_by_field_name("type")
+        constraint = _node_text(type_node, source_bytes) if type_node is not None else ""
+        for name_node in decl.children_by_field_name("name"):
+            out.append({"name": _node_text(name_node, source_bytes), "constraint": constraint})
+    return out
+
+
+def _python_signature_parts(node, source_bytes: bytes) -> tuple[list[dict], list[dict]]:
+    """``(params, returns)`` of a Python ``def``, ``self``/``cls`` included.
+
+    Annotations and defaults are source text ("" when absent).  ``*args``
+    has ``variadic: True``, ``**kwargs`` has ``keyword_variadic: True``, and
+    parameters after ``*`` or ``*args`` are ``keyword_only``.
+    """
+    params_node = node.child_by_field_name("parameters")
+    params: list[dict] = []
+    keyword_only = False
+    for child in params_node.named_children if params_node is not None else []:
+        if child.type == "keyword_separator":
+            keyword_only = True
+            continue
+        if child.type not in (
+            "identifier", "typed_parameter", "default_parameter", "typed_default_parameter",
+            "list_splat_pattern", "dictionary_splat_pattern",
+        ):
+            continue  # positional_separator (`/`), comments
+        type_node = child.child_by_field_name("type")
+        value_node = child.child_by_field_name("value")
+        target = child.child_by_field_name("name")
+        if target is None:
+            # typed_parameter has no name field: the name (or splat) comes first.
+            target = child.named_children[0] if child.type == "typed_parameter" and child.named_children else child
+        entry: dict = {"type": _node_text(type_node, source_bytes) if type_node is not None else ""}
+        if target.type == "list_splat_pattern":
+            keyword_only = True
+            entry["variadic"] = True
+        elif target.type == "dictionary_splat_pattern":
+            entry["keyword_variadic"] = True
+        elif keyword_only:
+            entry["keyword_only"] = True
+        entry = {"name": _node_text(target, source_bytes).lstrip("*"), **entry}
+        if value_node is not None:
+            entry["default"] = _node_text(value_node, source_bytes)
+        params.append(entry)
+    return_node = node.child_by_field_name("return_type")
+    returns = [{"name": "", "type": _node_text(return_node, source_bytes)}] if return_node is not None else []
+    return params, returns
+
+
+def _extract_signature_parts(node, language: str, source_bytes: bytes) -> tuple[list[dict], list[dict]]:
+    """Structured ``(params, returns)`` for Go and Python functions; ([], []) otherwise."""
+    if language == "go" and node.type in ("function_declaration", "method_declaration"):
+        return _go_signature_parts(node, source_bytes)
+    if language == "python":
+        if node.type == "decorated_definition":
+            node = node.child_by_field_name("definition") or node
+        if node.type == "function_definition":
+            return _python_signature_parts(node, source_bytes)
+    return [], []
+
+
 def _detect_interface_keywords(node, language: str) -> list[str]:
     """Tag interface/trait/abstract symbols for dispatch resolution.
 
@@ -603,8 +954,10 @@ def _extract_symbol(
     """Extract a Symbol from an AST node."""
     kind = spec.symbol_node_types[node.type]
     
-    # Skip nodes with errors
-    if node.has_error:
+    # Skip nodes whose declaration itself failed to parse.  Errors confined
+    # to the body still yield the symbol so one bad statement doesn't drop
+    # the function from the outline (see parser/diagnostics.py).
+    if node.has_error and _declaration_has_error(node):
         return None
     
     # Extract name
@@ -614,13 +967,19 @@ def _extract_symbol(
     
     # Build qualified name
     if language in ("cpp", "arduino"):
+        # Out-of-line `int Widget::Get() const {}` is qualified by its
+        # declarator scope so it shares a qualified name with the in-class
+        # declaration.  tree-sitter cannot tell a class scope from a
+        # namespace one, so `void ns::f() {}` is reported as a method too.
+        declarator_scope = (
+            _cpp_declarator_scope(node, spec, source_bytes)
+            if kind == "function" and not parent_symbol else []
+        )
         if parent_symbol:
             qualified_name = f"{parent_symbol.qualified_name}.{name}"
-        elif scope_parts:
-            qualified_name = ".".join([*scope_parts, name])
         else:
-            qualified_name = name
-        if kind == "function" and class_scope_depth > 0:
+            qualified_name = ".".join([*(scope_parts or []), *declarator_scope, name])
+        if kind == "function" and (class_scope_depth > 0 or declarator_scope):
             kind = "method"
     else:
         if parent_symbol:
@@ -640,6 +999,10 @@ def _extract_symbol(
 
     # Extract docstring
     docstring = _extract_docstring(signature_node, spec, source_bytes)
+    # JS/TS: the JSDoc block before `export function foo` is a sibling of the
+    # export_statement wrapper, not of the declaration inside it.
+    if not docstring and node.parent is not None and node.parent.type == "export_statement":
+        docstring = _extract_docstring(node.parent, spec, source_bytes)
 
     # Extract decorators
     decorators = _extract_decorators(node, spec, source_bytes)
@@ -660,6 +1023,16 @@ def _extract_symbol(
 
     # Detect interface / trait / abstract keywords for dispatch resolution
     iface_keywords = _detect_interface_keywords(node, language)
+    struct_fields = _extract_struct_fields(node, language, source_bytes)
+    receiver_type, pointer_receiver = (
+        _go_receiver(node, source_bytes) if language == "go" else ("", False)
+    )
+    is_declaration = language in ("c", "cpp", "arduino") and node.type in ("declaration", "field_declaration")
+    params, returns = _extract_signature_parts(node, language, source_bytes)
+    type_params = (
+        _go_type_params(node, source_bytes)
+        if language == "go" and node.type in ("function_declaration", "type_declaration") else []
+    )
 
     # Create symbol
     symbol = Symbol(
@@ -679,11 +1052,69 @@ def _extract_symbol(
         byte_offset=start_node.start_byte,
         byte_length=end_byte - start_node.start_byte,
         content_hash=c_hash,
+        fields=struct_fields,
+        receiver_type=receiver_type,
+        pointer_receiver=pointer_receiver,
+        is_declaration=is_declaration,
+        params=params,
+        returns=returns,
+        type_params=type_params,
     )
 
     return symbol
 
 
+# Value node types of an anonymous JS/TS default export, mapped to symbol kind.
+# Named forms (`export default function foo`, `export default class Foo`) are
+# ordinary declarations and go through _extract_symbol.
+_DEFAULT_EXPORT_KINDS = {
+    "function_expression": "function",
+    "function": "function",
+    "generator_function": "function",
+    "arrow_function": "function",
+    "class": "class",
+}
+
+
+def _extract_default_export(
+    node,
+    spec: LanguageSpec,
+    source_bytes: bytes,
+    filename: str,
+    language: str,
+) -> Optional[Symbol]:
+    """Extract an anonymous JS/TS default export as a symbol named ``default``."""
+    if node.has_error or not any(child.type == "default" for child in node.children):
+        return None
+    value = node.child_by_field_name("value")
+    if value is None:
+        value = next((c for c in node.named_children if c.type in _DEFAULT_EXPORT_KINDS), None)
+    if value is None or value.type not in _DEFAULT_EXPORT_KINDS:
+        return None
+    kind = _DEFAULT_EXPORT_KINDS[value.type]
+
+    body = value.child_by_field_name("body")
+    sig_end = body.start_byte if body else value.end_byte
+    signature = source_bytes[node.start_byte:sig_end].decode("utf-8").strip().rstrip("{: \n\t")
+
+    symbol_bytes = source_bytes[node.start_byte:node.end_byte]
+    return Symbol(
+        id=make_symbol_id(filename, "default", kind),
+        file=filename,
+        name="default",
+        qualified_name="default",
+        kind=kind,
+        language=language,
+        signature=signature,
+        docstring=_extract_docstring(node, spec, source_bytes),
+        line=node.start_point[0] + 1,
+        end_line=node.end_point[0] + 1,
+        byte_offset=node.start_byte,
+        byte_length=node.end_byte - node.start_byte,
+        content_hash=compute_content_hash(symbol_bytes),
+    )
+
+
 def _extract_name(node, spec: LanguageSpec, source_bytes: bytes) -> Optional[str]:
     """Extract the name from an AST node."""
     # Handle type_declaration in Go - name is in type_spec child
@@ -695,6 +1126,12 @@ def _extract_name(node, spec: LanguageSpec, source_bytes: bytes) -> Optional[str
                     return source_bytes[name_node.start_byte:name_node.end_byte].decode("utf-8")
         return None
 
+    # Rust: `impl [Trait for] Type` is named after Type, so its methods
+    # qualify as Type.method whether they sit in an inherent or trait impl.
+    if node.type == "impl_item" and spec.ts_language == "rust":
+        type_node = node.child_by_field_name("type")
+        return _rust_type_name(type_node, source_bytes) if type_node else None
+
     # Dart: mixin_declaration has identifier as direct child (no field name)
     if node.type == "mixin_declaration":
         for child in node.children:
@@ -775,6 +1212,19 @@ def _extract_name(node, spec: LanguageSpec, source_bytes: bytes) -> Optional[str
     return None
 
 
+def _rust_type_name(type_node, source_bytes: bytes) -> str:
+    """Bare name of a Rust type: ``&mut foo::Bar<T>`` -> ``Bar``."""
+    node = type_node
+    while node.type in ("generic_type", "reference_type", "pointer_type"):
+        inner = node.child_by_field_name("type")
+        if inner is None:
+            break
+        node = inner
+    if node.type == "scoped_type_identifier":
+        node = node.child_by_field_name("name") or node
+    return source_bytes[node.start_byte:node.end_byte].decode("utf-8").strip()
+
+
 def _extract_cpp_name(name_node, source_bytes: bytes) -> Optional[str]:
     """Extract C++ symbol names from nested declarators."""
     current = name_node
@@ -914,6 +1364,31 @@ def _has_function_declarator(node) -> bool:
     return False
 
 
+def _cpp_declarator_scope(node, spec: LanguageSpec, source_bytes: bytes) -> list[str]:
+    """Scope names of a qualified declarator: ``a::Box<T>::get`` -> ["a", "Box"]."""
+    field_name = spec.name_fields.get(node.type)
+    current = node.child_by_field_name(field_name) if field_name else None
+    while current is not None and current.type in (
+        "function_declarator", "pointer_declarator", "reference_declarator",
+        "parenthesized_declarator", "attributed_declarator",
+    ):
+        current = current.child_by_field_name("declarator") or next(
+            (c for c in current.named_children if c.type.endswith("declarator") or c.type == "qualified_identifier"),
+            None,
+        )
+    scope: list[str] = []
+    while current is not None and current.type == "qualified_identifier":
+        part = current.child_by_field_name("scope")
+        if part is not None and part.type == "template_type":
+            part = part.child_by_field_name("name")
+        if part is not None:
+            text = source_bytes[part.start_byte:part.end_byte].decode("utf-8").strip()
+            if text:
+                scope.append(text)
+        current = current.child_by_field_name("name")
+    return scope
+
+
 def _extract_cpp_namespace_name(node, source_bytes: bytes) -> Optional[str]:
     """Extract namespace name from a namespace_definition node."""
     name_node = node.child_by_field_name("name")
@@ -953,6 +1428,17 @@ def _looks_like_cpp_header(source_bytes: bytes) -> bool:
     return any(marker in text for marker in cpp_markers)
 
 
+def _declaration_has_error(node) -> bool:
+    """True when a parse error sits outside the node's ``body`` field."""
+    body = node.child_by_field_name("body")
+    if body is None:
+        return True
+    return any(
+        (child.has_error or child.is_missing) and child != body
+        for child in node.children
+    )
+
+
 def _count_error_nodes(node) -> int:
     """Count parser ERROR nodes in a syntax tree subtree."""
     count = 1 if node.type == "ERROR" else 0
@@ -966,7 +1452,7 @@ def _extract_docstring(node, spec: LanguageSpec, source_bytes: bytes) -> str:
     if spec.docstring_strategy == "next_sibling_string":
         return _extract_python_docstring(node, source_bytes)
     elif spec.docstring_strategy == "preceding_comment":
-        return _extract_preceding_comments(node, source_bytes)
+        return _extract_preceding_comments(node, source_bytes, inner_docs=spec.ts_language == "rust")
     return ""
 
 
@@ -1012,16 +1498,22 @@ def _strip_quotes(text: str) -> str:
     return text
 
 
-def _extract_preceding_comments(node, source_bytes: bytes) -> str:
-    """Extract comments that immediately precede a node."""
+def _extract_preceding_comments(node, source_bytes: bytes, inner_docs: bool = False) -> str:
+    """Extract comments that immediately precede a node.
+
+    With *inner_docs* (Rust), ``//!`` and ``/*!`` comments end the walk:
+    they document the enclosing module, not the item that follows them.
+    """
     comments = []
 
     # Walk backwards through siblings, skipping past annotations/decorators
     prev = node.prev_named_sibling
-    while prev and prev.type in ("annotation", "marker_annotation"):
+    while prev and prev.type in ("annotation", "marker_annotation", "attribute_item"):
         prev = prev.prev_named_sibling
     while prev and prev.type in ("comment", "line_comment", "block_comment", "documentation_comment", "pod"):
         comment_text = source_bytes[prev.start_byte:prev.end_byte].decode("utf-8")
+        if inner_docs and comment_text.startswith(("//!", "/*!")):
+            break
         comments.insert(0, comment_text)
         prev = prev.prev_named_sibling
     
@@ -1048,27 +1540,26 @@ def _clean_comment_markers(text: str) -> str:
     cleaned = []
     for line in lines:
         line = line.strip()
-        # Remove leading comment markers (order matters: longer prefixes first)
-        if line.startswith("/**"):
-            line = line[3:]
-        elif line.startswith("//!"):
-            line = line[3:]
-        elif line.startswith("///"):
-            line = line[3:]
-        elif line.startswith("//"):
-            line = line[2:]
-        elif line.startswith("/*"):
-            line = line[2:]
-        elif line.startswith("*"):
-            line = line[1:]
-        elif line.startswith("#"):
-            line = line[1:]
-
-        # Remove trailing */
+        # Remove trailing */ first so a closing " */" line doesn't leave a
+        # stray "/" behind once its leading "*" is taken as a marker.
         if line.endswith("*/"):
-            line = line[:-2]
+            line = line[:-2].rstrip()
 
-        cleaned.append(line.strip())
+        # Remove leading comment markers (order matters: longer prefixes first)
+        marker = ""
+        for prefix in ("/**", "//!", "///", "//", "/*", "*", "#"):
+            if line.startswith(prefix):
+                marker = prefix
+                line = line[len(prefix):]
+                break
+
+        # Drop the single space conventionally written after the marker but
+        # keep any deeper indentation: Go and Markdown mark code examples by
+        # indenting them (``//\tfmt.Println(x)``), and parser/docstring.py
+        # relies on that to pull them out as example blocks.
+        if marker and line.startswith(" "):
+            line = line[1:]
+        cleaned.append(line.rstrip() if marker else line.strip())
 
     return "\n".join(cleaned).strip()
 
@@ -1086,6 +1577,15 @@ def _extract_decorators(node, spec: LanguageSpec, source_bytes: bytes) -> list[s
             if child.type == spec.decorator_node_type:
                 decorator_text = source_bytes[child.start_byte:child.end_byte].decode("utf-8")
                 decorators.append(decorator_text.strip())
+    elif spec.decorator_container_type:
+        # Java: @Override and @SuppressWarnings("x") sit inside `modifiers`
+        # alongside the keywords.
+        for child in node.children:
+            if child.type == spec.decorator_container_type:
+                decorators.extend(
+                    _node_text(a, source_bytes) for a in child.children
+                    if a.type in _ANNOTATION_NODE_TYPES
+                )
     else:
         # Other languages: decorators are preceding siblings
         prev = node.prev_named_sibling
@@ -1201,10 +1701,11 @@ def _extract_constant(
                     content_hash=c_hash,
                 )
 
-    # C preprocessor #define macros
-    if node.type == "preproc_def":
+    # C preprocessor #define macros, object-like and function-like.  A bare
+    # `#define FOO_H` (include guard, feature flag) has no value and is skipped.
+    if node.type in ("preproc_def", "preproc_function_def"):
         name_node = node.child_by_field_name("name")
-        if name_node:
+        if name_node and node.child_by_field_name("value") is not None:
             name = source_bytes[name_node.start_byte:name_node.end_byte].decode("utf-8")
             if name.isupper() or (len(name) > 1 and name[0].isupper() and "_" in name):
                 sig = source_bytes[node.start_byte:node.end_byte].decode("utf-8").strip()
@@ -1219,6 +1720,7 @@ def _extract_constant(
                     kind="constant",
                     language=language,
                     signature=sig[:100],
+                    docstring=_extract_docstring(node, spec, source_bytes),
                     line=node.start_point[0] + 1,
                     end_line=node.end_point[0] + 1,
                     byte_offset=node.start_byte,
@@ -1325,6 +1827,31 @@ def _extract_constant(
             content_hash=c_hash,
         )
 
+    # Rust: const / static items (any case; the compiler already warns on
+    # non-UPPER_CASE names).
+    if node.type in ("const_item", "static_item") and language == "rust":
+        name_node = node.child_by_field_name("name")
+        if name_node:
+            name = source_bytes[name_node.start_byte:name_node.end_byte].decode("utf-8")
+            sig = source_bytes[node.start_byte:node.end_byte].decode("utf-8").strip()
+            const_bytes = source_bytes[node.start_byte:node.end_byte]
+            return Symbol(
+                id=make_symbol_id(filename, name, "constant"),
+                file=filename,
+                name=name,
+                qualified_name=name,
+                kind="constant",
+                language=language,
+                signature=sig[:100],
+                docstring=_extract_docstring(node, spec, source_bytes),
+                decorators=_extract_decorators(node, spec, source_bytes),
+                line=node.start_point[0] + 1,
+                end_line=node.end_point[0] + 1,
+                byte_offset=node.start_byte,
+                byte_length=node.end_byte - node.start_byte,
+                content_hash=compute_content_hash(const_bytes),
+            )
+
     # JS/TS/TSX: index `const` declarations as constants.
     # `export const foo = ...` appears as a lexical_declaration under an export_statement;
     # plain `const foo = ...` is a lexical_declaration at module scope.
@@ -1778,11 +2305,20 @@ def _disambiguate_and_compute_complexity(
         id_counts = Counter(s.id for s in symbols)
         duplicated = {sid for sid, count in id_counts.items() if count > 1}
 
+    # Children copy their parent's id before it gets its ~N suffix.  Symbols
+    # are in walk order (a parent precedes its children, and they precede
+    # the parent's next namesake), so the latest rename is the right one —
+    # e.g. the methods of each of several Rust `impl Point` blocks.
+    renamed: dict[str, str] = {}
     result = []
     for sym in symbols:
-        if has_duplicates and sym.id in duplicated:
-            ordinals[sym.id] = ordinals.get(sym.id, 0) + 1
-            sym.id = f"{sym.id}~{ordinals[sym.id]}"
+        if has_duplicates:
+            if sym.parent in renamed:
+                sym.parent = renamed[sym.parent]
+            if sym.id in duplicated:
+                ordinals[sym.id] = ordinals.get(sym.id, 0) + 1
+                renamed[sym.id] = f"{sym.id}~{ordinals[sym.id]}"
+                sym.id = renamed[sym.id]
         if sym.kind in _CALLABLE_KINDS and sym.byte_length > 0:
             body = source_bytes[sym.byte_offset:sym.byte_offset + sym.byte_length].decode("utf-8", errors="replace")
             sym.cyclomatic, sym.max_nesting, sym.param_count = compute_complexity(body, sym.signature)
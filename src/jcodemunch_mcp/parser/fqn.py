@@ -1,14 +1,34 @@
-"""Bi-directional translation between jcodemunch symbol IDs and PHP FQNs.
+"""Fully-qualified names: PHP FQNs via PSR-4, and import-path-aware IDs.
 
 Enables interoperability between jcodemunch (``app/Models/User.php::User#class``)
 and PhpStorm / Laravel Idea (``App\\Models\\User``).  Uses PSR-4 mappings from
 composer.json to perform the conversion.
+
+Go and Python symbols also carry a stored ``fqn`` computed at parse time, so
+``Config`` in two packages stays distinguishable:
+
+* Go: ``<import path>.<Type>.<Method>`` — the import path is the nearest
+  ``go.mod`` module plus the file's directory (the directory itself, or the
+  ``package`` clause name at the root, when no go.mod is available).  External
+  test packages (``package foo_test``) get a ``_test`` suffix, as ``go list``
+  reports them.
+* Python: ``<dotted module>.<qualified name>``, with a leading ``src/`` and
+  trailing ``__init__`` dropped from the module path.
 """
 
+import os
+import posixpath
+import re
 from typing import Optional
 
 from .imports import resolve_php_namespace
 
+_GO_MODULE_RE = re.compile(r"^module\s+(\S+)", re.MULTILINE)
+_GO_PACKAGE_RE = re.compile(r"^package\s+([A-Za-z_]\w*)", re.MULTILINE)
+
+# go.mod path -> (mtime, module path); re-read when the file changes.
+_go_mod_cache: dict[str, tuple[float, Optional[str]]] = {}
+
 
 def symbol_to_fqn(symbol_id: str, psr4_map: dict[str, str]) -> Optional[str]:
     """Convert a jcodemunch symbol ID to a PHP fully-qualified name.
@@ -95,3 +115,94 @@ def fqn_to_symbol(
     if method_name:
         return f"{file_path}::{class_name}.{method_name}#method"
     return f"{file_path}::{class_name}#class"
+
+
+def _go_module(go_mod: str) -> Optional[str]:
+    """Module path declared by the go.mod at *go_mod*, or None."""
+    try:
+        mtime = os.stat(go_mod).st_mtime
+    except OSError:
+        return None
+    cached = _go_mod_cache.get(go_mod)
+    if cached is not None and cached[0] == mtime:
+        return cached[1]
+    try:
+        with open(go_mod, encoding="utf-8") as fh:
+            m = _GO_MODULE_RE.search(fh.read())
+    except OSError:
+        return None
+    module = m.group(1) if m else None
+    _go_mod_cache[go_mod] = (mtime, module)
+    return module
+
+
+def go_module_import_path(source_root: Optional[str], directory: str) -> Optional[str]:
+    """Import path of *directory* from the nearest go.mod under *source_root*, or None."""
+    if not source_root or not os.path.isdir(source_root):
+        return None
+    parts = directory.split("/") if directory else []
+    for i in range(len(parts), -1, -1):
+        module = _go_module(os.path.join(source_root, *parts[:i], "go.mod"))
+        if module:
+            rel = "/".join(parts[i:])
+            return f"{module}/{rel}" if rel else module
+    return None
+
+
+def go_import_path(filename: str, content: str, source_root: Optional[str] = None) -> str:
+    """Import path of the package *filename* belongs to."""
+    directory = posixpath.dirname(filename)
+    m = _GO_PACKAGE_RE.search(content)
+    package = m.group(1) if m else ""
+
+    path = go_module_import_path(source_root, directory) or directory or package
+    if package.endswith("_test") and path and not path.endswith("_test"):
+        path += "_test"
+    return path
+
+
+def python_module_path(filename: str) -> str:
+    """Dotted module path of a Python file (``src/app/db.py`` → ``app.db``)."""
+    path = posixpath.splitext(filename)[0]
+    if path.startswith("src/"):
+        path = path[4:]
+    parts = [p for p in path.split("/") if p]
+    if parts and parts[-1] == "__init__":
+        parts.pop()
+    return ".".join(parts)
+
+
+def _local_name(qualified_name: str, receiver_type: str = "") -> str:
+    """Name within its package: Go methods are qualified by their receiver."""
+    return f"{receiver_type}.{qualified_name}" if receiver_type else qualified_name
+
+
+def assign_fqns(symbols: list, filename: str, language: str, content: str,
+                source_root: Optional[str] = None) -> None:
+    """Set ``fqn`` on Go and Python symbols in place; other languages are left empty."""
+    if language == "go":
+        prefix = go_import_path(filename, content, source_root)
+    elif language == "python":
+        prefix = python_module_path(filename)
+    else:
+        return
+    for sym in symbols:
+        local = _local_name(sym.qualified_name, getattr(sym, "receiver_type", ""))
+        sym.fqn = f"{prefix}.{local}" if prefix else local
+
+
+def fqn_package(symbol: dict) -> str:
+    """The import path (Go) or module (Python) part of a symbol's stored ``fqn``."""
+    fqn = symbol.get("fqn") or ""
+    local = _local_name(symbol.get("qualified_name", ""), symbol.get("receiver_type", ""))
+    if fqn.endswith("." + local):
+        return fqn[: -len(local) - 1]
+    return ""
+
+
+def find_symbol_by_fqn(symbols: list[dict], fqn: str) -> Optional[dict]:
+    """The indexed symbol whose stored ``fqn`` is *fqn*, preferring definitions."""
+    matches = [s for s in symbols if s.get("fqn") == fqn]
+    if not matches:
+        return None
+    return min(matches, key=lambda s: (bool(s.get("is_declaration")), s.get("file", ""), s.get("line", 0)))
@@ -0,0 +1,421 @@
+"""Go method sets and interface satisfaction from stored signatures.
+
+The Go extractor stores a ``type_declaration`` symbol's whole declaration
+as its signature (the node has no ``body`` field), and a method's
+signature includes its receiver.  That is enough to rebuild, without
+re-parsing any file:
+
+    interface method sets   explicit methods plus embedded interfaces
+    concrete method sets    value vs pointer receivers, plus methods
+                            promoted through embedded struct fields
+    satisfaction            the method-set rules of the Go spec: ``T``
+                            carries value-receiver methods, ``*T`` carries
+                            both; an embedded ``S`` promotes S's value
+                            methods to ``T`` and all of S's methods to
+                            ``*T``; an embedded ``*S`` promotes all of
+                            S's methods to both
+
+Types are keyed by ``(package_dir, name)``.  Method signatures compare
+parameter and result *types* (names dropped), with package qualifiers
+removed so ``a.Foo`` matches ``Foo`` across packages.  Interfaces with
+unexported methods are only satisfied inside their own package, as in
+Go.  Generic interfaces compare method names and arity only.
+
+Pure text processing over index symbol dicts — no parser access.
+"""
+
+from __future__ import annotations
+
+import posixpath
+import re
+from dataclasses import dataclass, field
+from typing import Optional
+
+_COMMENT_RE = re.compile(r"//[^\n]*|/\*.*?\*/", re.DOTALL)
+_WS_RE = re.compile(r"\s+")
+_QUALIFIER_RE = re.compile(r"\b[A-Za-z_]\w*\.(?=[A-Za-z_])")
+_IDENT_RE = re.compile(r"[A-Za-z_]\w*")
+_TYPE_KEYWORDS = frozenset({"chan", "func", "map", "struct", "interface"})
+
+# Predeclared and common standard-library interfaces, so embedding them
+# (``io.Reader``, ``error``) resolves without the stdlib being indexed.
+# Values are ``name -> normalised signature key``.
+_BUILTIN_INTERFACES: dict[str, dict[str, str]] = {
+    "error": {"Error": "()string"},
+    "any": {},
+    "fmt.Stringer": {"String": "()string"},
+    "io.Reader": {"Read": "([]byte)(int,error)"},
+    "io.Writer": {"Write": "([]byte)(int,error)"},
+    "io.Closer": {"Close": "()error"},
+    "io.ReadWriter": {"Read": "([]byte)(int,error)", "Write": "([]byte)(int,error)"},
+    "io.ReadCloser": {"Read": "([]byte)(int,error)", "Close": "()error"},
+    "io.WriteCloser": {"Write": "([]byte)(int,error)", "Close": "()error"},
+    "io.ReadWriteCloser": {
+        "Read": "([]byte)(int,error)", "Write": "([]byte)(int,error)", "Close": "()error",
+    },
+    "sort.Interface": {"Len": "()int", "Less": "(int,int)bool", "Swap": "(int,int)"},
+}
+
+
+@dataclass
+class GoTypeSpec:
+    """One ``type`` spec parsed out of a declaration signature."""
+    name: str
+    kind: str                       # "interface" | "struct" | "other"
+    generic: bool = False
+    methods: dict[str, str] = field(default_factory=dict)    # interface: name -> key
+    embeds: list[str] = field(default_factory=list)          # interface: embedded names
+    type_terms: bool = False        # interface has union / ~T elements (constraint)
+    fields: list[tuple[str, bool]] = field(default_factory=list)  # struct: (embedded, pointer)
+
+
+@dataclass
+class GoMethod:
+    """A method declaration, split into receiver and signature key."""
+    receiver: str
+    pointer: bool
+    name: str
+    key: str
+
+
+def _split_top(text: str, seps: str) -> list[str]:
+    """Split on any char in *seps* at bracket depth 0."""
+    parts: list[str] = []
+    depth = 0
+    cur: list[str] = []
+    for ch in text:
+        if ch in "([{":
+            depth += 1
+        elif ch in ")]}":
+            depth -= 1
+        if depth == 0 and ch in seps:
+            parts.append("".join(cur))
+            cur = []
+            continue
+        cur.append(ch)
+    parts.append("".join(cur))
+    return [p.strip() for p in parts if p.strip()]
+
+
+def _balanced(text: str, pos: int) -> int:
+    """Return the index just past the bracket group opening at ``text[pos]``."""
+    depth = 0
+    for i in range(pos, len(text)):
+        if text[i] in "([{":
+            depth += 1
+        elif text[i] in ")]}":
+            depth -= 1
+            if depth == 0:
+                return i + 1
+    return len(text)
+
+
+def _norm_type(text: str) -> str:
+    text = _QUALIFIER_RE.sub("", _WS_RE.sub(" ", text.strip()))
+    text = re.sub(r"\s*([,()\[\]{}*])\s*", r"\1", text)
+    return text
+
+
+def _param_types(inner: str) -> list[str]:
+    """Types of a parameter list body, one per parameter (names dropped)."""
+    entries = _split_top(inner, ",")
+    if not entries:
+        return []
+    split: list[tuple[str, Optional[str]]] = []
+    named = False
+    for entry in entries:
+        m = _IDENT_RE.match(entry)
+        rest = entry[m.end():].strip() if m else ""
+        qualified = rest.startswith(".") and not rest.startswith("...")  # pkg.Type
+        if m and rest and m.group(0) not in _TYPE_KEYWORDS and not qualified:
+            split.append((m.group(0), rest))
+            named = True
+        else:
+            split.append((entry, None))
+    if not named:
+        return [_norm_type(e) for e, _ in split]
+    # Named form: ``a, b int`` — a bare name takes the next entry's type.
+    types: list[str] = []
+    pending = 0
+    for _, typ in split:
+        if typ is None:
+            pending += 1
+            continue
+        types.extend([_norm_type(typ)] * (pending + 1))
+        pending = 0
+    return types
+
+
+def _signature_key(params: str, results: str) -> str:
+    """``(params)results`` with parameter names dropped."""
+    ptypes = _param_types(params)
+    results = results.strip()
+    if results.startswith("("):
+        rtypes = _param_types(results[1:_balanced(results, 0) - 1])
+        rkey = "(" + ",".join(rtypes) + ")" if len(rtypes) > 1 else "".join(rtypes)
+    else:
+        rkey = _norm_type(results)
+    return "(" + ",".join(ptypes) + ")" + rkey
+
+
+def _parse_interface_body(body: str, spec: GoTypeSpec) -> None:
+    for elem in _split_top(body, "\n;"):
+        m = re.match(r"([A-Za-z_]\w*)\s*\(", elem)
+        if m:
+            open_at = elem.index("(", m.end() - 1)
+            close_at = _balanced(elem, open_at)
+            spec.methods[m.group(1)] = _signature_key(elem[open_at + 1:close_at - 1], elem[close_at:])
+        elif "|" in elem or elem.startswith("~"):
+            spec.type_terms = True
+        else:
+            spec.embeds.append(re.sub(r"\[.*\]$", "", elem).strip())
+
+
+def _parse_struct_body(body: str, spec: GoTypeSpec) -> None:
+    for line in _split_top(body, "\n;"):
+        line = re.sub(r"\s*`[^`]*`\s*$|\s*\"[^\"]*\"\s*$", "", line)
+        m = re.fullmatch(r"(\*?)\s*((?:[A-Za-z_]\w*\.)?[A-Za-z_]\w*)(?:\[.*\])?", line)
+        if m:
+            spec.fields.append((m.group(2), bool(m.group(1))))
+
+
+def parse_type_declaration(signature: str) -> list[GoTypeSpec]:
+    """Parse every spec in a ``type X ...`` or ``type ( ... )`` declaration."""
+    text = _COMMENT_RE.sub("", signature or "").strip()
+    if not text.startswith("type"):
+        return []
+    text = text[4:].strip()
+    if text.startswith("("):
+        chunks = _split_top(text[1:_balanced(text, 0) - 1], "\n;")
+    else:
+        chunks = [text]
+
+    specs: list[GoTypeSpec] = []
+    for chunk in chunks:
+        m = _IDENT_RE.match(chunk)
+        if not m:
+            continue
+        name = m.group(0)
+        rest = chunk[m.end():].lstrip()
+        generic = False
+        if re.match(r"\[\s*[A-Za-z_]\w*\s+\S", rest):
+            rest = rest[_balanced(rest, 0):].lstrip()
+            generic = True
+        rest = rest.lstrip("=").lstrip()
+        for kind in ("interface", "struct"):
+            if rest.startswith(kind) and rest[len(kind):].lstrip().startswith("{"):
+                brace = rest.index("{")
+                body = rest[brace + 1:_balanced(rest, brace) - 1]
+                spec = GoTypeSpec(name=name, kind=kind, generic=generic)
+                if kind == "interface":
+                    _parse_interface_body(body, spec)
+                else:
+                    _parse_struct_body(body, spec)
+                specs.append(spec)
+                break
+        else:
+            specs.append(GoTypeSpec(name=name, kind="other", generic=generic))
+    return specs
+
+
+def parse_method_signature(signature: str) -> Optional[GoMethod]:
+    """Parse ``func (r *T) Name(params) results`` into a GoMethod."""
+    text = _COMMENT_RE.sub("", signature or "").strip()
+    if not text.startswith("func"):
+        return None
+    text = text[4:].lstrip()
+    if not text.startswith("("):
+        return None
+    recv_end = _balanced(text, 0)
+    recv = text[1:recv_end - 1].strip()
+    # Drop the type-parameter list first: ``m *Map[K, V]`` would otherwise
+    # split inside the brackets.
+    bracket = recv.find("[")
+    if bracket != -1:
+        recv = recv[:bracket].rstrip()
+    recv_type = recv.split()[-1] if recv else ""
+    pointer = recv_type.startswith("*")
+    recv_type = recv_type.lstrip("*")
+    rest = text[recv_end:].lstrip()
+    m = _IDENT_RE.match(rest)
+    if not m or not recv_type:
+        return None
+    rest = rest[m.end():].lstrip()
+    if not rest.startswith("("):
+        return None
+    params_end = _balanced(rest, 0)
+    key = _signature_key(rest[1:params_end - 1], rest[params_end:])
+    return GoMethod(receiver=recv_type, pointer=pointer, name=m.group(0), key=key)
+
+
+def _arity(key: str) -> int:
+    inner = key[1:_balanced(key, 0) - 1]
+    return len(_split_top(inner, ",")) if inner else 0
+
+
+class GoTypeIndex:
+    """Go types and methods of one index, keyed by ``(package_dir, name)``."""
+
+    def __init__(self, symbols: list[dict]):
+        self.types: dict[tuple[str, str], GoTypeSpec] = {}
+        self.symbols: dict[tuple[str, str], dict] = {}
+        self.methods: dict[tuple[str, str], dict[str, GoMethod]] = {}
+        self._dirs_by_pkg: dict[str, list[str]] = {}
+        for sym in symbols:
+            if sym.get("language") != "go":
+                continue
+            pkg = posixpath.dirname(sym.get("file", ""))
+            if sym.get("kind") == "type":
+                for spec in parse_type_declaration(sym.get("signature", "")):
+                    self.types.setdefault((pkg, spec.name), spec)
+                    self.symbols.setdefault((pkg, spec.name), sym)
+                    self._dirs_by_pkg.setdefault(posixpath.basename(pkg), []).append(pkg)
+            elif sym.get("kind") == "method":
+                meth = parse_method_signature(sym.get("signature", ""))
+                if meth is not None and sym.get("receiver_type"):
+                    # The signature split misreads ``(m *Map[K, V])``; the
+                    # extractor's receiver fields come from the syntax tree.
+                    meth = GoMethod(
+                        receiver=sym["receiver_type"], pointer=bool(sym.get("pointer_receiver")),
+                        name=meth.name, key=meth.key,
+                    )
+                if meth is not None:
+                    self.methods.setdefault((pkg, meth.receiver), {})[meth.name] = meth
+
+    def resolve(self, pkg: str, ref: str) -> Optional[tuple[str, str]]:
+        """Resolve a (possibly ``pkg.``-qualified) type name used in *pkg*."""
+        if "." not in ref:
+            return (pkg, ref) if (pkg, ref) in self.types else None
+        qual, name = ref.rsplit(".", 1)
+        candidates = [d for d in self._dirs_by_pkg.get(qual, []) if (d, name) in self.types]
+        return (candidates[0], name) if candidates else None
+
+    def interface_methods(
+        self, key: tuple[str, str], _seen: Optional[set] = None,
+    ) -> tuple[dict[str, str], list[str], bool]:
+        """Full method set of an interface: (methods, unresolved embeds, has type terms)."""
+        seen = _seen if _seen is not None else set()
+        seen.add(key)
+        spec = self.types[key]
+        methods = dict(spec.methods)
+        unresolved: list[str] = []
+        type_terms = spec.type_terms
+        for embed in spec.embeds:
+            target = self.resolve(key[0], embed)
+            if target is None:
+                builtin = _BUILTIN_INTERFACES.get(embed)
+                if builtin is None:
+                    unresolved.append(embed)
+                else:
+                    for name, sig in builtin.items():
+                        methods.setdefault(name, sig)
+                continue
+            if target in seen or self.types[target].kind != "interface":
+                continue
+            sub, sub_unresolved, sub_terms = self.interface_methods(target, seen)
+            for name, sig in sub.items():
+                methods.setdefault(name, sig)
+            unresolved.extend(sub_unresolved)
+            type_terms = type_terms or sub_terms
+        return methods, unresolved, type_terms
+
+    def method_sets(
+        self, key: tuple[str, str], _depth: int = 0,
+    ) -> tuple[dict[str, str], dict[str, str]]:
+        """(value method set, pointer method set) of a concrete type, name -> key."""
+        value: dict[str, str] = {}
+        pointer: dict[str, str] = {}
+        for name, meth in self.methods.get(key, {}).items():
+            pointer[name] = meth.key
+            if not meth.pointer:
+                value[name] = meth.key
+        spec = self.types.get(key)
+        if spec is None or spec.kind != "struct" or _depth >= 5:
+            return value, pointer
+        # Promotion through embedded fields; a shallower method shadows.
+        for ref, embedded_ptr in spec.fields:
+            target = self.resolve(key[0], ref)
+            if target is None:
+                builtin = _BUILTIN_INTERFACES.get(ref)
+                sub_value = sub_ptr = builtin or {}
+            elif self.types[target].kind == "interface":
+                sub_value = sub_ptr = self.interface_methods(target)[0]
+            else:
+                sub_value, sub_ptr = self.method_sets(target, _depth + 1)
+            for name, sig in (sub_ptr if embedded_ptr else sub_value).items():
+                value.setdefault(name, sig)
+            for name, sig in sub_ptr.items():
+                pointer.setdefault(name, sig)
+        return value, pointer
+
+    def satisfied_interfaces(
+        self, key: tuple[str, str],
+    ) -> list[tuple[Optional[tuple[str, str]], str, str]]:
+        """Interfaces concrete type *key* satisfies: ``(iface_key, name, form)``.
+
+        Indexed interfaces come back with their key; the builtin table
+        (``error``, ``io.Reader``, ...) with ``None``.  Interfaces with an
+        empty method set, type terms (constraints), or unresolved embeds
+        are skipped — respectively trivially satisfied, not usable as
+        ordinary interfaces, and not decidable from the index.
+        """
+        value, pointer = self.method_sets(key)
+        candidates: list[tuple[Optional[tuple[str, str]], str, dict[str, str], bool]] = []
+        for iface, spec in self.types.items():
+            if spec.kind != "interface" or iface == key:
+                continue
+            required, unresolved, terms = self.interface_methods(iface)
+            if not required or unresolved or terms:
+                continue
+            if any(not n[:1].isupper() for n in required) and iface[0] != key[0]:
+                continue
+            candidates.append((iface, spec.name, required, spec.generic))
+        for name, required in _BUILTIN_INTERFACES.items():
+            if required:
+                candidates.append((None, name, required, False))
+        found: list[tuple[Optional[tuple[str, str]], str, str]] = []
+        for iface, name, required, generic in candidates:
+            if satisfies(required, value, generic=generic):
+                found.append((iface, name, "value"))
+            elif satisfies(required, pointer, generic=generic):
+                found.append((iface, name, "pointer"))
+        return found
+
+    def implementers(self, key: tuple[str, str]) -> list[tuple[tuple[str, str], str]]:
+        """Concrete types satisfying interface *key*, with the receiver form needed.
+
+        The form is ``"value"`` when ``T`` itself satisfies the interface and
+        ``"pointer"`` when only ``*T`` does.  Callers should check for an
+        empty method set first — every type satisfies it.
+        """
+        required, _unresolved, _terms = self.interface_methods(key)
+        generic = self.types[key].generic
+        package_only = any(not name[:1].isupper() for name in required)
+        found: list[tuple[tuple[str, str], str]] = []
+        for cand, spec in self.types.items():
+            if spec.kind == "interface" or cand == key:
+                continue
+            if package_only and cand[0] != key[0]:
+                continue
+            value, pointer = self.method_sets(cand)
+            if satisfies(required, value, generic=generic):
+                found.append((cand, "value"))
+            elif satisfies(required, pointer, generic=generic):
+                found.append((cand, "pointer"))
+        return found
+
+
+def satisfies(
+    required: dict[str, str], have: dict[str, str], *, generic: bool = False,
+) -> bool:
+    """True when *have* contains every method in *required* with a matching signature."""
+    for name, sig in required.items():
+        got = have.get(name)
+        if got is None:
+            return False
+        if generic:
+            if _arity(got) != _arity(sig):
+                return False
+        elif got != sig:
+            return False
+    return True
@@ -13,21 +13,33 @@ class SymbolNode:
     children: list["SymbolNode"] = field(default_factory=list)
 
 
-def build_symbol_tree(symbols: list[Symbol]) -> list[SymbolNode]:
+def build_symbol_tree(symbols: list[Symbol], group_methods: bool = False) -> list[SymbolNode]:
     """Build a hierarchical tree from flat symbol list.
     
     Methods become children of their parent classes.
     Returns top-level symbols (classes and standalone functions).
+
+    Go methods have no parent (the type does not enclose them).  With
+    *group_methods*, each one becomes a child of the type named by its
+    ``receiver_type`` when that type is in *symbols*; the rest stay roots.
     """
     # Create a map of symbol ID to node
     node_map = {s.id: SymbolNode(symbol=s) for s in symbols}
+    go_types: dict[str, str] = {}
+    if group_methods:
+        for s in symbols:
+            if s.language == "go" and s.kind == "type":
+                go_types.setdefault(s.name, s.id)
     
     # Build parent-child relationships
     roots = []
     for symbol in symbols:
         node = node_map[symbol.id]
-        if symbol.parent and symbol.parent in node_map:
-            parent_node = node_map[symbol.parent]
+        parent = symbol.parent
+        if not parent and symbol.receiver_type:
+            parent = go_types.get(symbol.receiver_type)
+        if parent and parent in node_map:
+            parent_node = node_map[parent]
             parent_node.children.append(node)
         else:
             roots.append(node)
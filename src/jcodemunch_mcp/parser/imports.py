@@ -77,13 +77,16 @@ _PY_FROM = re.compile(
 )
 _PY_IMPORT = re.compile(r"""^[ \t]*import\s+([\w.,][^\n]*)$""", re.MULTILINE)
 
-# Go: import "pkg"  or import ( ... )
+# Go: import "pkg"  or import ( ... ); an optional name, "." or "_" alias
+# precedes the path.
 _GO_IMPORT_BLOCK = re.compile(r"""import\s*\((.*?)\)""", re.DOTALL)
-_GO_IMPORT_LINE = re.compile(r"""import\s+(?:\w+\s+)?["']([^"']+)["']""")
-_GO_IMPORT_ENTRY = re.compile(r"""(?:\w+\s+)?["']([^"']+)["']""")
+_GO_IMPORT_LINE = re.compile(r"""import\s+(?:(\.|\w+)\s+)?["']([^"']+)["']""")
+_GO_IMPORT_ENTRY = re.compile(r"""(?:(\.|\w+)\s+)?["']([^"']+)["']""")
 
 # Java/Kotlin: import com.example.Foo
 _JAVA_IMPORT = re.compile(r"""^import\s+(?:static\s+)?([\w.]+)\s*;?$""", re.MULTILINE)
+# Java: package com.example;  (package-info.java may annotate it first)
+_JAVA_PACKAGE = re.compile(r"""^[ \t]*(?:@[\w.]+(?:\([^)]*\))?\s*)*package\s+([\w.]+)\s*;""", re.MULTILINE)
 
 # Rust: use crate::foo::{Bar, Baz}
 _RUST_USE = re.compile(r"""^use\s+([\w::{},\s*]+)\s*;""", re.MULTILINE)
@@ -263,23 +266,28 @@ def _extract_python_imports(content: str) -> list[dict]:
 
 
 def _extract_go_imports(content: str) -> list[dict]:
+    """Go import specs.  Aliased, dot (``.``) and blank (``_``) imports carry
+    the alias in an ``alias`` key."""
     edges = []
     seen: set[str] = set()
 
+    def add(alias: Optional[str], spec: str) -> None:
+        if spec in seen:
+            return
+        seen.add(spec)
+        edge = {"specifier": spec, "names": []}
+        if alias:
+            edge["alias"] = alias
+        edges.append(edge)
+
     # Block imports
     for block_m in _GO_IMPORT_BLOCK.finditer(content):
         for entry_m in _GO_IMPORT_ENTRY.finditer(block_m.group(1)):
-            spec = entry_m.group(1)
-            if spec not in seen:
-                seen.add(spec)
-                edges.append({"specifier": spec, "names": []})
+            add(entry_m.group(1), entry_m.group(2))
 
     # Single-line imports
     for m in _GO_IMPORT_LINE.finditer(content):
-        spec = m.group(1)
-        if spec not in seen:
-            seen.add(spec)
-            edges.append({"specifier": spec, "names": []})
+        add(m.group(1), m.group(2))
 
     return edges
 
@@ -295,6 +303,12 @@ def _extract_java_imports(content: str, language: str) -> list[dict]:
     return edges
 
 
+def java_package(content: str) -> str:
+    """The ``package`` a Java file declares, or "" for the default package."""
+    m = _JAVA_PACKAGE.search(content)
+    return m.group(1) if m else ""
+
+
 def _extract_rust_imports(content: str) -> list[dict]:
     edges = []
     seen: set[str] = set()
@@ -46,9 +46,13 @@ class LanguageSpec:
     type_patterns: list[str]       # Node types for type definitions
 
     # If True, decorators are direct children of the declaration node (e.g. C#)
-    # If False (default), decorators are preceding siblings (e.g. Python, Java)
+    # If False (default), decorators are preceding siblings (e.g. Python)
     decorator_from_children: bool = False
 
+    # Child node that holds the decorators, when they are neither siblings
+    # nor direct children (Java annotations live inside ``modifiers``)
+    decorator_container_type: Optional[str] = None
+
 
 # File extension to language mapping
 LANGUAGE_EXTENSIONS = {
@@ -405,14 +409,16 @@ RUST_SPEC = LanguageSpec(
     ts_language="rust",
     symbol_node_types={
         "function_item": "function",
+        "function_signature_item": "function",  # trait method without a body
         "struct_item": "type",
         "enum_item": "type",
         "trait_item": "type",
-        "impl_item": "class",
+        "impl_item": "class",  # named after the implementing type
         "type_item": "type",
     },
     name_fields={
         "function_item": "name",
+        "function_signature_item": "name",
         "struct_item": "name",
         "enum_item": "name",
         "trait_item": "name",
@@ -420,9 +426,11 @@ RUST_SPEC = LanguageSpec(
     },
     param_fields={
         "function_item": "parameters",
+        "function_signature_item": "parameters",
     },
     return_type_fields={
         "function_item": "return_type",
+        "function_signature_item": "return_type",
     },
     docstring_strategy="preceding_comment",
     decorator_node_type="attribute_item",
@@ -439,28 +447,37 @@ JAVA_SPEC = LanguageSpec(
         "method_declaration": "method",
         "constructor_declaration": "method",
         "class_declaration": "class",
+        "record_declaration": "class",
         "interface_declaration": "type",
         "enum_declaration": "type",
+        "annotation_type_declaration": "type",
     },
     name_fields={
         "method_declaration": "name",
         "constructor_declaration": "name",
         "class_declaration": "name",
+        "record_declaration": "name",
         "interface_declaration": "name",
         "enum_declaration": "name",
+        "annotation_type_declaration": "name",
     },
     param_fields={
         "method_declaration": "parameters",
         "constructor_declaration": "parameters",
+        "record_declaration": "parameters",
     },
     return_type_fields={
         "method_declaration": "type",
     },
     docstring_strategy="preceding_comment",
     decorator_node_type="marker_annotation",
-    container_node_types=["class_declaration", "interface_declaration", "enum_declaration"],
-    constant_patterns=["field_declaration"],
-    type_patterns=["interface_declaration", "enum_declaration"],
+    container_node_types=[
+        "class_declaration", "record_declaration", "interface_declaration",
+        "enum_declaration", "annotation_type_declaration",
+    ],
+    constant_patterns=["field_declaration", "constant_declaration"],
+    type_patterns=["interface_declaration", "enum_declaration", "annotation_type_declaration"],
+    decorator_container_type="modifiers",
 )
 
 
@@ -606,6 +623,7 @@ C_SPEC = LanguageSpec(
     ts_language="c",
     symbol_node_types={
         "function_definition": "function",
+        "declaration": "function",  # prototypes; non-function declarations are filtered
         "struct_specifier": "type",
         "enum_specifier": "type",
         "union_specifier": "type",
@@ -613,6 +631,7 @@ C_SPEC = LanguageSpec(
     },
     name_fields={
         "function_definition": "declarator",
+        "declaration": "declarator",
         "struct_specifier": "name",
         "enum_specifier": "name",
         "union_specifier": "name",
@@ -620,14 +639,16 @@ C_SPEC = LanguageSpec(
     },
     param_fields={
         "function_definition": "declarator",
+        "declaration": "declarator",
     },
     return_type_fields={
         "function_definition": "type",
+        "declaration": "type",
     },
     docstring_strategy="preceding_comment",
     decorator_node_type=None,
     container_node_types=[],
-    constant_patterns=["preproc_def"],
+    constant_patterns=["preproc_def", "preproc_function_def"],
     type_patterns=["type_definition", "enum_specifier", "struct_specifier", "union_specifier"],
 )
 
@@ -706,7 +727,7 @@ CPP_SPEC = LanguageSpec(
     docstring_strategy="preceding_comment",
     decorator_node_type=None,
     container_node_types=["class_specifier", "struct_specifier", "union_specifier"],
-    constant_patterns=["preproc_def"],
+    constant_patterns=["preproc_def", "preproc_function_def"],
     type_patterns=["class_specifier", "struct_specifier", "union_specifier", "enum_specifier", "type_definition", "alias_declaration"],
 )
 
@@ -0,0 +1,176 @@
+"""In-process cache of ``parse_file`` results.
+
+Incremental indexing already skips files whose mtime/hash are unchanged, but
+several paths still re-parse identical content: ``incremental=false`` full
+rebuilds, ``index_file`` on an unchanged file, branch switches, and sibling
+worktrees that share most of their tree.  Tree-sitter parsing dominates those
+runs, so results are cached here keyed by (language, file path, content
+digest).  The file path is part of the key because symbol IDs embed it.
+
+Entries are evicted least-recently-used once either ``parse_cache_max_entries``
+or ``parse_cache_max_bytes`` is exceeded (0 for either disables the cache).
+An entry's size is estimated from its symbols' strings and lists when it is
+stored, so a long-lived server on a large monorepo stays near the byte
+budget rather than the entry count; an entry larger than the whole budget
+is not stored at all.  Hit, miss, and eviction counters are reported by
+``stats()`` (and ``server_info``).
+
+Symbols are cloned on the way in and out: the indexing pipeline mutates them
+in place (summaries, context enrichment), and those edits must not leak into
+later hits.  Every access holds one lock, so parallel parse workers can share
+the cache.
+"""
+
+from __future__ import annotations
+
+import copy
+import dataclasses
+import hashlib
+import threading
+from collections import OrderedDict
+from typing import Optional
+
+from .symbols import Symbol
+
+_DEFAULT_MAX_ENTRIES = 4096
+_DEFAULT_MAX_BYTES = 256 * 1024 * 1024
+
+# Rough CPython object overheads used by _approx_size.
+_OBJ_OVERHEAD = 56
+_STR_OVERHEAD = 49
+_REF_SIZE = 8
+
+_cache: "OrderedDict[tuple[str, str, str], tuple[list[Symbol], int]]" = OrderedDict()
+_lock = threading.Lock()
+_bytes = 0
+_hits = 0
+_misses = 0
+_evictions = 0
+
+
+def _max_entries() -> int:
+    try:
+        from .. import config as _cfg
+        return int(_cfg.get("parse_cache_max_entries", _DEFAULT_MAX_ENTRIES))
+    except Exception:
+        return _DEFAULT_MAX_ENTRIES
+
+
+def _max_bytes() -> int:
+    try:
+        from .. import config as _cfg
+        return int(_cfg.get("parse_cache_max_bytes", _DEFAULT_MAX_BYTES))
+    except Exception:
+        return _DEFAULT_MAX_BYTES
+
+
+def _approx_size(value) -> int:
+    """Approximate memory footprint of a symbol-field value, in bytes."""
+    if isinstance(value, str):
+        return _STR_OVERHEAD + len(value)
+    if isinstance(value, (list, tuple)):
+        return _OBJ_OVERHEAD + sum(_REF_SIZE + _approx_size(v) for v in value)
+    if isinstance(value, dict):
+        return _OBJ_OVERHEAD + sum(
+            2 * _REF_SIZE + _approx_size(k) + _approx_size(v) for k, v in value.items()
+        )
+    return _REF_SIZE * 3  # ints, bools, None
+
+
+def entry_size(symbols: list[Symbol]) -> int:
+    """Estimated bytes held by one cached entry."""
+    total = _OBJ_OVERHEAD
+    for s in symbols:
+        total += _OBJ_OVERHEAD + sum(
+            _REF_SIZE + _approx_size(getattr(s, f.name)) for f in dataclasses.fields(s)
+        )
+    return total
+
+
+def make_key(language: str, filename: str, source_bytes: bytes) -> tuple[str, str, str]:
+    """Build the cache key for one parse_file call."""
+    digest = hashlib.blake2b(source_bytes, digest_size=16).hexdigest()
+    return (language, filename, digest)
+
+
+def _clone(symbols: list[Symbol]) -> list[Symbol]:
+    out: list[Symbol] = []
+    for s in symbols:
+        copied = {}
+        for f in dataclasses.fields(s):
+            value = getattr(s, f.name)
+            if isinstance(value, list):
+                # fields / params / returns / type_params hold (nested) dicts.
+                if value and isinstance(value[0], dict):
+                    copied[f.name] = copy.deepcopy(value)
+                else:
+                    copied[f.name] = list(value)
+        out.append(dataclasses.replace(s, **copied))
+    return out
+
+
+def get(key: tuple[str, str, str]) -> Optional[list[Symbol]]:
+    """Return a private copy of the cached symbols for *key*, or None."""
+    global _hits, _misses
+    with _lock:
+        entry = _cache.get(key)
+        if entry is None:
+            _misses += 1
+            return None
+        _hits += 1
+        _cache.move_to_end(key)
+    return _clone(entry[0])
+
+
+def put(key: tuple[str, str, str], symbols: list[Symbol]) -> None:
+    """Store a copy of *symbols* under *key*, evicting LRU entries past either cap."""
+    global _bytes, _evictions
+    limit = _max_entries()
+    byte_limit = _max_bytes()
+    if limit <= 0 or byte_limit <= 0:
+        return
+    entry = _clone(symbols)
+    nbytes = entry_size(entry)
+    if nbytes > byte_limit:
+        return
+    with _lock:
+        old = _cache.pop(key, None)
+        if old is not None:
+            _bytes -= old[1]
+        _cache[key] = (entry, nbytes)
+        _bytes += nbytes
+        while len(_cache) > limit or _bytes > byte_limit:
+            _, (_, evicted) = _cache.popitem(last=False)
+            _bytes -= evicted
+            _evictions += 1
+
+
+def clear() -> None:
+    """Drop every cached parse result and reset the counters."""
+    global _bytes, _hits, _misses, _evictions
+    with _lock:
+        _cache.clear()
+        _bytes = _hits = _misses = _evictions = 0
+
+
+def size() -> int:
+    """Number of cached entries."""
+    with _lock:
+        return len(_cache)
+
+
+def stats() -> dict:
+    """Entry count, estimated bytes, caps, and hit/miss/eviction counters."""
+    max_entries, max_bytes = _max_entries(), _max_bytes()
+    with _lock:
+        lookups = _hits + _misses
+        return {
+            "entries": len(_cache),
+            "bytes": _bytes,
+            "max_entries": max_entries,
+            "max_bytes": max_bytes,
+            "hits": _hits,
+            "misses": _misses,
+            "evictions": _evictions,
+            "hit_rate": round(_hits / lookups, 3) if lookups else 0.0,
+        }
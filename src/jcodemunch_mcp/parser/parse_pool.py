@@ -0,0 +1,132 @@
+"""Bounded worker pool for ``parse_file``.
+
+Tree-sitter builds the syntax tree in C, so files can be parsed on a thread
+pool while the caller keeps reading, hashing, and caching the next files on
+its own thread.  Symbol extraction after the parse is Python and holds the
+GIL, so the pool only pays off when the C parse dominates; it is off by
+default and ``benchmarks/profile_parse_workers.py`` measures whether it helps
+on a given machine and tree.  Jobs are pulled lazily from the caller's iterable
+and at most ``2 * workers`` parses are in flight, so memory stays bounded on
+large trees.  Results come back in submission order — symbol order in the
+index is identical to a sequential run — and merging them is the caller's
+job on its own thread, so no shared index state is touched concurrently.
+
+A failure in one file is returned with that file's result instead of
+aborting the run.  With a :class:`~jcodemunch_mcp.deadline.Deadline`, the
+pool stops pulling jobs once it passes and drops parses still in flight;
+callers tell the parsed files from the skipped ones by what was yielded.
+"""
+
+from __future__ import annotations
+
+import os
+from collections import deque
+from concurrent.futures import Future, ThreadPoolExecutor
+from concurrent.futures import TimeoutError as FutureTimeout
+from typing import Iterable, Iterator, NamedTuple, Optional
+
+from ..deadline import Deadline, expired
+from .extractor import parse_file
+from .symbols import Symbol
+
+_MAX_AUTO_WORKERS = 32
+
+
+class ParseJob(NamedTuple):
+    """One file to parse.  ``source_bytes`` may be None (encoded on demand)."""
+    rel_path: str
+    content: str
+    language: str
+    source_bytes: Optional[bytes] = None
+
+
+class ParseResult(NamedTuple):
+    job: ParseJob
+    symbols: list[Symbol]
+    error: Optional[Exception]
+
+
+def resolve_workers(requested: Optional[int] = None, repo: Optional[str] = None) -> int:
+    """Worker count: *requested*, else the ``parse_workers`` config key.
+
+    ``1`` (the default) parses sequentially on the calling thread; ``0``
+    means one worker per CPU, capped at 32.
+    """
+    if requested is None:
+        try:
+            from .. import config as _config
+            requested = int(_config.get("parse_workers", 1, repo=repo))
+        except Exception:
+            requested = 1
+    if requested <= 0:
+        requested = min(_MAX_AUTO_WORKERS, os.cpu_count() or 1)
+    return max(1, requested)
+
+
+def _parse_one(job: ParseJob, repo: Optional[str]) -> ParseResult:
+    try:
+        symbols = parse_file(
+            job.content, job.rel_path, job.language,
+            source_bytes=job.source_bytes, repo=repo,
+        )
+        return ParseResult(job, symbols, None)
+    except Exception as e:  # collected per file, never raised
+        return ParseResult(job, [], e)
+
+
+def parse_files(
+    jobs: Iterable[ParseJob],
+    repo: Optional[str] = None,
+    max_workers: Optional[int] = None,
+    deadline: Optional[Deadline] = None,
+) -> Iterator[ParseResult]:
+    """Parse *jobs* concurrently, yielding results in input order.
+
+    Args:
+        jobs: Files to parse.  Consumed lazily, on the calling thread.
+        repo: Folder path forwarded to ``parse_file`` for project config.
+        max_workers: Pool size; None defers to :func:`resolve_workers`.
+        deadline: Stop early once it passes.  Results finished before then
+            are yielded; the remaining jobs are not.
+    """
+    workers = resolve_workers(max_workers, repo)
+    if workers == 1:
+        for job in jobs:
+            if expired(deadline):
+                return
+            yield _parse_one(job, repo)
+        return
+
+    window = workers * 2
+    pending: deque[Future] = deque()
+    executor = ThreadPoolExecutor(max_workers=workers, thread_name_prefix="jcm-parse")
+
+    def _next() -> Optional[ParseResult]:
+        try:
+            result = pending[0].result(
+                timeout=deadline.remaining() if deadline is not None else None
+            )
+        except FutureTimeout:
+            return None
+        pending.popleft()
+        return result
+
+    try:
+        for job in jobs:
+            if expired(deadline):
+                break
+            pending.append(executor.submit(_parse_one, job, repo))
+            if len(pending) >= window:
+                result = _next()
+                if result is None:
+                    return
+                yield result
+        # Past the deadline this drains only the parses that already finished.
+        while pending:
+            result = _next()
+            if result is None:
+                return
+            yield result
+    finally:
+        # Anything still pending was abandoned; do not wait for it.
+        executor.shutdown(wait=not pending, cancel_futures=True)
@@ -30,6 +30,16 @@ class Symbol:
     max_nesting: int = 0           # Max bracket-nesting depth relative to opening brace
     param_count: int = 0           # Number of parameters in the signature
     call_references: list[str] = field(default_factory=list)  # Called names from AST call_expression nodes
+    fields: list[dict] = field(default_factory=list)  # Go struct fields {name, type, tag, embedded, line}; Java class fields {name, type, modifiers, line}
+    build_tags: str = ""           # Build constraint of the defining file (Go: "linux && amd64")
+    is_test: bool = False          # Defined in a test-only file (Go: *_test.go)
+    receiver_type: str = ""        # Go methods: receiver base type ("User" for `(u *User)`)
+    pointer_receiver: bool = False # Go methods: receiver is a pointer (`*User`)
+    is_declaration: bool = False   # C/C++: function prototype with no body
+    fqn: str = ""                  # Import-path-qualified ID (Go: "github.com/acme/app/store.Config.Load")
+    params: list[dict] = field(default_factory=list)   # Function parameters {name, type, variadic?} in order
+    returns: list[dict] = field(default_factory=list)  # Function results {name, type}; Go named results keep their name
+    type_params: list[dict] = field(default_factory=list)  # Go generics: {name, constraint} per type parameter, in order
 
 
 
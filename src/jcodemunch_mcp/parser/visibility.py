@@ -0,0 +1,272 @@
+"""Exported / unexported classification for extracted symbols.
+
+Visibility is derived at query time from the symbol name, its parent, and
+the file's own export list: Python's module-level ``__all__``, or the
+``export`` statements of a JS/TS module.  Rust reads ``pub`` and Java its
+access modifier from the signature.  Nothing here is persisted in
+the index, so older indexes get the classification without a re-index.
+
+Languages without a rule return ``None`` so callers can omit the field
+instead of guessing.
+"""
+
+from __future__ import annotations
+
+import re
+from typing import Optional
+
+# ``__all__ = [...]`` / ``__all__: list[str] = (...)`` / ``__all__ += [...]``.
+# The bracketed body may span lines; string literals inside it are the names.
+_PY_ALL_RE = re.compile(
+    r"^__all__\s*(?::[^=\n]+)?(\+?=)\s*[\[(]([^\])]*)[\])]",
+    re.MULTILINE,
+)
+_PY_STR_RE = re.compile(r"""(['"])([A-Za-z_]\w*)\1""")
+
+
+def python_all_names(source: str) -> Optional[frozenset[str]]:
+    """Return the names listed in a module's ``__all__``, or None if undefined.
+
+    Only literal lists/tuples are understood.  ``__all__ += [...]`` extends
+    the set; a later plain assignment replaces it, matching runtime order.
+    Dynamic ``__all__`` (comprehensions, ``__all__.extend(...)``) is not
+    evaluated — the literal parts that can be read are still returned.
+    """
+    names: Optional[set[str]] = None
+    for m in _PY_ALL_RE.finditer(source):
+        op, body = m.group(1), m.group(2)
+        found = {s.group(2) for s in _PY_STR_RE.finditer(body)}
+        if op == "+=" and names is not None:
+            names |= found
+        else:
+            names = found
+    return frozenset(names) if names is not None else None
+
+
+_JS_LANGUAGES = frozenset({"javascript", "typescript", "tsx"})
+_JS_IDENT = r"[A-Za-z_$][\w$]*"
+# `export [default] [declare] [abstract] [async] function*|class|... Name`
+_JS_DECL_RE = re.compile(
+    r"^[ \t]*export\s+(default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:async\s+)?"
+    r"(?:function\s*\*?|class|interface|type|const\s+enum|enum|namespace|const|let|var)\s+"
+    r"(" + _JS_IDENT + r")",
+    re.MULTILINE,
+)
+# `export const { a, b: c } = ...` / `export const [a, b] = ...`
+_JS_DESTRUCTURE_RE = re.compile(
+    r"^[ \t]*export\s+(?:const|let|var)\s*([{\[][^=]*[}\]])\s*=",
+    re.MULTILINE,
+)
+# `export { a, b as c }` — a trailing `from '...'` makes it a re-export of
+# another module, which declares nothing locally.
+_JS_LIST_RE = re.compile(
+    r"^[ \t]*export\s+(?:type\s+)?\{([^}]*)\}(\s*from\b)?",
+    re.MULTILINE,
+)
+_JS_DEFAULT_IDENT_RE = re.compile(
+    r"^[ \t]*export\s+default\s+(" + _JS_IDENT + r")\s*;?[ \t]*$",
+    re.MULTILINE,
+)
+_CJS_DEFAULT_RE = re.compile(
+    r"^[ \t]*module\.exports\s*=\s*(" + _JS_IDENT + r")\s*;?[ \t]*$",
+    re.MULTILINE,
+)
+_CJS_OBJECT_RE = re.compile(r"^[ \t]*module\.exports\s*=\s*\{([^}]*)\}", re.MULTILINE)
+_CJS_PROP_RE = re.compile(
+    r"^[ \t]*(?:module\.)?exports\.(" + _JS_IDENT + r")\s*=(?!=)\s*"
+    r"(?:(" + _JS_IDENT + r")\s*;?[ \t]*$)?",
+    re.MULTILINE,
+)
+_JS_MEMBER_PRIVATE_RE = re.compile(r"^\s*(?:(?:public|static|readonly|override|abstract)\s+)*(?:private|protected)\b")
+
+
+def _js_binding_names(pattern: str) -> list[str]:
+    """Names bound by a destructuring pattern: ``{ a, b: c, ...d }`` -> a, c, d."""
+    names = []
+    for part in re.split(r"[,{}\[\]]", pattern):
+        part = part.split("=")[0].strip().lstrip(".")
+        if ":" in part:
+            part = part.split(":", 1)[1].strip()
+        if re.fullmatch(_JS_IDENT, part):
+            names.append(part)
+    return names
+
+
+def js_exports(source: str) -> Optional[dict[str, tuple[str, ...]]]:
+    """Map each locally declared name a JS/TS module exports to its public names.
+
+    ``export function foo`` gives ``{"foo": ("foo",)}``; ``export { foo as
+    bar }`` gives ``{"foo": ("bar",)}``; ``export default Foo`` and
+    ``module.exports = Foo`` give ``{"Foo": ("default",)}``.  Anonymous
+    default exports are indexed as a symbol named ``default`` and map to
+    themselves.  Returns None when the file has no export syntax at all
+    (a script, whose top-level names are global rather than unexported).
+    Line-anchored regexes only: exports built dynamically are not seen.
+    """
+    out: dict[str, list[str]] = {}
+    found = False
+
+    def add(local: str, public: str) -> None:
+        names = out.setdefault(local, [])
+        if public not in names:
+            names.append(public)
+
+    for m in _JS_DECL_RE.finditer(source):
+        found = True
+        add(m.group(2), "default" if m.group(1) else m.group(2))
+    for m in _JS_DESTRUCTURE_RE.finditer(source):
+        found = True
+        for local in _js_binding_names(m.group(1)):
+            add(local, local)
+    for m in _JS_LIST_RE.finditer(source):
+        found = True
+        if m.group(2):
+            continue
+        for item in m.group(1).split(","):
+            parts = item.split()
+            if parts and parts[0] == "type":
+                parts = parts[1:]
+            if len(parts) == 3 and parts[1] == "as":
+                add(parts[0], parts[2])
+            elif len(parts) == 1:
+                add(parts[0], parts[0])
+    for m in _JS_DEFAULT_IDENT_RE.finditer(source):
+        found = True
+        add(m.group(1), "default")
+    for m in _CJS_DEFAULT_RE.finditer(source):
+        found = True
+        add(m.group(1), "default")
+    for m in _CJS_OBJECT_RE.finditer(source):
+        found = True
+        for item in m.group(1).split(","):
+            key, _, value = item.partition(":")
+            key, value = key.strip(), value.strip() or key.strip()
+            if re.fullmatch(_JS_IDENT, key) and re.fullmatch(_JS_IDENT, value):
+                add(value, key)
+    for m in _CJS_PROP_RE.finditer(source):
+        found = True
+        add(m.group(2) or m.group(1), m.group(1))
+    if re.search(r"^[ \t]*export\s+default\b", source, re.MULTILINE):
+        found = True
+        add("default", "default")
+
+    if not found:
+        return None
+    return {local: tuple(names) for local, names in out.items()}
+
+
+# Bare `pub`; restricted forms such as `pub(crate)` are not public API.
+_RUST_PUB_RE = re.compile(r"^pub\b(?!\s*\()")
+_RUST_IMPL_RE = re.compile(r"^(?:unsafe\s+)?impl\b")
+_RUST_TRAIT_IMPL_RE = re.compile(r"^(?:unsafe\s+)?impl\b.*?\sfor\s", re.DOTALL)
+_RUST_TRAIT_RE = re.compile(r"^(?:pub(?:\s*\([^)]*\))?\s+)?(?:unsafe\s+)?(?:auto\s+)?trait\b")
+
+
+def _rust_exported(nested: bool, parent_exported: Optional[bool], signature: str, parent_signature: str) -> Optional[bool]:
+    if _RUST_IMPL_RE.match(signature):
+        # Impl blocks have no visibility of their own.
+        return None
+    if _RUST_PUB_RE.match(signature):
+        return True
+    if nested and _RUST_TRAIT_IMPL_RE.match(parent_signature):
+        # Trait impl members can't carry `pub`; they are as public as the trait.
+        return True
+    if nested and _RUST_TRAIT_RE.match(parent_signature):
+        return parent_exported
+    return False
+
+
+# Annotations, with or without an argument list: `@Deprecated`,
+# `@SuppressWarnings("unchecked")`, `@a.b.C(x = 1)`.  Arguments that nest
+# parentheses are not balanced.
+_JAVA_ANNOTATION_RE = re.compile(r"@(?!interface\b)[\w.]+(?:\s*\([^)]*\))?\s*")
+_JAVA_ACCESS_RE = re.compile(r"\b(public|protected|private)\b")
+_JAVA_DECL_KEYWORD_RE = re.compile(r"(?:\bclass|\binterface|@interface|\benum|\brecord)\b")
+_JAVA_INTERFACE_RE = re.compile(r"(?:\binterface|@interface)\b")
+
+
+def _java_head(signature: str) -> str:
+    """Modifier part of a Java signature: neither annotations nor anything
+    from the name onward (so parameter annotations and types can't match)."""
+    head = _JAVA_ANNOTATION_RE.sub(" ", signature)
+    paren = head.find("(")
+    if paren != -1:
+        head = head[:paren]
+    m = _JAVA_DECL_KEYWORD_RE.search(head)
+    return head[:m.start()] if m else head
+
+
+def java_visibility(signature: str, parent_signature: str = "") -> str:
+    """Access level of a Java declaration: public, protected, private, or package.
+
+    Members of an interface or annotation type are implicitly public.
+    """
+    m = _JAVA_ACCESS_RE.search(_java_head(signature))
+    if m:
+        return m.group(1)
+    if parent_signature and _JAVA_INTERFACE_RE.search(_JAVA_ANNOTATION_RE.sub(" ", parent_signature)):
+        return "public"
+    return "package"
+
+
+def _python_name_public(name: str) -> bool:
+    # Dunders (__init__, __call__) are part of the public protocol.
+    if name.startswith("__") and name.endswith("__"):
+        return True
+    return not name.startswith("_")
+
+
+def is_exported(
+    name: str,
+    language: str,
+    nested: bool = False,
+    module_all: Optional[frozenset[str]] = None,
+    parent_exported: Optional[bool] = None,
+    module_exports: Optional[frozenset[str]] = None,
+    signature: str = "",
+    parent_signature: str = "",
+) -> Optional[bool]:
+    """Classify a symbol as exported (True), unexported (False), or unknown (None).
+
+    Args:
+        name: Bare symbol name.
+        language: Language of the symbol's file.
+        nested: True when the symbol has a parent (method, nested class).
+        module_all: Python ``__all__`` names for the file, when defined.
+            Only consulted for top-level symbols.
+        parent_exported: Classification of the enclosing symbol, if any.
+            Members of an unexported container are never exported.
+        module_exports: JS/TS local names the file exports (keys of
+            ``js_exports``); None when the file has no export syntax.
+        signature: Symbol signature, used for member modifiers such as
+            TypeScript ``private``, Rust ``pub``, and Java access modifiers.
+        parent_signature: Signature of the enclosing symbol; Rust uses it
+            to tell trait and trait-impl members from inherent methods, Java
+            to spot interface members (implicitly public).
+    """
+    if not name:
+        return None
+    if parent_exported is False:
+        return False
+
+    if language == "python":
+        if not nested and module_all is not None:
+            return name in module_all
+        return _python_name_public(name)
+    if language == "go":
+        return name[:1].isupper()
+    if language == "rust":
+        return _rust_exported(nested, parent_exported, signature, parent_signature)
+    if language == "java":
+        # protected members are part of the API a subclass in another
+        # package can see, so they count as exported.
+        return java_visibility(signature, parent_signature) in ("public", "protected")
+    if language in _JS_LANGUAGES:
+        if module_exports is None:
+            return None
+        if not nested:
+            return name in module_exports
+        if name.startswith("#") or _JS_MEMBER_PRIVATE_RE.match(signature):
+            return False
+        return parent_exported
+    return None
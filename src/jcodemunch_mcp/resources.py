@@ -0,0 +1,209 @@
+"""MCP resources backed by the index: indexed files and per-package symbol indexes.
+
+URIs (path segments percent-encoded):
+
+    codemunch://file/{owner}/{name}/{path}        cached content of one indexed file
+    codemunch://symbols/{owner}/{name}/{package}  JSON symbol index of one directory
+                                                  (``.`` is the repo root)
+
+``resources/list`` pages through every repo's package indexes and files,
+``_PAGE_SIZE`` entries at a time.  Repos are enumerated from ``list_repos``
+metadata, so a page loads only the indexes it actually lists; the opaque
+``nextCursor`` names the repo and offset the next page starts at.  Reads go
+through the same cache and path checks as ``get_file_content``, so a URI can
+only name files the index holds.
+"""
+
+from __future__ import annotations
+
+import json
+import logging
+import mimetypes
+import posixpath
+from typing import Optional
+from urllib.parse import quote, unquote
+
+from .storage import IndexStore
+
+logger = logging.getLogger(__name__)
+
+SCHEME = "codemunch"
+_PAGE_SIZE = 500
+
+FILE_TEMPLATE = f"{SCHEME}://file/{{owner}}/{{name}}/{{path}}"
+SYMBOLS_TEMPLATE = f"{SCHEME}://symbols/{{owner}}/{{name}}/{{package}}"
+
+# Languages whose files mimetypes does not know (or guesses as binary).
+_LANGUAGE_MIME = {
+    "go": "text/x-go",
+    "rust": "text/x-rust",
+    "typescript": "text/x-typescript",
+    "tsx": "text/x-typescript",
+    "kotlin": "text/x-kotlin",
+    "swift": "text/x-swift",
+}
+
+
+def file_uri(repo: str, path: str) -> str:
+    return f"{SCHEME}://file/{quote(repo, safe='/')}/{quote(path, safe='/')}"
+
+
+def symbols_uri(repo: str, package: str) -> str:
+    return f"{SCHEME}://symbols/{quote(repo, safe='/')}/{quote(package or '.', safe='/')}"
+
+
+def parse_uri(uri: str) -> tuple[str, str, str, str]:
+    """Split a codemunch URI into ``(kind, owner, name, rest)``.
+
+    Raises ValueError for other schemes, unknown kinds, or a missing path.
+    """
+    prefix = f"{SCHEME}://"
+    if not uri.startswith(prefix):
+        raise ValueError(f"Unsupported resource URI {uri!r}: expected {prefix}...")
+    kind, _, remainder = uri[len(prefix):].partition("/")
+    if kind not in ("file", "symbols"):
+        raise ValueError(f"Unknown resource kind {kind!r}: expected 'file' or 'symbols'")
+    parts = remainder.split("/", 2)
+    if len(parts) < 3 or not all(parts):
+        raise ValueError(f"Malformed resource URI {uri!r}: expected {prefix}{kind}/OWNER/NAME/PATH")
+    owner, name, rest = (unquote(p) for p in parts)
+    return kind, owner, name, rest
+
+
+def _mime_type(path: str, language: str) -> str:
+    if language in _LANGUAGE_MIME:
+        return _LANGUAGE_MIME[language]
+    guessed, _ = mimetypes.guess_type(path)
+    if guessed and (guessed.startswith("text/") or guessed.endswith(("json", "xml", "javascript"))):
+        return guessed
+    return "text/plain"
+
+
+def _package_of(path: str) -> str:
+    return posixpath.dirname(path) or "."
+
+
+def _encode_cursor(repo: str, offset: int) -> str:
+    return f"{offset}:{repo}"
+
+
+def _decode_cursor(cursor: str) -> tuple[str, int]:
+    offset, sep, repo = cursor.partition(":")
+    if not sep or not repo or not offset.isdigit():
+        raise ValueError(f"Invalid resources cursor: {cursor!r}")
+    return repo, int(offset)
+
+
+def _repo_entries(store: IndexStore, repo: str) -> list[dict]:
+    """Package symbol indexes first (so a client can browse by package), then files."""
+    owner, name = repo.split("/", 1)
+    try:
+        index = store.load_index(owner, name)
+    except Exception:
+        logger.debug("resources: skipping %s", repo, exc_info=True)
+        return []
+    if index is None:
+        return []
+    files = sorted(index.source_files)
+    entries = [
+        {
+            "uri": symbols_uri(repo, package),
+            "name": f"{repo}: {package} symbols",
+            "description": f"Symbol index of package {package!r} in {repo}",
+            "mimeType": "application/json",
+        }
+        for package in sorted({_package_of(f) for f in files})
+    ]
+    entries.extend(
+        {
+            "uri": file_uri(repo, path),
+            "name": f"{repo}: {path}",
+            "description": index.file_summaries.get(path, "") or None,
+            "mimeType": _mime_type(path, index.file_languages.get(path, "")),
+        }
+        for path in files
+    )
+    return entries
+
+
+def list_resource_entries(
+    storage_path: Optional[str] = None,
+    cursor: Optional[str] = None,
+    page_size: int = _PAGE_SIZE,
+) -> tuple[list[dict], Optional[str]]:
+    """One page of resource descriptors, and the cursor of the next page.
+
+    Repos come from ``list_repos`` in name order; only the repos a page
+    covers are loaded, and repos whose index fails to load are skipped.
+    The returned cursor is None on the last page.  Raises ValueError for a
+    cursor this function did not issue.
+    """
+    store = IndexStore(base_path=storage_path)
+    repos = sorted(
+        r["repo"] for r in store.list_repos()
+        if "/" in r.get("repo", "") and r.get("loadable", True) is not False
+    )
+    start, offset = 0, 0
+    if cursor:
+        repo, offset = _decode_cursor(cursor)
+        # The cursor's repo may have been deleted since; resume at its successor.
+        start = next((i for i, r in enumerate(repos) if r >= repo), len(repos))
+        if start < len(repos) and repos[start] != repo:
+            offset = 0
+
+    page: list[dict] = []
+    for repo in repos[start:]:
+        room = page_size - len(page)
+        if room <= 0:
+            return page, _encode_cursor(repo, 0)
+        entries = _repo_entries(store, repo)[offset:]
+        if len(entries) > room:
+            page.extend(entries[:room])
+            return page, _encode_cursor(repo, offset + room)
+        page.extend(entries)
+        offset = 0
+    return page, None
+
+
+def read_resource_text(uri: str, storage_path: Optional[str] = None) -> tuple[str, str]:
+    """Return ``(text, mime_type)`` for a codemunch URI.  Raises ValueError if unreadable."""
+    kind, owner, name, rest = parse_uri(uri)
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if index is None:
+        raise ValueError(f"Repository not indexed: {owner}/{name}")
+    repo = f"{owner}/{name}"
+
+    if kind == "file":
+        if not index.has_source_file(rest):
+            raise ValueError(f"File not found in {repo}: {rest}")
+        content = store.get_file_content(owner, name, rest, _index=index)
+        if content is None:
+            raise ValueError(f"File content not cached: {rest}. Re-index to populate it.")
+        return content, _mime_type(rest, index.file_languages.get(rest, ""))
+
+    package = rest.strip("/") or "."
+    files = sorted(f for f in index.source_files if _package_of(f) == package)
+    if not files:
+        raise ValueError(f"Package not found in {repo}: {package}")
+    file_set = set(files)
+    symbols = [
+        {
+            "id": s["id"],
+            "name": s.get("name", ""),
+            "kind": s.get("kind", ""),
+            "file": s.get("file", ""),
+            "line": s.get("line", 0),
+            "signature": s.get("signature", ""),
+            "summary": s.get("summary", ""),
+        }
+        for s in index.symbols
+        if s.get("file") in file_set and s.get("kind") != "import"
+    ]
+    payload = {
+        "repo": repo,
+        "package": package,
+        "files": [{"path": f, "uri": file_uri(repo, f)} for f in files],
+        "symbols": symbols,
+    }
+    return json.dumps(payload, indent=2), "application/json"
@@ -26,6 +26,8 @@ from datetime import datetime, timezone
 from pathlib import Path
 from typing import Optional
 
+from ..config import default_storage_path
+
 logger = logging.getLogger(__name__)
 
 _CANARY_FILE = "embed_canary.json"
@@ -55,7 +57,7 @@ CANARY_STRINGS: tuple[str, ...] = (
 
 
 def _canary_path(base_path: Optional[str] = None) -> Path:
-    root = Path(base_path) if base_path else Path.home() / ".code-index"
+    root = Path(base_path) if base_path else default_storage_path()
     root.mkdir(parents=True, exist_ok=True)
     return root / _CANARY_FILE
 
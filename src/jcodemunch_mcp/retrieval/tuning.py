@@ -27,6 +27,7 @@ from pathlib import Path
 from threading import Lock
 from typing import Optional
 
+from ..config import default_config_dir
 from ..storage import token_tracker as _tt
 
 logger = logging.getLogger(__name__)
@@ -59,7 +60,7 @@ _cache_loaded_from: Optional[str] = None
 
 
 def _tuning_path(base_path: Optional[str] = None) -> Path:
-    root = Path(base_path) if base_path else Path.home() / ".code-index"
+    root = Path(base_path) if base_path else default_config_dir()
     root.mkdir(parents=True, exist_ok=True)
     return root / _TUNING_FILE
 
@@ -1,6 +1,7 @@
 """Security utilities for path validation, secret detection, and binary filtering."""
 
 import os
+import re
 from pathlib import Path
 from typing import Optional
 
@@ -314,6 +315,76 @@ def is_binary_file(file_path: Path, check_size: int = 8192) -> bool:
         return True  # Can't read -> skip
 
 
+# --- Generated-File Detection ---
+
+# The Go convention (``// Code generated <tool>. DO NOT EDIT.``) also emitted
+# by protoc, mockgen, sqlc and most codegen tools for other languages, so any
+# common line-comment marker is accepted.
+_GENERATED_HEADER_RE = re.compile(
+    rb"^[ \t]*(?://|#|--|/\*|\*)[ \t]*Code generated .*DO NOT EDIT\.?[ \t]*(?:\*/)?[ \t]*\r?$",
+    re.MULTILINE,
+)
+
+
+def is_generated_content(data: bytes, check_size: int = 8192) -> bool:
+    """Detect a ``Code generated ... DO NOT EDIT.`` header in the first bytes.
+
+    Args:
+        data: Raw bytes to check.
+        check_size: How many bytes to inspect (default 8KB).
+
+    Returns:
+        True if the data carries a generated-code marker.
+    """
+    return _GENERATED_HEADER_RE.search(data[:check_size]) is not None
+
+
+def is_generated_file(file_path: Path, check_size: int = 8192) -> bool:
+    """Check whether a file carries a generated-code header.
+
+    Args:
+        file_path: Path to the file.
+        check_size: Bytes to read for the header check.
+
+    Returns:
+        True if the file appears to be generated. Unreadable files return False.
+    """
+    try:
+        with open(file_path, "rb") as f:
+            data = f.read(check_size)
+    except OSError:
+        return False
+    return is_generated_content(data, check_size)
+
+
+def get_exclude_generated(
+    call_value: Optional[bool] = None,
+    repo: Optional[str] = None,
+) -> bool:
+    """Return whether generated files should be skipped during indexing.
+
+    A per-call value wins; otherwise the ``exclude_generated`` config key
+    (project config first when ``repo`` is supplied) decides.
+    """
+    if call_value is not None:
+        return bool(call_value)
+    return bool(_config.get("exclude_generated", False, repo=repo))
+
+
+def get_follow_symlinks(
+    call_value: Optional[bool] = None,
+    repo: Optional[str] = None,
+) -> bool:
+    """Return whether discovery follows symlinked files and directories.
+
+    A per-call value wins; otherwise the ``follow_symlinks`` config key
+    (project config first when ``repo`` is supplied) decides.
+    """
+    if call_value is not None:
+        return bool(call_value)
+    return bool(_config.get("follow_symlinks", True, repo=repo))
+
+
 # --- Encoding Safety ---
 
 def safe_decode(data: bytes, encoding: str = "utf-8") -> str:
@@ -420,6 +491,31 @@ def get_max_folder_files(max_files: Optional[int] = None) -> int:
     return DEFAULT_MAX_FOLDER_FILES
 
 
+def get_max_file_size(max_size: Optional[int] = None) -> int:
+    """Resolve the per-file size cap (bytes) from arg or config.
+
+    Files above the cap are skipped during discovery and counted under
+    ``too_large``; generated bundles and single-file vendored libraries
+    are the usual offenders.  Set ``max_file_size`` in config.jsonc or
+    ``JCODEMUNCH_MAX_FILE_SIZE`` to change it.
+
+    Args:
+        max_size: Explicit override. Must be a positive integer when provided.
+
+    Returns:
+        Positive byte limit.
+    """
+    if max_size is not None:
+        if max_size <= 0:
+            raise ValueError("max_size must be a positive integer")
+        return max_size
+
+    value = _config.get("max_file_size")
+    if isinstance(value, int) and value > 0:
+        return value
+    return DEFAULT_MAX_FILE_SIZE
+
+
 def should_exclude_file(
     file_path: Path,
     root: Path,
@@ -12,10 +12,14 @@ import os
 import sys
 import time
 from pathlib import Path
-from typing import Any, Optional
+from typing import Any, Awaitable, Callable, Optional
 
 from mcp.server import Server
-from mcp.types import Tool, TextContent, Resource, Prompt, PromptMessage, GetPromptResult
+from mcp.server.lowlevel.helper_types import ReadResourceContents
+from mcp.types import (
+    Tool, TextContent, Resource, ResourceTemplate, Prompt, PromptMessage, GetPromptResult,
+    ListResourcesRequest, ListResourcesResult, ServerResult,
+)
 
 from . import __version__
 from . import config as config_module
@@ -26,6 +30,7 @@ from . import config as config_module
 from .parser.symbols import VALID_KINDS
 from .summarizer import get_provider_name
 from .reindex_state import await_freshness_if_strict
+from .deadline import deadline_scope
 from .path_map import ENV_VAR as _PATH_MAP_ENV_VAR
 from .storage import result_cache_invalidate as _result_cache_invalidate
 from .storage import write_pulse as _write_pulse
@@ -48,20 +53,20 @@ _CANONICAL_TOOL_NAMES: tuple[str, ...] = (
     # Indexing
     "index_repo", "index_folder", "summarize_repo", "index_file",
     # Discovery
-    "list_repos", "resolve_repo", "suggest_queries",
-    "get_repo_outline", "get_file_tree", "get_file_outline",
+    "list_repos", "server_info", "resolve_repo", "suggest_queries",
+    "get_repo_outline", "project_stats", "get_file_tree", "get_file_outline", "describe_package",
     # Search & Retrieval
-    "search_symbols", "get_symbol_source", "get_context_bundle",
-    "get_file_content", "search_text", "search_columns", "get_ranked_context",
+    "search_symbols", "get_symbol_source", "summarize_symbol", "get_context_bundle",
+    "get_file_content", "read_file_range", "search_text", "search_content", "list_todos", "search_columns", "get_ranked_context",
     "assemble_task_context",
     # Relationships
-    "find_importers", "find_references", "check_references",
-    "get_dependency_graph", "get_class_hierarchy", "get_related_symbols",
-    "get_call_hierarchy",
+    "find_importers", "find_references", "locate_definition", "check_references",
+    "get_dependency_graph", "get_dependencies", "get_class_hierarchy", "get_type_hierarchy",
+    "get_related_symbols", "get_call_hierarchy", "get_call_graph",
     # Impact & Safety
-    "get_blast_radius", "check_rename_safe", "check_delete_safe",
+    "get_blast_radius", "check_rename_safe", "rename_preview", "check_delete_safe",
     "get_impact_preview", "get_changed_symbols", "plan_refactoring",
-    "get_symbol_provenance", "get_pr_risk_profile",
+    "get_symbol_provenance", "git_blame", "get_pr_risk_profile",
     # Symbol navigation
     "find_implementations",
     # Architecture
@@ -70,9 +75,9 @@ _CANONICAL_TOOL_NAMES: tuple[str, ...] = (
     "get_tectonic_map", "get_signal_chains",
     "render_diagram", "get_project_intel", "list_workspaces",
     # Quality & Metrics
-    "get_symbol_complexity", "get_churn_rate", "get_hotspots",
+    "get_symbol_complexity", "get_complexity", "get_churn_rate", "get_hotspots",
     "get_repo_health", "get_symbol_importance", "get_repo_map", "find_dead_code",
-    "get_dead_code_v2", "get_untested_symbols", "find_similar_symbols", "search_ast",
+    "get_dead_code_v2", "get_untested_symbols", "find_similar_symbols", "search_ast", "get_parse_errors", "format_check", "get_test_coverage_map",
     # Diffs & Embeddings
     "get_symbol_diff", "embed_repo",
     # Utilities
@@ -122,7 +127,9 @@ _TOOL_TIER_STANDARD: frozenset[str] = _TOOL_TIER_CORE | frozenset({
     "import_runtime_signal", "get_runtime_coverage", "find_hot_paths", "find_unused_paths",
     "get_redaction_log",
     # Discovery extras
-    "suggest_queries", "search_columns",
+    "server_info", "suggest_queries", "search_columns", "project_stats",
+    # Search & Retrieval extras
+    "summarize_symbol",
     # Relationships
     "check_references", "get_dependency_graph",
     "get_class_hierarchy", "get_related_symbols", "get_call_hierarchy",
@@ -303,6 +310,81 @@ async def _emit_tools_list_changed() -> None:
         logger.warning("tools/list_changed notification failed: %s", exc, exc_info=True)
 
 
+# Sessions that have called into a handler, so the background watcher can
+# push index-update notifications outside any request context.  Weak so a
+# closed HTTP session drops out on its own.
+_notify_sessions: "weakref.WeakSet[Any]" = weakref.WeakSet()
+
+
+def _remember_session() -> None:
+    session = _get_mcp_session(server)
+    if session is None:
+        return
+    try:
+        _notify_sessions.add(session)
+    except TypeError:
+        pass  # not weakref-able; such a session just gets no push updates
+
+
+def _changed_resources(payload: dict) -> tuple[list[str], bool]:
+    """Resource URIs a watcher re-index changed, and whether the list changed.
+
+    A modified file updates its ``codemunch://file/...`` resource; any change
+    updates its package's ``codemunch://symbols/...`` index.  Added or
+    deleted files, or a truncated file list, change the resource list itself.
+    """
+    from .resources import _package_of, file_uri, symbols_uri
+
+    repo = payload.get("repo", "")
+    uris: dict[str, None] = {}
+    list_changed = bool(payload.get("files_truncated"))
+    for f in payload.get("files") or []:
+        path, change = f.get("path", ""), f.get("change", "")
+        if not (repo and path):
+            continue
+        if change == "modified":
+            uris[file_uri(repo, path)] = None
+        else:
+            list_changed = True
+        uris[symbols_uri(repo, _package_of(path))] = None
+    return list(uris), list_changed
+
+
+async def _emit_index_updated(payload: dict) -> None:
+    """Push a watcher re-index to every known session, best-effort.
+
+    Sent as a ``notifications/message`` log entry (logger
+    ``jcodemunch.watch``) so any client that surfaces server logs sees the
+    index move, followed by ``notifications/resources/updated`` for each
+    changed resource and ``notifications/resources/list_changed`` when files
+    were added or removed.  Disabled with ``watch_notifications: false``.
+    """
+    if not config_module.get("watch_notifications", True):
+        return
+    data = {"event": "index_updated", **payload}
+    uris, list_changed = _changed_resources(payload)
+    for session in list(_notify_sessions):
+        send_fn = getattr(session, "send_log_message", None)
+        if send_fn is None:
+            continue
+        calls = [functools.partial(send_fn, level="info", data=data, logger="jcodemunch.watch")]
+        updated_fn = getattr(session, "send_resource_updated", None)
+        if updated_fn is not None:
+            calls.extend(functools.partial(updated_fn, uri) for uri in uris)
+        list_changed_fn = getattr(session, "send_resource_list_changed", None)
+        if list_changed and list_changed_fn is not None:
+            calls.append(list_changed_fn)
+        try:
+            for call in calls:
+                maybe_awaitable = call()
+                if asyncio.iscoroutine(maybe_awaitable):
+                    await maybe_awaitable
+        except Exception as exc:
+            # Closed streams raise anyio errors; forget the session.
+            logger.debug("index update notification failed: %s", exc)
+            _notify_sessions.discard(session)
+
+
 def _get_mcp_session(mcp_server: Server | None = None) -> Any | None:
     """Best-effort session lookup from an MCP server instance.
 
@@ -396,6 +478,16 @@ async def _apply_model_announcement(model: str) -> dict:
         await _emit_tools_list_changed()
     return res
 
+# Cross-cutting `output_format` (see .encoding.output_format), advertised on
+# the listing tools.  Every tool accepts it; get_context_bundle keeps its own
+# json|markdown parameter of the same name.
+_OUTPUT_FORMAT_SCHEMA = {
+    "type": "string",
+    "enum": ["compact", "pretty", "markdown", "json"],
+    "description": "Response serialization: compact (or json) / pretty JSON, or markdown tables.",
+}
+_TOOLS_WITH_OWN_OUTPUT_FORMAT = frozenset({"get_context_bundle"})
+
 # Parameters stripped from tool schemas when compact_schemas is enabled.
 # These are advanced/rarely-used params that cost tokens every session but
 # are used <5% of the time.  The underlying handler still accepts them.
@@ -403,15 +495,23 @@ _COMPACT_STRIP_PARAMS: dict[str, set[str]] = {
     "search_symbols": {
         "debug", "fusion", "semantic", "semantic_only", "semantic_weight",
         "fuzzy", "fuzzy_threshold", "max_edit_distance", "sort_by", "fqn",
-        "decorator", "token_budget",
+        "decorator", "token_budget", "offset", "case_sensitive",
+        "include_tests", "output_format",
     },
-    "get_context_bundle": {"budget_strategy"},
+    "search_text": {"offset", "count_all", "output_format"},
+    "get_symbol_source": {"include_doc", "name", "file_path"},
+    "get_context_bundle": {"budget_strategy", "max_bytes", "query"},
     "get_ranked_context": {"detail_level"},
+    "get_file_outline": {
+        "kinds", "exported_only", "max_results", "offset", "group_methods",
+        "include_tests", "output_format",
+    },
     "get_blast_radius": {"cross_repo", "max_depth"},
     "find_importers": {"cross_repo"},
+    "find_references": {"include_usages", "fqn", "file_path"},
     "get_dependency_graph": {"cross_repo"},
     "index_repo": {"extra_ignore_patterns", "incremental"},
-    "index_folder": {"extra_ignore_patterns", "incremental"},
+    "index_folder": {"extra_ignore_patterns", "incremental", "exclude_generated"},
 }
 
 # Tools eligible for Agent Selector complexity scoring
@@ -424,6 +524,7 @@ _AGENT_SELECTOR_TOOLS = frozenset({
 # Tools excluded from strict freshness mode (don't wait for reindex)
 _EXCLUDED_FROM_STRICT = frozenset({
     "list_repos",
+    "server_info",
     "resolve_repo",
     "get_session_stats",
     "get_session_context",
@@ -711,10 +812,14 @@ _handshake_event: Optional[asyncio.Event] = None
 
 
 def _signal_handshake() -> None:
-    """Mark the handshake watchdog as satisfied. Idempotent and cheap."""
+    """Mark the handshake watchdog as satisfied. Idempotent and cheap.
+
+    Also records the calling session for watcher notifications.
+    """
     ev = _handshake_event
     if ev is not None and not ev.is_set():
         ev.set()
+    _remember_session()
 
 
 @server.list_tools()
@@ -778,8 +883,7 @@ def _build_tools_list() -> list[Tool]:
                     },
                     "follow_symlinks": {
                         "type": "boolean",
-                        "description": "Include symlinked files. Symlinked directories are never followed.",
-                        "default": False
+                        "description": "Follow symlinks (cycle-safe; links outside the folder are skipped)."
                     },
                     "incremental": {
                         "type": "boolean",
@@ -796,6 +900,10 @@ def _build_tools_list() -> list[Tool]:
                         "enum": ["config", "local", "git"],
                         "description": "Repo-identity strategy. `config` (default): respect existing index. `local`: path-keyed. `git`: git-root-keyed (monorepo subdir merging).",
                         "default": "config"
+                    },
+                    "exclude_generated": {
+                        "type": "boolean",
+                        "description": "Skip files with a `Code generated ... DO NOT EDIT.` header. Defaults to the `exclude_generated` config key."
                     }
                 },
                 "required": ["path"]
@@ -1032,6 +1140,19 @@ def _build_tools_list() -> list[Tool]:
                 "properties": {}
             }
         ),
+        Tool(
+            name="server_info",
+            description="Describe this server: version, supported languages and file extensions, the tools currently registered, and index statistics (repos, files, symbols, last index time). Use it to adapt to the build instead of hardcoding capabilities.",
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Restrict index statistics to one repository (owner/repo or just repo name)."
+                    }
+                }
+            }
+        ),
         Tool(
             name="get_watch_status",
             description=(
@@ -1097,20 +1218,72 @@ def _build_tools_list() -> list[Tool]:
                     },
                     "file_path": {
                         "type": "string",
-                        "description": "Path to the file within the repository (e.g., 'src/main.py')"
+                        "description": "Path to the file within the repository (e.g., 'src/main.py'), or a glob/directory (batch results)."
                     },
                     "file_paths": {
                         "type": "array",
                         "items": {"type": "string"},
-                        "description": "List of file paths to query in batch mode. Returns a grouped results array."
-                    }
+                        "description": "File paths, globs, or directories for batch mode. Returns a grouped results array."
+                    },
+                    "kinds": {
+                        "type": "array",
+                        "items": {"type": "string"},
+                        "description": "Only return these kinds (function, class, method, constant, type)."
+                    },
+                    "exported_only": {
+                        "type": "boolean",
+                        "description": "Drop symbols classified as unexported.",
+                        "default": False
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Maximum symbols to return per file. Adds total_count and has_more."
+                    },
+                    "offset": {
+                        "type": "integer",
+                        "description": "Symbols to skip per file, for paging.",
+                        "default": 0
+                    },
+                    "group_methods": {
+                        "type": "boolean",
+                        "description": "Go: nest methods under their receiver type.",
+                        "default": False
+                    },
+                    "include_tests": {
+                        "type": "boolean",
+                        "description": "Include symbols from test-only files (Go *_test.go); false also skips them in globs and directories.",
+                        "default": True
+                    },
+                    "output_format": dict(_OUTPUT_FORMAT_SCHEMA),
                 },
                 "required": ["repo"]
             }
         ),
+        Tool(
+            name="describe_package",
+            description=(
+                "Table of contents for one package directory in a single call: package name, "
+                "import path, doc comment, exported/unexported symbol counts by kind, and its "
+                "files. Go uses the package clause and doc.go; Python the __init__.py docstring."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "package": {
+                        "type": "string",
+                        "description": "Directory path, Go import path, or dotted Python package."
+                    },
+                },
+                "required": ["repo", "package"],
+            },
+        ),
         Tool(
             name="get_symbol_source",
-            description="Get full source of one symbol (symbol_id → flat object) or many (symbol_ids[] → {symbols, errors}). Supports verify, context_lines, and fqn (PHP FQN via PSR-4).",
+            description="Get full source of one symbol (symbol_id → flat object) or many (symbol_ids[] → {symbols, errors}). Supports name lookup, verify, context_lines, and fqn (qualified ID or PHP FQN).",
             inputSchema={
                 "type": "object",
                 "properties": {
@@ -1139,7 +1312,47 @@ def _build_tools_list() -> list[Tool]:
                     },
                     "fqn": {
                         "type": "string",
-                        "description": "PHP fully-qualified class name (e.g. 'App\\Models\\User'). Resolves to symbol_id via PSR-4. Alternative to symbol_id."
+                        "description": "Qualified ID (Go 'example.com/app/store.Config', Python 'app.store.Config') or PHP FQN via PSR-4. Alternative to symbol_id."
+                    },
+                    "name": {
+                        "type": "string",
+                        "description": "Symbol name or qualified name (e.g. 'Server.Start'). Returns {symbols, errors} with all matches. Alternative to symbol_id."
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "With name: only match definitions in this file (path or path suffix)"
+                    },
+                    "include_doc": {
+                        "type": "boolean",
+                        "description": "Prepend the doc comment directly above the declaration to source",
+                        "default": False
+                    }
+                },
+                "required": ["repo"]
+            }
+        ),
+        Tool(
+            name="summarize_symbol",
+            description="Compact preview of one symbol for planning: doc summary, signature with structured params/returns, enclosing type and package, and the first max_lines lines of source (the whole body when it is that short). Use get_symbol_source for the full body.",
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "symbol_id": {
+                        "type": "string",
+                        "description": "Symbol ID from search_symbols or get_file_outline"
+                    },
+                    "fqn": {
+                        "type": "string",
+                        "description": "Qualified ID or PHP FQN. Alternative to symbol_id."
+                    },
+                    "max_lines": {
+                        "type": "integer",
+                        "description": "Source lines to include (default 10, max 200)",
+                        "default": 10
                     }
                 },
                 "required": ["repo"]
@@ -1171,6 +1384,32 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "file_path"]
             }
         ),
+        Tool(
+            name="read_file_range",
+            description="Read a line range of a cached file with each line prefixed by its line number and a tab. Out-of-range bounds clamp to the file; the response reports the range actually returned.",
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "Path to the file within the repository (e.g., 'src/main.py')"
+                    },
+                    "start_line": {
+                        "type": "integer",
+                        "description": "1-based start line (inclusive, default 1)"
+                    },
+                    "end_line": {
+                        "type": "integer",
+                        "description": "1-based end line (inclusive, default: end of file)"
+                    }
+                },
+                "required": ["repo", "file_path"]
+            }
+        ),
         Tool(
             name="search_symbols",
             description="Search for symbols matching a query across the entire indexed repository. Returns matches with signatures and summaries.",
@@ -1208,6 +1447,11 @@ def _build_tools_list() -> list[Tool]:
                         "description": "Maximum number of results to return (ignored when token_budget is set)",
                         "default": 10
                     },
+                    "offset": {
+                        "type": "integer",
+                        "description": "Ranked results to skip, for paging. Use with total_count/has_more.",
+                        "default": 0
+                    },
                     "token_budget": {
                         "type": "integer",
                         "description": "Token budget cap. When set, results are sorted by score and greedily packed until the budget is exhausted. Overrides max_results. Reports token_budget, tokens_used, and tokens_remaining in _meta."
@@ -1240,10 +1484,20 @@ def _build_tools_list() -> list[Tool]:
                     },
                     "sort_by": {
                         "type": "string",
-                        "enum": ["relevance", "centrality", "combined"],
-                        "description": "Ranking strategy. 'relevance' (default) = BM25 text match. 'centrality' = filter by query, rank by PageRank. 'combined' = BM25 + PageRank weighted.",
+                        "enum": ["relevance", "centrality", "combined", "name"],
+                        "description": "Ranking strategy. 'relevance' (default) = BM25 text match. 'centrality' = filter by query, rank by PageRank. 'combined' = BM25 + PageRank weighted. 'name' = go-to-symbol: exact > prefix > substring > subsequence on the name, with score and match_type per result.",
                         "default": "relevance"
                     },
+                    "case_sensitive": {
+                        "type": "boolean",
+                        "description": "Case-sensitive name matching for sort_by='name'.",
+                        "default": False
+                    },
+                    "include_tests": {
+                        "type": "boolean",
+                        "description": "Include symbols from test-only files (Go *_test.go). Set false to keep test helpers out of results.",
+                        "default": True
+                    },
                     "semantic": {
                         "type": "boolean",
                         "description": "Enable semantic (embedding-based) search. Requires an embedding provider: JCODEMUNCH_EMBED_MODEL (sentence-transformers), GOOGLE_API_KEY+GOOGLE_EMBED_MODEL (Gemini), or OPENAI_API_KEY+OPENAI_EMBED_MODEL (OpenAI). When false (default) there is zero performance impact.",
@@ -1267,7 +1521,8 @@ def _build_tools_list() -> list[Tool]:
                     "fqn": {
                         "type": "string",
                         "description": "PHP fully-qualified class name (e.g. 'App\\Models\\User'). Resolves via PSR-4 and uses the class name as query. Alternative to query."
-                    }
+                    },
+                    "output_format": dict(_OUTPUT_FORMAT_SCHEMA),
                 },
                 "required": ["repo", "query"]
             }
@@ -1314,15 +1569,95 @@ def _build_tools_list() -> list[Tool]:
                         "description": "Maximum number of matching lines to return",
                         "default": 20
                     },
+                    "offset": {
+                        "type": "integer",
+                        "description": "Matching lines to skip, for paging. Use with total_count/has_more.",
+                        "default": 0
+                    },
+                    "count_all": {
+                        "type": "boolean",
+                        "description": "Scan every file so total_count is exact. Default stops one match past the page (total_count_exact=false).",
+                        "default": False
+                    },
                     "context_lines": {
                         "type": "integer",
                         "description": "Lines of context to include before and after each match (like grep -C N). Essential for understanding code around matches.",
                         "default": 0
-                    }
+                    },
+                    "output_format": dict(_OUTPUT_FORMAT_SCHEMA),
                 },
                 "required": ["repo", "query"]
             }
         ),
+        Tool(
+            name="search_content",
+            description="grep-style search of indexed file contents with a Go regexp (RE2) pattern, case-sensitive unless prefixed (?i). Returns a flat list of matches (file, line, column, text) with optional context lines. Stops at max_results; ignored/vendored files are never searched.",
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "pattern": {
+                        "type": "string",
+                        "description": "Go regexp pattern, matched per line (e.g. 'errors\\.New\\(\"[^\"]*timeout')."
+                    },
+                    "paths": {
+                        "type": "array",
+                        "items": {"type": "string"},
+                        "description": "Globs ('*.go') or directory/file paths ('cmd/server') to search; any match is included."
+                    },
+                    "context_lines": {
+                        "type": "integer",
+                        "description": "Lines before and after each match (0-10).",
+                        "default": 0
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Stop after this many matches (max 500).",
+                        "default": 50
+                    },
+                    "max_per_file": {
+                        "type": "integer",
+                        "description": "Cap on matches from any single file."
+                    }
+                },
+                "required": ["repo", "pattern"]
+            }
+        ),
+        Tool(
+            name="list_todos",
+            description="List TODO/FIXME/HACK/XXX markers found in comments (any parsed language), each with file, line, full comment text, and the enclosing symbol it belongs to. Marker set is configurable and case-insensitive.",
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "markers": {
+                        "type": "array",
+                        "items": {"type": "string"},
+                        "description": "Marker words to find (default TODO, FIXME, HACK, XXX). Matched as whole words, case-insensitive."
+                    },
+                    "file_pattern": {
+                        "type": "string",
+                        "description": "Glob filter on file paths (e.g. 'src/**/*.go')."
+                    },
+                    "language": {
+                        "type": "string",
+                        "description": "Restrict to one language (e.g. 'go', 'python')."
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Cap on markers returned (max 1000).",
+                        "default": 200
+                    }
+                },
+                "required": ["repo"]
+            }
+        ),
         Tool(
             name="get_repo_outline",
             description="Get a high-level overview of an indexed repository: directories, file counts, language breakdown, symbol counts. Lighter than get_file_tree.",
@@ -1337,6 +1672,25 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo"]
             }
         ),
+        Tool(
+            name="project_stats",
+            description="Whole-repo metrics in one call: files/lines/bytes per language, symbol kinds, largest files, most-referenced symbols (approximate), package count.",
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "top_n": {
+                        "type": "integer",
+                        "description": "Entries in largest_files and most_referenced_symbols (default 10, max 100)",
+                        "default": 10
+                    }
+                },
+                "required": ["repo"]
+            }
+        ),
         Tool(
             name="find_importers",
             description="Find all files that import a given file. Answers 'what uses this file?'. has_importers=false on a result means that importer is itself unreachable (dead code chain). Supports dbt {{ ref() }} edges. Use file_paths for batch queries. Set cross_repo=true to also find importers in other indexed repos.",
@@ -1367,6 +1721,19 @@ def _build_tools_list() -> list[Tool]:
                         "default": False,
                         "description": "When true (singular mode only), each reference entry includes calling_symbols: symbols in that file whose bodies mention the identifier. Default false.",
                     },
+                    "include_usages": {
+                        "type": "boolean",
+                        "default": False,
+                        "description": "Singular mode: also return usages — every whole-word occurrence in indexed files with line text and role (definition|reference).",
+                    },
+                    "fqn": {
+                        "type": "string",
+                        "description": "Qualified ID (e.g. 'example.com/app/store.Config') instead of identifier: only importers of that symbol's package count, so same-named symbols elsewhere are excluded.",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "With identifier: the file or package directory (or path suffix) defining the symbol meant. Restricts results like fqn.",
+                    },
                 },
                 "required": ["repo"],
             },
@@ -1428,7 +1795,7 @@ def _build_tools_list() -> list[Tool]:
                 "Get full source + imports for one or more symbols in one call. "
                 "Multi-symbol bundles deduplicate shared imports. "
                 "Set token_budget to cap response size; use budget_strategy to control what's kept. "
-                "Supports fqn (PHP FQN via PSR-4) as alternative to symbol_id."
+                "Supports fqn (qualified ID or PHP FQN) as alternative to symbol_id."
             ),
             inputSchema={
                 "type": "object",
@@ -1478,7 +1845,15 @@ def _build_tools_list() -> list[Tool]:
                     },
                     "fqn": {
                         "type": "string",
-                        "description": "PHP fully-qualified class name (e.g. 'App\\Models\\User'). Resolves to symbol_id via PSR-4. Alternative to symbol_id."
+                        "description": "Qualified ID (Go 'example.com/app/store.Config', Python 'app.store.Config') or PHP FQN via PSR-4. Alternative to symbol_id."
+                    },
+                    "query": {
+                        "type": "string",
+                        "description": "Task description. Runs fuzzy symbol search + content search, dedupes, and packs the most relevant symbols/snippets until token_budget (default 4000) or max_bytes is hit. Replaces symbol_id/symbol_ids."
+                    },
+                    "max_bytes": {
+                        "type": "integer",
+                        "description": "Byte cap for query mode, enforced alongside token_budget."
                     }
                 },
                 "required": ["repo"]
@@ -1853,6 +2228,38 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "file"]
             }
         ),
+        Tool(
+            name="get_dependencies",
+            description=(
+                "What a package (or file) imports and who imports it. Imports are grouped "
+                "into stdlib, third_party, and internal (resolved to an indexed file or "
+                "directory); Go import specs keep their alias, including dot and blank "
+                "imports. Reverse dependencies list every indexed file outside the target "
+                "that imports it. Use before a refactor to gauge coupling."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "package": {
+                        "type": "string",
+                        "description": (
+                            "Directory path, Go import path, dotted Python package, "
+                            "or a single file path."
+                        ),
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Maximum number of importing files to return (default 200).",
+                        "default": 200,
+                    },
+                },
+                "required": ["repo", "package"],
+            },
+        ),
         Tool(
             name="get_symbol_diff",
             description="Diff symbol sets between two indexed snapshots. Shows added, removed, and changed symbols. Branch workflow: index branch A as repo-main, index branch B as repo-feature, then diff.",
@@ -1877,6 +2284,36 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "class_name"],
             },
         ),
+        Tool(
+            name="get_type_hierarchy",
+            description=(
+                "Supertype and subtype trees for a type, each node with file and line. Go: embedded "
+                "fields / embedded interfaces followed transitively, the types that embed it, and the "
+                "interfaces it satisfies (value or pointer receiver), or an interface's implementers. "
+                "Other languages: base classes and subclasses via extends/implements. A type reached "
+                "twice (diamond embedding) is expanded once; later nodes carry repeated=true."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {"type": "string", "description": "Repository identifier (owner/repo or just repo name)"},
+                    "type_name": {
+                        "type": "string",
+                        "description": "Type to analyse; Go types may be package-qualified (store.User)",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "File or package directory, to pick one of several same-named types",
+                    },
+                    "max_depth": {
+                        "type": "integer",
+                        "description": "Levels to expand in each direction (default 10, max 50)",
+                        "default": 10,
+                    },
+                },
+                "required": ["repo", "type_name"],
+            },
+        ),
         Tool(
             name="get_related_symbols",
             description="Find symbols related to a given symbol using heuristic clustering: same-file co-location (weight 3), shared importers (weight 1.5), and name-token overlap (weight 0.5/token). Useful for discovering what else to read when exploring an unfamiliar codebase.",
@@ -1992,6 +2429,44 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "symbol_id"],
             },
         ),
+        Tool(
+            name="get_call_graph",
+            description=(
+                "Return the call graph rooted at a symbol as directed caller→callee edges "
+                "(plus the nodes they connect), ready to render. Cycles become edges back to "
+                "visited nodes. Interface-dispatch and text-matched edges are flagged approximate."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)"
+                    },
+                    "symbol_id": {
+                        "type": "string",
+                        "description": "Root symbol name or full ID."
+                    },
+                    "direction": {
+                        "type": "string",
+                        "enum": ["callees", "callers", "both"],
+                        "description": "'callees' (default) = what it ultimately calls; 'callers' = who ultimately calls it.",
+                        "default": "callees",
+                    },
+                    "depth": {
+                        "type": "integer",
+                        "description": "Maximum hops from the root (1–5). Default 3.",
+                        "default": 3,
+                    },
+                    "max_edges": {
+                        "type": "integer",
+                        "description": "Edge cap; _meta.truncated is set when hit. Default 500.",
+                        "default": 500,
+                    },
+                },
+                "required": ["repo", "symbol_id"],
+            },
+        ),
         Tool(
             name="get_impact_preview",
             description=(
@@ -2047,6 +2522,47 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "symbol"],
             },
         ),
+        Tool(
+            name="git_blame",
+            description=(
+                "Per-line git blame for a file range or a symbol: last commit hash, author, "
+                "and date for each line, plus the distinct commits newest-first. With `symbol` "
+                "the symbol's line span is blamed and `most_recent` answers 'who last changed "
+                "it and when?'. Files outside git return status 'not_tracked' rather than an "
+                "error. Requires a locally indexed repo (index_folder)."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "File to blame (relative path). Use this or symbol.",
+                    },
+                    "symbol": {
+                        "type": "string",
+                        "description": "Symbol name or full ID; blames its line range.",
+                    },
+                    "start_line": {
+                        "type": "integer",
+                        "description": "1-based start line (file mode; clamped to the file).",
+                    },
+                    "end_line": {
+                        "type": "integer",
+                        "description": "1-based end line, inclusive (file mode; clamped to the file).",
+                    },
+                    "max_lines": {
+                        "type": "integer",
+                        "description": "Maximum per-line entries to return (default 500).",
+                        "default": 500,
+                    },
+                },
+                "required": ["repo"],
+            },
+        ),
         Tool(
             name="get_pr_risk_profile",
             description=(
@@ -2185,6 +2701,90 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "symbol_id", "new_name"],
             },
         ),
+        Tool(
+            name="rename_preview",
+            description=(
+                "Preview a scope-aware rename: every file/line/column edit that renaming an "
+                "identifier would make (definition plus all references). Read-only — never "
+                "modifies files. Resolves the identifier at the given location to its binding, "
+                "so a local variable is renamed only within its scope and never touches an "
+                "unrelated package-level name of the same spelling. Go only; uses gopls "
+                "(go/types) when installed and the package type-checks, else a syntactic "
+                "scope resolver."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "name": {
+                        "type": "string",
+                        "description": "Current identifier name.",
+                    },
+                    "new_name": {
+                        "type": "string",
+                        "description": "Replacement identifier name.",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "File holding the definition (any occurrence of it also works).",
+                    },
+                    "line": {
+                        "type": "integer",
+                        "description": "1-based line of the definition or occurrence.",
+                    },
+                    "column": {
+                        "type": "integer",
+                        "description": "1-based column, when the name occurs more than once on the line.",
+                    },
+                    "use_type_checker": {
+                        "type": "boolean",
+                        "description": "Try gopls (go/types) before the syntactic resolver (default true).",
+                        "default": True,
+                    },
+                },
+                "required": ["repo", "name", "new_name", "file_path", "line"],
+            },
+        ),
+        Tool(
+            name="locate_definition",
+            description=(
+                "Go to definition: resolve the identifier at file/line/column to the site "
+                "that declares it — locals and parameters by scope, pkg.Name through imports, "
+                "method calls and struct fields through the receiver's inferred type. Names "
+                "declared outside the index (stdlib, dependencies, builtins) return their "
+                "import path. Go only; uses gopls (go/types) when installed."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "File holding the reference.",
+                    },
+                    "line": {
+                        "type": "integer",
+                        "description": "1-based line of the reference.",
+                    },
+                    "column": {
+                        "type": "integer",
+                        "description": "1-based column inside the identifier.",
+                    },
+                    "use_type_checker": {
+                        "type": "boolean",
+                        "description": "Try gopls (go/types) before the syntactic resolver (default true).",
+                        "default": True,
+                    },
+                },
+                "required": ["repo", "file_path", "line", "column"],
+            },
+        ),
         Tool(
             name="check_delete_safe",
             description=(
@@ -2222,7 +2822,8 @@ def _build_tools_list() -> list[Tool]:
             name="find_implementations",
             description=(
                 "Find concrete implementations of an interface, abstract class, or method. "
-                "Multi-source resolution with confidence scoring: LSP dispatch (1.0), AST class "
+                "Multi-source resolution with confidence scoring: LSP dispatch (1.0), Go method "
+                "sets (0.95), AST class "
                 "hierarchy (0.85), duck-typed name match (0.65), decorator handler (0.45). "
                 "Classifies each impl (subclass_override / interface_impl / duck_typed / "
                 "decorator_handler / subclass), ranks by PageRank × byte_length, attaches "
@@ -2426,6 +3027,33 @@ def _build_tools_list() -> list[Tool]:
                 "required": ["repo", "symbol_id"],
             },
         ),
+        Tool(
+            name="get_complexity",
+            description=(
+                "Cyclomatic complexity and line count for every function in a Go file, counted by "
+                "walking the AST: 1 + each if, for, non-default case (switch/type switch/select), "
+                "and && / || operator. Sorted worst first. threshold returns only functions above it."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "Path to a Go file within the repository",
+                    },
+                    "threshold": {
+                        "type": "integer",
+                        "description": "Only return functions with complexity strictly above this value (default 0: all).",
+                        "default": 0,
+                    },
+                },
+                "required": ["repo", "file_path"],
+            },
+        ),
         Tool(
             name="get_churn_rate",
             description=(
@@ -2533,37 +3161,154 @@ def _build_tools_list() -> list[Tool]:
                         "type": "string",
                         "description": "Repository identifier (owner/repo or just repo name)",
                     },
-                    "file_pattern": {
+                    "file_pattern": {
+                        "type": "string",
+                        "description": "Optional glob to narrow which source files are analysed (e.g. 'src/**/*.py').",
+                    },
+                    "min_confidence": {
+                        "type": "number",
+                        "description": "Minimum confidence to include (0.0–1.0, default 0.5).",
+                        "default": 0.5,
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Cap on returned symbols (default 100).",
+                        "default": 100,
+                    },
+                },
+                "required": ["repo"],
+            },
+        ),
+        Tool(
+            name="search_ast",
+            description=(
+                "Cross-language AST pattern matching. Finds structural code patterns "
+                "across all 70+ indexed languages using a single query — no need to know "
+                "language-specific AST node types. Two modes: (1) preset anti-patterns "
+                "(empty_catch, bare_except, deeply_nested, nested_loops, god_function, "
+                "eval_exec, hardcoded_secret, todo_fixme, magic_number, reassigned_param), "
+                "or (2) custom mini-DSL (call:*.unwrap, string:/password/i, comment:/TODO/i, "
+                "nesting:5+, loops:3+, lines:80+). Use category='all' to run every preset "
+                "at once, or category='security'/'error_handling'/'complexity'/'performance'/"
+                "'maintenance' for a focused scan. Every match is attributed to its enclosing "
+                "indexed symbol with complexity metadata. Requires a locally indexed repo."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "pattern": {
+                        "type": "string",
+                        "description": (
+                            "Preset name (empty_catch, bare_except, deeply_nested, nested_loops, "
+                            "god_function, eval_exec, hardcoded_secret, todo_fixme, magic_number, "
+                            "reassigned_param) or custom query (call:NAME, string:/REGEX/i, "
+                            "comment:/REGEX/i, nesting:N+, loops:N+, lines:N+). "
+                            "Mutually exclusive with category."
+                        ),
+                    },
+                    "category": {
+                        "type": "string",
+                        "description": (
+                            "Run all presets in a category: security, error_handling, "
+                            "complexity, performance, maintenance, or all."
+                        ),
+                    },
+                    "language": {
+                        "type": "string",
+                        "description": "Restrict scan to one language (e.g. 'python', 'typescript').",
+                    },
+                    "file_pattern": {
+                        "type": "string",
+                        "description": "Glob filter on file paths (e.g. 'src/**/*.py').",
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Cap on total matches returned (default 50).",
+                        "default": 50,
+                    },
+                },
+                "required": ["repo"],
+            },
+        ),
+        Tool(
+            name="get_parse_errors",
+            description=(
+                "Syntax errors tree-sitter recovered from while indexing. Returns, per "
+                "file, each ERROR or MISSING node with 1-based line/column range and a "
+                "short message, plus how many symbols were still extracted. Use it when "
+                "a file's outline looks incomplete, to tell a broken parse from a file "
+                "that simply declares nothing. Requires a locally indexed repo."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "file_path": {
+                        "type": "string",
+                        "description": "Check only this file.",
+                    },
+                    "path_prefix": {
+                        "type": "string",
+                        "description": "Check only files under this directory prefix.",
+                    },
+                    "max_results": {
+                        "type": "integer",
+                        "description": "Maximum number of files with errors to return (default 100).",
+                        "default": 100,
+                    },
+                },
+                "required": ["repo"],
+            },
+        ),
+        Tool(
+            name="format_check",
+            description=(
+                "Check Go files against gofmt (or goimports) without writing anything. "
+                "Returns, per file, whether it is already formatted and a unified diff "
+                "of what formatting would change, plus the list of files that would "
+                "change. Use before editing to keep formatting churn out of the edit."
+            ),
+            inputSchema={
+                "type": "object",
+                "properties": {
+                    "repo": {
+                        "type": "string",
+                        "description": "Repository identifier (owner/repo or just repo name)",
+                    },
+                    "path": {
                         "type": "string",
-                        "description": "Optional glob to narrow which source files are analysed (e.g. 'src/**/*.py').",
+                        "description": "A Go file, or a directory to check recursively. Omit for the whole repo.",
                     },
-                    "min_confidence": {
-                        "type": "number",
-                        "description": "Minimum confidence to include (0.0–1.0, default 0.5).",
-                        "default": 0.5,
+                    "use_goimports": {
+                        "type": "boolean",
+                        "description": "Use goimports instead of gofmt when it is on PATH (default false).",
+                        "default": False,
                     },
-                    "max_results": {
+                    "max_files": {
                         "type": "integer",
-                        "description": "Cap on returned symbols (default 100).",
-                        "default": 100,
+                        "description": "Maximum number of files to check (default 200).",
+                        "default": 200,
                     },
                 },
                 "required": ["repo"],
             },
         ),
         Tool(
-            name="search_ast",
+            name="get_test_coverage_map",
             description=(
-                "Cross-language AST pattern matching. Finds structural code patterns "
-                "across all 70+ indexed languages using a single query — no need to know "
-                "language-specific AST node types. Two modes: (1) preset anti-patterns "
-                "(empty_catch, bare_except, deeply_nested, nested_loops, god_function, "
-                "eval_exec, hardcoded_secret, todo_fixme, magic_number, reassigned_param), "
-                "or (2) custom mini-DSL (call:*.unwrap, string:/password/i, comment:/TODO/i, "
-                "nesting:5+, loops:3+, lines:80+). Use category='all' to run every preset "
-                "at once, or category='security'/'error_handling'/'complexity'/'performance'/"
-                "'maintenance' for a focused scan. Every match is attributed to its enclosing "
-                "indexed symbol with complexity metadata. Requires a locally indexed repo."
+                "Map a Go package's functions and methods to the tests that exercise them. "
+                "Walks each Test/Benchmark/Fuzz/Example function in the package's _test.go files "
+                "through the call graph (test helpers included) and lists, per symbol, the covering "
+                "tests with their call depth; symbols no test reaches are marked covered=false. "
+                "Heuristic reachability; run_go_test=true also runs go test -coverprofile for real "
+                "per-function percentages."
             ),
             inputSchema={
                 "type": "object",
@@ -2572,38 +3317,27 @@ def _build_tools_list() -> list[Tool]:
                         "type": "string",
                         "description": "Repository identifier (owner/repo or just repo name)",
                     },
-                    "pattern": {
-                        "type": "string",
-                        "description": (
-                            "Preset name (empty_catch, bare_except, deeply_nested, nested_loops, "
-                            "god_function, eval_exec, hardcoded_secret, todo_fixme, magic_number, "
-                            "reassigned_param) or custom query (call:NAME, string:/REGEX/i, "
-                            "comment:/REGEX/i, nesting:N+, loops:N+, lines:N+). "
-                            "Mutually exclusive with category."
-                        ),
-                    },
-                    "category": {
+                    "package": {
                         "type": "string",
-                        "description": (
-                            "Run all presets in a category: security, error_handling, "
-                            "complexity, performance, maintenance, or all."
-                        ),
+                        "description": "Package directory relative to the repo root ('.' for the root) or its import path.",
                     },
-                    "language": {
-                        "type": "string",
-                        "description": "Restrict scan to one language (e.g. 'python', 'typescript').",
+                    "depth": {
+                        "type": "integer",
+                        "description": "Call-graph hops followed from each test (default 2, max 5).",
+                        "default": 2,
                     },
-                    "file_pattern": {
-                        "type": "string",
-                        "description": "Glob filter on file paths (e.g. 'src/**/*.py').",
+                    "uncovered_only": {
+                        "type": "boolean",
+                        "description": "Only list symbols no test reaches (default false).",
+                        "default": False,
                     },
-                    "max_results": {
-                        "type": "integer",
-                        "description": "Cap on total matches returned (default 50).",
-                        "default": 50,
+                    "run_go_test": {
+                        "type": "boolean",
+                        "description": "Run the package's tests with -coverprofile (executes repo code; local index, go on PATH). Default false.",
+                        "default": False,
                     },
                 },
-                "required": ["repo"],
+                "required": ["repo", "package"],
             },
         ),
         Tool(
@@ -2857,7 +3591,8 @@ def _build_tools_list() -> list[Tool]:
             name="get_changed_symbols",
             description=(
                 "Map a git diff to affected symbols: given two commits, returns which symbols "
-                "were added, removed, modified, or renamed. Useful after merging a PR to answer "
+                "were added, removed, modified, moved, or renamed; modified entries say whether the "
+                "signature, the struct/class fields, or only the body changed. Useful after merging a PR to answer "
                 "'what actually changed?' for code review or regression triage. "
                 "Requires a locally indexed repo (index_folder). "
                 "Defaults to comparing current HEAD against the SHA stored at index time."
@@ -2872,7 +3607,7 @@ def _build_tools_list() -> list[Tool]:
                     },
                     "until_sha": {
                         "type": "string",
-                        "description": "Compare to this git SHA or ref (default 'HEAD').",
+                        "description": "Compare to this git SHA or ref (default 'HEAD'); 'WORKTREE' compares against uncommitted and untracked files.",
                         "default": "HEAD",
                     },
                     "include_blast_radius": {
@@ -3388,11 +4123,62 @@ def _apply_description_overrides(tools: list) -> None:
                     props[param_name] = {**param_schema, "description": desc_override}
 
 
-@server.list_resources()
-async def list_resources() -> list[Resource]:
-    """Return empty resource list for client compatibility (e.g. Windsurf)."""
+async def list_resources(cursor: Optional[str] = None) -> ListResourcesResult:
+    """One page of indexed files and per-package symbol indexes."""
+    _signal_handshake()
+    from .resources import list_resource_entries
+    try:
+        entries, next_cursor = await asyncio.to_thread(
+            list_resource_entries, os.environ.get("CODE_INDEX_PATH"), cursor
+        )
+    except ValueError:
+        raise  # a cursor we did not issue
+    except Exception:
+        # Some clients (e.g. Windsurf) list resources during the handshake;
+        # a storage problem must not fail it.
+        logger.debug("list_resources failed", exc_info=True)
+        return ListResourcesResult(resources=[])
+    return ListResourcesResult(resources=[Resource(**e) for e in entries], nextCursor=next_cursor)
+
+
+async def _handle_list_resources(req: ListResourcesRequest) -> ServerResult:
+    # Registered directly rather than via @server.list_resources(): the
+    # decorator drops the request, and with it the pagination cursor.
+    cursor = req.params.cursor if req.params is not None else None
+    return ServerResult(await list_resources(cursor))
+
+
+server.request_handlers[ListResourcesRequest] = _handle_list_resources
+
+
+@server.list_resource_templates()
+async def list_resource_templates() -> list[ResourceTemplate]:
+    """URI templates for files and package symbol indexes."""
+    from .resources import FILE_TEMPLATE, SYMBOLS_TEMPLATE
+    return [
+        ResourceTemplate(
+            uriTemplate=FILE_TEMPLATE,
+            name="Indexed file",
+            description="Cached content of a file in an indexed repo (path relative to the repo root).",
+        ),
+        ResourceTemplate(
+            uriTemplate=SYMBOLS_TEMPLATE,
+            name="Package symbol index",
+            description="JSON list of the symbols defined directly in one directory of an indexed repo ('.' for the root).",
+            mimeType="application/json",
+        ),
+    ]
+
+
+@server.read_resource()
+async def read_resource(uri) -> list[ReadResourceContents]:
+    """Read a codemunch:// file or package symbol-index resource."""
     _signal_handshake()
-    return []
+    from .resources import read_resource_text
+    text, mime_type = await asyncio.to_thread(
+        read_resource_text, str(uri), os.environ.get("CODE_INDEX_PATH")
+    )
+    return [ReadResourceContents(content=text, mime_type=mime_type)]
 
 
 _WORKFLOW_PROMPT_TEXT = """\
@@ -3546,6 +4332,7 @@ async def get_prompt(name: str, arguments: dict | None = None) -> GetPromptResul
 # Tools excluded from auto-watch (no folder target, meta-only, or file-path arg)
 _AUTO_WATCH_EXCLUDED = frozenset({
     "list_repos",
+    "server_info",
     "get_session_stats",
     "get_session_context",
     "get_session_snapshot",
@@ -3634,9 +4421,68 @@ async def _auto_watch_if_needed(name: str, arguments: dict, storage_path: Option
         logger.debug("Auto-watch failed for %s", folder, exc_info=True)
 
 
+# How long a call may overrun its deadline before call_tool stops waiting.
+# Walking and parsing check the deadline themselves and return partial
+# results; this only catches code that never checks.
+_HARD_TIMEOUT_GRACE_SEC = 5.0
+
+# Indexing a large tree legitimately takes longer than any sensible default,
+# so tool_timeout_seconds does not apply to these; an explicit
+# timeout_seconds argument still does.
+_UNTIMED_BY_DEFAULT = frozenset({
+    "index_repo", "index_folder", "index_file", "summarize_repo", "embed_repo",
+})
+
+
 @server.call_tool(validate_input=False)
 async def call_tool(name: str, arguments: dict) -> list[TextContent]:
-    """Handle tool calls."""
+    """Handle tool calls under the per-call deadline (``tool_timeout_seconds``)."""
+    # `timeout_seconds` is cross-cutting like `format`: it overrides the
+    # tool_timeout_seconds config key for this call only.
+    timeout = 0 if name in _UNTIMED_BY_DEFAULT else config_module.get("tool_timeout_seconds", 300)
+    if isinstance(arguments, dict) and "timeout_seconds" in arguments:
+        raw_timeout = arguments.pop("timeout_seconds")
+        try:
+            if isinstance(raw_timeout, bool):
+                raise ValueError
+            timeout = float(raw_timeout)
+            if timeout < 0:
+                raise ValueError
+        except (TypeError, ValueError):
+            return [TextContent(type="text", text=json.dumps({
+                "error": (
+                    f"Input validation error: timeout_seconds must be a non-negative "
+                    f"number (0 = no limit), got {raw_timeout!r}"
+                )
+            }, indent=2))]
+    try:
+        timeout = float(timeout or 0)
+    except (TypeError, ValueError):
+        timeout = 0.0
+    if timeout <= 0:
+        return await _call_tool(name, arguments)
+
+    # The deadline is a context variable, so the task wait_for creates and
+    # the asyncio.to_thread workers it starts both inherit it.
+    with deadline_scope(timeout):
+        try:
+            return await asyncio.wait_for(
+                _call_tool(name, arguments), timeout=timeout + _HARD_TIMEOUT_GRACE_SEC,
+            )
+        except asyncio.TimeoutError:
+            logger.warning("tool_call: %s exceeded its %gs timeout", name, timeout)
+            return [TextContent(type="text", text=json.dumps({
+                "error": (
+                    f"{name} timed out after {timeout:g}s and was abandoned before "
+                    "returning a partial result. Retry with a larger timeout_seconds "
+                    "or a narrower request."
+                ),
+                "timed_out": True,
+            }, indent=2))]
+
+
+async def _call_tool(name: str, arguments: dict) -> list[TextContent]:
+    """Validate, dispatch and encode one tool call."""
     _signal_handshake()
     storage_path = os.environ.get("CODE_INDEX_PATH")
     logger.info("tool_call: %s args=%s", name, {k: v for k, v in arguments.items() if k != "content"})
@@ -3649,6 +4495,24 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
         _requested_format = None
         if isinstance(arguments, dict) and "format" in arguments:
             _requested_format = arguments.pop("format")
+        # `output_format` picks the text serialization instead (compact /
+        # pretty JSON or markdown, see .encoding.output_format).
+        _output_format = None
+        if (
+            isinstance(arguments, dict)
+            and "output_format" in arguments
+            and name not in _TOOLS_WITH_OWN_OUTPUT_FORMAT
+        ):
+            from .encoding.output_format import OUTPUT_FORMATS, normalize_output_format
+            _raw_output_format = arguments.pop("output_format")
+            _output_format = normalize_output_format(_raw_output_format)
+            if _output_format is None and _raw_output_format is not None:
+                return [TextContent(type="text", text=json.dumps({
+                    "error": (
+                        f"Input validation error: output_format must be one of "
+                        f"{', '.join(OUTPUT_FORMATS)}, got {_raw_output_format!r}"
+                    )
+                }, indent=2))]
         # Coerce stringified booleans/integers/numbers before routing
         schema = (await _ensure_tool_schemas()).get(name)
         if schema:
@@ -3684,9 +4548,11 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                 )
             }, indent=2))]
 
-        # Auto-watch: ensure unwatched repos are indexed before tool execution
+        # Auto-watch: ensure unwatched repos are indexed before tool execution.
+        # Outside the call's deadline: the watch tasks started here outlive it.
         try:
-            await _auto_watch_if_needed(name, arguments, storage_path)
+            with deadline_scope(None):
+                await _auto_watch_if_needed(name, arguments, storage_path)
         except Exception:
             logger.debug("Auto-watch check failed", exc_info=True)
 
@@ -3726,10 +4592,11 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     use_ai_summaries=_ai,
                     storage_path=storage_path,
                     extra_ignore_patterns=arguments.get("extra_ignore_patterns"),
-                    follow_symlinks=arguments.get("follow_symlinks", False),
+                    follow_symlinks=arguments.get("follow_symlinks"),
                     incremental=arguments.get("incremental", True),
                     paths=arguments.get("paths"),
                     identity_mode=arguments.get("identity_mode", "config"),
+                    exclude_generated=arguments.get("exclude_generated"),
                     progress_cb=_progress_cb,
                 )
             )
@@ -3821,6 +4688,17 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
             result = await asyncio.to_thread(
                 functools.partial(list_repos, storage_path=storage_path)
             )
+        elif name == "server_info":
+            from .tools.server_info import server_info
+            result = await asyncio.to_thread(
+                functools.partial(
+                    server_info,
+                    tool_names=[t.name for t in _build_tools_list()],
+                    tier=_effective_profile(),
+                    repo=arguments.get("repo"),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_watch_status":
             from .tools.get_watch_status import get_watch_status
             result = await asyncio.to_thread(
@@ -3856,6 +4734,22 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     file_path=arguments.get("file_path") or arguments.get("file"),
                     file_paths=arguments.get("file_paths"),
                     storage_path=storage_path,
+                    kinds=arguments.get("kinds"),
+                    exported_only=arguments.get("exported_only", False),
+                    max_results=arguments.get("max_results", arguments.get("limit")),
+                    offset=arguments.get("offset", 0),
+                    group_methods=arguments.get("group_methods", False),
+                    include_tests=arguments.get("include_tests", True),
+                )
+            )
+        elif name == "describe_package":
+            from .tools.describe_package import describe_package
+            result = await asyncio.to_thread(
+                functools.partial(
+                    describe_package,
+                    repo=arguments["repo"],
+                    package=arguments.get("package") or arguments.get("path", ""),
+                    storage_path=storage_path,
                 )
             )
         elif name == "get_file_content":
@@ -3870,6 +4764,18 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "read_file_range":
+            from .tools.read_file_range import read_file_range
+            result = await asyncio.to_thread(
+                functools.partial(
+                    read_file_range,
+                    repo=arguments["repo"],
+                    file_path=arguments["file_path"],
+                    start_line=arguments.get("start_line", 1),
+                    end_line=arguments.get("end_line"),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_symbol_source":
             from .tools.get_symbol import get_symbol_source
             result = await asyncio.to_thread(
@@ -3882,6 +4788,21 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     context_lines=arguments.get("context_lines", 0),
                     storage_path=storage_path,
                     fqn=arguments.get("fqn"),
+                    symbol_name=arguments.get("name"),
+                    file_path=arguments.get("file_path"),
+                    include_doc=arguments.get("include_doc", False),
+                )
+            )
+        elif name == "summarize_symbol":
+            from .tools.summarize_symbol import summarize_symbol
+            result = await asyncio.to_thread(
+                functools.partial(
+                    summarize_symbol,
+                    repo=arguments["repo"],
+                    symbol_id=arguments.get("symbol_id"),
+                    fqn=arguments.get("fqn"),
+                    max_lines=arguments.get("max_lines", 10),
+                    storage_path=storage_path,
                 )
             )
         elif name == "search_symbols":
@@ -3899,7 +4820,8 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                         file_pattern=arguments.get("file_pattern"),
                         language=arguments.get("language"),
                         decorator=arguments.get("decorator"),
-                        max_results=arguments.get("max_results", 10),
+                        max_results=arguments.get("max_results", arguments.get("limit", 10)),
+                        offset=arguments.get("offset", 0),
                         token_budget=arguments.get("token_budget"),
                         detail_level=arguments.get("detail_level", "standard"),
                         debug=arguments.get("debug", False),
@@ -3907,6 +4829,8 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                         fuzzy_threshold=arguments.get("fuzzy_threshold", 0.4),
                         max_edit_distance=arguments.get("max_edit_distance", 2),
                         sort_by=arguments.get("sort_by", "relevance"),
+                        case_sensitive=arguments.get("case_sensitive", False),
+                        include_tests=arguments.get("include_tests", True),
                         semantic=arguments.get("semantic", False),
                         semantic_weight=arguments.get("semantic_weight", 0.5),
                         semantic_only=arguments.get("semantic_only", False),
@@ -3933,12 +4857,41 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     repo=arguments["repo"],
                     query=arguments["query"],
                     file_pattern=arguments.get("file_pattern"),
-                    max_results=arguments.get("max_results", 20),
+                    max_results=arguments.get("max_results", arguments.get("limit", 20)),
+                    offset=arguments.get("offset", 0),
+                    count_all=arguments.get("count_all", False),
                     context_lines=arguments.get("context_lines", 0),
                     is_regex=arguments.get("is_regex", False),
                     storage_path=storage_path,
                 )
             )
+        elif name == "search_content":
+            from .tools.search_content import search_content
+            result = await asyncio.to_thread(
+                functools.partial(
+                    search_content,
+                    repo=arguments["repo"],
+                    pattern=arguments["pattern"],
+                    paths=arguments.get("paths"),
+                    context_lines=arguments.get("context_lines", 0),
+                    max_results=arguments.get("max_results", arguments.get("limit", 50)),
+                    max_per_file=arguments.get("max_per_file"),
+                    storage_path=storage_path,
+                )
+            )
+        elif name == "list_todos":
+            from .tools.list_todos import list_todos
+            result = await asyncio.to_thread(
+                functools.partial(
+                    list_todos,
+                    repo=arguments["repo"],
+                    markers=arguments.get("markers"),
+                    file_pattern=arguments.get("file_pattern"),
+                    language=arguments.get("language"),
+                    max_results=arguments.get("max_results", 200),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_repo_outline":
             from .tools.get_repo_outline import get_repo_outline
             result = await asyncio.to_thread(
@@ -3948,6 +4901,16 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "project_stats":
+            from .tools.project_stats import project_stats
+            result = await asyncio.to_thread(
+                functools.partial(
+                    project_stats,
+                    repo=arguments["repo"],
+                    top_n=arguments.get("top_n", 10),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "find_importers":
             from .tools.find_importers import find_importers
             result = await asyncio.to_thread(
@@ -3972,6 +4935,9 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     max_results=arguments.get("max_results", 50),
                     storage_path=storage_path,
                     include_call_chain=arguments.get("include_call_chain", False),
+                    include_usages=arguments.get("include_usages", False),
+                    fqn=arguments.get("fqn"),
+                    file_path=arguments.get("file_path"),
                 )
             )
         elif name == "check_references":
@@ -4014,6 +4980,8 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     include_budget_report=arguments.get("include_budget_report", False),
                     storage_path=storage_path,
                     fqn=arguments.get("fqn"),
+                    query=arguments.get("query"),
+                    max_bytes=arguments.get("max_bytes"),
                 )
             )
         elif name == "get_ranked_context":
@@ -4204,6 +5172,17 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     cross_repo=arguments.get("cross_repo"),
                 )
             )
+        elif name == "get_dependencies":
+            from .tools.get_dependencies import get_dependencies
+            result = await asyncio.to_thread(
+                functools.partial(
+                    get_dependencies,
+                    repo=arguments["repo"],
+                    package=arguments["package"],
+                    max_results=arguments.get("max_results", 200),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_blast_radius":
             from .tools.get_blast_radius import get_blast_radius
             result = await asyncio.to_thread(
@@ -4234,6 +5213,19 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "get_call_graph":
+            from .tools.get_call_graph import get_call_graph
+            result = await asyncio.to_thread(
+                functools.partial(
+                    get_call_graph,
+                    repo=arguments["repo"],
+                    symbol_id=arguments["symbol_id"],
+                    direction=arguments.get("direction", "callees"),
+                    depth=arguments.get("depth", 3),
+                    max_edges=arguments.get("max_edges", 500),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_impact_preview":
             from .tools.get_impact_preview import get_impact_preview
             result = await asyncio.to_thread(
@@ -4255,6 +5247,20 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "git_blame":
+            from .tools.git_blame import git_blame
+            result = await asyncio.to_thread(
+                functools.partial(
+                    git_blame,
+                    repo=arguments["repo"],
+                    file_path=arguments.get("file_path"),
+                    symbol=arguments.get("symbol"),
+                    start_line=arguments.get("start_line"),
+                    end_line=arguments.get("end_line"),
+                    max_lines=arguments.get("max_lines", 500),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_pr_risk_profile":
             from .tools.get_pr_risk_profile import get_pr_risk_profile
             result = await asyncio.to_thread(
@@ -4307,6 +5313,34 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "rename_preview":
+            from .tools.rename_preview import rename_preview
+            result = await asyncio.to_thread(
+                functools.partial(
+                    rename_preview,
+                    repo=arguments["repo"],
+                    name=arguments["name"],
+                    new_name=arguments["new_name"],
+                    file_path=arguments["file_path"],
+                    line=arguments["line"],
+                    column=arguments.get("column"),
+                    use_type_checker=arguments.get("use_type_checker", True),
+                    storage_path=storage_path,
+                )
+            )
+        elif name == "locate_definition":
+            from .tools.locate_definition import locate_definition
+            result = await asyncio.to_thread(
+                functools.partial(
+                    locate_definition,
+                    repo=arguments["repo"],
+                    file_path=arguments["file_path"],
+                    line=arguments["line"],
+                    column=arguments["column"],
+                    use_type_checker=arguments.get("use_type_checker", True),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "check_delete_safe":
             from .tools.check_delete_safe import check_delete_safe
             result = await asyncio.to_thread(
@@ -4385,6 +5419,17 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "get_complexity":
+            from .tools.get_complexity import get_complexity
+            result = await asyncio.to_thread(
+                functools.partial(
+                    get_complexity,
+                    repo=arguments["repo"],
+                    file_path=arguments["file_path"],
+                    threshold=arguments.get("threshold", 0),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_churn_rate":
             from .tools.get_churn_rate import get_churn_rate
             result = await asyncio.to_thread(
@@ -4438,6 +5483,18 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "get_type_hierarchy":
+            from .tools.get_type_hierarchy import get_type_hierarchy
+            result = await asyncio.to_thread(
+                functools.partial(
+                    get_type_hierarchy,
+                    repo=arguments["repo"],
+                    type_name=arguments["type_name"],
+                    file_path=arguments.get("file_path"),
+                    max_depth=arguments.get("max_depth", 10),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_related_symbols":
             from .tools.get_related_symbols import get_related_symbols
             result = await asyncio.to_thread(
@@ -4539,6 +5596,43 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                     storage_path=storage_path,
                 )
             )
+        elif name == "get_parse_errors":
+            from .tools.get_parse_errors import get_parse_errors
+            result = await asyncio.to_thread(
+                functools.partial(
+                    get_parse_errors,
+                    repo=arguments["repo"],
+                    file_path=arguments.get("file_path"),
+                    path_prefix=arguments.get("path_prefix", ""),
+                    max_results=arguments.get("max_results", 100),
+                    storage_path=storage_path,
+                )
+            )
+        elif name == "format_check":
+            from .tools.format_check import format_check
+            result = await asyncio.to_thread(
+                functools.partial(
+                    format_check,
+                    repo=arguments["repo"],
+                    path=arguments.get("path"),
+                    use_goimports=arguments.get("use_goimports", False),
+                    max_files=arguments.get("max_files", 200),
+                    storage_path=storage_path,
+                )
+            )
+        elif name == "get_test_coverage_map":
+            from .tools.get_test_coverage_map import get_test_coverage_map
+            result = await asyncio.to_thread(
+                functools.partial(
+                    get_test_coverage_map,
+                    repo=arguments["repo"],
+                    package=arguments["package"],
+                    depth=arguments.get("depth", 2),
+                    uncovered_only=arguments.get("uncovered_only", False),
+                    run_go_test=arguments.get("run_go_test", False),
+                    storage_path=storage_path,
+                )
+            )
         elif name == "get_changed_symbols":
             from .tools.get_changed_symbols import get_changed_symbols
             result = await asyncio.to_thread(
@@ -4689,10 +5783,10 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
                 journal = get_journal()
                 journal.record_tool_call(name)
                 # Record file reads for relevant tools
-                if name in {"get_file_content", "get_file_outline", "get_symbol_source", "get_context_bundle"}:
+                if name in {"get_file_content", "read_file_range", "get_file_outline", "get_symbol_source", "get_context_bundle"}:
                     if isinstance(result, dict):
                         # Extract file paths from result
-                        if name == "get_file_content" and "content" in result:
+                        if name in ("get_file_content", "read_file_range") and "content" in result:
                             journal.record_read(arguments.get("file_path", ""), name)
                         elif name == "get_file_outline" and "symbols" in result:
                             journal.record_read(arguments.get("file_path", ""), name)
@@ -4853,7 +5947,8 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
         # checked-in code; the per-byte regex sweep is wasted latency on
         # tools whose payloads can be hundreds of KB).
         _SOURCE_DUMP_TOOLS = frozenset({
-            "get_file_content", "get_symbol_source", "get_context_bundle",
+            "get_file_content", "read_file_range", "get_symbol_source", "get_context_bundle",
+            "summarize_symbol",
         })
         if isinstance(result, dict) and name not in _SOURCE_DUMP_TOOLS:
             try:
@@ -4866,6 +5961,10 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
             except Exception:
                 logger.debug("Secret redaction failed", exc_info=True)
 
+        if _output_format is not None:
+            from .encoding.output_format import render
+            return [TextContent(type="text", text=render(name, result, _output_format))]
+
         # Compact output encoding (MUNCH). Opt-in via `format` argument or
         # JCODEMUNCH_DEFAULT_FORMAT env; "auto" falls back to JSON unless
         # savings clear the gate threshold.
@@ -4890,6 +5989,10 @@ async def call_tool(name: str, arguments: dict) -> list[TextContent]:
     except KeyError as e:
         _call_ok = False
         return [TextContent(type="text", text=json.dumps({"error": f"Missing required argument: {e}. Check the tool schema for correct parameter names."}, separators=(',', ':')))]
+    except asyncio.CancelledError:
+        # Hard timeout in call_tool (or client cancellation).
+        _call_ok = False
+        raise
     except Exception as exc:
         _call_ok = False
         logger.error("call_tool %s failed", name, exc_info=True)
@@ -4961,9 +6064,10 @@ async def _run_server_with_watcher(
         use_ai_summaries=watcher_kwargs.get("use_ai_summaries", True),
         storage_path=watcher_kwargs.get("storage_path"),
         extra_ignore_patterns=watcher_kwargs.get("extra_ignore_patterns"),
-        follow_symlinks=watcher_kwargs.get("follow_symlinks", False),
+        follow_symlinks=watcher_kwargs.get("follow_symlinks"),
         quiet=True,
         log_file_handle=_log_file_handle,
+        on_index_update=_emit_index_updated,
     )
     manager._stop_event = stop_event
 
@@ -5022,7 +6126,7 @@ async def run_stdio_server():
     logger.info(
         "startup version=%s transport=stdio storage=%s ai_summaries=%s",
         __version__,
-        os.path.expanduser(os.environ.get("CODE_INDEX_PATH", "~/.code-index/")),
+        config_module.default_storage_path(),
         _default_use_ai_summaries(),
     )
     # Version-drift probe: on first launch after upgrade, emit a one-line
@@ -5238,7 +6342,7 @@ async def run_sse_server(host: str, port: int):
     logger.info(
         "startup version=%s transport=sse host=%s port=%d storage=%s",
         __version__, host, port,
-        os.path.expanduser(os.environ.get("CODE_INDEX_PATH", "~/.code-index/")),
+        config_module.default_storage_path(),
     )
     _note_adaptive_tiering_transport("sse")
     _log_startup_validation_warnings()
@@ -5420,7 +6524,7 @@ async def run_streamable_http_server(host: str, port: int):
     logger.info(
         "startup version=%s transport=streamable-http host=%s port=%d storage=%s",
         __version__, host, port,
-        os.path.expanduser(os.environ.get("CODE_INDEX_PATH", "~/.code-index/")),
+        config_module.default_storage_path(),
     )
     _note_adaptive_tiering_transport("streamable-http")
     _log_startup_validation_warnings()
@@ -5430,6 +6534,58 @@ async def run_streamable_http_server(host: str, port: int):
     await uvicorn.Server(config).serve()
 
 
+# Transport registry: every runner serves the same module-level ``server``
+# (one tool list, one call_tool dispatcher), so adding a transport never
+# touches the tool handlers.  HTTP runners take (host, port); stdio takes
+# nothing.  Tool calls run in worker threads via asyncio.to_thread on every
+# transport, so HTTP sessions share the index caches the same way
+# concurrent stdio calls do.
+_TRANSPORTS: dict[str, tuple[Callable[..., Awaitable[None]], bool]] = {
+    "stdio": (run_stdio_server, False),
+    "sse": (run_sse_server, True),
+    "streamable-http": (run_streamable_http_server, True),
+}
+_TRANSPORT_ALIASES = {"http": "streamable-http"}
+
+
+def _parse_listen(addr: str) -> tuple[str, int]:
+    """Split a listen address into (host, port).
+
+    Accepts ``HOST:PORT``, ``:PORT`` (loopback), a bare ``PORT``, and
+    bracketed IPv6 (``[::1]:8901``).  Raises ValueError on anything else.
+    """
+    addr = addr.strip()
+    if addr.isdigit():
+        host, port_s = "127.0.0.1", addr
+    elif addr.startswith("["):
+        host, sep, port_s = addr[1:].partition("]:")
+        if not sep:
+            raise ValueError(f"invalid listen address {addr!r}: expected [HOST]:PORT")
+    else:
+        host, sep, port_s = addr.rpartition(":")
+        if not sep:
+            raise ValueError(f"invalid listen address {addr!r}: expected HOST:PORT")
+        host = host or "127.0.0.1"
+    if not port_s.isdigit() or not 0 < int(port_s) < 65536:
+        raise ValueError(f"invalid listen address {addr!r}: port must be 1-65535")
+    return host, int(port_s)
+
+
+def _resolve_transport(args) -> tuple[Callable[..., Awaitable[None]], tuple]:
+    """Pick the runner and its arguments for ``serve`` from parsed args.
+
+    ``--listen`` wins over ``--host`` / ``--port`` when given.
+    """
+    transport = _TRANSPORT_ALIASES.get(args.transport, args.transport)
+    runner, needs_addr = _TRANSPORTS[transport]
+    if not needs_addr:
+        return runner, ()
+    listen = getattr(args, "listen", None)
+    if listen:
+        return runner, _parse_listen(listen)
+    return runner, (args.host, args.port)
+
+
 def _setup_logging(args) -> None:
     """Configure logging from parsed args."""
     log_level = getattr(logging, args.log_level)
@@ -5493,18 +6649,19 @@ def _generate_claude_md_snippet(missing_only: bool = False) -> str:
     # Group tools by category for readability
     categories = [
         ("Indexing", ["index_repo", "index_folder", "summarize_repo", "index_file"]),
-        ("Discovery", ["list_repos", "resolve_repo", "suggest_queries",
-                       "get_repo_outline", "get_file_tree", "get_file_outline"]),
-        ("Search & Retrieval", ["search_symbols", "get_symbol_source", "get_context_bundle",
-                                 "get_file_content", "search_text", "search_columns",
+        ("Discovery", ["list_repos", "server_info", "resolve_repo", "suggest_queries",
+                       "get_repo_outline", "project_stats", "get_file_tree", "get_file_outline",
+                       "describe_package"]),
+        ("Search & Retrieval", ["search_symbols", "get_symbol_source", "summarize_symbol", "get_context_bundle",
+                                 "get_file_content", "read_file_range", "search_text", "search_content", "list_todos", "search_columns",
                                  "get_ranked_context", "assemble_task_context"]),
-        ("Relationships", ["find_importers", "find_references", "check_references",
-                           "get_dependency_graph", "get_class_hierarchy",
-                           "get_related_symbols", "get_call_hierarchy",
-                           "find_implementations"]),
-        ("Impact & Safety", ["get_blast_radius", "check_rename_safe", "check_delete_safe",
+        ("Relationships", ["find_importers", "find_references", "locate_definition", "check_references",
+                           "get_dependency_graph", "get_dependencies", "get_class_hierarchy",
+                           "get_type_hierarchy", "get_related_symbols", "get_call_hierarchy",
+                           "get_call_graph", "find_implementations"]),
+        ("Impact & Safety", ["get_blast_radius", "check_rename_safe", "rename_preview", "check_delete_safe",
                               "get_impact_preview", "get_changed_symbols",
-                              "plan_refactoring", "get_symbol_provenance",
+                              "plan_refactoring", "get_symbol_provenance", "git_blame",
                               "get_pr_risk_profile"]),
         ("Architecture", ["get_dependency_cycles", "get_coupling_metrics",
                           "get_layer_violations", "get_extraction_candidates",
@@ -5512,12 +6669,12 @@ def _generate_claude_md_snippet(missing_only: bool = False) -> str:
                           "get_signal_chains", "render_diagram",
                           "get_project_intel", "list_workspaces",
                           "get_group_contracts"]),
-        ("Quality & Metrics", ["get_symbol_complexity", "get_churn_rate", "get_hotspots",
+        ("Quality & Metrics", ["get_symbol_complexity", "get_complexity", "get_churn_rate", "get_hotspots",
                                 "get_repo_health", "diff_health_radar",
                                 "get_file_risk", "get_symbol_importance",
                                 "get_repo_map", "find_similar_symbols",
                                 "find_dead_code", "get_dead_code_v2",
-                                "get_untested_symbols", "search_ast",
+                                "get_untested_symbols", "search_ast", "get_parse_errors", "format_check", "get_test_coverage_map",
                                 "winnow_symbols"]),
         ("Diffs & Embeddings", ["get_symbol_diff", "embed_repo"]),
         ("Session-Aware Routing", ["plan_turn", "get_session_context", "get_session_snapshot", "register_edit", "digest"]),
@@ -5611,8 +6768,7 @@ def _run_config(check: bool = False, init: bool = False, upgrade: bool = False)
 
     # Handle --upgrade
     if upgrade:
-        storage_path = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
-        config_path = Path(storage_path) / "config.jsonc"
+        config_path = config_module._global_config_path()
 
         if not config_path.exists():
             print(f"No config file found at: {config_path}")
@@ -5632,8 +6788,7 @@ def _run_config(check: bool = False, init: bool = False, upgrade: bool = False)
 
     # Handle --init
     if init:
-        storage_path = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
-        config_path = Path(storage_path) / "config.jsonc"
+        config_path = config_module._global_config_path()
 
         if config_path.exists():
             print(f"Config file already exists: {config_path}")
@@ -5695,8 +6850,8 @@ def _run_config(check: bool = False, init: bool = False, upgrade: bool = False)
 
     # ── Config File ───────────────────────────────────────────────────────
     section("Config File")
-    storage_path = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
-    config_path = Path(storage_path) / "config.jsonc"
+    storage_path = str(config_module.default_storage_path())
+    config_path = config_module._global_config_path()
     if config_path.exists():
         print(f"  {green(CHECK)} config.jsonc found: {config_path}")
     else:
@@ -6155,8 +7310,10 @@ def main(argv: Optional[list[str]] = None):
     serve_parser.add_argument(
         "--transport",
         default=os.environ.get("JCODEMUNCH_TRANSPORT", "stdio"),
-        choices=["stdio", "sse", "streamable-http"],
-        help="Transport mode: stdio (default), sse, or streamable-http (also via JCODEMUNCH_TRANSPORT env var)",
+        choices=["stdio", "http", "sse", "streamable-http"],
+        help="Transport mode: stdio (default), http (alias for streamable-http: POST requests, "
+             "SSE for server-to-client messages), sse, or streamable-http "
+             "(also via JCODEMUNCH_TRANSPORT env var)",
     )
     serve_parser.add_argument(
         "--host",
@@ -6169,6 +7326,13 @@ def main(argv: Optional[list[str]] = None):
         default=int(os.environ.get("JCODEMUNCH_PORT", "8901")),
         help="Port to listen on in HTTP transport mode (also via JCODEMUNCH_PORT env var, default: 8901)",
     )
+    serve_parser.add_argument(
+        "--listen",
+        default=os.environ.get("JCODEMUNCH_LISTEN"),
+        metavar="ADDR",
+        help="Listen address for HTTP transports as HOST:PORT, :PORT, or [IPv6]:PORT; "
+             "overrides --host/--port (also via JCODEMUNCH_LISTEN env var)",
+    )
     _add_common_args(serve_parser)
 
     # --- Watcher options for serve ---
@@ -6214,8 +7378,9 @@ def main(argv: Optional[list[str]] = None):
     )
     serve_parser.add_argument(
         "--watcher-follow-symlinks",
-        action="store_true",
-        help="Include symlinked files in watcher indexing",
+        action=argparse.BooleanOptionalAction,
+        default=None,
+        help="Follow symlinks in watcher indexing (default: watch_follow_symlinks, then follow_symlinks config)",
     )
     serve_parser.add_argument(
         "--watcher-log",
@@ -6257,8 +7422,9 @@ def main(argv: Optional[list[str]] = None):
     )
     watch_parser.add_argument(
         "--follow-symlinks",
-        action="store_true",
-        help="Include symlinked files in indexing",
+        action=argparse.BooleanOptionalAction,
+        default=None,
+        help="Follow symlinks (cycle-safe; default: follow_symlinks config, true)",
     )
     watch_parser.add_argument(
         "--extra-ignore",
@@ -6337,8 +7503,9 @@ def main(argv: Optional[list[str]] = None):
     )
     index_parser.add_argument(
         "--follow-symlinks",
-        action="store_true",
-        help="Include symlinked files in indexing",
+        action=argparse.BooleanOptionalAction,
+        default=None,
+        help="Follow symlinks (cycle-safe; default: follow_symlinks config, true)",
     )
     index_parser.add_argument(
         "--extra-ignore",
@@ -6776,8 +7943,9 @@ def main(argv: Optional[list[str]] = None):
     )
     wc_parser.add_argument(
         "--follow-symlinks",
-        action="store_true",
-        help="Include symlinked files in indexing",
+        action=argparse.BooleanOptionalAction,
+        default=None,
+        help="Follow symlinks (cycle-safe; default: follow_symlinks config, true)",
     )
     wc_parser.add_argument(
         "--extra-ignore",
@@ -6801,8 +7969,8 @@ def main(argv: Optional[list[str]] = None):
     )
     wa_parser.add_argument("--no-ai-summaries", action="store_true",
         help="Disable AI-generated summaries during re-indexing")
-    wa_parser.add_argument("--follow-symlinks", action="store_true",
-        help="Include symlinked files in indexing")
+    wa_parser.add_argument("--follow-symlinks", action=argparse.BooleanOptionalAction, default=None,
+        help="Follow symlinks (cycle-safe; default: follow_symlinks config, true)")
     wa_parser.add_argument("--extra-ignore", nargs="*",
         help="Additional gitignore-style patterns to exclude")
     _add_common_args(wa_parser)
@@ -7350,6 +8518,11 @@ def main(argv: Optional[list[str]] = None):
         if args.freshness_mode is None:
             args.freshness_mode = config_module.get("freshness_mode", "relaxed")
         set_freshness_mode(args.freshness_mode)
+        try:
+            runner, runner_args = _resolve_transport(args)
+        except ValueError as e:
+            print(f"jcodemunch-mcp: error: {e}", file=sys.stderr)
+            sys.exit(2)
         watcher_enabled = _get_watcher_enabled(args)
         watcher_from_cli = getattr(args, "watcher", None) is not None
 
@@ -7400,7 +8573,8 @@ def main(argv: Optional[list[str]] = None):
                 ),
                 follow_symlinks=(
                     args.watcher_follow_symlinks
-                    or config_module.get("watch_follow_symlinks", False)
+                    if args.watcher_follow_symlinks is not None
+                    else config_module.get("watch_follow_symlinks", False) or None
                 ),
                 idle_timeout_minutes=(
                     args.watcher_idle_timeout
@@ -7415,27 +8589,13 @@ def main(argv: Optional[list[str]] = None):
             )
 
             try:
-                if args.transport == "sse":
-                    asyncio.run(_run_server_with_watcher(
-                        run_sse_server, (args.host, args.port), watcher_kwargs, log_path,
-                    ))
-                elif args.transport == "streamable-http":
-                    asyncio.run(_run_server_with_watcher(
-                        run_streamable_http_server, (args.host, args.port), watcher_kwargs, log_path,
-                    ))
-                else:
-                    asyncio.run(_run_server_with_watcher(
-                        run_stdio_server, (), watcher_kwargs, log_path,
-                    ))
+                asyncio.run(_run_server_with_watcher(
+                    runner, runner_args, watcher_kwargs, log_path,
+                ))
             except KeyboardInterrupt:
                 pass
         else:
-            if args.transport == "sse":
-                asyncio.run(run_sse_server(args.host, args.port))
-            elif args.transport == "streamable-http":
-                asyncio.run(run_streamable_http_server(args.host, args.port))
-            else:
-                asyncio.run(run_stdio_server())
+            asyncio.run(runner(*runner_args))
 
 
 if __name__ == "__main__":
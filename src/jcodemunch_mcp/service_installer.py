@@ -20,6 +20,8 @@ import sys
 from pathlib import Path
 from typing import Optional
 
+from .config import default_storage_path
+
 logger = logging.getLogger(__name__)
 
 SERVICE_NAME = "jcodemunch-watch"
@@ -42,7 +44,7 @@ def _launchd_plist_path() -> Path:
 
 
 def _log_dir() -> Path:
-    base = Path(os.environ.get("CODE_INDEX_PATH") or (Path.home() / ".code-index"))
+    base = default_storage_path()
     return base / "logs"
 
 
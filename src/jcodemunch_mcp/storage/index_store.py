@@ -13,6 +13,7 @@ from datetime import datetime
 from pathlib import Path
 from typing import Callable, Optional
 
+from .. import body_policy
 from .. import config as _config
 from ..parser.symbols import Symbol
 from ..path_map import parse_path_map, remap
@@ -21,6 +22,13 @@ from .sqlite_store import SQLiteIndexStore, _VERIFIED_PATHS
 logger = logging.getLogger(__name__)
 
 # Bump this when the index schema changes in an incompatible way.
+# v17: adds the parse-time symbol columns `fields` (struct fields),
+# `build_tags` / `is_test` (Go build constraints, *_test.go),
+# `receiver_type` / `pointer_receiver` (Go method receivers),
+# `is_declaration` (C/C++ prototypes), `fqn`, `params` / `returns`, and
+# `type_params` (Go generics). Tables 16-vintage upgrade in place via
+# _migrate_v16_to_v17, which also invalidates every stored file hash so the
+# next index run re-extracts all files and fills the new columns.
 # v16 (1.98.0): adds `runtime_stack_events(symbol_id, source, severity,
 # count, first_seen, last_seen)` table for Phase 5 stack-frame ingest.
 # Severity ∈ {error, warn, info} per stack frame; lets agents distinguish
@@ -44,7 +52,7 @@ logger = logging.getLogger(__name__)
 # relative to the indexed subdir (not the git root); they are
 # detected as old-format on first v1.96 indexing run and discarded
 # in favour of a fresh git-root-rooted walk.
-INDEX_VERSION = 16
+INDEX_VERSION = 17
 
 
 @dataclass(frozen=True)
@@ -193,6 +201,10 @@ class CodeIndex:
         self._import_name_index: Optional[dict[str, list[tuple[str, dict]]]] = None
         # Lazy reverse lookup: built on first access via get_callers_by_name()
         self._callers_by_name: Optional[dict[tuple[str, str], list[str]]] = None
+        # Lazy file -> symbols map: built on first get_file_symbols() call
+        self._symbols_by_file: Optional[dict[str, list[dict]]] = None
+        # file -> ids whose bodies the withhold policy hides (see body_policy)
+        self._withheld_ids: dict[str, frozenset[str]] = {}
         # Load tsconfig/jsconfig path aliases from source_root if not already provided
         if not self.alias_map and self.source_root:
             try:
@@ -225,6 +237,15 @@ class CodeIndex:
             self._callers_by_name = idx
         return self._callers_by_name
 
+    def get_file_symbols(self, file_path: str) -> list[dict]:
+        """Symbols defined in *file_path*, in index order (lazy, O(1) after first call)."""
+        if self._symbols_by_file is None:
+            by_file: dict[str, list[dict]] = {}
+            for s in self.symbols:
+                by_file.setdefault(s.get("file", ""), []).append(s)
+            self._symbols_by_file = by_file
+        return self._symbols_by_file.get(file_path, [])
+
     # Keys added by BM25 caching — must not leak into API responses
     _INTERNAL_KEYS = {"_tokens", "_tf", "_dl"}
 
@@ -351,12 +372,13 @@ class IndexStore:
         """Initialize store.
 
         Args:
-            base_path: Base directory for storage. Defaults to ~/.code-index/
+            base_path: Base directory for storage. Defaults to
+                ``config.default_storage_path()``.
         """
         if base_path:
             self.base_path = Path(base_path)
         else:
-            self.base_path = Path.home() / ".code-index"
+            self.base_path = _config.default_storage_path()
 
         _key = str(self.base_path)
         if _key not in _VERIFIED_PATHS:
@@ -698,14 +720,27 @@ class IndexStore:
 
         return None
 
-    def get_symbol_content(self, owner: str, name: str, symbol_id: str, _index: Optional["CodeIndex"] = None) -> Optional[str]:
+    def get_symbol_content(
+        self,
+        owner: str,
+        name: str,
+        symbol_id: str,
+        _index: Optional["CodeIndex"] = None,
+        raw: bool = False,
+    ) -> Optional[str]:
         """Read symbol source using stored byte offsets.
 
         Delegates to the SQLite backend for a single-row lookup.
         Pass _index to avoid a redundant load_index() call when the caller
-        already holds a loaded index.
+        already holds a loaded index.  Under the withhold policy an
+        unexported function's body is replaced by a marker line unless
+        *raw* is set (see ``body_policy``).
         """
-        return self._sqlite.get_symbol_content(owner, name, symbol_id, _index)
+        source = self._sqlite.get_symbol_content(owner, name, symbol_id, _index)
+        if source is not None and not raw and self.body_withheld(owner, name, symbol_id, _index):
+            index = _index or self.load_index(owner, name)
+            source = body_policy.withhold_source(source, index.get_symbol(symbol_id))
+        return source
 
     def get_file_content(
         self,
@@ -713,9 +748,37 @@ class IndexStore:
         name: str,
         file_path: str,
         _index: Optional["CodeIndex"] = None,
+        raw: bool = False,
     ) -> Optional[str]:
-        """Read a cached file's full content."""
-        return self._sqlite.get_file_content(owner, name, file_path, _index)
+        """Read a cached file's full content.
+
+        Under the withhold policy unexported function bodies are blanked
+        (line numbers unchanged) unless *raw* is set.  Pass ``raw=True``
+        only for analysis that returns no source text.
+        """
+        content = self._sqlite.get_file_content(owner, name, file_path, _index)
+        if content and not raw and body_policy.is_enabled():
+            index = _index or self.load_index(owner, name)
+            if index is not None:
+                content = body_policy.withhold_file(content, index, file_path)
+        return content
+
+    def body_withheld(
+        self,
+        owner: str,
+        name: str,
+        symbol_id: str,
+        _index: Optional["CodeIndex"] = None,
+    ) -> bool:
+        """True when the withhold policy hides this symbol's body."""
+        if not body_policy.is_enabled():
+            return False
+        index = _index or self.load_index(owner, name)
+        symbol = index.get_symbol(symbol_id) if index is not None else None
+        if symbol is None:
+            return False
+        content = self._sqlite.get_file_content(owner, name, symbol["file"], index) or ""
+        return symbol_id in body_policy.withheld_ids(index, symbol["file"], content)
 
     def detect_changes(
         self,
@@ -1049,6 +1112,16 @@ class IndexStore:
             "max_nesting": getattr(symbol, "max_nesting", 0) or 0,
             "param_count": getattr(symbol, "param_count", 0) or 0,
             "call_references": getattr(symbol, "call_references", []) or [],
+            "fields": getattr(symbol, "fields", []) or [],
+            "build_tags": getattr(symbol, "build_tags", "") or "",
+            "is_test": bool(getattr(symbol, "is_test", False)),
+            "receiver_type": getattr(symbol, "receiver_type", "") or "",
+            "pointer_receiver": bool(getattr(symbol, "pointer_receiver", False)),
+            "is_declaration": bool(getattr(symbol, "is_declaration", False)),
+            "fqn": getattr(symbol, "fqn", "") or "",
+            "params": getattr(symbol, "params", []) or [],
+            "returns": getattr(symbol, "returns", []) or [],
+            "type_params": getattr(symbol, "type_params", []) or [],
         }
 
     def _index_to_dict(self, index: CodeIndex) -> dict:
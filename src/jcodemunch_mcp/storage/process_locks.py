@@ -42,6 +42,8 @@ from datetime import datetime, timezone
 from pathlib import Path
 from typing import Optional
 
+from ..config import default_storage_path
+
 logger = logging.getLogger(__name__)
 
 # fcntl is Unix-only. On Windows we rely on the atomic O_EXCL guarantee.
@@ -96,7 +98,7 @@ def _path_hash(target: str) -> str:
 
 def _lock_dir(storage_path: Optional[str]) -> Path:
     """Return the directory for lock files, creating it if needed."""
-    base = Path(storage_path) if storage_path else Path.home() / ".code-index"
+    base = Path(storage_path) if storage_path else default_storage_path()
     base.mkdir(parents=True, exist_ok=True)
     return base
 
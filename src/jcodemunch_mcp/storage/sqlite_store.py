@@ -17,6 +17,7 @@ from datetime import datetime
 from pathlib import Path
 from typing import TYPE_CHECKING, Callable, NamedTuple, Optional, cast
 
+from ..config import default_storage_path
 from ..parser.symbols import Symbol
 from ..path_map import parse_path_map, remap
 
@@ -58,7 +59,17 @@ CREATE TABLE IF NOT EXISTS symbols (
     data              TEXT,
     cyclomatic        INTEGER,
     max_nesting       INTEGER,
-    param_count       INTEGER
+    param_count       INTEGER,
+    fields            TEXT,
+    build_tags        TEXT,
+    is_test           INTEGER,
+    receiver_type     TEXT,
+    pointer_receiver  INTEGER,
+    is_declaration    INTEGER,
+    fqn               TEXT,
+    params            TEXT,
+    returns           TEXT,
+    type_params       TEXT
 );
 
 CREATE INDEX IF NOT EXISTS idx_symbols_file ON symbols(file);
@@ -547,6 +558,46 @@ def _migrate_v15_to_v16(conn: sqlite3.Connection) -> None:
     logger.info("Migrated v15→v16: added runtime_stack_events table for stack-log ingest")
 
 
+# Columns v17 added to ``symbols``, all filled by the extractor at parse time.
+_V17_SYMBOL_COLUMNS = (
+    ("fields", "TEXT"),
+    ("build_tags", "TEXT"),
+    ("is_test", "INTEGER"),
+    ("receiver_type", "TEXT"),
+    ("pointer_receiver", "INTEGER"),
+    ("is_declaration", "INTEGER"),
+    ("fqn", "TEXT"),
+    ("params", "TEXT"),
+    ("returns", "TEXT"),
+    ("type_params", "TEXT"),
+)
+
+
+def _migrate_v16_to_v17(conn: sqlite3.Connection) -> None:
+    """Migrate a v16 database to v17: add the parse-time symbol columns.
+
+    The new columns (struct fields, build constraints, Go receivers, C/C++
+    declarations, fqn, params/returns, type parameters) can only come from
+    re-parsing, and incremental indexing skips unchanged files.  So every
+    file's hash is replaced with a value no content hashes to and its mtime
+    and blob SHA are cleared: the next index run sees each file as changed
+    and re-extracts it.  Branch deltas hold symbols of the old shape too,
+    and are dropped so the next branch switch rebuilds them.
+    """
+    existing = {row[1] for row in conn.execute("PRAGMA table_info(symbols)").fetchall()}
+    for column, sql_type in _V17_SYMBOL_COLUMNS:
+        if column not in existing:
+            conn.execute(f"ALTER TABLE symbols ADD COLUMN {column} {sql_type}")
+    conn.execute("UPDATE files SET hash = 'reparse-v17', mtime_ns = NULL, blob_sha = NULL")
+    conn.execute("DELETE FROM branch_deltas")
+    conn.execute("DELETE FROM branch_meta")
+    conn.execute(
+        "INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?)",
+        ("index_version", "17"),
+    )
+    logger.info("Migrated v16→v17: added parse-time symbol columns; every file re-extracts on next index")
+
+
 def _unlink_retry(path: Path, retries: int = 3, delay: float = 0.1) -> bool:
     """Delete a file with retry logic for Windows file-locking (PermissionError).
 
@@ -581,12 +632,13 @@ class SQLiteIndexStore:
         """Initialize store.
 
         Args:
-            base_path: Base directory for storage. Defaults to ~/.code-index/
+            base_path: Base directory for storage. Defaults to
+                ``config.default_storage_path()``.
         """
         if base_path:
             self.base_path = Path(base_path)
         else:
-            self.base_path = Path.home() / ".code-index"
+            self.base_path = default_storage_path()
         _key = str(self.base_path)
         if _key not in _VERIFIED_PATHS:
             self.base_path.mkdir(parents=True, exist_ok=True)
@@ -606,6 +658,16 @@ class SQLiteIndexStore:
     def _connect(self, db_path: Path) -> sqlite3.Connection:
         """Open a connection with WAL pragmas and schema ensured on first visit."""
         conn = sqlite3.connect(str(db_path), isolation_level=None)  # autocommit
+        try:
+            self._prepare_connection(conn, db_path)
+        except BaseException:
+            # The caller never gets this connection, so close it here; an
+            # open handle would keep a corrupt file from being moved aside.
+            conn.close()
+            raise
+        return conn
+
+    def _prepare_connection(self, conn: sqlite3.Connection, db_path: Path) -> None:
         conn.row_factory = sqlite3.Row
         for pragma in _PRAGMAS:
             conn.execute(pragma)
@@ -642,11 +704,11 @@ class SQLiteIndexStore:
                     _migrate_v14_to_v15(conn)
                 if stored_version < 16:
                     _migrate_v15_to_v16(conn)
+                if stored_version < 17:
+                    _migrate_v16_to_v17(conn)
 
             SQLiteIndexStore._initialized_dbs.add(db_key)
 
-        return conn
-
     def checkpoint_and_close(self, owner: str, name: str) -> None:
         """Compact WAL file on graceful shutdown. Call from server shutdown hook."""
         self.checkpoint_db(self._db_path(owner, name))
@@ -1050,6 +1112,16 @@ class SQLiteIndexStore:
             "max_nesting": getattr(symbol, "max_nesting", 0) or 0,
             "param_count": getattr(symbol, "param_count", 0) or 0,
             "call_references": getattr(symbol, "call_references", []) or [],
+            "fields": getattr(symbol, "fields", []) or [],
+            "build_tags": getattr(symbol, "build_tags", "") or "",
+            "is_test": bool(getattr(symbol, "is_test", False)),
+            "receiver_type": getattr(symbol, "receiver_type", "") or "",
+            "pointer_receiver": bool(getattr(symbol, "pointer_receiver", False)),
+            "is_declaration": bool(getattr(symbol, "is_declaration", False)),
+            "fqn": getattr(symbol, "fqn", "") or "",
+            "params": getattr(symbol, "params", []) or [],
+            "returns": getattr(symbol, "returns", []) or [],
+            "type_params": getattr(symbol, "type_params", []) or [],
         }
 
     # ── Public API (mirrors IndexStore) ─────────────────────────────
@@ -1104,7 +1176,17 @@ class SQLiteIndexStore:
              "cyclomatic": getattr(s, "cyclomatic", 0) or 0,
              "max_nesting": getattr(s, "max_nesting", 0) or 0,
              "param_count": getattr(s, "param_count", 0) or 0,
-             "call_references": getattr(s, "call_references", []) or []}
+             "call_references": getattr(s, "call_references", []) or [],
+             "fields": getattr(s, "fields", []) or [],
+             "build_tags": getattr(s, "build_tags", "") or "",
+             "is_test": bool(getattr(s, "is_test", False)),
+             "receiver_type": getattr(s, "receiver_type", "") or "",
+             "pointer_receiver": bool(getattr(s, "pointer_receiver", False)),
+             "is_declaration": bool(getattr(s, "is_declaration", False)),
+             "fqn": getattr(s, "fqn", "") or "",
+             "params": getattr(s, "params", []) or [],
+             "returns": getattr(s, "returns", []) or [],
+             "type_params": getattr(s, "type_params", []) or []}
             for s in symbols
         ]
 
@@ -1194,8 +1276,9 @@ class SQLiteIndexStore:
                 "INSERT INTO symbols (id, file, name, kind, signature, summary, "
                 "docstring, line, end_line, byte_offset, byte_length, parent, "
                 "qualified_name, language, decorators, keywords, content_hash, "
-                "ecosystem_context, data, cyclomatic, max_nesting, param_count) "
-                "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+                "ecosystem_context, data, cyclomatic, max_nesting, param_count, fields, "
+                "build_tags, is_test, receiver_type, pointer_receiver, is_declaration, fqn, params, returns, type_params) "
+                "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
                 [self._symbol_to_row(s) for s in symbols],
             )
 
@@ -1272,7 +1355,16 @@ class SQLiteIndexStore:
             if cached is not None:
                 return cached
 
-        conn = self._connect(db_path)
+        # OperationalError (locked, busy, I/O) is transient and propagates;
+        # any other DatabaseError means the file itself is unreadable.
+        try:
+            conn = self._connect(db_path)
+        except sqlite3.OperationalError:
+            raise
+        except sqlite3.DatabaseError:
+            self._discard_corrupt_db(db_path, owner, safe_name)
+            return None
+        index = None
         try:
             meta = self._read_meta(conn)
             if not meta:
@@ -1300,8 +1392,16 @@ class SQLiteIndexStore:
                     "Run 'jcodemunch-mcp index-folder' to re-index for AST-based call graphs.",
                     owner, name,
                 )
+        except sqlite3.OperationalError:
+            raise
+        except sqlite3.DatabaseError:
+            pass
         finally:
             conn.close()
+        if index is None:
+            # Only reachable through the corruption branch above.
+            self._discard_corrupt_db(db_path, owner, safe_name)
+            return None
 
         # If a branch is requested, compose the delta on top of the base
         if branch:
@@ -1433,6 +1533,32 @@ class SQLiteIndexStore:
                 hint="Re-index this repository to rebuild the corrupt SQLite index.",
             )
 
+    def _discard_corrupt_db(self, db_path: Path, owner: str, safe_name: str) -> None:
+        """Move an unreadable .db aside so the next index run rebuilds from scratch.
+
+        The file is renamed to ``<slug>.db.corrupt`` (replacing any earlier
+        one) rather than deleted, so it can still be inspected; its WAL and
+        SHM sidecars are dropped because they belong to the broken file.
+        """
+        logger.warning(
+            "Index %s/%s is corrupt (%s); discarding it so the next index run rebuilds it",
+            owner, safe_name, db_path,
+        )
+        _cache_evict(owner, safe_name)
+        SQLiteIndexStore._initialized_dbs.discard(str(db_path))
+        try:
+            os.replace(db_path, str(db_path) + ".corrupt")
+        except OSError:
+            logger.debug("Could not move corrupt index aside: %s", db_path, exc_info=True)
+            return
+        for suffix in ("-wal", "-shm"):
+            sidecar = Path(str(db_path) + suffix)
+            if sidecar.exists():
+                try:
+                    _unlink_retry(sidecar)
+                except OSError:
+                    logger.debug("Could not remove %s", sidecar, exc_info=True)
+
     def has_index(self, owner: str, name: str) -> bool:
         """Return True if a .db file exists for this repo."""
         safe_name = self._safe_repo_component(name, "name")
@@ -1504,8 +1630,9 @@ class SQLiteIndexStore:
                     "INSERT OR REPLACE INTO symbols (id, file, name, kind, signature, summary, "
                     "docstring, line, end_line, byte_offset, byte_length, parent, "
                     "qualified_name, language, decorators, keywords, content_hash, "
-                    "ecosystem_context, data, cyclomatic, max_nesting, param_count) "
-                    "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+                    "ecosystem_context, data, cyclomatic, max_nesting, param_count, fields, "
+                    "build_tags, is_test, receiver_type, pointer_receiver, is_declaration, fqn, params, returns, type_params) "
+                    "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
                     [self._symbol_to_row(s) for s in new_symbols],
                 )
 
@@ -2042,8 +2169,12 @@ class SQLiteIndexStore:
     # ── Internal helpers ────────────────────────────────────────────
 
     def _symbol_to_row(self, symbol: Symbol) -> tuple:
-        """Convert a Symbol to a row tuple for INSERT (v8 schema)."""
+        """Convert a Symbol to a row tuple for INSERT (v8 schema + v17 fields + v18 build tags + v19 receivers + v20 declarations + v21 fqn + v22 params and returns + v23 type params)."""
         call_refs = getattr(symbol, "call_references", []) or []
+        fields = getattr(symbol, "fields", []) or []
+        params = getattr(symbol, "params", []) or []
+        returns = getattr(symbol, "returns", []) or []
+        type_params = getattr(symbol, "type_params", []) or []
         return (
             symbol.id, symbol.file, symbol.name, symbol.kind,
             symbol.signature, symbol.summary, symbol.docstring,
@@ -2060,13 +2191,27 @@ class SQLiteIndexStore:
             getattr(symbol, "cyclomatic", 0) or None,
             getattr(symbol, "max_nesting", 0) or None,
             getattr(symbol, "param_count", 0) or None,
+            json.dumps(fields) if fields else None,
+            getattr(symbol, "build_tags", "") or None,
+            1 if getattr(symbol, "is_test", False) else None,
+            getattr(symbol, "receiver_type", "") or None,
+            1 if getattr(symbol, "pointer_receiver", False) else None,
+            1 if getattr(symbol, "is_declaration", False) else None,
+            getattr(symbol, "fqn", "") or None,
+            json.dumps(params) if params else None,
+            json.dumps(returns) if returns else None,
+            json.dumps(type_params) if type_params else None,
         )
 
     def _symbol_dict_to_row(self, d: dict) -> tuple:
-        """Convert a serialized symbol dict to a row tuple for INSERT (v8 schema)."""
+        """Convert a serialized symbol dict to a row tuple for INSERT (v8 schema + v17 fields + v18 build tags + v19 receivers + v20 declarations + v21 fqn + v22 params and returns + v23 type params)."""
         decorators = d.get("decorators", [])
         keywords = d.get("keywords", [])
         call_refs = d.get("call_references", [])
+        fields = d.get("fields", [])
+        params = d.get("params", [])
+        returns = d.get("returns", [])
+        type_params = d.get("type_params", [])
         return (
             d["id"], d["file"], d["name"], d.get("kind", ""),
             d.get("signature", ""), d.get("summary", ""), d.get("docstring", ""),
@@ -2083,6 +2228,16 @@ class SQLiteIndexStore:
             d.get("cyclomatic") or None,
             d.get("max_nesting") or None,
             d.get("param_count") or None,
+            json.dumps(fields) if fields else None,
+            d.get("build_tags") or None,
+            1 if d.get("is_test") else None,
+            d.get("receiver_type") or None,
+            1 if d.get("pointer_receiver") else None,
+            1 if d.get("is_declaration") else None,
+            d.get("fqn") or None,
+            json.dumps(params) if params else None,
+            json.dumps(returns) if returns else None,
+            json.dumps(type_params) if type_params else None,
         )
 
     def _row_to_symbol_dict(self, row: sqlite3.Row) -> dict:
@@ -2141,6 +2296,16 @@ class SQLiteIndexStore:
                 keywords = []
             content_hash = row["content_hash"] or ""
             ecosystem_context = row["ecosystem_context"] or ""
+        keys = row.keys()
+        json_lists: dict[str, list[dict]] = {}
+        for column in ("fields", "params", "returns", "type_params"):
+            json_lists[column] = []
+            raw = row[column] if column in keys else None
+            if raw:
+                try:
+                    json_lists[column] = json.loads(raw)
+                except (json.JSONDecodeError, ValueError):
+                    logger.warning("Corrupted %s JSON for symbol %s", column, row["name"])
         return {
             "id": row["id"],
             "file": row["file"],
@@ -2164,6 +2329,16 @@ class SQLiteIndexStore:
             "max_nesting": row["max_nesting"] or 0,
             "param_count": row["param_count"] or 0,
             "call_references": call_references,
+            "fields": json_lists["fields"],
+            "build_tags": (row["build_tags"] if "build_tags" in keys else None) or "",
+            "is_test": bool(row["is_test"] if "is_test" in keys else 0),
+            "receiver_type": (row["receiver_type"] if "receiver_type" in keys else None) or "",
+            "pointer_receiver": bool(row["pointer_receiver"] if "pointer_receiver" in keys else 0),
+            "is_declaration": bool(row["is_declaration"] if "is_declaration" in keys else 0),
+            "fqn": (row["fqn"] if "fqn" in keys else None) or "",
+            "params": json_lists["params"],
+            "returns": json_lists["returns"],
+            "type_params": json_lists["type_params"],
         }
 
     def _symbol_to_dict(self, symbol: "Symbol") -> dict:
@@ -2195,6 +2370,16 @@ class SQLiteIndexStore:
             "max_nesting": getattr(symbol, "max_nesting", 0) or 0,
             "param_count": getattr(symbol, "param_count", 0) or 0,
             "call_references": getattr(symbol, "call_references", []) or [],
+            "fields": getattr(symbol, "fields", []) or [],
+            "build_tags": getattr(symbol, "build_tags", "") or "",
+            "is_test": bool(getattr(symbol, "is_test", False)),
+            "receiver_type": getattr(symbol, "receiver_type", "") or "",
+            "pointer_receiver": bool(getattr(symbol, "pointer_receiver", False)),
+            "is_declaration": bool(getattr(symbol, "is_declaration", False)),
+            "fqn": getattr(symbol, "fqn", "") or "",
+            "params": getattr(symbol, "params", []) or [],
+            "returns": getattr(symbol, "returns", []) or [],
+            "type_params": getattr(symbol, "type_params", []) or [],
         }
 
     def _patch_index_from_delta(
@@ -2603,8 +2788,9 @@ class SQLiteIndexStore:
                     "INSERT OR REPLACE INTO symbols (id, file, name, kind, signature, summary, "
                     "docstring, line, end_line, byte_offset, byte_length, parent, "
                     "qualified_name, language, decorators, keywords, content_hash, "
-                    "ecosystem_context, data, cyclomatic, max_nesting, param_count) "
-                    "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+                    "ecosystem_context, data, cyclomatic, max_nesting, param_count, fields, "
+                    "build_tags, is_test, receiver_type, pointer_receiver, is_declaration, fqn, params, returns, type_params) "
+                    "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
                     [self._symbol_dict_to_row(s) for s in symbols],
                 )
 
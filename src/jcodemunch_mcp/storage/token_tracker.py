@@ -278,7 +278,7 @@ class _State:
         if self._perf_db_path_cached is not None:
             return self._perf_db_path_cached
         try:
-            root = Path(self._base_path) if self._base_path else Path.home() / ".code-index"
+            root = Path(self._base_path) if self._base_path else _config.default_storage_path()
             root.mkdir(parents=True, exist_ok=True)
             path = root / _PERF_DB_FILE
             self._perf_db_path_cached = path
@@ -528,13 +528,13 @@ for _sig in (signal.SIGTERM, signal.SIGINT):
 # ---------------------------------------------------------------------------
 
 def _savings_path(base_path: Optional[str] = None) -> Path:
-    root = Path(base_path) if base_path else Path.home() / ".code-index"
+    root = Path(base_path) if base_path else _config.default_storage_path()
     root.mkdir(parents=True, exist_ok=True)
     return root / _SAVINGS_FILE
 
 
 def _session_stats_path(base_path: Optional[str] = None) -> Path:
-    root = Path(base_path) if base_path else Path.home() / ".code-index"
+    root = Path(base_path) if base_path else _config.default_storage_path()
     root.mkdir(parents=True, exist_ok=True)
     return root / _SESSION_STATS_FILE
 
@@ -622,7 +622,7 @@ def write_pulse(tool_name: str, tokens_saved: int = 0, base_path: Optional[str]
     if not os.environ.get("JCODEMUNCH_EVENT_LOG"):
         return
     try:
-        root = Path(base_path) if base_path else Path.home() / ".code-index"
+        root = Path(base_path) if base_path else _config.default_storage_path()
         pulse_path = root / _PULSE_FILE
         with _state._lock:
             calls = _state._session_calls
@@ -676,7 +676,7 @@ def _runtime_signal_summary(base_path: Optional[str] = None) -> dict:
     import sqlite3 as _sqlite3
     summary = {"rows": 0, "by_source": {}}
     try:
-        root = Path(base_path) if base_path else Path.home() / ".code-index"
+        root = Path(base_path) if base_path else _config.default_storage_path()
         if not root.exists():
             return summary
         # Skip non-repo files (telemetry.db, config.jsonc, etc.)
@@ -754,7 +754,7 @@ def latency_stats() -> dict:
 
 def perf_db_path(base_path: Optional[str] = None) -> Path:
     """Return the perf telemetry SQLite path (creating its parent dir)."""
-    root = Path(base_path) if base_path else Path.home() / ".code-index"
+    root = Path(base_path) if base_path else _config.default_storage_path()
     root.mkdir(parents=True, exist_ok=True)
     return root / _PERF_DB_FILE
 
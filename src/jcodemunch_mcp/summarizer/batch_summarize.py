@@ -9,6 +9,7 @@ from typing import Optional
 from urllib.parse import urlparse
 
 from .. import config as _config
+from ..deadline import Deadline, current_deadline, expired
 from ..parser.symbols import Symbol
 
 logger = logging.getLogger(__name__)
@@ -123,7 +124,8 @@ class BaseSummarizer:
         JCODEMUNCH_SUMMARIZER_CONCURRENCY, default 4).
         Trips a circuit breaker after summarizer_max_failures (default 3)
         consecutive failures, falling back to signature for all remaining.
-        Returns updated symbols.
+        Batches not started when the call's deadline passes fall back the
+        same way.  Returns updated symbols.
         """
         if not self.client:
             for sym in symbols:
@@ -140,6 +142,8 @@ class BaseSummarizer:
         logger.info("AI summarization starting: %d symbols to process", total)
 
         max_workers = _config.get("summarizer_concurrency", 4)
+        # Read here: the pool's worker threads do not inherit the context.
+        deadline = current_deadline()
         batches = [
             to_summarize[i : i + batch_size]
             for i in range(0, len(to_summarize), batch_size)
@@ -148,7 +152,7 @@ class BaseSummarizer:
 
         if max_workers <= 1 or len(batches) <= 1:
             for i, batch in enumerate(batches):
-                self._run_batch(batch)
+                self._run_batch(batch, deadline)
                 if (i + 1) % log_every == 0 or (i + 1) == len(batches):
                     processed = min((i + 1) * batch_size, total)
                     logger.info(
@@ -159,7 +163,7 @@ class BaseSummarizer:
             completed_count = 0
             with ThreadPoolExecutor(max_workers=max_workers) as executor:
                 futures = {
-                    executor.submit(self._run_batch, batch): batch
+                    executor.submit(self._run_batch, batch, deadline): batch
                     for batch in batches
                 }
                 for future in as_completed(futures):
@@ -175,9 +179,9 @@ class BaseSummarizer:
         logger.info("AI summarization complete: %d symbols processed", total)
         return symbols
 
-    def _run_batch(self, batch: list[Symbol]) -> None:
-        """Run a single batch with circuit breaker check."""
-        if self._circuit_broken:
+    def _run_batch(self, batch: list[Symbol], deadline: Optional[Deadline] = None) -> None:
+        """Run a single batch with circuit breaker and deadline checks."""
+        if self._circuit_broken or expired(deadline):
             for sym in batch:
                 if not sym.summary:
                     sym.summary = signature_fallback(sym)
@@ -461,6 +465,16 @@ class OpenAIBatchSummarizer(BaseSummarizer):
         logger.info("AI summarization starting: %d symbols to process (provider=openai model=%s)", total, self.model)
 
         max_workers = int(os.environ.get("OPENAI_CONCURRENCY", str(_config.get("summarizer_concurrency", 4))))
+        deadline = current_deadline()
+
+        def _run(batch: list[Symbol]) -> None:
+            if expired(deadline):
+                for sym in batch:
+                    if not sym.summary:
+                        sym.summary = signature_fallback(sym)
+                return
+            self._summarize_one_batch(batch)
+
         batches = [
             to_summarize[i : i + batch_size]
             for i in range(0, len(to_summarize), batch_size)
@@ -470,7 +484,7 @@ class OpenAIBatchSummarizer(BaseSummarizer):
         completed_count = 0
         with ThreadPoolExecutor(max_workers=max_workers) as executor:
             futures = {
-                executor.submit(self._summarize_one_batch, batch): batch
+                executor.submit(_run, batch): batch
                 for batch in batches
             }
             for future in as_completed(futures):
@@ -437,7 +437,7 @@ def find_direct_callers(
     seen_ids: set[str] = set(ast_caller_ids) | lsp_ids
 
     for imp_file in reverse_adj.get(sym_file, []):
-        file_content = store.get_file_content(owner, repo_name, imp_file)
+        file_content = store.get_file_content(owner, repo_name, imp_file, raw=True)
         if not file_content:
             continue
         # Fast gate: skip file if sym_name not present anywhere
@@ -503,7 +503,7 @@ def find_direct_callees(
     if not sym_file:
         return list(dispatch_cls) + list(lsp_callees)
 
-    file_content = store.get_file_content(owner, repo_name, sym_file)
+    file_content = store.get_file_content(owner, repo_name, sym_file, raw=True)
     if not file_content:
         return list(dispatch_cls) + list(lsp_callees)
 
@@ -0,0 +1,88 @@
+"""Shared ``gopls`` CLI access for the type-checked Go paths.
+
+``locate_definition`` (``gopls definition``) and ``rename_preview``
+(``gopls references -declaration``) both hand gopls the identifier under a
+tree-sitter node and map the spans it prints back into the index.  gopls
+reads files from disk, so a query is only made when the file still matches
+what was indexed; positions on both sides are 1-based lines and 1-based
+*byte* columns, which is what gopls speaks.
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+import re
+import shutil
+import subprocess
+from typing import Optional
+
+logger = logging.getLogger(__name__)
+
+GOPLS_TIMEOUT = 60
+
+# ``path:line:col``, an optional ``-[line:]col`` span end, then ``:`` and
+# free text (definition) or end of line (references).
+_SPAN_RE = re.compile(r"^(.*?):(\d+):(\d+)(?:-(?:\d+:)?\d+)?(?::|$)")
+
+
+def available(source_root: str) -> bool:
+    """True when gopls can be asked about files under *source_root*."""
+    return bool(source_root) and shutil.which("gopls") is not None
+
+
+def position(source_root: str, file_path: str, source: bytes, node) -> tuple[Optional[str], str]:
+    """gopls position argument ``abs_path:line:byte_col`` for *node*, or ``(None, reason)``."""
+    abs_path = os.path.join(source_root, file_path)
+    try:
+        with open(abs_path, "rb") as fh:
+            on_disk = fh.read()
+    except OSError:
+        return None, "source file not readable"
+    if on_disk != source:
+        return None, "file changed since indexing"
+    row_start = source.rfind(b"\n", 0, node.start_byte) + 1
+    return f"{abs_path}:{node.start_point[0] + 1}:{node.start_byte - row_start + 1}", ""
+
+
+def query(source_root: str, *args: str) -> tuple[Optional[list[tuple[str, int, int]]], str]:
+    """Run ``gopls <args>`` in *source_root*.
+
+    Returns the ``(abs_path, line, byte_col)`` start of every span gopls
+    printed, or ``(None, error)``.
+    """
+    gopls = shutil.which("gopls")
+    if not gopls:
+        return None, "gopls not found on PATH"
+    try:
+        r = subprocess.run(
+            [gopls, *args],
+            cwd=source_root, capture_output=True, text=True,
+            timeout=GOPLS_TIMEOUT, stdin=subprocess.DEVNULL,
+        )
+    except subprocess.TimeoutExpired:
+        return None, "gopls timed out"
+    except Exception as exc:
+        logger.debug("gopls subprocess error: %s", exc, exc_info=True)
+        return None, str(exc)
+    if r.returncode != 0:
+        return None, (r.stderr.strip().splitlines() or ["gopls failed"])[-1]
+    spans = []
+    for raw in r.stdout.splitlines():
+        m = _SPAN_RE.match(raw.strip())
+        if m:
+            spans.append((m.group(1), int(m.group(2)), int(m.group(3))))
+    return spans, ""
+
+
+def relative_path(span_path: str, source_root: str) -> str:
+    """*span_path* relative to *source_root*, posix-style; starts with ``../`` when outside it."""
+    return os.path.relpath(os.path.realpath(span_path), os.path.realpath(source_root)).replace(os.sep, "/")
+
+
+def byte_offset(source: bytes, line: int, byte_col: int) -> Optional[int]:
+    """Offset into *source* of a gopls ``line:byte_col``, or None past the last line."""
+    lines = source.split(b"\n")
+    if line - 1 >= len(lines):
+        return None
+    return sum(len(row) + 1 for row in lines[: line - 1]) + byte_col - 1
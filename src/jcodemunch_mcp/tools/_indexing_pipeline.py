@@ -4,9 +4,11 @@ import logging
 from collections import defaultdict
 from typing import Optional
 
-from ..parser import parse_file, get_language_for_path
+from ..deadline import Deadline, expired
+from ..parser import get_language_for_path
 from ..parser.context import ContextProvider, enrich_symbols, collect_extra_imports
 from ..parser.imports import extract_imports
+from ..parser.parse_pool import ParseJob, parse_files
 from ..parser.symbols import Symbol
 from ..summarizer import summarize_symbols, generate_file_summaries
 
@@ -93,6 +95,7 @@ def parse_immediate(
     no_symbols_files: list[str] = []
     file_language_map: dict[str, str] = {}
 
+    jobs: list[ParseJob] = []
     for rel_path in sorted(files_to_parse):
         content = file_contents.get(rel_path)
         if content is None:
@@ -102,16 +105,18 @@ def parse_immediate(
             no_symbols_files.append(rel_path)
             continue
         file_language_map[rel_path] = language
-        try:
-            symbols = parse_file(content, rel_path, language, repo=repo)
-            if symbols:
-                new_symbols.extend(symbols)
-            else:
-                no_symbols_files.append(rel_path)
-                logger.debug("NO SYMBOLS (parse_immediate): %s", rel_path)
-        except Exception as e:
-            warnings.append(f"Failed to parse {rel_path}: {e}")
-            logger.debug("PARSE ERROR (parse_immediate): %s — %s", rel_path, e)
+        jobs.append(ParseJob(rel_path, content, language))
+
+    for job, symbols, parse_error in parse_files(jobs, repo=repo):
+        rel_path = job.rel_path
+        if parse_error is not None:
+            warnings.append(f"Failed to parse {rel_path}: {parse_error}")
+            logger.debug("PARSE ERROR (parse_immediate): %s — %s", rel_path, parse_error)
+        elif symbols:
+            new_symbols.extend(symbols)
+        else:
+            no_symbols_files.append(rel_path)
+            logger.debug("NO SYMBOLS (parse_immediate): %s", rel_path)
 
     # 2. Enrich with context providers
     if providers and new_symbols:
@@ -185,6 +190,19 @@ def deferred_summarize(
     return summarized
 
 
+def mark_timed_out(
+    result: dict, warnings: list[str], deadline: Deadline, remaining: int, tool: str,
+) -> None:
+    """Flag a partial index result whose last *remaining* files were not parsed."""
+    result["timed_out"] = True
+    result["files_remaining"] = remaining
+    warnings.append(
+        f"Timed out after {deadline.seconds:g}s with {remaining} file(s) not yet parsed; "
+        f"they were left out of this save. Re-run {tool} to index them "
+        "(the next run is incremental and skips the files done here)."
+    )
+
+
 def parse_and_prepare_incremental(
     files_to_parse: set[str],
     file_contents: dict[str, str],
@@ -192,6 +210,8 @@ def parse_and_prepare_incremental(
     use_ai_summaries: bool = True,
     warnings: Optional[list[str]] = None,
     repo: Optional[str] = None,
+    deadline: Optional[Deadline] = None,
+    unparsed: Optional[list[str]] = None,
 ) -> tuple[list[Symbol], dict[str, str], dict[str, str], dict[str, list[dict]], list[str]]:
     """Shared incremental pipeline: parse, enrich, summarize, extract metadata.
 
@@ -201,6 +221,10 @@ def parse_and_prepare_incremental(
         active_providers: Context providers for enrichment (empty/None for remote repos).
         use_ai_summaries: Whether to use AI summaries.
         warnings: Mutable list to append warnings to.
+        deadline: Stop parsing once it passes.  Files left unparsed are
+            appended to *unparsed* and excluded from every returned map;
+            AI summaries are skipped if the deadline has passed by then.
+        unparsed: Mutable list to append skipped rel_paths to.
 
     Returns:
         (symbols, file_summaries, file_languages, file_imports, no_symbols_files)
@@ -214,6 +238,7 @@ def parse_and_prepare_incremental(
     no_symbols_files: list[str] = []
     file_language_map: dict[str, str] = {}
 
+    jobs: list[ParseJob] = []
     for rel_path in sorted(files_to_parse):
         content = file_contents.get(rel_path)
         if content is None:
@@ -223,16 +248,29 @@ def parse_and_prepare_incremental(
             no_symbols_files.append(rel_path)
             continue
         file_language_map[rel_path] = language
-        try:
-            symbols = parse_file(content, rel_path, language, repo=repo)
-            if symbols:
-                new_symbols.extend(symbols)
-            else:
-                no_symbols_files.append(rel_path)
-                logger.debug("NO SYMBOLS (incremental): %s", rel_path)
-        except Exception as e:
-            warnings.append(f"Failed to parse {rel_path}: {e}")
-            logger.debug("PARSE ERROR (incremental): %s — %s", rel_path, e)
+        jobs.append(ParseJob(rel_path, content, language))
+
+    parsed: set[str] = set()
+    for job, symbols, parse_error in parse_files(jobs, repo=repo, deadline=deadline):
+        rel_path = job.rel_path
+        parsed.add(rel_path)
+        if parse_error is not None:
+            warnings.append(f"Failed to parse {rel_path}: {parse_error}")
+            logger.debug("PARSE ERROR (incremental): %s — %s", rel_path, parse_error)
+        elif symbols:
+            new_symbols.extend(symbols)
+        else:
+            no_symbols_files.append(rel_path)
+            logger.debug("NO SYMBOLS (incremental): %s", rel_path)
+
+    skipped = [job.rel_path for job in jobs if job.rel_path not in parsed]
+    if skipped:
+        logger.info("Incremental parsing timed out — %d file(s) left unparsed", len(skipped))
+        if unparsed is not None:
+            unparsed.extend(skipped)
+        files_to_parse = set(files_to_parse) - set(skipped)
+        for rel_path in skipped:
+            file_language_map.pop(rel_path, None)
 
     logger.info(
         "Incremental parsing — with symbols: %d, no symbols: %d",
@@ -245,7 +283,9 @@ def parse_and_prepare_incremental(
         enrich_symbols(new_symbols, providers)
 
     # 3. Summarize (repo passed for project-aware config reads, #304)
-    new_symbols = summarize_symbols(new_symbols, use_ai=use_ai_summaries, repo=repo)
+    new_symbols = summarize_symbols(
+        new_symbols, use_ai=use_ai_summaries and not expired(deadline), repo=repo,
+    )
 
     # 4. Build symbols-by-file map, file summaries, file languages
     symbols_map: dict[str, list] = defaultdict(list)
@@ -311,6 +351,8 @@ def parse_and_prepare_full(
     repo: Optional[str] = None,
     existing_summaries: Optional[dict[tuple[str, str, str], str]] = None,
     unchanged_files: Optional[set[str]] = None,
+    deadline: Optional[Deadline] = None,
+    unparsed: Optional[list[str]] = None,
 ) -> tuple[list[Symbol], dict[str, str], dict[str, int], dict[str, str], dict[str, list[dict]], list[str]]:
     """Shared full-index pipeline: parse all files, enrich, summarize.
 
@@ -325,6 +367,8 @@ def parse_and_prepare_full(
             triggering a new AI call.
         unchanged_files: Set of rel_paths whose content hash matches the existing
             index.  Required alongside ``existing_summaries`` to enable preservation.
+        deadline: As for :func:`parse_and_prepare_incremental`.
+        unparsed: Mutable list to append skipped rel_paths to.
 
     Returns:
         (symbols, file_summaries, languages, file_languages, file_imports, no_symbols_files)
@@ -341,6 +385,7 @@ def parse_and_prepare_full(
     no_symbols_files: list[str] = []
     file_language_map: dict[str, str] = {}
 
+    jobs: list[ParseJob] = []
     for path in source_file_list:
         content = file_contents[path]
         language = get_language_for_path(path)
@@ -348,17 +393,32 @@ def parse_and_prepare_full(
             no_symbols_files.append(path)
             continue
         file_language_map[path] = language
-        try:
-            symbols = parse_file(content, path, language, repo=repo)
-            if symbols:
-                all_symbols.extend(symbols)
-                symbols_by_file[path].extend(symbols)
-            else:
-                no_symbols_files.append(path)
-                logger.debug("NO SYMBOLS: %s", path)
-        except Exception as e:
-            warnings.append(f"Failed to parse {path}: {e}")
-            logger.debug("PARSE ERROR: %s — %s", path, e)
+        jobs.append(ParseJob(path, content, language))
+
+    parsed: set[str] = set()
+    for job, symbols, parse_error in parse_files(jobs, repo=repo, deadline=deadline):
+        path = job.rel_path
+        parsed.add(path)
+        if parse_error is not None:
+            warnings.append(f"Failed to parse {path}: {parse_error}")
+            logger.debug("PARSE ERROR: %s — %s", path, parse_error)
+        elif symbols:
+            all_symbols.extend(symbols)
+            symbols_by_file[path].extend(symbols)
+        else:
+            no_symbols_files.append(path)
+            logger.debug("NO SYMBOLS: %s", path)
+
+    skipped = [job.rel_path for job in jobs if job.rel_path not in parsed]
+    if skipped:
+        logger.info("Parsing timed out — %d file(s) left unparsed", len(skipped))
+        if unparsed is not None:
+            unparsed.extend(skipped)
+        skipped_set = set(skipped)
+        source_file_list = [p for p in source_file_list if p not in skipped_set]
+        for path in skipped:
+            file_language_map.pop(path, None)
+    use_ai = use_ai_summaries and not expired(deadline)
 
     logger.info(
         "Parsing complete — with symbols: %d, no symbols: %d",
@@ -380,10 +440,10 @@ def parse_and_prepare_full(
                 "Summary preservation: %d symbols reuse existing, %d need AI summarization",
                 len(already_summarized), len(needs_summary),
             )
-            summarized = summarize_symbols(needs_summary, use_ai=use_ai_summaries, repo=repo) if needs_summary else []
+            summarized = summarize_symbols(needs_summary, use_ai=use_ai, repo=repo) if needs_summary else []
             all_symbols = summarized + already_summarized
         else:
-            all_symbols = summarize_symbols(all_symbols, use_ai=use_ai_summaries, repo=repo)
+            all_symbols = summarize_symbols(all_symbols, use_ai=use_ai, repo=repo)
 
     # 4. Rebuild symbols_by_file after summarization (summaries may update fields)
     file_symbols_map: dict[str, list] = defaultdict(list)
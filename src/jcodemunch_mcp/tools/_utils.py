@@ -113,11 +113,14 @@ def load_repo_index_or_error(
 def resolve_fqn(
     repo: str, fqn: str, storage_path: Optional[str] = None
 ) -> tuple[Optional[str], Optional[str]]:
-    """Resolve a PHP FQN to a jcodemunch symbol_id.
+    """Resolve a qualified name to a jcodemunch symbol_id.
+
+    Accepts a stored symbol ``fqn`` (Go ``github.com/acme/app/store.Config``,
+    Python ``app.store.Config``) or a PHP FQN, which is resolved via PSR-4.
 
     Returns ``(symbol_id, None)`` on success or ``(None, error_message)`` on failure.
     """
-    from ..parser.fqn import fqn_to_symbol
+    from ..parser.fqn import find_symbol_by_fqn, fqn_to_symbol
     from ..parser.imports import build_psr4_map
 
     try:
@@ -130,10 +133,18 @@ def resolve_fqn(
         status = store.inspect_index(owner, name)
         err = index_status_to_tool_error(status)
         return None, f"{err['error']} ({err['load_error']}). {err['hint']}"
+    stored = find_symbol_by_fqn(index.symbols, fqn)
+    if stored is not None:
+        return stored["id"], None
+    not_stored = f"No indexed symbol has fqn '{fqn}' (Go and Python symbols carry one once re-indexed)."
     if not getattr(index, "source_root", None):
+        if "\\" not in fqn:
+            return None, not_stored
         return None, "Index has no source_root (remote indexes don't support FQN resolution)"
     psr4 = build_psr4_map(index.source_root)
     if not psr4:
+        if "\\" not in fqn:
+            return None, not_stored
         return None, "No PSR-4 autoload config found in composer.json"
     resolved = fqn_to_symbol(fqn, psr4, frozenset(index.source_files))
     if not resolved:
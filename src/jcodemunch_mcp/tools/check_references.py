@@ -8,6 +8,7 @@ import posixpath
 import time
 from typing import Optional
 
+from .. import body_policy
 from ..storage import IndexStore
 from ._utils import index_status_to_tool_error, resolve_repo
 
@@ -62,6 +63,7 @@ def _check_single(
 
     if search_content:
         content_dir = store._content_dir(owner, name)
+        withhold = body_policy.is_enabled()
         for file_path in index.source_files:
             if file_path in defining_files:
                 continue
@@ -76,8 +78,11 @@ def _check_single(
             except OSError:
                 continue
 
+            lines = content.split("\n")
+            if withhold:
+                lines = body_policy.blank_hidden_lines(lines, index, file_path)
             file_matches = []
-            for line_index, line in enumerate(content.split("\n")):
+            for line_index, line in enumerate(lines):
                 if ident_lower in line.lower():
                     file_matches.append({
                         "line": line_index + 1,
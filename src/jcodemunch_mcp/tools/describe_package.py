@@ -0,0 +1,207 @@
+"""describe_package: one-call table of contents for a package directory."""
+
+import ast
+import json
+import posixpath
+import re
+import time
+from collections import Counter
+from typing import Optional
+
+from ..parser import build_symbol_tree
+from ..parser.docstring import parse_docstring
+from ..parser.extractor import _clean_comment_markers
+from ..parser.fqn import go_module_import_path
+from ..parser.visibility import js_exports, python_all_names
+from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
+from ._utils import load_repo_index_or_error
+from .get_file_outline import _dict_to_symbol, _flatten_tree_with_parents
+
+_GO_PACKAGE_RE = re.compile(r"^package\s+([A-Za-z_]\w*)", re.MULTILINE)
+# A // run or a /* */ block ending on the line right before `package`.
+_GO_DOC_RE = re.compile(
+    r"((?:^[ \t]*//[^\n]*\n)+|^[ \t]*/\*.*?\*/[ \t]*\n)(?=package\s)",
+    re.MULTILINE | re.DOTALL,
+)
+
+# Leading `//!` lines (inner doc comments) of a Rust module.
+_RUST_INNER_DOC_RE = re.compile(r"\A\s*((?:[ \t]*//![^\n]*(?:\n|\Z))+)")
+_RUST_MODULE_ROOTS = frozenset({"lib.rs", "main.rs", "mod.rs"})
+
+
+def _normalize_package(package: str) -> str:
+    pkg = package.strip().replace("\\", "/").strip("/")
+    if pkg.startswith("./"):
+        pkg = pkg[2:]
+    return "" if pkg == "." else pkg
+
+
+def _resolve_directory(package: str, dirs: set[str]) -> Optional[str]:
+    """Map a directory path, Go import path, or dotted Python path to an index dir."""
+    pkg = _normalize_package(package)
+    if pkg in dirs:
+        return pkg
+    dotted = pkg.replace(".", "/")
+    for cand in (dotted, f"src/{dotted}"):
+        if cand in dirs:
+            return cand
+    # Go import path: the longest index dir that the path ends with.
+    suffixes = [d for d in dirs if d and pkg.endswith("/" + d)]
+    return max(suffixes, key=len) if suffixes else None
+
+
+def _go_doc(content: str) -> str:
+    m = _GO_DOC_RE.search(content)
+    return _clean_comment_markers(m.group(1).rstrip()) if m else ""
+
+
+def _rust_doc(content: str) -> str:
+    m = _RUST_INNER_DOC_RE.match(content)
+    return _clean_comment_markers(m.group(1).rstrip()) if m else ""
+
+
+def _python_import_path(directory: str) -> str:
+    path = directory[4:] if directory.startswith("src/") else directory
+    return path.replace("/", ".")
+
+
+def describe_package(
+    repo: str,
+    package: str,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Summarise one package directory: import path, doc, symbol counts, files.
+
+    Aggregates every indexed file directly inside the directory (not
+    subdirectories).  For Go the package name comes from the ``package``
+    clause and the doc comment from ``doc.go`` when present, otherwise from
+    the first file that documents the package clause.  For Python the doc
+    is the ``__init__.py`` module docstring; for Rust the leading ``//!``
+    comments of ``lib.rs`` / ``main.rs`` / ``mod.rs``, else of any file.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        package: Directory path (``internal/store``), Go import path
+            (``github.com/acme/app/internal/store``), or dotted Python
+            package (``app.store``).
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with package, directory, import_path, language, doc, summary,
+        symbols ({exported, unexported, unclassified} → kind → count),
+        files, and _meta.
+    """
+    start = time.perf_counter()
+
+    index, error, _status = load_repo_index_or_error(repo, storage_path)
+    if error:
+        return error
+    owner, name = index.owner, index.name
+    store = IndexStore(base_path=storage_path)
+
+    dirs = {posixpath.dirname(f) for f in index.source_files}
+    directory = _resolve_directory(package, dirs)
+    if directory is None:
+        leaf = _normalize_package(package).rsplit("/", 1)[-1]
+        candidates = sorted(d for d in dirs if d.rsplit("/", 1)[-1] == leaf)
+        result = {"error": f"Package not found: '{package}'."}
+        if candidates:
+            result["candidates"] = candidates[:10]
+        return result
+
+    files = sorted(f for f in index.source_files if posixpath.dirname(f) == directory)
+    languages = Counter(index.file_languages.get(f, "") for f in files)
+    language = languages.most_common(1)[0][0] if languages else ""
+
+    file_set = set(files)
+    symbols_by_file: dict[str, list[dict]] = {}
+    for sym in index.symbols:
+        if sym.get("file") in file_set:
+            symbols_by_file.setdefault(sym["file"], []).append(sym)
+
+    counts: dict[str, Counter] = {"exported": Counter(), "unexported": Counter(), "unclassified": Counter()}
+    package_name = directory.rsplit("/", 1)[-1] if directory else name
+    doc = ""
+    doc_preferred = False
+    raw_bytes = 0
+    for file_path in files:
+        file_lang = index.file_languages.get(file_path, "")
+        raw_bytes += index.file_sizes.get(file_path, 0)
+        content = store.get_file_content(owner, name, file_path, _index=index, raw=True) or ""
+
+        module_all = None
+        module_exports = None
+        if file_lang == "go":
+            clause = _GO_PACKAGE_RE.search(content)
+            if clause and not file_path.endswith("_test.go"):
+                package_name = clause.group(1)
+            is_doc_go = posixpath.basename(file_path) == "doc.go"
+            if not doc_preferred and (is_doc_go or not doc):
+                file_doc = _go_doc(content)
+                if file_doc:
+                    doc, doc_preferred = file_doc, is_doc_go
+        elif file_lang == "python":
+            module_all = python_all_names(content)
+            if posixpath.basename(file_path) == "__init__.py":
+                try:
+                    doc = ast.get_docstring(ast.parse(content)) or doc
+                except (SyntaxError, ValueError):
+                    pass
+        elif file_lang in ("javascript", "typescript", "tsx"):
+            module_exports = js_exports(content)
+        elif file_lang == "rust":
+            is_root = posixpath.basename(file_path) in _RUST_MODULE_ROOTS
+            if not doc_preferred and (is_root or not doc):
+                file_doc = _rust_doc(content)
+                if file_doc:
+                    doc, doc_preferred = file_doc, is_root
+
+        file_symbols = [s for s in symbols_by_file.get(file_path, []) if s.get("kind") != "import"]
+        if not file_symbols:
+            continue
+        tree = build_symbol_tree([_dict_to_symbol(s) for s in file_symbols])
+        for entry in _flatten_tree_with_parents(
+            tree, language=file_lang, module_all=module_all, module_exports=module_exports,
+        ):
+            exported = entry.get("exported")
+            bucket = "unclassified" if exported is None else ("exported" if exported else "unexported")
+            counts[bucket][entry["kind"]] += 1
+
+    if language == "go":
+        import_path = go_module_import_path(getattr(index, "source_root", ""), directory) or directory
+    elif language == "python":
+        import_path = _python_import_path(directory)
+    else:
+        import_path = directory
+
+    symbols = {
+        bucket: dict(sorted(c.items()))
+        for bucket, c in counts.items()
+        if c
+    }
+    result = {
+        "repo": f"{owner}/{name}",
+        "package": package_name,
+        "directory": directory,
+        "import_path": import_path,
+        "language": language,
+        "doc": doc,
+        "summary": parse_docstring(doc)["summary"] if doc else "",
+        "symbols": symbols,
+        "symbol_count": sum(sum(c.values()) for c in counts.values()),
+        "files": files,
+        "file_count": len(files),
+    }
+
+    response_bytes = len(json.dumps(result).encode("utf-8"))
+    tokens_saved = estimate_savings(raw_bytes, response_bytes)
+    total_saved = record_savings(tokens_saved, tool_name="describe_package")
+    elapsed = (time.perf_counter() - start) * 1000
+    result["_meta"] = {
+        "timing_ms": round(elapsed, 1),
+        "tokens_saved": tokens_saved,
+        "total_tokens_saved": total_saved,
+        **cost_avoided(tokens_saved, total_saved),
+        "tip": "Use get_file_outline(file_paths=[...]) to drill into the listed files.",
+    }
+    return result
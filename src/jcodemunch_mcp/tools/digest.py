@@ -27,11 +27,11 @@ from __future__ import annotations
 import datetime as _dt
 import json
 import logging
-import os
 import time
 from pathlib import Path
 from typing import Optional
 
+from ..config import default_storage_path
 from ..storage import IndexStore
 from ._utils import index_status_to_tool_error, resolve_repo
 
@@ -45,7 +45,7 @@ def _state_path(owner: str, name: str, base_path: Optional[str] = None) -> Path:
     if base_path:
         root = Path(base_path)
     else:
-        root = Path(os.environ.get("CODE_INDEX_PATH") or Path.home() / ".code-index")
+        root = default_storage_path()
     return root / _DIGEST_STATE_SUBDIR / f"{owner}--{name}.json"
 
 
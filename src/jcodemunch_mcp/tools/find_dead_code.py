@@ -82,7 +82,7 @@ def _package_json_entries(index, store, owner: str, repo_name: str) -> set[str]:
         fn = f.replace("\\", "/").rsplit("/", 1)[-1]
         if fn != "package.json":
             continue
-        content = store.get_file_content(owner, repo_name, f)
+        content = store.get_file_content(owner, repo_name, f, raw=True)
         if not content:
             continue
         try:
@@ -228,7 +228,7 @@ def find_dead_code(
             continue
         if not (f.endswith(".py") or f.endswith(".pyw")):
             continue
-        content = store.get_file_content(owner, name, f)
+        content = store.get_file_content(owner, name, f, raw=True)
         if content and _MAIN_GUARD_RE.search(content):
             live_roots.add(f)
 
@@ -1,7 +1,8 @@
 """Find concrete implementations of an interface, abstract class, or method.
 
-Multi-source resolution across four channels, each scored by confidence:
+Multi-source resolution across five channels, each scored by confidence:
   - LSP dispatch (1.0)        — interface/trait dispatch_edges from the LSP bridge
+  - Go method sets (0.95)     — concrete types whose method sets satisfy a Go interface
   - AST class hierarchy (0.85) — subclasses that override the method (or class subtypes)
   - Duck-typed (0.65)         — classes with a matching method name and no declared inheritance
   - Decorator handler (0.45)  — @decorator-registered handlers (route/cli/signal/event)
@@ -14,10 +15,12 @@ package registry.
 from __future__ import annotations
 
 import logging
+import posixpath
 import re
 import time
 from typing import Optional
 
+from ..parser.go_types import GoTypeIndex
 from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
 from ._utils import index_status_to_tool_error, resolve_repo
 from .get_class_hierarchy import _build_class_maps
@@ -28,6 +31,7 @@ logger = logging.getLogger(__name__)
 # Confidence tiers
 # ---------------------------------------------------------------------------
 _CONF_LSP = 1.0
+_CONF_METHOD_SET = 0.95
 _CONF_AST = 0.85
 _CONF_DUCK = 0.65
 _CONF_DECORATOR = 0.45
@@ -261,6 +265,40 @@ def find_implementations(
                     )
                     break
 
+    # ── Channel 1b: Go method-set satisfaction ─────────────────────────
+    # Go has no implements clause; a type satisfies an interface when its
+    # method set (value or pointer receiver) covers the interface's.
+    go_method_set: Optional[list[str]] = None
+    go_note: Optional[str] = None
+    go_unresolved: list[str] = []
+    if target.get("language") == "go" and target_kind == "type":
+        go_index = GoTypeIndex(index.symbols)
+        go_key = (posixpath.dirname(target.get("file", "")), target_name)
+        spec = go_index.types.get(go_key)
+        if spec is not None and spec.kind == "interface":
+            required, go_unresolved, type_terms = go_index.interface_methods(go_key)
+            go_method_set = sorted(f"{m}{sig}" for m, sig in required.items())
+            if type_terms:
+                go_note = (
+                    "Constraint interface (union / ~T terms): satisfied by its type "
+                    "set, not by methods, so implementations are not enumerated."
+                )
+            elif not required and not go_unresolved:
+                go_note = (
+                    "Empty interface: every type satisfies it, so implementations "
+                    "are not enumerated."
+                )
+            else:
+                for cand_key, form in go_index.implementers(go_key):
+                    cand = go_index.symbols[cand_key]
+                    _add_impl(
+                        cand, kind="interface_impl",
+                        confidence=_CONF_METHOD_SET, source="method_set",
+                        via=target_name,
+                    )
+                    if cand.get("id") in impls_by_id:
+                        impls_by_id[cand["id"]]["receiver"] = form
+
     # ── Channel 2: AST class hierarchy ─────────────────────────────────
     class_by_name, children_of = _build_class_maps(index.symbols)
 
@@ -532,6 +570,12 @@ def find_implementations(
     }
     if cross_repo:
         result["cross_repo_count"] = cross_repo_count
+    if go_method_set is not None:
+        result["method_set"] = go_method_set
+        if go_unresolved:
+            result["unresolved_embeds"] = go_unresolved
+        if go_note:
+            result["note"] = go_note
     if not dispatch_edges and target_kind in ("method", "function"):
         result["note"] = (
             "No LSP dispatch_edges available — implementations resolved via AST + duck-typed "
@@ -5,6 +5,7 @@ import re
 import time
 from typing import Optional
 
+from .. import body_policy
 from ..storage import IndexStore, result_cache_get, result_cache_put
 from ._utils import index_status_to_tool_error, resolve_repo
 
@@ -69,7 +70,7 @@ def _calling_symbols_in_file(
     # Lazy: build symbols_by_file only once per call_chain enrichment pass.
     # We do it here inline to keep the function self-contained; callers can
     # pass a pre-built map if they need efficiency across multiple files.
-    file_content = store.get_file_content(owner, repo_name, src_file)
+    file_content = store.get_file_content(owner, repo_name, src_file, raw=True)
     if not file_content:
         return []
     if not _word_match(file_content, identifier):
@@ -97,6 +98,82 @@ def _calling_symbols_in_file(
     return results
 
 
+def _innermost_enclosing(syms_in_file: list[dict], line: int) -> Optional[dict]:
+    """Return the narrowest symbol whose line span contains *line*."""
+    best: Optional[dict] = None
+    best_span = 0
+    for sym in syms_in_file:
+        start_line = sym.get("line") or 0
+        end_line = sym.get("end_line") or start_line
+        if not start_line or not (start_line <= line <= end_line):
+            continue
+        span = end_line - start_line
+        if best is None or span < best_span:
+            best, best_span = sym, span
+    return best
+
+
+def _collect_usages(
+    index,
+    store,
+    owner: str,
+    repo_name: str,
+    identifier: str,
+    max_results: int,
+    files: Optional[set[str]] = None,
+) -> tuple[list[dict], int]:
+    """Scan cached file content for whole-word occurrences of *identifier*.
+
+    Each hit is ``{file, line, text, role, symbol?}`` where ``role`` is
+    ``"definition"`` when an indexed symbol named *identifier* starts on that
+    line and ``"reference"`` otherwise.  ``symbol`` is the id of the innermost
+    enclosing symbol, when there is one.  Matching is case-sensitive and
+    token-bounded, so ``GetUser`` does not match ``GetUserProfile``.
+
+    Only *files* are scanned when given.  Ordered by (file, line).  Returns
+    ``(usages[:max_results], total)``.
+    """
+    from ._call_graph import build_symbols_by_file
+
+    pattern = re.compile(r"(?<![\w$])" + re.escape(identifier) + r"(?![\w$])")
+    symbols_by_file = build_symbols_by_file(index)
+    usages: list[dict] = []
+    total = 0
+
+    for src_file in sorted(files if files is not None else index.source_files):
+        try:
+            content = store.get_file_content(owner, repo_name, src_file, _index=index, raw=True)
+        except Exception:
+            content = None
+        if not content or identifier not in content:
+            continue
+        syms_in_file = symbols_by_file.get(src_file, [])
+        def_lines = {
+            s.get("line") for s in syms_in_file
+            if s.get("name") == identifier and s.get("line")
+        }
+        first = len(usages)
+        for lineno, text in enumerate(content.splitlines(), start=1):
+            if not pattern.search(text):
+                continue
+            total += 1
+            if len(usages) >= max_results:
+                continue
+            entry = {
+                "file": src_file,
+                "line": lineno,
+                "text": text.strip(),
+                "role": "definition" if lineno in def_lines else "reference",
+            }
+            enclosing = _innermost_enclosing(syms_in_file, lineno)
+            if enclosing is not None:
+                entry["symbol"] = enclosing.get("id", "")
+            usages.append(entry)
+        body_policy.mask_snippets(usages[first:], index, src_file, content)
+
+    return usages, total
+
+
 def _find_references_single(
     identifier: str,
     index,
@@ -106,6 +183,7 @@ def _find_references_single(
     start: float,
     include_call_chain: bool = False,
     store=None,
+    include_usages: bool = False,
 ) -> dict:
     """Core logic for a single identifier query. Returns the original flat shape."""
     if index.imports is None:
@@ -171,19 +249,166 @@ def _find_references_single(
                 index, store, owner, name, ref["file"], identifier
             )
 
+    response = {
+        "repo": f"{owner}/{name}",
+        "identifier": identifier,
+        "reference_count": len(results),
+        "references": results[:max_results],
+    }
+
+    # Optional: every whole-word occurrence across the indexed tree, not just
+    # import sites. Token-level — no scope resolution, so a local variable
+    # that shadows the identifier is still reported as a reference.
+    usages_truncated = False
+    if include_usages and store is not None:
+        usages, usage_total = _collect_usages(index, store, owner, name, identifier, max_results)
+        response["usage_count"] = usage_total
+        response["usages"] = usages
+        usages_truncated = usage_total > len(usages)
+
     elapsed = (time.perf_counter() - start) * 1000
-    return {
+    response["_meta"] = {
+        "timing_ms": round(elapsed, 1),
+        "truncated": len(results) > max_results or usages_truncated,
+        "tip": "Tip: use identifiers=[...] to query multiple identifiers in one call. "
+               "For usage-site matching beyond imports, pass include_usages=true.",
+    }
+    return response
+
+
+def _find_references_qualified(
+    target: dict,
+    index,
+    max_results: int,
+    owner: str,
+    name: str,
+    start: float,
+    include_call_chain: bool = False,
+    store=None,
+    include_usages: bool = False,
+) -> dict:
+    """References to one resolved symbol (by ``fqn`` or ``file_path``).
+
+    Only files that import the symbol's own package (Go: an import spec equal
+    to the import path) or module (other languages: a specifier resolving to
+    the defining file) count, so a same-named symbol elsewhere is never
+    reported.  Usages are scanned in those files plus the symbol's package.
+    """
+    from ..parser.fqn import fqn_package
+    from ..parser.imports import resolve_specifier
+
+    identifier = target["name"]
+    target_file = target["file"]
+    is_go = target.get("language") == "go"
+    import_path = fqn_package(target)
+    if is_go:
+        target_dir = posixpath.dirname(target_file)
+        home_files = {
+            f for f, lang in (index.file_languages or {}).items()
+            if lang == "go" and posixpath.dirname(f) == target_dir
+        }
+    else:
+        home_files = {target_file}
+
+    source_files = frozenset(index.source_files)
+    psr4_map = getattr(index, "psr4_map", None)
+    file_matches: dict[str, list[dict]] = {}
+    for src_file, file_imports in (index.imports or {}).items():
+        if src_file in home_files:
+            continue
+        for imp in file_imports:
+            spec = imp["specifier"]
+            names = imp.get("names", [])
+            if is_go:
+                hit = bool(import_path) and spec == import_path
+            else:
+                hit = (
+                    (not names or identifier in names)
+                    and resolve_specifier(spec, src_file, source_files, index.alias_map, psr4_map) == target_file
+                )
+            if hit:
+                match = {"specifier": spec, "names": names, "match_type": "qualified"}
+                if imp.get("alias"):
+                    match["alias"] = imp["alias"]
+                file_matches.setdefault(src_file, []).append(match)
+
+    results = [{"file": f, "matches": m} for f, m in sorted(file_matches.items())]
+    if store is not None:
+        for ref in results:
+            try:
+                content = store.get_file_content(owner, name, ref["file"])
+            except Exception:
+                content = None
+            for match in ref["matches"]:
+                line = _find_import_line(content or "", match["specifier"])
+                if line is not None:
+                    match["line"] = line
+            if include_call_chain:
+                ref["calling_symbols"] = _calling_symbols_in_file(
+                    index, store, owner, name, ref["file"], identifier
+                )
+
+    response = {
         "repo": f"{owner}/{name}",
         "identifier": identifier,
+        "fqn": target.get("fqn", ""),
+        "symbol_id": target["id"],
         "reference_count": len(results),
         "references": results[:max_results],
-        "_meta": {
-            "timing_ms": round(elapsed, 1),
-            "truncated": len(results) > max_results,
-            "tip": "Tip: use identifiers=[...] to query multiple identifiers in one call. "
-                   "For usage-site matching beyond imports, also try search_text or check_references.",
-        },
     }
+    usages_truncated = False
+    if include_usages and store is not None:
+        usages, usage_total = _collect_usages(
+            index, store, owner, name, identifier, max_results,
+            files=home_files | set(file_matches),
+        )
+        response["usage_count"] = usage_total
+        response["usages"] = usages
+        usages_truncated = usage_total > len(usages)
+
+    response["_meta"] = {
+        "timing_ms": round((time.perf_counter() - start) * 1000, 1),
+        "truncated": len(results) > max_results or usages_truncated,
+    }
+    return response
+
+
+def _target_in_path(index, identifier: str, file_path: str) -> tuple[Optional[dict], str]:
+    """The one definition of *identifier* in *file_path*, or ``(None, error)``.
+
+    *file_path* is a file or a directory (a Go package), matched exactly or
+    as a path suffix; exact matches win.  Go definitions in one directory are one package, so
+    build variants do not make the lookup ambiguous.
+    """
+    fp = file_path.replace("\\", "/").strip("/")
+
+    def _covers(path: str) -> bool:
+        return path == fp or path.endswith("/" + fp)
+
+    matches = sorted(
+        (
+            sym for sym in index.symbols
+            if sym.get("kind") != "import"
+            and identifier in (sym.get("name"), sym.get("qualified_name"))
+            and (_covers(sym["file"]) or _covers(posixpath.dirname(sym["file"])))
+        ),
+        key=lambda s: (s["file"], s.get("line", 0)),
+    )
+    if not matches:
+        return None, f"No definition of '{identifier}' in '{file_path}'."
+    exact = [m for m in matches if fp in (m["file"], posixpath.dirname(m["file"]))]
+    if exact:
+        matches = exact
+    homes = sorted({
+        posixpath.dirname(m["file"]) if m.get("language") == "go" else m["file"]
+        for m in matches
+    })
+    if len(homes) > 1:
+        return None, (
+            f"'{identifier}' is defined in several places under '{file_path}' "
+            f"({', '.join(homes)}); pass one of them as file_path."
+        )
+    return matches[0], ""
 
 
 def _find_references_batch(
@@ -278,13 +503,20 @@ def find_references(
     storage_path: Optional[str] = None,
     identifiers: Optional[list[str]] = None,
     include_call_chain: bool = False,
+    include_usages: bool = False,
+    fqn: Optional[str] = None,
+    file_path: Optional[str] = None,
 ) -> dict:
     """Find all indexed files that import or reference an identifier.
 
-    Supports two modes:
+    Supports three modes:
     - Singular: pass ``identifier`` to get the original flat response shape.
     - Batch: pass ``identifiers`` (list) to query multiple identifiers at once,
       returning a grouped ``results`` array.
+    - Qualified: pass ``fqn`` (e.g. ``github.com/acme/app/store.Config``) to
+      get the singular shape restricted to importers of that symbol's package.
+      ``identifier`` plus ``file_path`` does the same for the definition in
+      that file or package directory.
 
     Args:
         repo: Repository identifier (owner/repo or display name).
@@ -295,19 +527,32 @@ def find_references(
         include_call_chain: When True (singular mode only), each reference entry gains a
             ``calling_symbols`` list of symbols in that file whose bodies mention the
             identifier. Gated off by default; batch mode ignores this flag.
+        include_usages: When True (singular mode only), adds a ``usages`` list of
+            every whole-word occurrence in indexed file content, each tagged
+            ``role="definition"`` or ``"reference"`` with its line text and
+            enclosing symbol. Capped at ``max_results``; ``usage_count`` is the
+            uncapped total. Batch mode ignores this flag.
+        fqn: Qualified symbol ID (or PHP FQN) to disambiguate same-named
+            symbols; replaces ``identifier``.
+        file_path: With ``identifier``, the file or package directory (exact
+            or path suffix) defining the symbol meant; references are then
+            restricted as for ``fqn``.
 
     Returns:
         Singular mode: dict with flat ``references`` list and _meta envelope.
         Batch mode: dict with ``results`` array (one entry per input identifier).
 
     Raises:
-        ValueError: if neither or both of identifier and identifiers are provided.
+        ValueError: if not exactly one of identifier, identifiers, and fqn is
+            provided, or if file_path is given without identifier.
     """
     # Normalize: some MCP clients send identifiers=[] alongside identifier when they mean singular mode
-    if identifier is not None and identifiers is not None and len(identifiers) == 0:
+    if identifiers is not None and len(identifiers) == 0 and (identifier is not None or fqn):
         identifiers = None
-    if (identifier is None and identifiers is None) or (identifier is not None and identifiers is not None):
-        raise ValueError("Provide exactly one of 'identifier' or 'identifiers', not both and not neither.")
+    if sum(x is not None for x in (identifier, identifiers, fqn or None)) != 1:
+        raise ValueError("Provide exactly one of 'identifier', 'identifiers', or 'fqn'.")
+    if file_path and identifier is None:
+        raise ValueError("'file_path' narrows 'identifier' only.")
 
     start = time.perf_counter()
     max_results = max(1, min(max_results, 200))
@@ -322,12 +567,29 @@ def find_references(
     if not index:
         return index_status_to_tool_error(store.inspect_index(owner, name))
 
+    if fqn or file_path:
+        if fqn:
+            from ._utils import resolve_fqn
+            symbol_id, err = resolve_fqn(repo, fqn, storage_path)
+            target = index.get_symbol(symbol_id) if symbol_id else None
+            err = err or f"Could not resolve FQN '{fqn}'."
+        else:
+            target, err = _target_in_path(index, identifier, file_path)
+        if target is None:
+            return {"error": err}
+        result = _find_references_qualified(
+            target, index, max_results, owner, name, start,
+            include_call_chain=include_call_chain,
+            store=store,
+            include_usages=include_usages,
+        )
+        return _attach_runtime_to_response(result, store, owner, name)
     if identifiers is not None:
         result = _find_references_batch(identifiers, index, max_results, owner, name, start)
         return _attach_runtime_to_response(result, store, owner, name)
     else:
         repo_key = f"{owner}/{name}"
-        specific_key = (identifier, max_results, include_call_chain)
+        specific_key = (identifier, max_results, include_call_chain, include_usages)
         cached = result_cache_get("find_references", repo_key, specific_key)
         if cached is not None:
             result = dict(cached)
@@ -339,6 +601,7 @@ def find_references(
             identifier, index, max_results, owner, name, start,
             include_call_chain=include_call_chain,
             store=store,
+            include_usages=include_usages,
         )
         result_cache_put("find_references", repo_key, specific_key, result)
         return _attach_runtime_to_response(result, store, owner, name)
@@ -0,0 +1,169 @@
+"""format_check — which Go files gofmt (or goimports) would rewrite, and how.
+
+Each file's indexed content is piped through the formatter on stdin and the
+output compared with the input, so nothing is read from or written to the
+working tree and remote (index_repo) repos work too.  A file that would
+change comes back with a unified diff of the rewrite; a file the formatter
+cannot parse comes back with its error instead.
+
+``goimports`` is used when asked for and on PATH.  Otherwise ``gofmt``
+answers and ``_meta.fallback`` says why.
+"""
+
+from __future__ import annotations
+
+import difflib
+import logging
+import os
+import posixpath
+import shutil
+import subprocess
+import time
+from typing import Optional
+
+from .. import body_policy
+from ..storage import IndexStore
+from ._utils import index_status_to_tool_error, resolve_repo
+
+logger = logging.getLogger(__name__)
+
+_FORMAT_TIMEOUT = 15
+_DEFAULT_MAX_FILES = 200
+_MAX_FILES_CAP = 2000
+
+
+def _run_formatter(cmd: list[str], content: str) -> tuple[Optional[str], str]:
+    """Formatted *content*, or ``(None, error)``."""
+    try:
+        r = subprocess.run(
+            cmd, input=content.encode("utf-8"), capture_output=True, timeout=_FORMAT_TIMEOUT,
+        )
+    except subprocess.TimeoutExpired:
+        return None, f"{os.path.basename(cmd[0])} timed out"
+    except Exception as exc:
+        logger.debug("formatter subprocess error: %s", exc, exc_info=True)
+        return None, str(exc)
+    if r.returncode != 0:
+        err = r.stderr.decode("utf-8", errors="replace").strip()
+        # gofmt names stdin "<standard input>"; the caller knows the file.
+        return None, err.replace("<standard input>:", "line ").splitlines()[0] if err else "formatter failed"
+    return r.stdout.decode("utf-8", errors="replace"), ""
+
+
+def _unified_diff(before: str, after: str, file_path: str) -> str:
+    return "".join(difflib.unified_diff(
+        before.splitlines(keepends=True),
+        after.splitlines(keepends=True),
+        fromfile=f"a/{file_path}",
+        tofile=f"b/{file_path}",
+    ))
+
+
+def format_check(
+    repo: str,
+    path: Optional[str] = None,
+    use_goimports: bool = False,
+    max_files: int = _DEFAULT_MAX_FILES,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Report Go files that are not gofmt-clean, with the diff formatting would apply.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        path: A Go file, or a directory whose Go files (recursively) are
+            checked.  Omit to check the whole repo.
+        use_goimports: Format with goimports (import grouping and
+            add/remove) instead of gofmt when it is on PATH.
+        max_files: Cap on files checked (default 200, max 2000).
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with ``files`` (file, ``formatted``, and ``diff`` when it would
+        change, or ``error`` when it does not parse), ``changed_files``,
+        ``checked``, ``formatter``, ``truncated``, and _meta.  Under the
+        withhold policy a file with withheld bodies reports
+        ``diff_withheld`` in place of its diff.
+    """
+    start = time.perf_counter()
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    meta: dict = {}
+    formatter = "gofmt"
+    exe = None
+    if use_goimports:
+        exe = shutil.which("goimports")
+        if exe:
+            formatter = "goimports"
+        else:
+            meta["fallback"] = "goimports not found on PATH; used gofmt"
+    if exe is None:
+        exe = shutil.which("gofmt")
+    if not exe:
+        return {"error": "gofmt not found on PATH. Install Go and ensure gofmt is in PATH."}
+    cmd = [exe]
+    if formatter == "goimports" and index.source_root:
+        cmd += ["-srcdir", index.source_root]
+
+    file_langs = getattr(index, "file_languages", {}) or {}
+    go_files = sorted(
+        f for f in index.source_files
+        if (file_langs.get(f) or ("go" if f.endswith(".go") else "")) == "go"
+    )
+    if path:
+        target = posixpath.normpath(path.replace("\\", "/").strip("/"))
+        if target in ("", "."):
+            pass
+        elif target in go_files:
+            go_files = [target]
+        else:
+            go_files = [f for f in go_files if f.startswith(target + "/")]
+            if not go_files:
+                return {"error": f"No indexed Go files at path: {path}"}
+
+    max_files = max(1, min(int(max_files), _MAX_FILES_CAP))
+    truncated = len(go_files) > max_files
+    go_files = go_files[:max_files]
+
+    withhold = body_policy.is_enabled()
+    files: list[dict] = []
+    changed: list[str] = []
+    for file_path in go_files:
+        # Format the real text: gofmt would "fix" the blank lines a
+        # withheld body leaves behind.  The diff is dropped instead.
+        content = store.get_file_content(owner, name, file_path, _index=index, raw=True)
+        if content is None:
+            continue
+        formatted, err = _run_formatter(cmd, content)
+        if formatted is None:
+            files.append({"file": file_path, "formatted": False, "error": err})
+            continue
+        entry = {"file": file_path, "formatted": formatted == content}
+        if formatted != content:
+            if withhold and body_policy.withheld_ids(index, file_path, content):
+                entry["diff_withheld"] = True
+            else:
+                entry["diff"] = _unified_diff(content, formatted, file_path)
+            changed.append(file_path)
+        files.append(entry)
+
+    meta["timing_ms"] = round((time.perf_counter() - start) * 1000, 1)
+    return {
+        "repo": f"{owner}/{name}",
+        "formatter": formatter,
+        "checked": len(files),
+        "changed_files": changed,
+        "changed_count": len(changed),
+        "error_count": sum(1 for f in files if "error" in f),
+        "truncated": truncated,
+        "files": files,
+        "_meta": meta,
+    }
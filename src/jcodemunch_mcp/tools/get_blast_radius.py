@@ -5,6 +5,7 @@ import time
 from collections import deque
 from typing import Optional
 
+from .. import body_policy
 from ..storage import IndexStore, result_cache_get, result_cache_put
 from ..parser.imports import resolve_specifier
 from ._utils import index_status_to_tool_error, resolve_repo, resolve_fqn
@@ -233,7 +234,7 @@ def get_blast_radius(
     content_cache: dict[str, str] = {}
 
     for imp_file in importer_files:
-        content = store.get_file_content(owner, name, imp_file)
+        content = store.get_file_content(owner, name, imp_file, raw=True)
         if content is not None:
             content_cache[imp_file] = content
         if content is None:
@@ -283,6 +284,7 @@ def get_blast_radius(
                 if not content:
                     continue
                 snippets = _extract_reference_snippets(content, sym_name)
+                body_policy.mask_snippets(snippets, index, entry["file"], content)
                 # Rough token estimate: ~4 chars per token
                 snippet_tokens = sum(len(s["text"]) // 4 + 1 for s in snippets)
                 if snippet_tokens > budget_remaining:
@@ -318,7 +320,7 @@ def get_blast_radius(
         # Any test file reference the affected symbol by name?
         reached = False
         for tf in test_importers:
-            tf_content = content_cache.get(tf) or store.get_file_content(owner, name, tf)
+            tf_content = content_cache.get(tf) or store.get_file_content(owner, name, tf, raw=True)
             if tf_content and _name_in_content(tf_content, sym_name):
                 reached = True
                 break
@@ -0,0 +1,190 @@
+"""get_call_graph: directed caller → callee edges reachable from a symbol."""
+
+import time
+from collections import deque
+from typing import Optional
+
+from ..storage import IndexStore
+from ._utils import index_status_to_tool_error, resolve_repo
+from .get_blast_radius import _build_reverse_adjacency, _find_symbol
+from ._call_graph import build_symbols_by_file, find_direct_callers, find_direct_callees
+
+# Resolutions that rest on name matching or interface dispatch rather than a
+# resolved call site.  Edges carrying them are flagged ``approximate``.
+_APPROXIMATE_RESOLUTIONS = frozenset({"lsp_dispatch", "text_matched"})
+
+_DEFAULT_MAX_EDGES = 500
+
+
+def _node(sym: dict, depth: int) -> dict:
+    return {
+        "id": sym.get("id", ""),
+        "name": sym.get("name", ""),
+        "kind": sym.get("kind", ""),
+        "file": sym.get("file", ""),
+        "line": sym.get("line", 0),
+        "depth": depth,
+    }
+
+
+def get_call_graph(
+    repo: str,
+    symbol_id: str,
+    direction: str = "callees",
+    depth: int = 3,
+    max_edges: int = _DEFAULT_MAX_EDGES,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Return the call graph rooted at a symbol as a flat list of directed edges.
+
+    Unlike get_call_hierarchy (which lists reachable symbols with their hop
+    count), every edge here names both endpoints, so a client can render the
+    graph directly.  Edges always point caller → callee regardless of the
+    traversal direction.  Cycles are reported as edges back to an already
+    visited node; each node is expanded at most once.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        symbol_id: Symbol name or full ID to start from.
+        direction: 'callees' (what it ultimately calls, default), 'callers'
+            (who ultimately calls it), or 'both'.
+        depth: Maximum hops from the root (1–5). Default 3.
+        max_edges: Stop collecting after this many edges. Default 500.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with root, nodes, edges, and _meta. Each edge is
+        {from, to, resolution, approximate}.
+    """
+    depth = max(1, min(depth, 5))
+    max_edges = max(1, max_edges)
+    if direction not in ("callers", "callees", "both"):
+        direction = "callees"
+    start = time.perf_counter()
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    if index.imports is None:
+        return {
+            "error": (
+                "No import data available. Re-index with jcodemunch-mcp >= 1.3.0 "
+                "to enable call graph analysis."
+            )
+        }
+
+    matches = _find_symbol(index, symbol_id)
+    if not matches:
+        return {"error": f"Symbol not found: '{symbol_id}'. Try search_symbols first."}
+    if len(matches) > 1:
+        ambiguous = [{"name": s["name"], "file": s["file"], "id": s["id"]} for s in matches]
+        return {
+            "error": (
+                f"Ambiguous symbol '{symbol_id}': found {len(matches)} definitions. "
+                "Use the symbol 'id' field to disambiguate."
+            ),
+            "candidates": ambiguous,
+        }
+
+    root = matches[0]
+    symbols_by_file = build_symbols_by_file(index)
+    symbol_index: dict[str, dict] = getattr(index, "_symbol_index", {})
+    reverse_adj: dict[str, list[str]] = {}
+    if direction in ("callers", "both"):
+        reverse_adj = _build_reverse_adjacency(
+            index.imports,
+            frozenset(index.source_files),
+            getattr(index, "alias_map", None),
+            getattr(index, "psr4_map", None),
+        )
+
+    nodes: dict[str, dict] = {root["id"]: _node(root, 0)}
+    edges: list[dict] = []
+    edge_keys: set[tuple[str, str]] = set()
+    truncated = False
+
+    def _add_edge(caller_id: str, callee_id: str, resolution: str) -> bool:
+        """Record an edge; return False once the edge budget is exhausted."""
+        nonlocal truncated
+        if (caller_id, callee_id) in edge_keys:
+            return True
+        if len(edges) >= max_edges:
+            truncated = True
+            return False
+        edge_keys.add((caller_id, callee_id))
+        edges.append({
+            "from": caller_id,
+            "to": callee_id,
+            "resolution": resolution,
+            "approximate": resolution in _APPROXIMATE_RESOLUTIONS,
+        })
+        return True
+
+    def _walk(outgoing: bool) -> int:
+        expanded: set[str] = set()
+        queue: deque[tuple[dict, int]] = deque([(root, 0)])
+        reached = 0
+        while queue and not truncated:
+            sym, d = queue.popleft()
+            sid = sym.get("id", "")
+            if sid in expanded or d >= depth:
+                continue
+            expanded.add(sid)
+
+            if outgoing:
+                neighbours = find_direct_callees(index, store, owner, name, sym, symbols_by_file)
+            else:
+                neighbours = find_direct_callers(
+                    index, store, owner, name, sym, reverse_adj, symbols_by_file
+                )
+            for nb in neighbours:
+                nid = nb["id"]
+                resolution = nb.get("resolution", "text_matched")
+                ok = _add_edge(sid, nid, resolution) if outgoing else _add_edge(nid, sid, resolution)
+                if not ok:
+                    break
+                reached = max(reached, d + 1)
+                if nid not in nodes:
+                    nodes[nid] = _node(symbol_index.get(nid, nb), d + 1)
+                if nid not in expanded:
+                    full = symbol_index.get(nid)
+                    if full:
+                        queue.append((full, d + 1))
+        return reached
+
+    depth_reached = 0
+    if direction in ("callees", "both"):
+        depth_reached = max(depth_reached, _walk(outgoing=True))
+    if direction in ("callers", "both"):
+        depth_reached = max(depth_reached, _walk(outgoing=False))
+
+    approximate_count = sum(1 for e in edges if e["approximate"])
+    elapsed = (time.perf_counter() - start) * 1000
+
+    return {
+        "repo": f"{owner}/{name}",
+        "root": root["id"],
+        "direction": direction,
+        "depth": depth,
+        "depth_reached": depth_reached,
+        "node_count": len(nodes),
+        "edge_count": len(edges),
+        "nodes": list(nodes.values()),
+        "edges": edges,
+        "_meta": {
+            "timing_ms": round(elapsed, 1),
+            "truncated": truncated,
+            "approximate_edges": approximate_count,
+            "tip": (
+                "Edges point caller → callee. approximate=true marks interface-dispatch "
+                "or text-matched edges. Use get_call_hierarchy for a per-hop symbol list."
+            ),
+        },
+    }
@@ -3,6 +3,7 @@
 from __future__ import annotations
 
 import logging
+import re
 import subprocess
 import time
 from pathlib import Path
@@ -16,6 +17,11 @@ from .get_blast_radius import _build_reverse_adjacency, _bfs_importers
 
 logger = logging.getLogger(__name__)
 
+# ``until_sha`` value that compares against the working tree instead of a commit.
+WORKTREE = "WORKTREE"
+
+_WS_RE = re.compile(r"\s+")
+
 
 def _run_git(args: list[str], cwd: str, timeout: int = 10) -> tuple[int, str, str]:
     """Run a git command; return (returncode, stdout, stderr)."""
@@ -49,6 +55,17 @@ def _get_file_content_at(sha: str, file_path: str, cwd: str) -> Optional[str]:
     return out
 
 
+def _get_worktree_content(git_root: str, file_path: str) -> Optional[str]:
+    """Return the working-tree content of *file_path*, or None (binary / not present)."""
+    try:
+        data = (Path(git_root) / file_path).read_bytes()
+    except OSError:
+        return None
+    if b"\x00" in data[:8192]:
+        return None
+    return data.decode("utf-8", errors="replace")
+
+
 def _parse_symbols_from_content(content: str, rel_path: str, repo: str | None = None) -> dict[str, dict]:
     """Parse content → dict keyed by symbol qualified_name#kind → symbol dict."""
     language = get_language_for_path(rel_path)
@@ -80,11 +97,71 @@ def _parse_symbols_from_content(content: str, rel_path: str, repo: str | None =
             "kind": sym.kind,
             "file": rel_path,
             "line": sym.line,
+            "signature": sym.signature,
             "content_hash": ch,
+            "fields": sym.fields,
         }
     return result
 
 
+def _public(sym: dict) -> dict:
+    """Copy of a parsed symbol without the raw field list."""
+    return {k: v for k, v in sym.items() if k != "fields"}
+
+
+def _field_changes(before: list[dict], after: list[dict]) -> dict:
+    """Added/removed field names and fields whose type, tag, or modifiers changed."""
+    b = {f.get("name", ""): f for f in before or []}
+    a = {f.get("name", ""): f for f in after or []}
+    changed = []
+    for name in sorted(set(b) & set(a)):
+        diffs = {
+            attr: {"before": b[name].get(attr), "after": a[name].get(attr)}
+            for attr in ("type", "tag", "modifiers", "embedded")
+            if b[name].get(attr) != a[name].get(attr)
+        }
+        if diffs:
+            changed.append({"name": name, **diffs})
+    out: dict = {}
+    if set(a) - set(b):
+        out["added"] = sorted(set(a) - set(b))
+    if set(b) - set(a):
+        out["removed"] = sorted(set(b) - set(a))
+    if changed:
+        out["changed"] = changed
+    return out
+
+
+def _classify(before: dict, after: dict) -> Optional[dict]:
+    """Modified-symbol entry for a matched pair, or None when nothing changed.
+
+    ``changes`` lists any of ``moved`` (other file), ``signature``
+    (parameters / return type), ``fields`` (struct/class fields), or
+    ``body`` when only the implementation differs.
+    """
+    changes: list[str] = []
+    entry = _public(after)
+    if before["file"] != after["file"]:
+        changes.append("moved")
+        entry["previous_file"] = before["file"]
+    if _WS_RE.sub(" ", before["signature"]).strip() != _WS_RE.sub(" ", after["signature"]).strip():
+        changes.append("signature")
+        entry["previous_signature"] = before["signature"]
+    fields = _field_changes(before["fields"], after["fields"])
+    if fields:
+        changes.append("fields")
+        entry["fields"] = fields
+    if not changes and before["content_hash"] != after["content_hash"] and (
+        before["content_hash"] or after["content_hash"]
+    ):
+        changes.append("body")
+    if not changes:
+        return None
+    entry["change_type"] = "moved" if "moved" in changes else "modified"
+    entry["changes"] = changes
+    return entry
+
+
 def get_changed_symbols(
     repo: str,
     since_sha: Optional[str] = None,
@@ -98,13 +175,15 @@ def get_changed_symbols(
     """Return symbols that changed between two git commits for a locally indexed repo.
 
     Uses ``git diff --name-only`` to find changed files, re-parses both versions
-    of each file, and diffs the symbol sets. Optionally includes downstream blast
-    radius for each changed symbol.
+    of each file, and diffs the symbol sets. Both sides are read with ``git show``
+    (or off disk for the working tree), so nothing is checked out. Optionally
+    includes downstream blast radius for each changed symbol.
 
     Args:
         repo: Repository identifier (must be locally indexed with index_folder).
         since_sha: Compare from this SHA. Defaults to the SHA stored at index time.
-        until_sha: Compare to this SHA (default "HEAD").
+        until_sha: Compare to this SHA (default "HEAD"); ``"WORKTREE"`` compares
+            against the working tree, uncommitted and untracked files included.
         include_blast_radius: Also return downstream importers for each changed symbol.
         max_blast_depth: Hop limit for blast radius traversal (capped at 5).
         suppress_meta: Strip the _meta envelope from the response.
@@ -112,7 +191,10 @@ def get_changed_symbols(
 
     Returns:
         Dict with from_sha, to_sha, changed_files, changed_symbols, added_symbols,
-        removed_symbols, and optionally blast_radius per symbol.
+        removed_symbols, and optionally blast_radius per symbol. Each modified
+        entry carries ``changes`` (any of moved, signature, fields, body) with
+        ``previous_signature``, ``previous_file``, and a ``fields`` breakdown
+        where they apply.
     """
     start = time.perf_counter()
     max_blast_depth = max(1, min(max_blast_depth, 5))
@@ -157,7 +239,8 @@ def get_changed_symbols(
     if not resolved_since:
         return {"error": f"since_sha not found in git history: {since_sha!r}"}
 
-    resolved_until = _resolve_sha(until_sha, cwd)
+    worktree = until_sha == WORKTREE
+    resolved_until = _resolve_sha("HEAD" if worktree else until_sha, cwd)
     if not resolved_until:
         return {"error": f"until_sha not found: {until_sha!r}"}
 
@@ -167,16 +250,27 @@ def get_changed_symbols(
     )
     commits_spanned = int(count_out) if rc2 == 0 and count_out.isdigit() else None
 
-    # Get changed files (name-only diff, exclude binary files)
-    rc3, diff_out, diff_err = _run_git(
-        ["diff", "--name-only", "--diff-filter=ACDMRT", resolved_since, resolved_until],
-        cwd=cwd,
-    )
+    # Get changed files (name-only diff, exclude binary files). Renames are
+    # split into a delete and an add so moved symbols can be paired below.
+    diff_args = ["diff", "--name-only", "--no-renames", "--diff-filter=ACDMT", resolved_since]
+    if not worktree:
+        diff_args.append(resolved_until)
+    rc3, diff_out, diff_err = _run_git(diff_args, cwd=cwd)
     if rc3 != 0:
         return {"error": f"git diff failed: {diff_err}"}
 
     all_diff_files = [f for f in diff_out.splitlines() if f.strip()] if diff_out else []
 
+    git_root = cwd
+    if worktree:
+        _, top, _ = _run_git(["rev-parse", "--show-toplevel"], cwd=cwd)
+        git_root = top or cwd
+        rc4, untracked, _ = _run_git(
+            ["ls-files", "--others", "--exclude-standard", "--full-name"], cwd=cwd
+        )
+        if rc4 == 0:
+            all_diff_files = sorted(set(all_diff_files) | {f for f in untracked.splitlines() if f.strip()})
+
     # Exclude any files that live inside the index storage directory when it
     # happens to be under the repo root (e.g. .index/ as a test-time storage dir).
     storage_exclude_prefix: str = ""
@@ -203,13 +297,22 @@ def get_changed_symbols(
     removed_symbols: list[dict] = []
     changed_symbols: list[dict] = []
 
+    def _with_blast(entry: dict) -> dict:
+        if include_blast_radius and rev_adj is not None:
+            flat, _ = _bfs_importers(entry["file"], rev_adj, max_blast_depth)
+            entry["blast_radius"] = flat
+        return entry
+
     for file_path in changed_files:
         language = get_language_for_path(file_path)
         if not language:
             continue  # binary, config, etc. — skip silently
 
         before_content = _get_file_content_at(resolved_since, file_path, cwd)
-        after_content = _get_file_content_at(resolved_until, file_path, cwd)
+        if worktree:
+            after_content = _get_worktree_content(git_root, file_path)
+        else:
+            after_content = _get_file_content_at(resolved_until, file_path, cwd)
 
         before_syms: dict[str, dict] = {}
         after_syms: dict[str, dict] = {}
@@ -223,40 +326,49 @@ def get_changed_symbols(
         after_keys = set(after_syms)
 
         for key in after_keys - before_keys:
-            entry = dict(after_syms[key])
-            entry["change_type"] = "added"
-            if include_blast_radius and rev_adj is not None:
-                flat, _ = _bfs_importers(file_path, rev_adj, max_blast_depth)
-                entry["blast_radius"] = flat
-            added_symbols.append(entry)
+            added_symbols.append(after_syms[key])
 
         for key in before_keys - after_keys:
-            entry = dict(before_syms[key])
-            entry["change_type"] = "removed"
-            if include_blast_radius and rev_adj is not None:
-                flat, _ = _bfs_importers(file_path, rev_adj, max_blast_depth)
-                entry["blast_radius"] = flat
-            removed_symbols.append(entry)
+            removed_symbols.append(before_syms[key])
 
         for key in before_keys & after_keys:
             b = before_syms[key]
             a = after_syms[key]
             # Detect rename: same body hash but different name
             if b["name"] != a["name"] and b["content_hash"] == a["content_hash"] and b["content_hash"]:
-                entry = dict(a)
+                entry = _public(a)
                 entry["change_type"] = "renamed"
                 entry["previous_name"] = b["name"]
-                if include_blast_radius and rev_adj is not None:
-                    flat, _ = _bfs_importers(file_path, rev_adj, max_blast_depth)
-                    entry["blast_radius"] = flat
-                changed_symbols.append(entry)
-            elif b["content_hash"] != a["content_hash"] and (b["content_hash"] or a["content_hash"]):
-                entry = dict(a)
-                entry["change_type"] = "modified"
-                if include_blast_radius and rev_adj is not None:
-                    flat, _ = _bfs_importers(file_path, rev_adj, max_blast_depth)
-                    entry["blast_radius"] = flat
-                changed_symbols.append(entry)
+                changed_symbols.append(_with_blast(entry))
+            else:
+                entry = _classify(b, a)
+                if entry:
+                    changed_symbols.append(_with_blast(entry))
+
+    # Pair a removal and an addition of the same symbol in different files
+    # as a move — but only when the pairing is unambiguous.
+    def _by_key(syms: list[dict]) -> dict[str, list[dict]]:
+        out: dict[str, list[dict]] = {}
+        for s in syms:
+            out.setdefault(f"{s['qualified_name']}#{s['kind']}", []).append(s)
+        return out
+
+    added_by_key = _by_key(added_symbols)
+    moved_ids: set[int] = set()
+    for key, olds in _by_key(removed_symbols).items():
+        news = added_by_key.get(key, [])
+        if len(olds) == 1 and len(news) == 1:
+            moved_ids.update((id(olds[0]), id(news[0])))
+            changed_symbols.append(_with_blast(_classify(olds[0], news[0])))
+
+    added_symbols = [
+        _with_blast({**_public(s), "change_type": "added"})
+        for s in added_symbols if id(s) not in moved_ids
+    ]
+    removed_symbols = [
+        _with_blast({**_public(s), "change_type": "removed"})
+        for s in removed_symbols if id(s) not in moved_ids
+    ]
 
     def _sort_key(e: dict) -> tuple:
         return (e.get("file", ""), e.get("name", ""))
@@ -268,7 +380,7 @@ def get_changed_symbols(
     elapsed = (time.perf_counter() - start) * 1000
     result: dict = {
         "from_sha": resolved_since[:12],
-        "to_sha": resolved_until[:12],
+        "to_sha": WORKTREE if worktree else resolved_until[:12],
         "commits_spanned": commits_spanned,
         "is_local": True,
         "changed_files": changed_files,
@@ -279,14 +391,19 @@ def get_changed_symbols(
         "added_count": len(added_symbols),
         "removed_count": len(removed_symbols),
         "changed_count": len(changed_symbols),
+        "signature_changed_count": sum(
+            1 for e in changed_symbols
+            if {"signature", "fields"} & set(e.get("changes", ()))
+        ),
     }
 
     if not suppress_meta:
         result["_meta"] = {
             "timing_ms": round(elapsed, 1),
             "tip": (
-                "changed_symbols = body modified; added_symbols = new; "
-                "removed_symbols = deleted; renamed = same body, different name. "
+                "changed_symbols[].changes = moved / signature / fields / body; "
+                "added_symbols = new; removed_symbols = deleted; renamed = same body, "
+                "different name. until_sha='WORKTREE' diffs uncommitted work. "
                 "Set include_blast_radius=true to see downstream impact."
             ),
         }
@@ -0,0 +1,176 @@
+"""get_complexity — AST-counted cyclomatic complexity for every function in a file.
+
+Unlike ``get_symbol_complexity``, which reads the keyword-count estimate
+stored at index time, this re-parses the cached file and walks each
+function's syntax tree, so the numbers follow exact, documented rules.
+
+Counting rules (Go), per function or method declaration:
+
+    base            1
+    if_statement    +1 each; ``else if`` is a nested if and counts once,
+                    a plain ``else`` adds nothing
+    for_statement   +1 each, whatever the form (three-clause, condition
+                    only, ``range``, or bare ``for {}``)
+    case clauses    +1 per ``case`` in ``switch``, type ``switch`` and
+                    ``select``; ``default`` adds nothing
+    && and ||       +1 per operator occurrence (each short-circuit is a
+                    branch)
+
+Function literals (closures) are not indexed as symbols, so their decision
+points count toward the declaration that contains them.  ``goto``,
+``break``/``continue`` labels, and ``defer``/``recover`` add nothing.
+"""
+
+from __future__ import annotations
+
+import time
+from typing import Optional
+
+from ..storage import IndexStore
+from ._utils import resolve_repo, index_status_to_tool_error
+
+# language -> (function node types, {decision node type: bucket}, logical operators)
+_RULES: dict[str, tuple[frozenset[str], dict[str, str], frozenset[str]]] = {
+    "go": (
+        frozenset({"function_declaration", "method_declaration"}),
+        {
+            "if_statement": "if",
+            "for_statement": "for",
+            "expression_case": "case",
+            "type_case": "case",
+            "communication_case": "case",
+        },
+        frozenset({"&&", "||"}),
+    ),
+}
+
+
+def _count_decisions(node, decision_types: dict[str, str], logical_ops: frozenset[str]) -> dict[str, int]:
+    """Decision points under *node*, by bucket (``if``/``for``/``case``/``logical``)."""
+    counts = {"if": 0, "for": 0, "case": 0, "logical": 0}
+    stack = list(node.children)
+    while stack:
+        n = stack.pop()
+        bucket = decision_types.get(n.type)
+        if bucket:
+            counts[bucket] += 1
+        elif n.type == "binary_expression":
+            op = n.child_by_field_name("operator")
+            if op is not None and op.type in logical_ops:
+                counts["logical"] += 1
+        stack.extend(n.children)
+    return counts
+
+
+def _function_nodes(root, function_types: frozenset[str]) -> list:
+    out = []
+    stack = [root]
+    while stack:
+        n = stack.pop()
+        if n.type in function_types:
+            out.append(n)
+            continue  # nested declarations are not legal Go; literals count toward this one
+        stack.extend(n.children)
+    return out
+
+
+def get_complexity(
+    repo: str,
+    file_path: str,
+    threshold: int = 0,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Cyclomatic complexity and line count for each function in *file_path*.
+
+    Results are sorted worst first (complexity descending, then by line).
+    Only functions whose complexity is strictly above *threshold* are
+    returned; ``function_count`` still counts every function in the file.
+
+    Args:
+        repo:         Repository identifier (owner/repo or bare name).
+        file_path:    Relative path of a Go file in the index.
+        threshold:    Return only functions with complexity > threshold (default 0: all).
+        storage_path: Optional index storage path override.
+
+    Returns:
+        ``{repo, file, language, threshold, function_count, returned,
+        functions, _meta}``.  Each function is ``{id, name, kind, line,
+        end_line, lines, complexity, decisions}``; ``decisions`` breaks the
+        count down into ``if``/``for``/``case``/``logical``.
+    """
+    t0 = time.perf_counter()
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+    if not index.has_source_file(file_path):
+        return {"error": f"File not found: {file_path}"}
+
+    language = index.file_languages.get(file_path, "")
+    rules = _RULES.get(language)
+    if rules is None:
+        return {
+            "error": (
+                f"get_complexity does not support {language or 'this'} files yet "
+                f"(supported: {', '.join(sorted(_RULES))}). "
+                "Use get_symbol_complexity for the index-time estimate."
+            )
+        }
+    function_types, decision_types, logical_ops = rules
+
+    content = store.get_file_content(owner, name, file_path, _index=index, raw=True)
+    if content is None:
+        return {"error": f"File content not cached: {file_path}. Re-index to populate it."}
+
+    from tree_sitter_language_pack import get_parser
+    tree = get_parser(language).parse(content.encode("utf-8"))
+
+    symbols = [
+        s for s in index.symbols
+        if s.get("file") == file_path and s.get("kind") in ("function", "method")
+    ]
+
+    functions: list[dict] = []
+    for node in _function_nodes(tree.root_node, function_types):
+        name_node = node.child_by_field_name("name")
+        fn_name = name_node.text.decode("utf-8", errors="replace") if name_node else ""
+        line = node.start_point[0] + 1
+        end_line = node.end_point[0] + 1
+        sym = next(
+            (s for s in symbols
+             if s.get("name") == fn_name and (s.get("line") or 0) <= line <= (s.get("end_line") or 0)),
+            None,
+        )
+        decisions = _count_decisions(node, decision_types, logical_ops)
+        functions.append({
+            "id": sym["id"] if sym else None,
+            "name": fn_name,
+            "kind": "method" if node.type == "method_declaration" else "function",
+            "line": line,
+            "end_line": end_line,
+            "lines": end_line - line + 1,
+            "complexity": 1 + sum(decisions.values()),
+            "decisions": decisions,
+        })
+
+    functions.sort(key=lambda f: (-f["complexity"], f["line"]))
+    returned = [f for f in functions if f["complexity"] > threshold]
+
+    return {
+        "repo": f"{owner}/{name}",
+        "file": file_path,
+        "language": language,
+        "threshold": threshold,
+        "function_count": len(functions),
+        "returned": len(returned),
+        "functions": returned,
+        "_meta": {
+            "timing_ms": round((time.perf_counter() - t0) * 1000, 1),
+            "methodology": "ast",
+        },
+    }
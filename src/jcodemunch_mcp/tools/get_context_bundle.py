@@ -6,12 +6,21 @@ import re
 import time
 from typing import Optional
 
+from .. import body_policy
 from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided as _cost_avoided
 from ..parser.imports import resolve_specifier
 from ._utils import index_status_to_tool_error, resolve_fqn, resolve_repo
 
 _BYTES_PER_TOKEN = 4
 
+# Query mode: candidate pool sizes and ranking constants.
+_QUERY_SYMBOL_POOL = 30
+_QUERY_SNIPPET_POOL = 30
+_QUERY_SNIPPET_RADIUS = 3     # lines of context either side of a text hit
+_QUERY_DEFAULT_BUDGET = 4000
+_RRF_K = 60                   # reciprocal-rank fusion damping
+_QUERY_TERM_RE = re.compile(r"[A-Za-z_][A-Za-z0-9_]{2,}")
+
 
 def _count_tokens(text: str) -> int:
     """Estimate token count. Uses tiktoken (cl100k_base) when available, else len/4."""
@@ -215,6 +224,215 @@ def _to_markdown(repo: str, symbol_entries: list[dict], file_imports_cache: dict
     return "\n".join(lines)
 
 
+def _query_terms(query: str) -> list[str]:
+    """Distinct lower-cased words of 3+ characters, in query order."""
+    return list(dict.fromkeys(t.lower() for t in _QUERY_TERM_RE.findall(query)))
+
+
+def _text_snippets(store, owner: str, name: str, index, terms: list[str]) -> list[dict]:
+    """Line windows around content hits for *terms*, best coverage first.
+
+    Hits in the same file whose windows overlap are merged into one snippet,
+    so a dense region is returned once rather than once per line.
+    """
+    from .search_text import _REGEX_BUDGET_SEC
+
+    if not terms:
+        return []
+    regex = re.compile("|".join(re.escape(t) for t in terms), re.IGNORECASE)
+    deadline = time.perf_counter() + _REGEX_BUDGET_SEC
+    snippets: list[dict] = []
+    for file_path in sorted(index.source_files):
+        if time.perf_counter() > deadline:
+            break
+        content = store.get_file_content(owner, name, file_path, _index=index)
+        if not content:
+            continue
+        lines = content.splitlines()
+        windows: list[list] = []  # [first, last, matched terms, hit lines]
+        for i, line in enumerate(lines):
+            found = {m.group(0).lower() for m in regex.finditer(line)}
+            if not found:
+                continue
+            first = max(0, i - _QUERY_SNIPPET_RADIUS)
+            last = min(len(lines) - 1, i + _QUERY_SNIPPET_RADIUS)
+            if windows and first <= windows[-1][1] + 1:
+                windows[-1][1] = last
+                windows[-1][2] |= found
+                windows[-1][3].append(i + 1)
+            else:
+                windows.append([first, last, found, [i + 1]])
+        for first, last, found, hits in windows:
+            snippets.append({
+                "file": file_path,
+                "line": first + 1,
+                "end_line": last + 1,
+                "hits": hits,
+                "coverage": len(found) / len(terms),
+                "text": "\n".join(lines[first:last + 1]),
+            })
+    snippets.sort(key=lambda s: (-s["coverage"], s["file"], s["line"]))
+    return snippets[:_QUERY_SNIPPET_POOL]
+
+
+def _query_bundle(
+    repo: str,
+    query: str,
+    token_budget: Optional[int],
+    max_bytes: Optional[int],
+    storage_path: Optional[str],
+    start: float,
+) -> dict:
+    """Relevance-ranked bundle for a free-text task query.
+
+    Two channels feed one ranking: fuzzy symbol search (rank order from
+    search_symbols) and a content scan for the query's words.  Scores are
+    reciprocal-rank fused, so a symbol that is also a strong text hit
+    outranks one found by name alone.  Text hits that all fall inside a
+    candidate symbol credit that symbol instead of becoming a snippet.
+
+    Packing is greedy in score order with ties broken by (file, line): an
+    item that does not fit is skipped and smaller ones after it may still
+    be taken, and nothing overlapping an already-packed range in the same
+    file is added twice, so the used totals never exceed either limit.
+    """
+    from .search_symbols import search_symbols
+
+    if token_budget is None and max_bytes is None:
+        token_budget = _QUERY_DEFAULT_BUDGET
+    if (token_budget is not None and token_budget <= 0) or (max_bytes is not None and max_bytes <= 0):
+        return {"error": "token_budget and max_bytes must be positive."}
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    found = search_symbols(
+        repo=f"{owner}/{name}", query=query, max_results=_QUERY_SYMBOL_POOL,
+        detail_level="compact", fuzzy=True, storage_path=storage_path,
+    )
+    if "error" in found:
+        return found
+
+    # candidate key -> item; symbols keyed by id, snippets by (file, line)
+    items: dict = {}
+    for rank, hit in enumerate(found.get("results", [])):
+        sym = index.get_symbol(hit["id"])
+        if not sym or sym["id"] in items:
+            continue
+        items[sym["id"]] = {
+            "type": "symbol",
+            "symbol_id": sym["id"],
+            "name": sym["name"],
+            "kind": sym["kind"],
+            "file": sym["file"],
+            "line": sym["line"],
+            "end_line": sym["end_line"],
+            "channels": ["symbol"],
+            "_score": 1.0 / (_RRF_K + rank),
+        }
+
+    symbols_by_file: dict[str, list[dict]] = {}
+    for item in items.values():
+        symbols_by_file.setdefault(item["file"], []).append(item)
+
+    terms = _query_terms(query)
+    for rank, snip in enumerate(_text_snippets(store, owner, name, index, terms)):
+        weight = snip["coverage"] / (_RRF_K + rank)
+        owner_sym = next(
+            (
+                s for s in sorted(symbols_by_file.get(snip["file"], ()), key=lambda s: s["end_line"] - s["line"])
+                if s["line"] <= snip["hits"][0] and snip["hits"][-1] <= s["end_line"]
+            ),
+            None,
+        )
+        if owner_sym is not None:
+            if "text" not in owner_sym["channels"]:
+                owner_sym["channels"].append("text")
+                owner_sym["_score"] += weight
+            continue
+        items[(snip["file"], snip["line"])] = {
+            "type": "snippet",
+            "file": snip["file"],
+            "line": snip["line"],
+            "end_line": snip["end_line"],
+            "channels": ["text"],
+            "_score": weight,
+            "source": snip["text"],
+        }
+
+    ranked = sorted(
+        items.values(),
+        key=lambda it: (-it["_score"], it["file"], it["line"], it.get("symbol_id", "")),
+    )
+    top = ranked[0]["_score"] if ranked else 1.0
+
+    withhold = body_policy.is_enabled()
+    packed: list[dict] = []
+    taken: dict[str, list[tuple[int, int]]] = {}
+    used_tokens = 0
+    used_bytes = 0
+    skipped_budget = 0
+    skipped_overlap = 0
+    for it in ranked:
+        spans = taken.get(it["file"], [])
+        if any(it["line"] <= hi and lo <= it["end_line"] for lo, hi in spans):
+            skipped_overlap += 1
+            continue
+        if it["type"] == "symbol":
+            it["source"] = store.get_symbol_content(owner, name, it["symbol_id"], _index=index) or ""
+            if withhold and store.body_withheld(owner, name, it["symbol_id"], _index=index):
+                it["body_withheld"] = True
+        cost_tokens = _count_tokens(it["source"])
+        cost_bytes = len(it["source"].encode("utf-8"))
+        if (token_budget is not None and used_tokens + cost_tokens > token_budget) or (
+            max_bytes is not None and used_bytes + cost_bytes > max_bytes
+        ):
+            skipped_budget += 1
+            continue
+        used_tokens += cost_tokens
+        used_bytes += cost_bytes
+        spans.append((it["line"], it["end_line"]))
+        taken[it["file"]] = spans
+        score = it.pop("_score")
+        packed.append({**it, "score": round(score / top, 4), "tokens": cost_tokens, "bytes": cost_bytes})
+
+    raw_bytes = sum(index.file_sizes.get(f, 0) for f in {it["file"] for it in packed})
+    tokens_saved = estimate_savings(raw_bytes, used_bytes)
+    total_saved = record_savings(tokens_saved, tool_name="get_context_bundle")
+    elapsed = (time.perf_counter() - start) * 1000
+
+    return {
+        "repo": f"{owner}/{name}",
+        "query": query,
+        "item_count": len(packed),
+        "items": packed,
+        "budget": {
+            "token_budget": token_budget,
+            "max_bytes": max_bytes,
+            "used_tokens": used_tokens,
+            "used_bytes": used_bytes,
+            "candidates": len(ranked),
+            "included": len(packed),
+            "excluded_by_budget": skipped_budget,
+            "merged_overlaps": skipped_overlap,
+        },
+        "_meta": _make_meta(
+            elapsed,
+            query_terms=terms,
+            tokens_saved=tokens_saved,
+            total_tokens_saved=total_saved,
+            **_cost_avoided(tokens_saved, total_saved),
+        ),
+    }
+
+
 def get_context_bundle(
     repo: str,
     symbol_id: Optional[str] = None,
@@ -226,6 +444,8 @@ def get_context_bundle(
     include_budget_report: bool = False,
     storage_path: Optional[str] = None,
     fqn: Optional[str] = None,
+    query: Optional[str] = None,
+    max_bytes: Optional[int] = None,
 ) -> dict:
     """Get a context bundle: symbol definitions + imports from their files.
 
@@ -250,13 +470,30 @@ def get_context_bundle(
         include_budget_report: When True, include a 'budget_report' field
             showing what was included/excluded.
         storage_path: Custom storage path.
+        query: Free-text task description.  Instead of named symbols, the
+            bundle is built from fuzzy symbol search plus a content scan,
+            ranked by relevance and packed until ``token_budget`` (default
+            4000) or ``max_bytes`` is reached.  Mutually exclusive with
+            symbol_id/symbol_ids/fqn; JSON output only.
+        max_bytes: Byte cap for query mode, applied alongside token_budget.
 
     Returns:
         Single-symbol: legacy flat response (backward-compatible).
         Multi-symbol: ``symbols`` list + ``files`` import map.
+        Query mode: ``items`` (symbols and snippets, best first, each with
+        score, tokens, bytes, source) + a ``budget`` report.
     """
     start = time.perf_counter()
 
+    if query is not None:
+        if symbol_id is not None or symbol_ids is not None or fqn:
+            return {"error": "'query' cannot be combined with 'symbol_id', 'symbol_ids', or 'fqn'."}
+        if not query.strip():
+            return {"error": "'query' must not be empty."}
+        if output_format != "json":
+            return {"error": "Query mode supports output_format 'json' only."}
+        return _query_bundle(repo, query, token_budget, max_bytes, storage_path, start)
+
     if output_format not in ("json", "markdown"):
         return {"error": f"Invalid output_format '{output_format}'. Must be 'json' or 'markdown'."}
 
@@ -320,6 +557,7 @@ def get_context_bundle(
     response_bytes_total = 0
 
     # Build per-symbol entries
+    withhold = body_policy.is_enabled()
     symbol_entries: list[dict] = []
     for sym in resolved:
         source = store.get_symbol_content(owner, name, sym["id"], _index=index)
@@ -338,6 +576,8 @@ def get_context_bundle(
             "source": source or "",
             "imports": imports,
         }
+        if withhold and store.body_withheld(owner, name, sym["id"], _index=index):
+            entry["body_withheld"] = True
 
         if include_callers:
             entry["callers"] = _direct_callers(index, store, owner, name, sym["file"])
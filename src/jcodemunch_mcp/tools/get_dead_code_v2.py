@@ -165,7 +165,7 @@ def _barrel_exports(
         if file_path in visited or depth > MAX_DEPTH:
             return
         visited.add(file_path)
-        content = store.get_file_content(owner, repo_name, file_path)
+        content = store.get_file_content(owner, repo_name, file_path, raw=True)
         if not content:
             return
         # Identifiers literally present in this file (original behavior).
@@ -205,7 +205,7 @@ def _package_json_entries(index, store, owner, repo_name) -> set[str]:
     for f in index.source_files:
         if _filename(f) != "package.json":
             continue
-        content = store.get_file_content(owner, repo_name, f)
+        content = store.get_file_content(owner, repo_name, f, raw=True)
         if not content:
             continue
         try:
@@ -387,7 +387,7 @@ def get_dead_code_v2(
             sym_line = sym.get("line", 0)
             sym_end_line = sym.get("end_line", sym_line)
             if sym_file not in _file_cache:
-                _file_cache[sym_file] = store.get_file_content(owner, name, sym_file) or ""
+                _file_cache[sym_file] = store.get_file_content(owner, name, sym_file, raw=True) or ""
             own_content = _file_cache[sym_file]
             if own_content and sym_line:
                 lines = own_content.splitlines()
@@ -399,7 +399,7 @@ def get_dead_code_v2(
                     continue
             for importer_file in rev.get(sym_file, []):
                 if importer_file not in _file_cache:
-                    _file_cache[importer_file] = store.get_file_content(owner, name, importer_file) or ""
+                    _file_cache[importer_file] = store.get_file_content(owner, name, importer_file, raw=True) or ""
                 content = _file_cache[importer_file]
                 if content and _word_match(content, sym_name):
                     callee_has_caller.add(sym["id"])
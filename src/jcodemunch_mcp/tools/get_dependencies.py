@@ -0,0 +1,217 @@
+"""get_dependencies: what a package or file imports, and who imports it."""
+
+import posixpath
+import sys
+import time
+from typing import Optional
+
+from ..parser.fqn import go_module_import_path
+from ..parser.imports import resolve_specifier
+from ._utils import load_repo_index_or_error
+from .describe_package import _normalize_package, _resolve_directory
+from .package_registry import extract_root_package_from_specifier
+
+_DEFAULT_MAX_RESULTS = 200
+
+_PYTHON_STDLIB = frozenset(sys.stdlib_module_names) | {"__future__"}
+_NODE_BUILTINS = frozenset({
+    "assert", "async_hooks", "buffer", "child_process", "cluster", "console",
+    "constants", "crypto", "dgram", "diagnostics_channel", "dns", "domain",
+    "events", "fs", "http", "http2", "https", "inspector", "module", "net",
+    "os", "path", "perf_hooks", "process", "punycode", "querystring",
+    "readline", "repl", "stream", "string_decoder", "sys", "timers", "tls",
+    "trace_events", "tty", "url", "util", "v8", "vm", "wasi",
+    "worker_threads", "zlib",
+})
+_JS_LANGUAGES = frozenset({"javascript", "typescript", "tsx", "jsx", "vue"})
+_JVM_STDLIB_PREFIXES = ("java.", "javax.", "jdk.", "kotlin.", "kotlinx.", "scala.")
+_RUST_STDLIB = frozenset({"std", "core", "alloc", "proc_macro", "test"})
+_RUST_INTERNAL = frozenset({"crate", "self", "super"})
+
+
+def _is_stdlib(specifier: str, language: str) -> bool:
+    if language == "go":
+        # Standard-library import paths never have a dot in their first element.
+        return "." not in specifier.split("/", 1)[0]
+    if language == "python":
+        return specifier.split(".", 1)[0] in _PYTHON_STDLIB
+    if language in _JS_LANGUAGES:
+        return specifier.startswith("node:") or specifier.split("/", 1)[0] in _NODE_BUILTINS
+    if language in ("java", "kotlin", "scala"):
+        return specifier.startswith(_JVM_STDLIB_PREFIXES)
+    if language == "rust":
+        return specifier.split("::", 1)[0] in _RUST_STDLIB
+    if language == "csharp":
+        return specifier == "System" or specifier.startswith("System.")
+    return False
+
+
+def _is_relative(specifier: str, language: str) -> bool:
+    if language == "rust":
+        return specifier.split("::", 1)[0] in _RUST_INTERNAL
+    return specifier.startswith(".")
+
+
+def _go_package_dirs(index, dirs: set[str]) -> dict[str, str]:
+    """Map Go import path -> index directory, using go.mod under the source root."""
+    source_root = getattr(index, "source_root", "") or ""
+    go_dirs = {posixpath.dirname(f) for f, lang in index.file_languages.items() if lang == "go"}
+    out: dict[str, str] = {}
+    for d in go_dirs & dirs:
+        path = go_module_import_path(source_root, d)
+        if path:
+            out[path] = d
+    return out
+
+
+class _Resolver:
+    """Resolve one file's import specifier to an internal file or directory."""
+
+    def __init__(self, index):
+        self.source_files = frozenset(index.source_files)
+        self.alias_map = index.alias_map
+        self.psr4_map = getattr(index, "psr4_map", None)
+        self.dirs = {posixpath.dirname(f) for f in index.source_files}
+        self.go_packages = _go_package_dirs(index, self.dirs)
+
+    def resolve(self, specifier: str, importer: str, language: str) -> Optional[str]:
+        if language == "go":
+            if specifier in self.go_packages:
+                return self.go_packages[specifier]
+            if not self.go_packages:
+                # No go.mod available (remote index): longest directory
+                # suffix of a non-stdlib import path.
+                if _is_stdlib(specifier, "go"):
+                    return None
+                matches = [d for d in self.dirs if d and specifier.endswith("/" + d)]
+                return max(matches, key=len) if matches else None
+            return None
+        return resolve_specifier(specifier, importer, self.source_files, self.alias_map, self.psr4_map)
+
+
+def get_dependencies(
+    repo: str,
+    package: str,
+    max_results: int = _DEFAULT_MAX_RESULTS,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """List a package's (or file's) imports and its reverse dependencies.
+
+    Imports are grouped as ``stdlib``, ``third_party`` or ``internal``.  An
+    import is internal when it resolves to an indexed file or directory (or
+    is relative); stdlib detection is per language (Go: no dot in the first
+    path element; Python: ``sys.stdlib_module_names``; Node builtins; JVM
+    ``java.``/``kotlin.``; Rust ``std``/``core``; C# ``System``); anything
+    else is third-party.  Imports between files of the same package are
+    left out.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        package: Directory path, Go import path, dotted Python package, or a
+            single file path.
+        max_results: Maximum number of importing files to return.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with target, kind ('package' | 'file'), files, imports
+        ({stdlib, third_party, internal} lists), import_counts,
+        imported_by ([{file, package, import}]), imported_by_packages, and
+        _meta.
+    """
+    start = time.perf_counter()
+    max_results = max(1, max_results)
+
+    index, error, _status = load_repo_index_or_error(repo, storage_path)
+    if error:
+        return error
+    owner, name = index.owner, index.name
+    if index.imports is None:
+        return {"error": "No import data available. Re-index to enable get_dependencies."}
+
+    resolver = _Resolver(index)
+    target = _normalize_package(package)
+    if index.has_source_file(target):
+        kind = "file"
+        files = [target]
+        directory = posixpath.dirname(target)
+    else:
+        directory = _resolve_directory(package, resolver.dirs)
+        if directory is None:
+            return {"error": f"Package or file not found: '{package}'."}
+        kind = "package"
+        files = sorted(f for f in index.source_files if posixpath.dirname(f) == directory)
+    scope = set(files)
+
+    # Go imports resolve to a directory, so a Go file is reached through its package.
+    match_directory = kind == "package" or index.file_languages.get(target) == "go"
+
+    def in_scope(resolved: str) -> bool:
+        return resolved in scope or (match_directory and resolved == directory)
+
+    # --- forward: what the target imports -------------------------------
+    groups: dict[str, dict[str, dict]] = {"stdlib": {}, "third_party": {}, "internal": {}}
+    for f in files:
+        language = index.file_languages.get(f, "")
+        for imp in index.imports.get(f, []):
+            spec = imp.get("specifier", "")
+            if not spec:
+                continue
+            resolved = resolver.resolve(spec, f, language)
+            if resolved is not None and in_scope(resolved):
+                continue
+            if resolved is not None or _is_relative(spec, language):
+                group = "internal"
+            elif _is_stdlib(spec, language):
+                group = "stdlib"
+            else:
+                group = "third_party"
+            entry = groups[group].get(spec)
+            if entry is None:
+                entry = {"import": spec}
+                if group == "internal" and resolved is not None:
+                    entry["target"] = resolved
+                elif group == "third_party":
+                    pkg = extract_root_package_from_specifier(spec, language)
+                    if pkg:
+                        entry["package"] = pkg
+                if imp.get("alias"):
+                    entry["alias"] = imp["alias"]
+                if kind == "package":
+                    entry["files"] = []
+                groups[group][spec] = entry
+            if kind == "package" and f not in entry["files"]:
+                entry["files"].append(f)
+
+    imports = {g: sorted(entries.values(), key=lambda e: e["import"]) for g, entries in groups.items()}
+
+    # --- reverse: who imports the target --------------------------------
+    importers: list[dict] = []
+    for src in sorted(index.imports):
+        if src in scope:
+            continue
+        language = index.file_languages.get(src, "")
+        for imp in index.imports[src]:
+            spec = imp.get("specifier", "")
+            resolved = resolver.resolve(spec, src, language) if spec else None
+            if resolved is not None and in_scope(resolved):
+                importers.append({"file": src, "package": posixpath.dirname(src), "import": spec})
+                break
+
+    result = {
+        "repo": f"{owner}/{name}",
+        "target": target if kind == "file" else directory,
+        "kind": kind,
+        "files": files,
+        "imports": imports,
+        "import_counts": {g: len(entries) for g, entries in imports.items()},
+        "imported_by": importers[:max_results],
+        "imported_by_count": len(importers),
+        "imported_by_packages": sorted({i["package"] for i in importers}),
+    }
+    elapsed = (time.perf_counter() - start) * 1000
+    result["_meta"] = {
+        "timing_ms": round(elapsed, 1),
+        "truncated": len(importers) > max_results,
+        "tip": "Use get_blast_radius on a symbol to see which importers actually reference it.",
+    }
+    return result
@@ -114,7 +114,7 @@ def get_extraction_candidates(
         sym_name = sym.get("name", "")
         caller_files: list[str] = []
         for imp_file in importer_files:
-            content = store.get_file_content(owner, name, imp_file)
+            content = store.get_file_content(owner, name, imp_file, raw=True)
             if content and _word_match(content, sym_name):
                 caller_files.append(imp_file)
 
@@ -7,7 +7,86 @@ from typing import Optional
 
 from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
 from ..parser import Symbol, build_symbol_tree
+from ..parser.build_constraints import is_go_test_file
+from ..parser.symbols import VALID_KINDS
+from ..parser.imports import java_package
+from ..parser.visibility import is_exported, java_visibility, js_exports, python_all_names
 from ._utils import load_repo_index_or_error
+from .search_content import _path_filter
+
+# Shorthands accepted in ``kinds`` alongside the canonical VALID_KINDS names.
+_KIND_ALIASES = {"func": "function", "fn": "function", "const": "constant"}
+
+# Cap on files a glob or directory entry may expand to in one call.
+_MAX_EXPANDED_FILES = 500
+
+
+def _is_pattern(path: str) -> bool:
+    return any(ch in path for ch in "*?[")
+
+
+def _is_directory(index, path: str) -> bool:
+    """True when *path* is not an indexed file but has indexed files under it."""
+    path = path.strip().removeprefix("./").rstrip("/")
+    if not path or index.has_source_file(path):
+        return False
+    return any(f.startswith(path + "/") for f in index.source_files)
+
+
+def _expand_file_paths(
+    index, entries: list[str], include_tests: bool = True,
+) -> tuple[list[str], list[str], bool]:
+    """Expand globs and directories in *entries* to indexed files.
+
+    Returns (files in first-seen order without duplicates, entries that
+    matched nothing, whether the expansion hit ``_MAX_EXPANDED_FILES``).
+    A plain path that is neither an indexed file nor a directory of indexed
+    files is kept as-is, so it still gets an (empty) result entry.  Without
+    *include_tests*, globs and directories skip test-only files; a test file
+    named outright is kept.
+    """
+    all_files = sorted(index.source_files)
+    files: list[str] = []
+    seen: set[str] = set()
+    unmatched: list[str] = []
+    truncated = False
+    for entry in entries:
+        path = entry.strip()
+        if path.startswith("./"):
+            path = path[2:]
+        if not _is_pattern(path) and (index.has_source_file(path) or not path):
+            matches = [path]
+        else:
+            keep = _path_filter([path])
+            matches = [
+                f for f in all_files
+                if keep(f) and (include_tests or not is_go_test_file(f))
+            ] if keep else []
+            if not matches:
+                if _is_pattern(path):
+                    unmatched.append(entry)
+                    continue
+                matches = [path]
+        for f in matches:
+            if f in seen:
+                continue
+            if len(files) >= _MAX_EXPANDED_FILES:
+                truncated = True
+                break
+            seen.add(f)
+            files.append(f)
+    return files, unmatched, truncated
+
+
+def _normalize_kinds(kinds: Optional[list[str]]) -> tuple[Optional[frozenset[str]], Optional[str]]:
+    """Return (kind set or None for no filter, error message or None)."""
+    if not kinds:
+        return None, None
+    wanted = {_KIND_ALIASES.get(k.lower(), k.lower()) for k in kinds}
+    unknown = sorted(wanted - VALID_KINDS)
+    if unknown:
+        return None, f"Unknown kinds: {unknown}. Valid kinds: {sorted(VALID_KINDS)}."
+    return frozenset(wanted), None
 
 
 def _get_file_outline_single(
@@ -17,6 +96,12 @@ def _get_file_outline_single(
     name: str,
     store: IndexStore,
     start: float,
+    kinds: Optional[frozenset[str]] = None,
+    exported_only: bool = False,
+    max_results: Optional[int] = None,
+    offset: int = 0,
+    group_methods: bool = False,
+    include_tests: bool = True,
 ) -> dict:
     """Core logic for a single file_path query. Returns the original flat shape."""
     if not index.has_source_file(file_path):
@@ -64,8 +149,42 @@ def _get_file_outline_single(
     # DFS-flatten the symbol tree into a list with parent ids; class members
     # follow their class in order.
     symbol_objects = [_dict_to_symbol(s) for s in file_symbols]
-    tree = build_symbol_tree(symbol_objects)
-    symbols_output = _flatten_tree_with_parents(tree)
+    tree = build_symbol_tree(symbol_objects, group_methods=group_methods)
+    module_all = None
+    module_exports = None
+    package = ""
+    if language in ("python", "javascript", "typescript", "tsx", "java"):
+        content = store.get_file_content(owner, name, file_path, _index=index, raw=True)
+        if content and language == "python":
+            module_all = python_all_names(content)
+        elif content and language == "java":
+            package = java_package(content)
+        elif content:
+            module_exports = js_exports(content)
+    symbols_output = _flatten_tree_with_parents(
+        tree, language=language, module_all=module_all, module_exports=module_exports,
+    )
+    # Filter after classification (children inherit visibility from their
+    # parent) but before serialisation, so the response itself shrinks.
+    filtered_out = 0
+    if kinds is not None or exported_only or not include_tests:
+        kept = [
+            d for d in symbols_output
+            if (kinds is None or d["kind"] in kinds)
+            and not (exported_only and d.get("exported") is False)
+            and (include_tests or not d.get("is_test"))
+        ]
+        filtered_out = len(symbols_output) - len(kept)
+        symbols_output = kept
+
+    # Page over the filtered DFS order. A page may begin with members whose
+    # parent is on an earlier page; ``parent`` still names it.
+    paging: dict = {}
+    if max_results is not None or offset:
+        total_count = len(symbols_output)
+        end = total_count if max_results is None else offset + max_results
+        symbols_output = symbols_output[offset:end]
+        paging = {"total_count": total_count, "has_more": end < total_count}
 
     elapsed = (time.perf_counter() - start) * 1000
     response_bytes = len(json.dumps(symbols_output).encode("utf-8"))
@@ -77,10 +196,13 @@ def _get_file_outline_single(
         "file": file_path,
         "language": language,
         "file_summary": file_summary,
+        **({"package": package} if package else {}),
         "symbols": symbols_output,
+        **paging,
         "_meta": {
             "timing_ms": round(elapsed, 1),
             "symbol_count": len(symbols_output),
+            **({"filtered_out": filtered_out} if filtered_out else {}),
             "tokens_saved": tokens_saved,
             "total_tokens_saved": total_saved,
             **cost_avoided(tokens_saved, total_saved),
@@ -96,12 +218,23 @@ def _get_file_outline_batch(
     name: str,
     store: IndexStore,
     start: float,
+    kinds: Optional[frozenset[str]] = None,
+    exported_only: bool = False,
+    max_results: Optional[int] = None,
+    offset: int = 0,
+    group_methods: bool = False,
+    include_tests: bool = True,
 ) -> dict:
     """Batch logic: loop over file_paths, return grouped results array."""
     results = []
 
     for file_path in file_paths:
-        result = _get_file_outline_single(file_path, index, owner, name, store, start)
+        result = _get_file_outline_single(
+            file_path, index, owner, name, store, start,
+            kinds=kinds, exported_only=exported_only,
+            max_results=max_results, offset=offset, group_methods=group_methods,
+            include_tests=include_tests,
+        )
         # Strip tip from batch results to keep them clean
         if "_meta" in result and "tip" in result["_meta"]:
             del result["_meta"]["tip"]
@@ -120,6 +253,12 @@ def get_file_outline(
     file_path: Optional[str] = None,
     storage_path: Optional[str] = None,
     file_paths: Optional[list[str]] = None,
+    kinds: Optional[list[str]] = None,
+    exported_only: bool = False,
+    max_results: Optional[int] = None,
+    offset: int = 0,
+    group_methods: bool = False,
+    include_tests: bool = True,
 ) -> dict:
     """Get all symbols in a file as a flat list with ``parent`` ids.
 
@@ -131,15 +270,38 @@ def get_file_outline(
     - Singular: pass ``file_path`` for one file's outline.
     - Batch: pass ``file_paths`` (list) to return a grouped ``results`` array.
 
+    Any path may be a glob (``internal/**/*.go``; ``**/`` also matches the
+    root) or a directory, which walks its subdirectories.  Either expands to
+    the indexed files it covers, in path order, and answers in batch shape;
+    a file covered by several entries appears once.  Each result carries its
+    ``file``.
+
     Args:
         repo: Repository identifier (owner/repo or just repo name)
-        file_path: Path to file within repository (singular mode)
+        file_path: Path to file within repository (singular mode), or a
+            glob or directory
         storage_path: Custom storage path
-        file_paths: List of file paths (batch mode)
+        file_paths: List of file paths, globs, or directories (batch mode)
+        kinds: Only return symbols of these kinds (``func``/``fn``/``const``
+            accepted as shorthands). None or empty returns every kind.
+        exported_only: Drop symbols classified ``exported=False``. Symbols in
+            languages without a visibility rule are kept.
+        max_results: Return at most this many symbols per file (after
+            filtering). None returns them all.
+        offset: Skip this many symbols per file, for paging. When either
+            paging argument is given the response adds ``total_count`` and
+            ``has_more``.
+        group_methods: Nest Go methods under their receiver type (``parent``
+            is the type's id) when the type is declared in the same file.
+            Methods carry ``receiver_type`` and ``pointer_receiver`` either way.
+        include_tests: False drops symbols from test-only files (Go
+            ``*_test.go``), and globs and directories skip those files.
 
     Returns:
         Singular mode: dict with file, language, file_summary, symbols, _meta.
-        Batch mode: dict with ``results`` array (one entry per input file_path).
+        Batch mode: dict with ``results`` array (one entry per file), and
+        ``_meta.file_count``; ``_meta.unmatched`` lists globs that matched no
+        file and ``_meta.truncated`` flags an expansion cut at 500 files.
 
     Raises:
         ValueError: if neither or both of file_path and file_paths are provided.
@@ -150,6 +312,14 @@ def get_file_outline(
     if (file_path is None and file_paths is None) or (file_path is not None and file_paths is not None):
         raise ValueError("Provide exactly one of 'file_path' or 'file_paths', not both and not neither.")
 
+    kind_filter, kind_error = _normalize_kinds(kinds)
+    if kind_error:
+        return {"error": kind_error}
+
+    if max_results is not None:
+        max_results = max(1, max_results)
+    offset = max(0, offset)
+
     start = time.perf_counter()
 
     # Load index ONCE for both modes
@@ -159,10 +329,31 @@ def get_file_outline(
     owner, name = index.owner, index.name
     store = IndexStore(base_path=storage_path)
 
-    if file_paths is not None:
-        return _get_file_outline_batch(file_paths, index, owner, name, store, start)
+    # A glob or directory in either mode expands to the indexed files it
+    # covers and answers in batch shape.
+    if file_paths is not None or _is_pattern(file_path) or _is_directory(index, file_path):
+        files, unmatched, truncated = _expand_file_paths(
+            index, file_paths if file_paths is not None else [file_path], include_tests,
+        )
+        result = _get_file_outline_batch(
+            files, index, owner, name, store, start,
+            kinds=kind_filter, exported_only=exported_only,
+            max_results=max_results, offset=offset, group_methods=group_methods,
+            include_tests=include_tests,
+        )
+        result["_meta"]["file_count"] = len(files)
+        if unmatched:
+            result["_meta"]["unmatched"] = unmatched
+        if truncated:
+            result["_meta"]["truncated"] = True
+        return result
     else:
-        return _get_file_outline_single(file_path, index, owner, name, store, start)
+        return _get_file_outline_single(
+            file_path, index, owner, name, store, start,
+            kinds=kind_filter, exported_only=exported_only,
+            max_results=max_results, offset=offset, group_methods=group_methods,
+            include_tests=include_tests,
+        )
 
 
 def _dict_to_symbol(d: dict) -> Symbol:
@@ -185,11 +376,38 @@ def _dict_to_symbol(d: dict) -> Symbol:
         byte_offset=d["byte_offset"],
         byte_length=d["byte_length"],
         content_hash=d.get("content_hash", ""),
+        fields=d.get("fields", []),
+        build_tags=d.get("build_tags", ""),
+        is_test=d.get("is_test", False),
+        receiver_type=d.get("receiver_type", ""),
+        pointer_receiver=d.get("pointer_receiver", False),
+        is_declaration=d.get("is_declaration", False),
+        fqn=d.get("fqn", ""),
+        params=d.get("params", []),
+        returns=d.get("returns", []),
+        type_params=d.get("type_params", []),
     )
 
 
-def _flatten_tree_with_parents(nodes, parent_id=None) -> list[dict]:
-    """DFS-flatten a SymbolNode tree into dicts; each carries its parent's id (None for roots)."""
+def _flatten_tree_with_parents(
+    nodes,
+    parent_id=None,
+    language: str = "",
+    module_all=None,
+    parent_exported=None,
+    module_exports=None,
+    parent_signature: str = "",
+) -> list[dict]:
+    """DFS-flatten a SymbolNode tree into dicts; each carries its parent's id (None for roots).
+
+    ``exported`` is added for languages with a visibility rule (see
+    ``parser.visibility``) and omitted otherwise.  ``module_exports`` is the
+    ``js_exports`` map for JS/TS files; a top-level symbol exported under
+    other names (``export { foo as bar }``, ``export default Foo``) also
+    gets ``exported_as``.  Java symbols also carry ``visibility``, the access
+    modifier (``package`` when none is written).
+    """
+    export_names = frozenset(module_exports) if module_exports is not None else None
     out: list[dict] = []
     for node in nodes:
         sym = node.symbol
@@ -203,9 +421,51 @@ def _flatten_tree_with_parents(nodes, parent_id=None) -> list[dict]:
             "end_line": sym.end_line,
             "parent": parent_id,
         }
+        exported = is_exported(
+            sym.name, language,
+            nested=parent_id is not None,
+            module_all=module_all,
+            parent_exported=parent_exported,
+            module_exports=export_names,
+            signature=sym.signature,
+            parent_signature=parent_signature,
+        )
+        if exported is not None:
+            d["exported"] = exported
+        if language == "java":
+            d["visibility"] = java_visibility(sym.signature, parent_signature)
+        if exported and parent_id is None and module_exports:
+            public = module_exports.get(sym.name, ())
+            if public and public != (sym.name,):
+                d["exported_as"] = ",".join(public)
         if sym.decorators:
             d["decorators"] = sym.decorators
+        if sym.fields:
+            d["fields"] = sym.fields
+        if sym.build_tags:
+            d["build_tags"] = sym.build_tags
+        if sym.is_test:
+            d["is_test"] = True
+        if sym.receiver_type:
+            d["receiver_type"] = sym.receiver_type
+            d["pointer_receiver"] = sym.pointer_receiver
+        if sym.is_declaration:
+            d["is_declaration"] = True
+        if sym.fqn:
+            d["fqn"] = sym.fqn
+        if sym.params:
+            d["params"] = sym.params
+        if sym.returns:
+            d["returns"] = sym.returns
+        if sym.type_params:
+            d["type_params"] = sym.type_params
         out.append(d)
         if node.children:
-            out.extend(_flatten_tree_with_parents(node.children, parent_id=sym.id))
+            # A Go method grouped under an unexported type is still callable
+            # through interfaces, so its own name decides.
+            out.extend(_flatten_tree_with_parents(
+                node.children, parent_id=sym.id, language=language,
+                module_all=module_all, parent_exported=exported if language != "go" else None,
+                module_exports=module_exports, parent_signature=sym.signature,
+            ))
     return out
@@ -0,0 +1,103 @@
+"""get_parse_errors: syntax diagnostics for indexed files."""
+
+import time
+from collections import Counter
+from typing import Optional
+
+from ..parser.diagnostics import parse_diagnostics
+from ..storage import IndexStore
+from ._utils import load_repo_index_or_error
+
+_DEFAULT_MAX_RESULTS = 100
+_PER_FILE_LIMIT = 20
+
+
+def get_parse_errors(
+    repo: str,
+    file_path: Optional[str] = None,
+    path_prefix: str = "",
+    max_results: int = _DEFAULT_MAX_RESULTS,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Report syntax errors tree-sitter recovered from in indexed files.
+
+    Re-parses the cached content of each file in scope, so results reflect
+    exactly what was indexed.  Symbols outside the damaged region are still
+    in the index — ``symbol_count`` shows how much of each file survived.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        file_path: Check only this file.
+        path_prefix: Check only files under this prefix (ignored with file_path).
+        max_results: Maximum number of files with errors to return.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with files ([{file, language, symbol_count, error_count,
+        diagnostics}]), file_count, error_count, files_checked,
+        files_unchecked, and _meta.  Each diagnostic has line, column,
+        end_line, end_column (1-based), kind ('error' | 'missing'), message,
+        and optionally context (the enclosing node type).
+    """
+    start = time.perf_counter()
+    max_results = max(1, max_results)
+
+    index, error, _status = load_repo_index_or_error(repo, storage_path)
+    if error:
+        return error
+    owner, name = index.owner, index.name
+    store = IndexStore(base_path=storage_path)
+
+    if file_path:
+        if not index.has_source_file(file_path):
+            return {"error": f"File not found: {file_path}"}
+        files = [file_path]
+    else:
+        prefix = path_prefix.replace("\\", "/").lstrip("/")
+        files = sorted(f for f in index.source_files if f.startswith(prefix))
+
+    symbol_counts = Counter(s.get("file") for s in index.symbols)
+    results: list[dict] = []
+    error_total = 0
+    files_with_errors = 0
+    checked = 0
+    unchecked = 0
+    for f in files:
+        language = index.file_languages.get(f, "")
+        content = store.get_file_content(owner, name, f, _index=index)
+        diagnostics = parse_diagnostics(content, language, limit=_PER_FILE_LIMIT) if content is not None else None
+        if diagnostics is None:
+            unchecked += 1
+            continue
+        checked += 1
+        if not diagnostics:
+            continue
+        files_with_errors += 1
+        error_total += len(diagnostics)
+        if len(results) < max_results:
+            results.append({
+                "file": f,
+                "language": language,
+                "symbol_count": symbol_counts.get(f, 0),
+                "error_count": len(diagnostics),
+                "diagnostics": diagnostics,
+            })
+
+    elapsed = (time.perf_counter() - start) * 1000
+    return {
+        "repo": f"{owner}/{name}",
+        "files": results,
+        "file_count": files_with_errors,
+        "error_count": error_total,
+        "files_checked": checked,
+        "files_unchecked": unchecked,
+        "_meta": {
+            "timing_ms": round(elapsed, 1),
+            "truncated": files_with_errors > len(results),
+            "tip": (
+                "Diagnostics come from tree-sitter error recovery; at most "
+                f"{_PER_FILE_LIMIT} per file. Use get_file_content with "
+                "start_line/end_line to inspect a reported range."
+            ),
+        },
+    }
@@ -9,7 +9,7 @@ from ._utils import index_status_to_tool_error, resolve_repo
 from .get_context_bundle import _count_tokens
 from .search_symbols import (
     _tokenize,
-    _compute_bm25,
+    _ensure_bm25_corpus,
     _bm25_score,
     _NEGATIVE_EVIDENCE_THRESHOLD,
     BYTES_PER_TOKEN,
@@ -133,11 +133,7 @@ def get_ranked_context(
     query_terms = _tokenize(query) or [query.lower()]
     # Guard: empty string in query_terms causes "" to match every filename
     query_terms = [t for t in query_terms if t]
-    cache = index._bm25_cache
-    if "idf" not in cache:
-        from .search_symbols import _compute_centrality  # noqa: PLC0415
-        cache["idf"], cache["avgdl"], cache["inverted"] = _compute_bm25(index.symbols)
-        cache["centrality"] = _compute_centrality(index.symbols, index.imports, index.alias_map, getattr(index, "psr4_map", None))
+    cache = _ensure_bm25_corpus(index)
     idf = cache["idf"]
     avgdl = cache["avgdl"]
     inverted = cache["inverted"]
@@ -5,10 +5,98 @@ import os
 import time
 from typing import Optional
 
+from .. import body_policy
+from ..parser.docstring import parse_docstring
+from ..parser.visibility import java_visibility
 from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided as _cost_avoided
 from ._utils import index_status_to_tool_error, resolve_repo, resolve_fqn
 
 
+# Line-comment markers per language family for doc-comment capture.  ``#``
+# is only a comment where it isn't a preprocessor / attribute prefix.
+_HASH_COMMENT_LANGUAGES = frozenset({
+    "python", "ruby", "bash", "perl", "r", "elixir", "powershell", "gdscript",
+    "nix", "toml", "yaml", "julia", "crystal", "tcl", "makefile", "cmake",
+})
+_DASH_COMMENT_LANGUAGES = frozenset({"sql", "lua", "haskell", "elm", "ada"})
+
+
+def _is_doc_line(stripped: str, language: str) -> bool:
+    if stripped.startswith(("//", "/*", "*")):
+        return True
+    if language in _HASH_COMMENT_LANGUAGES and stripped.startswith("#"):
+        return True
+    if language in _DASH_COMMENT_LANGUAGES and stripped.startswith("--"):
+        return True
+    # Rust attributes / C# attributes sit between the doc comment and the item.
+    if language in ("rust", "csharp") and stripped.startswith(("#[", "[")):
+        return True
+    return False
+
+
+def _leading_doc_start(lines: list[str], start_idx: int, language: str) -> int:
+    """Index of the first line of the comment block directly above ``start_idx``.
+
+    Walks upward over contiguous comment lines (a blank line ends the block,
+    matching Go's doc-comment rule).  Returns ``start_idx`` when there is none.
+    """
+    i = start_idx
+    in_block = False
+    while i > 0:
+        stripped = lines[i - 1].strip()
+        if in_block:
+            i -= 1
+            if stripped.startswith("/*"):
+                in_block = False
+            continue
+        if stripped.endswith("*/") and not stripped.startswith("/*"):
+            in_block = True
+            i -= 1
+            continue
+        if not stripped or not _is_doc_line(stripped, language):
+            break
+        i -= 1
+    return i
+
+
+def _find_symbols_by_name(index, symbol_name: str, file_path: Optional[str]) -> list[dict]:
+    """All non-import definitions whose name, qualified name, or fqn equals ``symbol_name``."""
+    fp = (file_path or "").replace("\\", "/").strip("/")
+    matches = []
+    for sym in index.symbols:
+        if sym.get("kind") == "import":
+            continue
+        if symbol_name not in (sym.get("name"), sym.get("qualified_name"), sym.get("fqn")):
+            continue
+        if fp and not (sym["file"] == fp or sym["file"].endswith("/" + fp)):
+            continue
+        matches.append(sym)
+    matches.sort(key=lambda s: (s["file"], s.get("line", 0)))
+    return matches
+
+
+def _declaration_links(index, symbol: dict) -> tuple[str, list[dict]]:
+    """C/C++: the definitions of a prototype, or the prototypes of a definition.
+
+    Matched by qualified name and kind across the whole index, so a header
+    declaration finds its body in the ``.cpp`` file.  Overloads share a
+    qualified name and are all listed.
+    """
+    if symbol.get("language") not in ("c", "cpp", "arduino") or symbol.get("kind") not in ("function", "method"):
+        return "", []
+    want_declaration = not symbol.get("is_declaration")
+    links = [
+        {"id": s["id"], "file": s["file"], "line": s["line"]}
+        for s in index.symbols
+        if s.get("qualified_name") == symbol.get("qualified_name")
+        and s.get("kind") == symbol["kind"]
+        and s["id"] != symbol["id"]
+        and bool(s.get("is_declaration")) == want_declaration
+        and s.get("language") in ("c", "cpp", "arduino")
+    ]
+    return ("declarations" if want_declaration else "definitions"), links
+
+
 def _make_meta(timing_ms: float, **kwargs) -> dict:
     """Build a _meta envelope dict."""
     meta = {"timing_ms": round(timing_ms, 1)}
@@ -24,15 +112,27 @@ def get_symbol_source(
     context_lines: int = 0,
     storage_path: Optional[str] = None,
     fqn: Optional[str] = None,
+    symbol_name: Optional[str] = None,
+    file_path: Optional[str] = None,
+    include_doc: bool = False,
 ) -> dict:
     """Get full source of one or more symbols by ID.
 
     Pass symbol_id (string) for one symbol — returns flat symbol object.
     Pass symbol_ids (array) for batch — returns {symbols, errors}.
     Both modes support verify and context_lines.
-    Pass fqn (PHP FQN like 'App\\Models\\User') to resolve via PSR-4.
+    Pass fqn — a stored qualified ID ('example.com/app/store.Config') or a
+    PHP FQN like 'App\\Models\\User', resolved via PSR-4 — instead of an ID.
+    Pass symbol_name (plain or qualified name, optionally narrowed by
+    file_path) to look up by name — always returns {symbols, errors} with
+    every matching definition, so duplicates across files all come back.
+    include_doc prepends the verbatim comment block directly above each
+    declaration to ``source`` and reports its first line as ``doc_line``.
+    Under the withhold policy an unexported function's ``source`` is its
+    signature (and Python docstring) with a marker line for the body, and
+    the entry carries ``body_withheld: true``.
     """
-    # FQN resolution: translate PHP FQN → symbol_id
+    # FQN resolution: translate qualified ID / PHP FQN → symbol_id
     if fqn and symbol_id is None and symbol_ids is None:
         resolved, fqn_error = resolve_fqn(repo, fqn, storage_path)
         if resolved is None:
@@ -42,13 +142,14 @@ def get_symbol_source(
     # Normalize: some MCP clients send symbol_ids=[] alongside symbol_id when they mean singular mode
     if symbol_id is not None and symbol_ids is not None and len(symbol_ids) == 0:
         symbol_ids = None
-    if symbol_id is None and symbol_ids is None:
-        return {"error": "Provide symbol_id (string), symbol_ids (array), or fqn (PHP FQN)."}
+    by_name = bool(symbol_name) and symbol_id is None and symbol_ids is None
+    if symbol_id is None and symbol_ids is None and not by_name:
+        return {"error": "Provide symbol_id (string), symbol_ids (array), name, or fqn (qualified ID or PHP FQN)."}
     if symbol_id is not None and symbol_ids is not None:
         return {"error": "Provide symbol_id or symbol_ids, not both."}
 
-    batch_mode = symbol_ids is not None
-    ids = symbol_ids if batch_mode else [symbol_id]
+    batch_mode = symbol_ids is not None or by_name
+    ids = symbol_ids if symbol_ids is not None else [symbol_id]
 
     start = time.perf_counter()
     context_lines = max(0, min(context_lines, 50))
@@ -64,11 +165,19 @@ def get_symbol_source(
     if not index:
         return index_status_to_tool_error(store.inspect_index(owner, name))
 
+    if by_name:
+        matches = _find_symbols_by_name(index, symbol_name, file_path)
+        if not matches:
+            where = f" in '{file_path}'" if file_path else ""
+            return {"error": f"Symbol not found: '{symbol_name}'{where}. Try search_symbols first."}
+        ids = [m["id"] for m in matches]
+
     symbols_out = []
     errors_out = []
     seen_files: set = set()
     raw_bytes = 0
     response_bytes = 0
+    withhold = body_policy.is_enabled()
 
     for sid in ids:
         symbol = index.get_symbol(sid)
@@ -77,17 +186,29 @@ def get_symbol_source(
             errors_out.append({"id": sid, "error": f"Symbol not found: {sid}"})
             continue
 
-        source = store.get_symbol_content(owner, name, sid, _index=index)
+        source = store.get_symbol_content(owner, name, sid, _index=index, raw=True)
+        withheld = bool(source) and withhold and store.body_withheld(owner, name, sid, _index=index)
         content_dir = store._content_dir(owner, name)
         file_full_path = content_dir / symbol["file"]
 
         context_before = ""
         context_after = ""
-        if context_lines > 0 and source and file_full_path.exists():
+        doc_text = ""
+        doc_line = 0
+        if (context_lines > 0 or include_doc) and source and file_full_path.exists():
             try:
-                all_lines = file_full_path.read_text(encoding="utf-8", errors="replace").split("\n")
+                file_text = file_full_path.read_text(encoding="utf-8", errors="replace")
+                if withhold:
+                    file_text = body_policy.withhold_file(file_text, index, symbol["file"])
+                all_lines = file_text.split("\n")
                 s_line = symbol["line"] - 1  # 0-indexed
                 e_line = symbol["end_line"]   # exclusive
+                if include_doc:
+                    doc_start = _leading_doc_start(all_lines, s_line, symbol.get("language", ""))
+                    if doc_start < s_line:
+                        doc_text = "\n".join(all_lines[doc_start:s_line]) + "\n"
+                        doc_line = doc_start + 1
+                        s_line = doc_start  # context_before sits above the doc block
                 before_start = max(0, s_line - context_lines)
                 after_end = min(len(all_lines), e_line + context_lines)
                 if before_start < s_line:
@@ -108,8 +229,50 @@ def get_symbol_source(
             "decorators": symbol.get("decorators", []),
             "docstring": symbol.get("docstring", ""),
             "content_hash": symbol.get("content_hash", ""),
-            "source": source or "",
+            "source": doc_text + (body_policy.withhold_source(source, symbol) if withheld else source or ""),
         }
+        if withheld:
+            entry["body_withheld"] = True
+        if doc_line:
+            entry["doc_line"] = doc_line
+        if symbol.get("fields"):
+            entry["fields"] = symbol["fields"]
+        if symbol.get("build_tags"):
+            entry["build_tags"] = symbol["build_tags"]
+        if symbol.get("is_test"):
+            entry["is_test"] = True
+        if symbol.get("receiver_type"):
+            entry["receiver_type"] = symbol["receiver_type"]
+            entry["pointer_receiver"] = bool(symbol.get("pointer_receiver"))
+        if symbol.get("is_declaration"):
+            entry["is_declaration"] = True
+        if symbol.get("fqn"):
+            entry["fqn"] = symbol["fqn"]
+        if symbol.get("params"):
+            entry["params"] = symbol["params"]
+        if symbol.get("returns"):
+            entry["returns"] = symbol["returns"]
+        if symbol.get("type_params"):
+            entry["type_params"] = symbol["type_params"]
+        link_key, links = _declaration_links(index, symbol)
+        if links:
+            entry[link_key] = links
+            # Headers carry the doc comment; an undocumented body borrows it.
+            if link_key == "declarations" and not entry["docstring"]:
+                for link in links:
+                    decl_doc = (index.get_symbol(link["id"]) or {}).get("docstring", "")
+                    if decl_doc:
+                        entry["docstring"] = decl_doc
+                        entry["docstring_from"] = link["id"]
+                        break
+        if symbol.get("language") == "java":
+            parent = index.get_symbol(symbol["parent"]) if symbol.get("parent") else None
+            entry["visibility"] = java_visibility(
+                symbol["signature"], parent.get("signature", "") if parent else "",
+            )
+        doc = parse_docstring(entry["docstring"], entry["decorators"])
+        if doc["summary"] or doc["examples"] or doc["deprecated"] is not None:
+            entry["doc"] = doc
         if context_before:
             entry["context_before"] = context_before
         if context_after:
@@ -130,7 +293,7 @@ def get_symbol_source(
                 raw_bytes += os.path.getsize(file_full_path)
             except OSError:
                 pass
-        response_bytes += symbol.get("byte_length", 0)
+        response_bytes += symbol.get("byte_length", 0) + len(doc_text.encode("utf-8"))
 
     tokens_saved = estimate_savings(raw_bytes, response_bytes)
     total_saved = record_savings(tokens_saved, tool_name="get_symbol_source")
@@ -0,0 +1,232 @@
+"""get_test_coverage_map — which Go tests exercise which functions of a package.
+
+Each ``Test``/``Benchmark``/``Fuzz``/``Example`` function in the package's
+``_test.go`` files is walked through the call graph (``bfs_callees``: AST
+``call_references``, with the text heuristic as fallback), so calls made
+through test helpers count too.  Every production function and method of
+the package is then listed with the tests that reach it; ``depth`` 1 means
+the test calls it directly.  Symbols no test reaches are flagged
+``covered: false``.
+
+This is heuristic reachability, like ``get_untested_symbols``.  With
+``run_go_test`` the package's tests are actually run
+(``go test -coverprofile`` + ``go tool cover -func``) in the indexed
+folder and each symbol also gets its statement ``coverage_pct``.  That
+executes the repository's test code, so it is opt-in and needs a locally
+indexed folder with ``go`` on PATH.
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+import posixpath
+import re
+import shutil
+import subprocess
+import tempfile
+import time
+from typing import Optional
+
+from ..parser.build_constraints import is_go_test_file
+from ..parser.fqn import fqn_package
+from ..storage import IndexStore
+from ._call_graph import bfs_callees, build_symbols_by_file
+from ._utils import index_status_to_tool_error, resolve_repo
+
+logger = logging.getLogger(__name__)
+
+_DEFAULT_DEPTH = 2
+_MAX_DEPTH = 5
+_GO_TEST_TIMEOUT = 300
+_TEST_FUNC_RE = re.compile(r"^(Test|Benchmark|Fuzz|Example)($|[^a-z])")
+_COVER_FUNC_RE = re.compile(r"^(?P<file>.+\.go):(?P<line>\d+):\s+(?P<name>\S+)\s+(?P<pct>[\d.]+)%$")
+_COVER_TOTAL_RE = re.compile(r"^total:\s+\(statements\)\s+(?P<pct>[\d.]+)%$")
+
+
+def _package_dir(index, package: str) -> Optional[str]:
+    """Repo-relative directory for *package*: a directory or a Go import path."""
+    wanted = package.strip().strip("/")
+    if wanted in ("", "."):
+        wanted = ""
+    go_dirs = {posixpath.dirname(f) for f in index.source_files if f.endswith(".go")}
+    if wanted in go_dirs:
+        return wanted
+    for sym in index.symbols:
+        if sym.get("language") == "go" and fqn_package(sym) == wanted:
+            return posixpath.dirname(sym["file"])
+    return None
+
+
+def _run_go_coverage(source_root: str, package_dir: str) -> dict:
+    """``{"functions": {(file_basename, line): pct}, "total_pct": ...}`` or ``{"error": ...}``."""
+    go = shutil.which("go")
+    if not go:
+        return {"error": "go not found on PATH"}
+    fd, profile = tempfile.mkstemp(prefix="jcm-cover-", suffix=".out")
+    os.close(fd)
+    result: dict = {}
+    try:
+        target = "./" + package_dir if package_dir else "."
+        try:
+            r = subprocess.run(
+                [go, "test", f"-coverprofile={profile}", target],
+                cwd=source_root, capture_output=True, timeout=_GO_TEST_TIMEOUT,
+            )
+        except subprocess.TimeoutExpired:
+            return {"error": f"go test timed out after {_GO_TEST_TIMEOUT}s"}
+        if r.returncode != 0:
+            # A failing test still writes the profile; keep its numbers.
+            out = (r.stdout + r.stderr).decode("utf-8", errors="replace").strip().splitlines()
+            result["tests_failed"] = True
+            result["output_tail"] = out[-5:]
+        if not os.path.getsize(profile):
+            result.setdefault("error", "go test wrote no coverage profile")
+            return result
+        r = subprocess.run(
+            [go, "tool", "cover", f"-func={profile}"],
+            cwd=source_root, capture_output=True, timeout=_GO_TEST_TIMEOUT,
+        )
+        if r.returncode != 0:
+            return {"error": r.stderr.decode("utf-8", errors="replace").strip() or "go tool cover failed"}
+    except Exception as exc:
+        logger.debug("go coverage subprocess error: %s", exc, exc_info=True)
+        return {"error": str(exc)}
+    finally:
+        try:
+            os.unlink(profile)
+        except OSError:
+            pass
+
+    functions: dict[tuple[str, int], float] = {}
+    for line in r.stdout.decode("utf-8", errors="replace").splitlines():
+        m = _COVER_FUNC_RE.match(line.strip())
+        if m:
+            functions[(posixpath.basename(m["file"]), int(m["line"]))] = float(m["pct"])
+            continue
+        m = _COVER_TOTAL_RE.match(line.strip())
+        if m:
+            result["total_pct"] = float(m["pct"])
+    result["functions"] = functions
+    return result
+
+
+def get_test_coverage_map(
+    repo: str,
+    package: str,
+    depth: int = _DEFAULT_DEPTH,
+    uncovered_only: bool = False,
+    run_go_test: bool = False,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Map a Go package's functions to the tests that exercise them.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        package: Package directory relative to the repo root
+            (``internal/store``; ``.`` for the root) or its import path.
+        depth: Call-graph hops followed from each test (default 2, max 5).
+            Hops through test helpers count.
+        uncovered_only: Only list symbols no test reaches.
+        run_go_test: Also run ``go test -coverprofile`` for the package and
+            report real statement coverage per function.  Runs the
+            repository's tests; needs a local index and ``go`` on PATH.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with package, import_path, tests (the test functions found),
+        symbol_count, covered_count, uncovered_count, covered_pct, symbols
+        (each with ``covered`` and ``tests`` — ``{name, depth}`` — plus
+        ``coverage_pct`` when go test ran), ``go_coverage`` when requested,
+        and _meta.
+    """
+    start = time.perf_counter()
+    depth = max(1, min(int(depth), _MAX_DEPTH))
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    package_dir = _package_dir(index, package)
+    if package_dir is None:
+        return {"error": f"No Go package found for '{package}'. Pass its directory or import path."}
+
+    in_package = [sym for sym in index.symbols if posixpath.dirname(sym.get("file", "")) == package_dir
+                  and sym.get("file", "").endswith(".go")]
+    production = [
+        sym for sym in in_package
+        if sym.get("kind") in ("function", "method") and not is_go_test_file(sym["file"])
+    ]
+    tests = [
+        sym for sym in in_package
+        if sym.get("kind") == "function" and is_go_test_file(sym["file"]) and _TEST_FUNC_RE.match(sym["name"])
+    ]
+    production_ids = {sym["id"] for sym in production}
+
+    symbols_by_file = build_symbols_by_file(index)
+    covering: dict[str, list[dict]] = {sid: [] for sid in production_ids}
+    for test in sorted(tests, key=lambda s: (s["file"], s["line"])):
+        reached, _ = bfs_callees(index, store, owner, name, test, symbols_by_file, depth)
+        for callee in reached:
+            if callee["id"] in production_ids:
+                covering[callee["id"]].append({"name": test["name"], "depth": callee["depth"]})
+
+    go_coverage: Optional[dict] = None
+    if run_go_test:
+        if not index.source_root or not os.path.isdir(index.source_root):
+            go_coverage = {"error": "run_go_test needs a locally indexed folder (index_folder)."}
+        else:
+            go_coverage = _run_go_coverage(index.source_root, package_dir)
+    func_pcts = (go_coverage or {}).get("functions", {})
+
+    entries: list[dict] = []
+    covered_count = 0
+    for sym in sorted(production, key=lambda s: (s["file"], s["line"])):
+        hits = sorted(covering[sym["id"]], key=lambda t: (t["depth"], t["name"]))
+        if hits:
+            covered_count += 1
+        if uncovered_only and hits:
+            continue
+        entry = {
+            "id": sym["id"],
+            "name": sym["name"],
+            "kind": sym["kind"],
+            "file": sym["file"],
+            "line": sym["line"],
+            "covered": bool(hits),
+            "tests": hits,
+        }
+        pct = func_pcts.get((posixpath.basename(sym["file"]), sym["line"]))
+        if pct is not None:
+            entry["coverage_pct"] = pct
+        entries.append(entry)
+
+    result: dict = {
+        "repo": f"{owner}/{name}",
+        "package": package_dir or ".",
+        "import_path": next((fqn_package(s) for s in production if fqn_package(s)), ""),
+        "tests": [{"name": t["name"], "file": t["file"], "line": t["line"]} for t in tests],
+        "symbol_count": len(production),
+        "covered_count": covered_count,
+        "uncovered_count": len(production) - covered_count,
+        "covered_pct": round(100.0 * covered_count / len(production), 1) if production else 0.0,
+        "symbols": entries,
+    }
+    if go_coverage is not None:
+        result["go_coverage"] = {k: v for k, v in go_coverage.items() if k != "functions"}
+    result["_meta"] = {
+        "timing_ms": round((time.perf_counter() - start) * 1000, 1),
+        "depth": depth,
+        "heuristic": True,
+        "note": "covered = reachable from a test through the call graph, not runtime coverage"
+                + ("; coverage_pct is from go test" if func_pcts else ""),
+    }
+    if not tests:
+        result["_meta"]["hint"] = "No Test/Benchmark/Fuzz/Example functions in this package's _test.go files"
+    return result
@@ -0,0 +1,292 @@
+"""Type hierarchy trees: Go embedding and interface satisfaction, class inheritance elsewhere.
+
+Go has no inheritance, so for a Go type the "supertypes" are its embedded
+fields (structs) or embedded interfaces, followed transitively, and the
+"subtypes" are the types that embed it.  A concrete type also reports the
+interfaces it satisfies (with the receiver form needed, per
+``GoTypeIndex.satisfied_interfaces``); an interface reports its
+implementers.  Every other language reuses the ``extends``/``implements``
+/``class Foo(Bar)`` signature parsing from get_class_hierarchy.
+
+Each type is expanded once per tree.  When it is reached again — diamond
+embedding through two anonymous fields, or a repeated base class — the
+later node carries ``repeated: true`` and no children, so shared ancestry
+appears once instead of being copied under every path.
+"""
+
+from __future__ import annotations
+
+import posixpath
+import time
+from typing import Callable, Hashable, Optional
+
+from ..parser.go_types import _BUILTIN_INTERFACES, GoTypeIndex
+from ..storage import IndexStore
+from ._utils import index_status_to_tool_error, resolve_repo
+from .get_class_hierarchy import _build_class_maps, _parse_bases
+
+_DEFAULT_MAX_DEPTH = 10
+_MAX_DEPTH_CAP = 50
+
+_Key = tuple[str, str]
+# (child key or None for an unindexed type, relation, leaf node for the None case)
+_Children = list[tuple[Optional[Hashable], str, dict]]
+
+
+def _external(name: str, relation: str = "", kind: str = "") -> dict:
+    """Leaf for a type the index does not define (stdlib, third-party)."""
+    node: dict = {"name": name}
+    if kind:
+        node["kind"] = kind
+    node.update({"file": "(external)", "line": 0})
+    if relation:
+        node["relation"] = relation
+    return node
+
+
+def _expand(
+    root: Hashable,
+    children_of: Callable[[Hashable], _Children],
+    node_of: Callable[[Hashable, str], dict],
+    max_depth: int,
+) -> tuple[list[dict], int]:
+    """Build one direction of the tree below *root*; returns (children, unique count).
+
+    ``children_of(key)`` yields ``(child_key, relation, external_node)`` —
+    a ``None`` key means the child is not indexed and ``external_node`` is
+    emitted as a leaf.
+    """
+    expanded: set[Hashable] = {root}
+
+    def _walk(key: Hashable, depth: int) -> list[dict]:
+        out: list[dict] = []
+        for child, relation, external in children_of(key):
+            if child is None:
+                out.append(external)
+                continue
+            node = node_of(child, relation)
+            if child in expanded:
+                node["repeated"] = True
+            elif depth >= max_depth:
+                expanded.add(child)
+                if children_of(child):
+                    node["truncated"] = True
+            else:
+                expanded.add(child)
+                node["children"] = _walk(child, depth + 1)
+            out.append(node)
+        return out
+
+    tree = _walk(root, 1)
+    return tree, len(expanded) - 1
+
+
+def _go_hierarchy(go: GoTypeIndex, key: _Key, max_depth: int) -> dict:
+    def _node(k: _Key, relation: str) -> dict:
+        sym = go.symbols[k]
+        node = {
+            "name": k[1],
+            "kind": go.types[k].kind,
+            "package": k[0] or ".",
+            "file": sym.get("file", ""),
+            "line": sym.get("line", 0),
+        }
+        if relation:
+            node["relation"] = relation
+        return node
+
+    def _embeds(k: _Key) -> _Children:
+        spec = go.types[k]
+        refs = spec.fields if spec.kind == "struct" else [(e, False) for e in spec.embeds]
+        out: _Children = []
+        for ref, pointer in refs:
+            relation = "embeds_pointer" if pointer else "embeds"
+            target = go.resolve(k[0], ref)
+            if target is None:
+                kind = "interface" if ref in _BUILTIN_INTERFACES else ""
+                out.append((None, relation, _external(ref, relation, kind)))
+            else:
+                out.append((target, relation, {}))
+        return out
+
+    embedded_by: dict[_Key, list[tuple[_Key, str]]] = {}
+    for cand in sorted(go.types):
+        for child, relation, _ in _embeds(cand):
+            if child is not None:
+                embedded_by.setdefault(child, []).append((cand, relation))
+
+    def _embedders(k: _Key) -> _Children:
+        return [
+            (cand, "embedded_by_pointer" if rel == "embeds_pointer" else "embedded_by", {})
+            for cand, rel in embedded_by.get(k, [])
+        ]
+
+    supertypes, super_count = _expand(key, _embeds, _node, max_depth)
+    subtypes, sub_count = _expand(key, _embedders, _node, max_depth)
+    result: dict = {
+        "type": {**_node(key, ""), "signature": go.symbols[key].get("signature", "")},
+        "supertype_count": super_count,
+        "subtype_count": sub_count,
+        "supertypes": supertypes,
+        "subtypes": subtypes,
+    }
+
+    spec = go.types[key]
+    if spec.kind == "interface":
+        required = go.interface_methods(key)[0]
+        implementers = go.implementers(key) if required else []
+        result["implementers"] = [
+            {**_node(cand, ""), "form": form}
+            for cand, form in sorted(implementers)
+        ]
+        if not required:
+            result["note"] = "Empty method set: every type satisfies this interface."
+    else:
+        interfaces = []
+        for iface, name, form in go.satisfied_interfaces(key):
+            node = _external(name, kind="interface") if iface is None else _node(iface, "")
+            interfaces.append({**node, "form": form})
+        interfaces.sort(key=lambda n: (n["file"] == "(external)", n["name"], n["file"]))
+        result["interfaces"] = interfaces
+    return result
+
+
+def _class_hierarchy(symbols: list[dict], target: dict, max_depth: int) -> dict:
+    class_by_name, children_of = _build_class_maps(symbols)
+
+    def _node(name: str, relation: str) -> dict:
+        sym = class_by_name[name]
+        node = {
+            "name": name,
+            "kind": sym.get("kind", ""),
+            "file": sym.get("file", ""),
+            "line": sym.get("line", 0),
+        }
+        if relation:
+            node["relation"] = relation
+        return node
+
+    def _bases(name: str) -> _Children:
+        sym = target if name == target["name"] else class_by_name[name]
+        out: _Children = []
+        for base in _parse_bases(sym.get("signature", "")):
+            if base in class_by_name:
+                out.append((base, "extends", {}))
+            else:
+                out.append((None, "extends", _external(base, "extends")))
+        return out
+
+    def _subclasses(name: str) -> _Children:
+        return [(child, "extended_by", {}) for child in children_of.get(name, [])]
+
+    root = target["name"]
+    supertypes, super_count = _expand(root, _bases, _node, max_depth)
+    subtypes, sub_count = _expand(root, _subclasses, _node, max_depth)
+    return {
+        "type": {
+            "name": root,
+            "kind": target.get("kind", ""),
+            "file": target.get("file", ""),
+            "line": target.get("line", 0),
+            "signature": target.get("signature", ""),
+        },
+        "supertype_count": super_count,
+        "subtype_count": sub_count,
+        "supertypes": supertypes,
+        "subtypes": subtypes,
+    }
+
+
+def _in_scope(file: str, package: str, scope: Optional[str]) -> bool:
+    if not scope:
+        return True
+    scope = scope.strip("/")
+    return file == scope or (package or ".") == scope
+
+
+def get_type_hierarchy(
+    repo: str,
+    type_name: str,
+    file_path: Optional[str] = None,
+    max_depth: int = _DEFAULT_MAX_DEPTH,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Supertype and subtype trees for a type, with Go interface satisfaction.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        type_name: Type to analyse.  Go types may be package-qualified
+            (``store.User``).
+        file_path: Optional file or package directory, to pick one of
+            several same-named types.
+        max_depth: Levels to expand in each direction (default 10, max 50).
+            Nodes cut off by the limit carry ``truncated: true``.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with the target ``type``, ``supertypes`` and ``subtypes`` trees
+        (each node: name, kind, file, line, relation, children), and for Go
+        either ``interfaces`` satisfied (concrete types) or ``implementers``
+        (interfaces), each with the ``form`` (value or pointer) required.
+    """
+    start = time.perf_counter()
+    max_depth = max(1, min(int(max_depth), _MAX_DEPTH_CAP))
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    qualifier, _, bare = type_name.rpartition(".")
+    go = GoTypeIndex(index.symbols)
+    go_keys = sorted(
+        k for k in go.types
+        if k[1] == bare
+        and (not qualifier or posixpath.basename(k[0]) == qualifier)
+        and _in_scope(go.symbols[k].get("file", ""), k[0], file_path)
+    )
+    class_syms = sorted(
+        (
+            s for s in index.symbols
+            if s.get("kind") in ("class", "type") and s.get("language") != "go"
+            and s.get("name") == type_name
+            and _in_scope(s.get("file", ""), posixpath.dirname(s.get("file", "")), file_path)
+        ),
+        key=lambda s: (s.get("file", ""), s.get("line", 0)),
+    )
+    if not go_keys and not class_syms:
+        lower = type_name.lower()
+        class_syms = sorted(
+            (
+                s for s in index.symbols
+                if s.get("kind") in ("class", "type") and s.get("language") != "go"
+                and s.get("name", "").lower() == lower
+                and _in_scope(s.get("file", ""), posixpath.dirname(s.get("file", "")), file_path)
+            ),
+            key=lambda s: (s.get("file", ""), s.get("line", 0)),
+        )
+    if not go_keys and not class_syms:
+        where = f" in {file_path}" if file_path else ""
+        return {"error": f"Type '{type_name}' not found{where}. Only 'class' and 'type' kinds are searched."}
+
+    # (symbol, Go key or None), earliest definition first.
+    candidates = [(go.symbols[k], k) for k in go_keys] + [(s, None) for s in class_syms]
+    candidates.sort(key=lambda c: (c[0].get("file", ""), c[0].get("line", 0)))
+    chosen, go_key = candidates[0]
+    if go_key is not None:
+        result = _go_hierarchy(go, go_key, max_depth)
+    else:
+        result = _class_hierarchy(index.symbols, chosen, max_depth)
+
+    out: dict = {"repo": f"{owner}/{name}", "language": chosen.get("language", ""), **result}
+    if len(candidates) > 1:
+        out["alternatives"] = [
+            {"file": s.get("file", ""), "line": s.get("line", 0)} for s, _ in candidates[1:]
+        ]
+    out["_meta"] = {"timing_ms": round((time.perf_counter() - start) * 1000, 1)}
+    return out
@@ -71,7 +71,7 @@ def _symbol_reached_by_tests(
 
     # --- Text heuristic fallback: word-boundary match in test file content ---
     for tf in test_importers:
-        content = store.get_file_content(owner, repo_name, tf)
+        content = store.get_file_content(owner, repo_name, tf, raw=True)
         if content and _word_match(content, sym_name):
             return True, 0.0, "reached"
 
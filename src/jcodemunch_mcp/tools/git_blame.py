@@ -0,0 +1,264 @@
+"""git_blame — last commit, author, and date for each line of a file or symbol.
+
+Shells out to ``git blame --porcelain`` against the repo's local working
+tree (requires the repo to have been indexed with ``index_folder``).  For a
+symbol the blamed range is the symbol's indexed line span, and the newest
+commit in that span answers "who last changed this, and when?".
+
+Files outside a git work tree, or not tracked by git, return
+``status: "not_tracked"`` instead of an error so callers can branch on it.
+"""
+
+from __future__ import annotations
+
+import logging
+import os
+import subprocess
+import time
+from datetime import datetime, timedelta, timezone
+from typing import Optional
+
+from ..storage import IndexStore
+from ._utils import resolve_repo
+
+logger = logging.getLogger(__name__)
+
+_DEFAULT_MAX_LINES = 500
+
+# git blame reports lines that only exist in the working tree under this hash.
+_UNCOMMITTED_SHA = "0" * 40
+
+
+def _run_git(args: list[str], cwd: str, timeout: int = 30) -> tuple[int, str, str]:
+    try:
+        r = subprocess.run(
+            ["git"] + args,
+            cwd=cwd, capture_output=True, text=True,
+            timeout=timeout, stdin=subprocess.DEVNULL,
+        )
+        return r.returncode, r.stdout, r.stderr.strip()
+    except FileNotFoundError:
+        return -1, "", "git not found on PATH"
+    except subprocess.TimeoutExpired:
+        return -2, "", "git command timed out"
+    except Exception as exc:
+        logger.debug("git subprocess error: %s", exc, exc_info=True)
+        return -3, "", str(exc)
+
+
+def _author_date(epoch: str, tz: str) -> str:
+    """ISO-8601 timestamp in the author's own offset: ``1700000000``, ``+0200``."""
+    try:
+        sign = -1 if tz.startswith("-") else 1
+        offset = timedelta(hours=int(tz[1:3]), minutes=int(tz[3:5])) * sign
+        return datetime.fromtimestamp(int(epoch), timezone(offset)).isoformat()
+    except (ValueError, IndexError, OverflowError):
+        return ""
+
+
+def _parse_porcelain(output: str) -> tuple[list[dict], dict[str, dict]]:
+    """Parse ``git blame --porcelain`` into per-line entries and commit details.
+
+    Porcelain prints a ``<sha> <orig> <final> [<count>]`` header per line,
+    followed by the commit's metadata only the first time that commit
+    appears, then the line content prefixed by a tab.
+    """
+    lines: list[dict] = []
+    commits: dict[str, dict] = {}
+    sha = ""
+    final_line = 0
+    for raw in output.split("\n"):
+        if raw.startswith("\t"):
+            lines.append({"line": final_line, "sha": sha})
+            continue
+        key, _, value = raw.partition(" ")
+        if len(key) == 40 and all(c in "0123456789abcdef" for c in key):
+            sha = key
+            parts = value.split()
+            final_line = int(parts[1]) if len(parts) > 1 else 0
+            commits.setdefault(sha, {})
+        elif sha and key in ("author", "author-mail", "author-time", "author-tz", "summary"):
+            commits[sha][key] = value
+    return lines, commits
+
+
+def _commit_entry(sha: str, meta: dict) -> dict:
+    if sha == _UNCOMMITTED_SHA:
+        return {"commit": None, "author": "", "date": "", "summary": "", "uncommitted": True}
+    return {
+        "commit": sha[:12],
+        "author": meta.get("author", ""),
+        "date": _author_date(meta.get("author-time", ""), meta.get("author-tz", "+0000")),
+        "summary": meta.get("summary", ""),
+    }
+
+
+def _resolve_symbol(index, symbol: str) -> tuple[Optional[dict], Optional[dict]]:
+    """Find a symbol dict by id, then by unique name.  Returns (symbol, error)."""
+    sym = next((s for s in index.symbols if s.get("id") == symbol), None)
+    if sym is not None:
+        return sym, None
+    by_name = [s for s in index.symbols if s.get("name") == symbol]
+    if len(by_name) == 1:
+        return by_name[0], None
+    if by_name:
+        return None, {
+            "error": f"Ambiguous symbol name '{symbol}': found {len(by_name)} definitions. Use the symbol ID to disambiguate.",
+            "candidates": [{"name": s["name"], "file": s["file"], "id": s["id"]} for s in by_name],
+        }
+    return None, {"error": f"Symbol not found: '{symbol}'. Try search_symbols first."}
+
+
+def git_blame(
+    repo: str,
+    file_path: Optional[str] = None,
+    symbol: Optional[str] = None,
+    start_line: Optional[int] = None,
+    end_line: Optional[int] = None,
+    max_lines: int = _DEFAULT_MAX_LINES,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Blame a file range or a symbol: last commit, author, and date per line.
+
+    Pass either *file_path* (optionally with *start_line* / *end_line*,
+    1-based inclusive, clamped to the file) or *symbol* (an ID or unique
+    name; its indexed line span is blamed).  Blame runs against the working
+    tree, so lines edited but not yet committed come back as
+    ``uncommitted``.
+
+    Args:
+        repo:         Repository identifier (owner/repo or bare name).
+        file_path:    Relative file path within the repo.
+        symbol:       Symbol ID or name, instead of *file_path*.
+        start_line:   First line to blame (file mode; default 1).
+        end_line:     Last line to blame (file mode; default end of file).
+        max_lines:    Cap on per-line entries returned (default 500).
+        storage_path: Optional index storage path override.
+
+    Returns:
+        ``{repo, file, status, start_line, end_line, symbol?, most_recent,
+        commits, lines, _meta}``.  ``status`` is ``"ok"`` or
+        ``"not_tracked"`` (with a ``reason``).  ``lines`` holds
+        ``{line, commit, author, date}``; ``commits`` lists each distinct
+        commit with its line count, newest first; ``most_recent`` is the
+        first of those.
+    """
+    t0 = time.perf_counter()
+    max_lines = max(1, max_lines)
+
+    if not file_path and not symbol:
+        return {"error": "Provide file_path or symbol."}
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if index is None:
+        return {"error": f"No index found for {repo!r}. Run index_folder first."}
+    if not index.source_root:
+        return {
+            "error": (
+                "git_blame requires a locally indexed repo (index_folder). "
+                "GitHub-indexed repos (index_repo) do not have a local git working tree."
+            )
+        }
+    cwd = index.source_root
+
+    sym_info = None
+    if symbol:
+        sym, error = _resolve_symbol(index, symbol)
+        if error:
+            return error
+        file_path = sym.get("file", "")
+        start_line = sym.get("line") or 1
+        end_line = sym.get("end_line") or start_line
+        sym_info = {
+            "id": sym.get("id", ""),
+            "name": sym.get("name", ""),
+            "kind": sym.get("kind", ""),
+        }
+    elif not index.has_source_file(file_path):
+        return {"error": f"File not found: {file_path}"}
+
+    result: dict = {"repo": f"{owner}/{name}", "file": file_path}
+    if sym_info:
+        result["symbol"] = sym_info
+
+    def not_tracked(reason: str) -> dict:
+        result.update({"status": "not_tracked", "reason": reason})
+        result["_meta"] = {"timing_ms": round((time.perf_counter() - t0) * 1000, 1)}
+        return result
+
+    rc, _, err = _run_git(["rev-parse", "--is-inside-work-tree"], cwd=cwd, timeout=10)
+    if rc == -1:
+        return {"error": "git not found on PATH."}
+    if rc != 0:
+        return not_tracked("not a git repository")
+    rc, _, _ = _run_git(["ls-files", "--error-unmatch", "--", file_path], cwd=cwd, timeout=10)
+    if rc != 0:
+        return not_tracked("file is not tracked by git")
+
+    try:
+        with open(os.path.join(cwd, file_path), encoding="utf-8", errors="replace") as fh:
+            line_count = sum(1 for _ in fh)
+    except OSError:
+        return not_tracked("file is missing from the working tree")
+    if line_count == 0:
+        result.update({"status": "ok", "start_line": 0, "end_line": 0,
+                       "most_recent": None, "commits": [], "lines": []})
+        result["_meta"] = {"timing_ms": round((time.perf_counter() - t0) * 1000, 1), "truncated": False}
+        return result
+
+    actual_start = max(1, min(start_line or 1, line_count))
+    actual_end = max(actual_start, min(end_line or line_count, line_count))
+
+    rc, out, err = _run_git(
+        ["blame", "--porcelain", f"-L{actual_start},{actual_end}", "--", file_path],
+        cwd=cwd,
+    )
+    if rc != 0:
+        return {"error": f"git blame failed: {err}"}
+
+    blamed, commit_meta = _parse_porcelain(out)
+    entries = {sha: _commit_entry(sha, meta) for sha, meta in commit_meta.items()}
+    line_counts: dict[str, int] = {}
+    for b in blamed:
+        line_counts[b["sha"]] = line_counts.get(b["sha"], 0) + 1
+
+    # Newest first; uncommitted edits are newer than any commit.
+    def recency(sha: str) -> tuple[int, int]:
+        if sha == _UNCOMMITTED_SHA:
+            return (1, 0)
+        try:
+            return (0, int(commit_meta[sha].get("author-time", 0)))
+        except ValueError:
+            return (0, 0)
+
+    ordered = sorted(line_counts, key=recency, reverse=True)
+    commits = [{**entries[sha], "lines": line_counts[sha]} for sha in ordered]
+
+    result.update({
+        "status": "ok",
+        "start_line": actual_start,
+        "end_line": actual_end,
+        "most_recent": commits[0] if commits else None,
+        "commits": commits,
+        "lines": [
+            {
+                "line": b["line"],
+                "commit": entries[b["sha"]]["commit"],
+                "author": entries[b["sha"]]["author"],
+                "date": entries[b["sha"]]["date"],
+            }
+            for b in blamed[:max_lines]
+        ],
+    })
+    result["_meta"] = {
+        "timing_ms": round((time.perf_counter() - t0) * 1000, 1),
+        "truncated": len(blamed) > max_lines,
+        "tip": "Use get_symbol_provenance for the full commit history behind a symbol.",
+    }
+    return result
@@ -18,7 +18,9 @@ import pathspec
 logger = logging.getLogger(__name__)
 
 from .. import config as _config
-from ..parser import parse_file, LANGUAGE_EXTENSIONS, get_language_for_path
+from ..deadline import Deadline, current_deadline, expired
+from ..parser import LANGUAGE_EXTENSIONS, get_language_for_path
+from ..parser.parse_pool import ParseJob, parse_files
 from ..parser.context import discover_providers, enrich_symbols, collect_metadata, collect_extra_imports
 from ..parser.context.framework_profiles import detect_framework, profile_to_meta
 from ..parser.imports import extract_imports, _alias_map_cache as _imap_cache, _LANGUAGE_EXTRACTORS as _IMPORT_EXTRACTORS
@@ -27,10 +29,14 @@ from ..security import (
     is_symlink_escape,
     is_secret_file,
     is_binary_file,
+    is_generated_file,
     should_exclude_file,
     DEFAULT_MAX_FILE_SIZE,
     get_max_folder_files,
+    get_max_file_size,
     get_extra_ignore_patterns,
+    get_exclude_generated,
+    get_follow_symlinks,
     get_skip_directories,
     SKIP_FILES
 )
@@ -51,7 +57,8 @@ def _build_skip_dirs_regex() -> re.Pattern:
 
 def _maybe_apply_adaptive(folder_path: str, result: dict) -> None:
     """Apply adaptive language config if enabled. Never raises."""
-    if not isinstance(result, dict) or not result.get("success"):
+    # A timed-out run has not seen every language yet.
+    if not isinstance(result, dict) or not result.get("success") or result.get("timed_out"):
         return
     detected = set(result.get("languages", {}).keys())
     if not detected:
@@ -184,6 +191,41 @@ def _is_gitignored_fast(resolved_str: str, specs: list[tuple[str, "pathspec.Path
     return False
 
 
+def _ancestor_gitignore_specs(
+    root: Path,
+    file_path: Path,
+    cache: dict[str, Optional["pathspec.PathSpec"]],
+) -> list[tuple[str, "pathspec.PathSpec"]]:
+    """String-prefix specs for every .gitignore from ``root`` down to the file's directory.
+
+    Gives the watcher fast path the same nested-.gitignore view the full
+    walk builds incrementally.  ``cache`` maps directory → spec (or None)
+    so sibling events don't re-read the same files.
+    """
+    try:
+        rel_parts = file_path.parent.relative_to(root).parts
+    except ValueError:
+        return []
+    specs: list[tuple[str, pathspec.PathSpec]] = []
+    dpath = root
+    for part in ("",) + rel_parts:
+        if part:
+            dpath = dpath / part
+        key = str(dpath)
+        if key not in cache:
+            cache[key] = None
+            gitignore_path = dpath / ".gitignore"
+            if gitignore_path.is_file():
+                try:
+                    content = gitignore_path.read_text(encoding="utf-8", errors="replace")
+                    cache[key] = pathspec.PathSpec.from_lines("gitignore", content.splitlines())
+                except Exception:
+                    pass
+        if cache[key] is not None:
+            specs.append((key + os.sep, cache[key]))
+    return specs
+
+
 def _local_repo_name(folder_path: Path) -> str:
     """Stable local repo id derived from basename + resolved path hash."""
     digest = hashlib.sha1(str(folder_path).encode("utf-8")).hexdigest()[:8]
@@ -211,6 +253,7 @@ class _IndexFilters:
     skip_dirs_regex: Optional[re.Pattern] = None
     check_binary: bool = True
     check_filename: bool = True
+    exclude_generated: bool = False
 
 
 def _build_index_filters(
@@ -223,6 +266,7 @@ def _build_index_filters(
     skip_dirs_regex: Optional[re.Pattern] = None,
     check_binary: bool = True,
     check_filename: bool = True,
+    exclude_generated: bool = False,
 ) -> _IndexFilters:
     """Bundle pre-computed filter config for ``_should_index_file``.
 
@@ -242,6 +286,7 @@ def _build_index_filters(
         skip_dirs_regex=skip_dirs_regex,
         check_binary=check_binary,
         check_filename=check_filename,
+        exclude_generated=exclude_generated,
     )
 
 
@@ -265,11 +310,13 @@ def _should_index_file(
         ``skip_counts`` keys (``skip_file``, ``symlink``,
         ``symlink_escape``, ``path_traversal``, ``skip_dir``,
         ``gitignore``, ``extra_ignore``, ``secret``, ``wrong_extension``,
-        ``too_large``, ``unreadable``, ``binary``). ``rel_path`` may
+        ``too_large``, ``unreadable``, ``binary``, ``generated``).
+        ``rel_path`` may
         be empty if rejection happened before path resolution.
         ``warning`` is a user-facing one-liner the caller should
         append to its warnings list for the user-visible rejections
-        (``symlink_escape``, ``path_traversal``, ``secret``, ``binary``);
+        (``symlink_escape``, ``path_traversal``, ``secret``, ``too_large``,
+        ``binary``);
         None otherwise.
 
     Args:
@@ -352,12 +399,16 @@ def _should_index_file(
     except OSError:
         return False, "unreadable", rel_path, None
     if size > cfg.max_size and resolved_str not in cfg.forced_paths:
-        return False, "too_large", rel_path, None
+        return False, "too_large", rel_path, f"Skipped oversize file (>{cfg.max_size} bytes): {rel_path}"
 
     # 13. Binary detection (opt-out for callers that read the file separately)
     if cfg.check_binary and is_binary_file(file_path):
         return False, "binary", rel_path, f"Skipped binary file: {rel_path}"
 
+    # 14. Generated-code header (opt-in via exclude_generated)
+    if cfg.exclude_generated and is_generated_file(file_path):
+        return False, "generated", rel_path, None
+
     return True, "", rel_path, None
 
 
@@ -380,11 +431,13 @@ class _CarriedSymbol:
             return self._d[name]
         except KeyError:
             # Defaults that match Symbol dataclass field types.
-            if name in ("decorators", "keywords", "call_references"):
+            if name in ("decorators", "keywords", "call_references", "fields", "params", "returns", "type_params"):
                 return []
             if name in ("line", "end_line", "byte_offset", "byte_length",
                          "cyclomatic", "max_nesting", "param_count"):
                 return 0
+            if name in ("is_test", "pointer_receiver", "is_declaration"):
+                return False
             return ""
 
 
@@ -514,6 +567,7 @@ from ._indexing_pipeline import (
     file_languages_for_paths as _file_languages_for_paths,
     language_counts as _language_counts,
     complete_file_summaries as _complete_file_summaries,
+    mark_timed_out,
     parse_and_prepare_incremental,
     parse_and_prepare_full,
     parse_immediate,
@@ -597,8 +651,9 @@ def resolve_explicit_paths(
     walk_root: Path,
     paths: list,
     max_files: Optional[int],
-    max_size: int = DEFAULT_MAX_FILE_SIZE,
+    max_size: Optional[int] = None,
     follow_symlinks: bool = False,
+    exclude_generated: bool = False,
 ) -> tuple[list[Path], list[str], dict[str, int]]:
     """Materialise a caller-supplied list of paths into the (files, warnings,
     skip_counts) shape that the standard indexing pipeline expects.
@@ -619,6 +674,7 @@ def resolve_explicit_paths(
     warnings: list[str] = []
     skip_counts: dict[str, int] = {}
     seen: set = set()
+    max_size = get_max_file_size(max_size)
 
     cap = max_files if max_files is not None else 10_000_000
 
@@ -654,6 +710,7 @@ def resolve_explicit_paths(
                 max_files=remaining,
                 max_size=max_size,
                 follow_symlinks=follow_symlinks,
+                exclude_generated=exclude_generated,
             )
             warnings.extend(sub_warnings)
             for k, v in sub_skip.items():
@@ -691,6 +748,16 @@ def resolve_explicit_paths(
             warnings.append(f"Skipped stat-error path {raw!r}: {e}")
             continue
 
+        if is_binary_file(p):
+            warnings.append(f"Skipped binary file: {raw!r}")
+            skip_counts["binary"] = skip_counts.get("binary", 0) + 1
+            continue
+
+        # Counted only, as in the walk: generated files are policy, not errors.
+        if exclude_generated and is_generated_file(p):
+            skip_counts["generated"] = skip_counts.get("generated", 0) + 1
+            continue
+
         pr = p.resolve()
         if pr not in seen:
             seen.add(pr)
@@ -699,28 +766,78 @@ def resolve_explicit_paths(
     return files[:cap], warnings, skip_counts
 
 
+def _prune_linked_dirs(
+    root: Path,
+    dirpath: str,
+    dirnames: list[str],
+    visited: set[str],
+    skip_counts: dict[str, int],
+    warnings: list[str],
+) -> list[str]:
+    """Subdirectories of *dirpath* that a symlink-following walk may enter.
+
+    Drops directories whose real path was already walked (a symlink cycle,
+    or a second route to the same directory) and symlinks that resolve
+    outside *root*.  Kept directories are added to *visited*.
+    """
+    kept = []
+    for d in dirnames:
+        full = os.path.join(dirpath, d)
+        try:
+            real = os.path.realpath(full)
+        except (OSError, ValueError):
+            skip_counts["unreadable"] += 1
+            continue
+        if os.path.islink(full) and is_symlink_escape(root, Path(full)):
+            skip_counts["symlink_escape"] += 1
+            warnings.append(f"Skipped symlink escape: {full}")
+            continue
+        if real in visited:
+            skip_counts["symlink_cycle"] += 1
+            logger.debug("SKIP symlink_cycle: %s -> %s", full, real)
+            continue
+        visited.add(real)
+        kept.append(d)
+    return kept
+
+
 def discover_local_files(
     folder_path: Path,
     max_files: Optional[int] = None,
-    max_size: int = DEFAULT_MAX_FILE_SIZE,
+    max_size: Optional[int] = None,
     extra_ignore_patterns: Optional[list[str]] = None,
-    follow_symlinks: bool = False,
+    follow_symlinks: Optional[bool] = None,
+    exclude_generated: bool = False,
+    deadline: Optional[Deadline] = None,
 ) -> tuple[list[Path], list[str], dict[str, int]]:
     """Discover source files in a local folder with security filtering.
 
     Args:
         folder_path: Root folder to scan (must be resolved).
         max_files: Maximum number of files to index.
-        max_size: Maximum file size in bytes.
+        max_size: Maximum file size in bytes (default: ``max_file_size``
+            config, 500KB).
         extra_ignore_patterns: Additional gitignore-style patterns to exclude.
-        follow_symlinks: Whether to include symlinked files in indexing.
-            Symlinked directories are never followed to prevent infinite
-            loops from circular symlinks. Default False for safety.
+        follow_symlinks: Whether to follow symlinked files and directories.
+            None (default) defers to the ``follow_symlinks`` config key
+            (default True).  Each real directory is walked once, so a link
+            back to an ancestor (a cycle) or a second route to the same
+            directory is pruned (counted under ``symlink_cycle``).  Links
+            that resolve outside the root are skipped with a warning
+            (``symlink_escape``).  Files reached through a link are indexed
+            under their real path.
+        exclude_generated: Skip files with a ``Code generated ... DO NOT
+            EDIT.`` header (counted under ``generated``).
+        deadline: Stop walking once it passes; directories left unwalked
+            are counted under ``deadline``, so a non-zero count means the
+            file list is incomplete.
 
     Returns:
         Tuple of (list of Path objects for source files, list of warning strings).
     """
     max_files = get_max_folder_files(max_files)
+    max_size = get_max_file_size(max_size)
+    follow_symlinks = get_follow_symlinks(follow_symlinks, repo=str(folder_path))
     files = []
     warnings = []
     root = folder_path.resolve()
@@ -730,6 +847,7 @@ def discover_local_files(
         "skip_file": 0,
         "symlink": 0,
         "symlink_escape": 0,
+        "symlink_cycle": 0,
         "path_traversal": 0,
         "gitignore": 0,
         "extra_ignore": 0,
@@ -738,7 +856,9 @@ def discover_local_files(
         "too_large": 0,
         "unreadable": 0,
         "binary": 0,
+        "generated": 0,
         "file_limit": 0,
+        "deadline": 0,
     }
 
     # Pre-compute string-based gitignore specs — built incrementally during
@@ -783,10 +903,20 @@ def discover_local_files(
         skip_dirs_regex=None,
         check_binary=True,
         check_filename=True,
+        exclude_generated=exclude_generated,
     )
 
     skip_dirs_regex = _build_skip_dirs_regex()
-    for dirpath, dirnames, filenames in os.walk(str(root), followlinks=False):
+    # Real paths of the directories walked so far (and of accepted files):
+    # with followlinks=True os.walk has no cycle guard of its own, and a
+    # link to an in-root directory would otherwise index it twice.
+    visited_dirs = {root_str}
+    seen_files: set[str] = set()
+    for dirpath, dirnames, filenames in os.walk(str(root), followlinks=follow_symlinks):
+        if expired(deadline):
+            skip_counts["deadline"] += 1
+            dirnames[:] = []
+            continue
         # Prune directories that should always be skipped before descending.
         pruned = []
         kept = []
@@ -800,6 +930,10 @@ def discover_local_files(
             for d in pruned:
                 skip_counts["skip_dir"] += 1
                 logger.debug("SKIP skip_dir: %s", os.path.join(rel_dir, d))
+        if follow_symlinks:
+            kept = _prune_linked_dirs(
+                root, dirpath, kept, visited_dirs, skip_counts, warnings,
+            )
         dirnames[:] = kept
         dpath = Path(dirpath)
 
@@ -829,8 +963,16 @@ def discover_local_files(
                 )
                 continue
 
+            if rel_path in seen_files:
+                # Second link to a file already accepted under its real path.
+                skip_counts["symlink_cycle"] += 1
+                logger.debug("SKIP symlink_cycle: %s", rel_path)
+                continue
+            seen_files.add(rel_path)
             logger.debug("ACCEPT: %s", rel_path)
-            files.append(file_path)
+            # rel_path is resolved: a file reached through a link is
+            # indexed once, under its real location.
+            files.append(root / rel_path if follow_symlinks else file_path)
 
     logger.info(
         "Discovery complete — accepted: %d, skipped by reason: %s",
@@ -868,13 +1010,14 @@ def index_folder(
     use_ai_summaries: bool = True,
     storage_path: Optional[str] = None,
     extra_ignore_patterns: Optional[list[str]] = None,
-    follow_symlinks: bool = False,
+    follow_symlinks: Optional[bool] = None,
     incremental: bool = True,
     context_providers: bool = True,
     changed_paths: Optional[list[WatcherChange]] = None,
     paths: Optional[list[str]] = None,
     progress_cb: "Optional[Callable[[int, int, str], None]]" = None,
     identity_mode: str = "config",
+    exclude_generated: Optional[bool] = None,
 ) -> dict:
     """Index a local folder containing source code.
 
@@ -883,8 +1026,10 @@ def index_folder(
         use_ai_summaries: Whether to use AI for symbol summaries.
         storage_path: Custom storage path (default: ~/.code-index/).
         extra_ignore_patterns: Additional gitignore-style patterns to exclude.
-        follow_symlinks: Whether to include symlinked files. Symlinked directories
-            are never followed (prevents infinite loops). Default False.
+        follow_symlinks: Whether to follow symlinked files and directories
+            (cycle-safe; links resolving outside the folder are skipped and
+            reported). None (default) defers to the ``follow_symlinks``
+            config key, which defaults to True.
         context_providers: Whether to run context providers (default True).
             Set to False or set JCODEMUNCH_CONTEXT_PROVIDERS=0 to disable.
         incremental: When True and an existing index exists, only re-index changed files.
@@ -894,6 +1039,9 @@ def index_folder(
             and an existing index, skips full directory discovery (~3s → ~50ms).
         identity_mode: "config" (default), "local", or "git". Local mode keeps
             v1.90 path-hash identity; git mode opts in to git-root identity.
+        exclude_generated: Skip files with a ``Code generated ... DO NOT
+            EDIT.`` header. None (default) defers to the ``exclude_generated``
+            config key.
 
     Returns:
         Dict with indexing results.
@@ -914,6 +1062,10 @@ def index_folder(
     # config.get() calls within this indexing run use project overrides.
     # This handles both first-time indexing and re-indexing of existing projects.
     _config.load_project_config(str(folder_path))
+    exclude_generated = get_exclude_generated(exclude_generated, repo=str(folder_path))
+    follow_symlinks = get_follow_symlinks(follow_symlinks, repo=str(folder_path))
+    # Set when called through the MCP server (tool_timeout_seconds).
+    deadline = current_deadline()
 
     warnings = []
     trusted_folders = _config.get("trusted_folders", [], repo=str(folder_path))
@@ -1125,8 +1277,6 @@ def index_folder(
             # re-index it. ``_should_index_file`` is the shared helper.
             #
             # Tradeoffs accepted on this path for ~50ms per-event latency:
-            #   - gitignore loads root-level only (not per-subdir). Nested
-            #     .gitignores miss; documented in #306 as a known limit.
             #   - package.json forced-path exemption from the size cap is
             #     skipped (would require an rglob). Initial full walk
             #     handles the exemption; subsequent fast-path edits to a
@@ -1146,32 +1296,20 @@ def index_folder(
                 except Exception:
                     _fast_extra_spec = None
 
-            # Load root-level .gitignore once (string-prefix spec form).
-            _fast_gitignore_specs: list[tuple[str, "pathspec.PathSpec"]] = []
-            try:
-                _root_gitignore = folder_path / ".gitignore"
-                if _root_gitignore.is_file():
-                    _gi_content = _root_gitignore.read_text(
-                        encoding="utf-8", errors="replace"
-                    )
-                    _gi_spec = pathspec.PathSpec.from_lines(
-                        "gitignore", _gi_content.splitlines()
-                    )
-                    _fast_gitignore_specs.append(
-                        (str(folder_path.resolve()) + os.sep, _gi_spec)
-                    )
-            except Exception:
-                pass
+            # .gitignore specs along each changed file's ancestor chain,
+            # loaded lazily and shared across the batch.
+            _fast_gitignore_cache: dict[str, Optional["pathspec.PathSpec"]] = {}
 
             _fast_filter_cfg = _build_index_filters(
                 root=folder_path.resolve(),
                 follow_symlinks=follow_symlinks,
-                max_size=DEFAULT_MAX_FILE_SIZE,
+                max_size=get_max_file_size(),
                 extra_spec=_fast_extra_spec,
                 forced_paths=set(),
                 skip_dirs_regex=_build_skip_dirs_regex(),
                 check_binary=False,
                 check_filename=True,
+                exclude_generated=exclude_generated,
             )
 
             # Branch detection for watcher fast-path
@@ -1254,7 +1392,10 @@ def index_folder(
                     # filter checks that stat the path would fail anyway).
                     if change_type != "deleted":
                         _ok, _reason, _hl_rel_path, _warning = _should_index_file(
-                            abs_path, _fast_filter_cfg, _fast_gitignore_specs
+                            abs_path, _fast_filter_cfg,
+                            _ancestor_gitignore_specs(
+                                folder_path.resolve(), abs_path, _fast_gitignore_cache
+                            ),
                         )
                         if not _ok:
                             logger.debug(
@@ -1493,6 +1634,7 @@ def index_folder(
                 list(paths),
                 max_files=max_files,
                 follow_symlinks=follow_symlinks,
+                exclude_generated=exclude_generated,
             )
         else:
             source_files, discover_warnings, skip_counts = discover_local_files(
@@ -1500,10 +1642,30 @@ def index_folder(
                 max_files=max_files,
                 extra_ignore_patterns=_merged_ignore or None,
                 follow_symlinks=follow_symlinks,
+                exclude_generated=exclude_generated,
+                deadline=deadline,
             )
         warnings.extend(discover_warnings)
         logger.info("Discovery skip counts: %s", skip_counts)
 
+        # A truncated walk cannot tell unwalked files from deleted ones, so
+        # nothing is saved: the existing index (if any) stays as it was.
+        if skip_counts.get("deadline"):
+            result = {
+                "success": False,
+                "timed_out": True,
+                "error": (
+                    f"Timed out after {deadline.seconds:g}s while discovering files "
+                    f"({len(source_files)} found so far); nothing was saved. "
+                    "Re-run with a larger timeout_seconds, or narrow the folder "
+                    "with extra_ignore_patterns or paths."
+                ),
+                "discovery_skip_counts": skip_counts,
+            }
+            if warnings:
+                result["warnings"] = warnings
+            return result
+
         # Warn when no root .gitignore is present and the file count is large —
         # a common cause of bloated indexes that then overflow get_file_tree.
         # Project-overridable (#301): big monorepos vs small repos want different thresholds.
@@ -1762,6 +1924,7 @@ def index_folder(
                 progress_cb(_incr_total, _incr_total, "Parsing complete")
 
             # Shared pipeline: parse, enrich, summarize, extract metadata
+            incr_unparsed: list[str] = []
             new_symbols, incr_file_summaries, incr_file_languages, incr_file_imports, incremental_no_symbols = (
                 parse_and_prepare_incremental(
                     files_to_parse=files_to_parse,
@@ -1770,8 +1933,20 @@ def index_folder(
                     use_ai_summaries=use_ai_summaries,
                     warnings=warnings,
                     repo=str(folder_path),
+                    deadline=deadline,
+                    unparsed=incr_unparsed,
                 )
             )
+            if incr_unparsed:
+                # Leave skipped files out of the save entirely: their stored
+                # hash and mtime stay stale, so the next run picks them up.
+                _skipped = set(incr_unparsed)
+                changed = [f for f in changed if f not in _skipped]
+                new = [f for f in new if f not in _skipped]
+                for rel_path in _skipped:
+                    raw_files_subset.pop(rel_path, None)
+                    subset_hashes.pop(rel_path, None)
+                    updated_mtimes.pop(rel_path, None)
 
             git_head = _get_git_head(folder_path) or ""
             incr_context_metadata = collect_metadata(active_providers) if active_providers else None
@@ -1854,6 +2029,8 @@ def index_folder(
                 "no_symbols_count": len(incremental_no_symbols),
                 "no_symbols_files": incremental_no_symbols[:50],
             }
+            if incr_unparsed:
+                mark_timed_out(result, warnings, deadline, len(incr_unparsed), "index_folder")
             if _is_branch_delta:
                 result["branch"] = _current_branch
                 result["branch_delta"] = True
@@ -1876,40 +2053,52 @@ def index_folder(
         no_symbols_files: list[str] = []
         _languages_with_symbols: set[str] = set()
         _total_files = len(source_file_list)
-        for _file_idx, rel_path in enumerate(source_file_list):
-            if progress_cb:
-                progress_cb(_file_idx, _total_files, rel_path)
-            content = _read_file(rel_path)
-            if content is None:
-                continue
 
-            # Encode once — reused for both hashing and tree-sitter parsing
-            content_bytes = content.encode("utf-8")
-            file_hashes[rel_path] = _file_hash_bytes(content_bytes)
+        def _parse_jobs():
+            # Runs on this thread: reads, hashes and caches each file, then
+            # hands it to the parse pool.  Only in-flight files are held.
+            for _file_idx, rel_path in enumerate(source_file_list):
+                if progress_cb:
+                    progress_cb(_file_idx, _total_files, rel_path)
+                content = _read_file(rel_path)
+                if content is None:
+                    _done.add(rel_path)
+                    continue
 
-            # Write raw content to cache immediately, then process
-            file_dest = store._safe_content_path(content_dir, rel_path)
-            if file_dest:
-                file_dest.parent.mkdir(parents=True, exist_ok=True)
-                store._write_cached_text(file_dest, content)
+                # Encode once — reused for both hashing and tree-sitter parsing
+                content_bytes = content.encode("utf-8")
+                file_hashes[rel_path] = _file_hash_bytes(content_bytes)
 
-            language = get_language_for_path(rel_path)
-            if not language:
-                no_symbols_files.append(rel_path)
-                # content eligible for GC after this iteration
-                continue
-            try:
-                symbols = parse_file(content, rel_path, language, source_bytes=content_bytes, repo=str(folder_path))
-                if symbols:
-                    all_symbols.extend(symbols)
-                    symbols_by_file[rel_path].extend(symbols)
-                    _languages_with_symbols.add(language)
-                else:
+                # Write raw content to cache immediately, then process
+                file_dest = store._safe_content_path(content_dir, rel_path)
+                if file_dest:
+                    file_dest.parent.mkdir(parents=True, exist_ok=True)
+                    store._write_cached_text(file_dest, content)
+
+                language = get_language_for_path(rel_path)
+                if not language:
                     no_symbols_files.append(rel_path)
-                    logger.debug("NO SYMBOLS: %s", rel_path)
-            except Exception as e:
-                warnings.append(f"Failed to parse {rel_path}: {e}")
-                logger.debug("PARSE ERROR: %s — %s", rel_path, e)
+                    _done.add(rel_path)
+                    continue
+                yield ParseJob(rel_path, content, language, content_bytes)
+
+        # Results arrive in file order, so merging here needs no locking.
+        _done: set[str] = set()
+        for job, symbols, parse_error in parse_files(
+            _parse_jobs(), repo=str(folder_path), deadline=deadline,
+        ):
+            rel_path, content, language = job.rel_path, job.content, job.language
+            _done.add(rel_path)
+            if parse_error is not None:
+                warnings.append(f"Failed to parse {rel_path}: {parse_error}")
+                logger.debug("PARSE ERROR: %s — %s", rel_path, parse_error)
+            elif symbols:
+                all_symbols.extend(symbols)
+                symbols_by_file[rel_path].extend(symbols)
+                _languages_with_symbols.add(language)
+            else:
+                no_symbols_files.append(rel_path)
+                logger.debug("NO SYMBOLS: %s", rel_path)
 
             # Extract imports while content is in scope
             imps = extract_imports(content, rel_path, language)
@@ -1920,6 +2109,16 @@ def index_folder(
         if progress_cb:
             progress_cb(_total_files, _total_files, "Parsing complete")
 
+        # On a timeout, save only what was parsed.  Files left over get no
+        # hash or mtime, so the next (incremental) run treats them as new.
+        _unparsed = {f for f in source_file_list if f not in _done}
+        if _unparsed:
+            logger.info("Parsing timed out — %d file(s) left unparsed", len(_unparsed))
+            source_file_list = [f for f in source_file_list if f not in _unparsed]
+            file_hashes = {f: h for f, h in file_hashes.items() if f not in _unparsed}
+            file_mtimes = {f: m for f, m in file_mtimes.items() if f not in _unparsed}
+        _use_ai = use_ai_summaries and not expired(deadline)
+
         logger.info(
             "Parsing complete — with symbols: %d, no symbols: %d",
             len(symbols_by_file),
@@ -1964,10 +2163,10 @@ def index_folder(
                 _needs_summary, _already_summarized = _split_for_summarization(
                     all_symbols, _folder_existing_summaries, _folder_unchanged_files
                 )
-                _summarized = summarize_symbols(_needs_summary, use_ai=use_ai_summaries, repo=str(folder_path)) if _needs_summary else []
+                _summarized = summarize_symbols(_needs_summary, use_ai=_use_ai, repo=str(folder_path)) if _needs_summary else []
                 all_symbols = _summarized + _already_summarized
             else:
-                all_symbols = summarize_symbols(all_symbols, use_ai=use_ai_summaries, repo=str(folder_path))
+                all_symbols = summarize_symbols(all_symbols, use_ai=_use_ai, repo=str(folder_path))
 
         # Generate file-level summaries (single-pass grouping) using shared helpers
         file_symbols_map = defaultdict(list)
@@ -2041,7 +2240,7 @@ def index_folder(
                 current_files_set = set(source_file_list)
 
                 delta_new = sorted(current_files_set - base_files)
-                delta_deleted = sorted(base_files - current_files_set)
+                delta_deleted = sorted(base_files - current_files_set - _unparsed)
                 delta_changed = sorted(
                     f for f in (current_files_set & base_files)
                     if file_hashes.get(f, "") != base_index.file_hashes.get(f, "")
@@ -2179,6 +2378,8 @@ def index_folder(
             "no_symbols_count": len(no_symbols_files),
             "no_symbols_files": no_symbols_files[:50],  # Show up to 50 for inspection
         }
+        if _unparsed:
+            mark_timed_out(result, warnings, deadline, len(_unparsed), "index_folder")
         if _is_branch_delta:
             result["branch"] = _current_branch
             result["branch_delta"] = True
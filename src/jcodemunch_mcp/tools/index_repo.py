@@ -13,13 +13,19 @@ import httpx
 
 logger = logging.getLogger(__name__)
 
+from ..deadline import current_deadline
 from ..parser import get_language_for_path
-from ..security import is_secret_file, is_binary_extension, get_max_index_files, get_extra_ignore_patterns, get_skip_patterns
+from ..security import (
+    is_secret_file, is_binary_extension, is_binary_content, is_generated_content,
+    get_max_index_files, get_max_file_size,
+    get_extra_ignore_patterns, get_exclude_generated, get_skip_patterns,
+)
 from ..storage import IndexStore
 from ._indexing_pipeline import (
     file_languages_for_paths as _file_languages_for_paths,
     language_counts as _language_counts,
     complete_file_summaries as _complete_file_summaries,
+    mark_timed_out,
     parse_and_prepare_incremental,
     parse_and_prepare_full,
 )
@@ -160,7 +166,7 @@ def discover_source_files(
     tree_entries: list[dict],
     gitignore_content: Optional[str] = None,
     max_files: Optional[int] = None,
-    max_size: int = 500 * 1024,  # 500KB
+    max_size: Optional[int] = None,  # default: max_file_size config, 500KB
     extra_ignore_patterns: Optional[list] = None,
 ) -> tuple[list[str], dict[str, str], bool]:
     """Discover source files from tree entries.
@@ -180,6 +186,7 @@ def discover_source_files(
     import pathspec
 
     max_files = get_max_index_files(max_files)
+    max_size = get_max_file_size(max_size)
 
     # Parse gitignore if provided
     gitignore_spec = None
@@ -332,6 +339,7 @@ async def index_repo(
 
     warnings = []
     max_files = get_max_index_files()
+    deadline = current_deadline()
 
     try:
         t0 = time.monotonic()
@@ -433,9 +441,18 @@ async def index_repo(
 
         # Build current_files map from fetched content
         current_files: dict[str, str] = {}
+        exclude_generated = get_exclude_generated()
         for path, content in file_contents:
-            if content:
-                current_files[path] = content
+            if not content:
+                continue
+            # Extension checks miss binaries with source-like names; the
+            # NUL-byte heuristic catches them before they reach the parser.
+            if is_binary_content(content[:8192].encode("utf-8", errors="replace")):
+                warnings.append(f"Skipped binary file: {path}")
+                continue
+            if exclude_generated and is_generated_content(content.encode("utf-8", errors="replace")):
+                continue
+            current_files[path] = content
 
         if existing_index is None and store.has_index(owner, repo):
             logger.warning(
@@ -478,14 +495,25 @@ async def index_repo(
             raw_files_subset = {p: current_files[p] for p in files_to_parse if p in current_files}
 
             # Shared pipeline: parse, enrich, summarize, extract metadata
+            incr_unparsed: list[str] = []
             new_symbols, incr_file_summaries, incr_file_languages, incr_file_imports, incremental_no_symbols = (
                 parse_and_prepare_incremental(
                     files_to_parse=files_to_parse,
                     file_contents=raw_files_subset,
                     use_ai_summaries=use_ai_summaries,
                     warnings=warnings,
+                    deadline=deadline,
+                    unparsed=incr_unparsed,
                 )
             )
+            if incr_unparsed:
+                # Treated like failed fetches: left out of the save so their
+                # old blob SHA (or none) brings them back next run.
+                _skipped = set(incr_unparsed)
+                changed = [p for p in changed if p not in _skipped]
+                new = [p for p in new if p not in _skipped]
+                raw_files_subset = {p: c for p, c in raw_files_subset.items() if p not in _skipped}
+                current_files = {p: c for p, c in current_files.items() if p not in _skipped}
 
             # Only record blob SHAs for files we successfully fetched
             # (failed fetches keep their old SHA so they're retried next run)
@@ -513,6 +541,8 @@ async def index_repo(
                 "no_symbols_count": len(incremental_no_symbols),
                 "no_symbols_files": incremental_no_symbols[:50],
             }
+            if incr_unparsed:
+                mark_timed_out(result, warnings, deadline, len(incr_unparsed), "index_repo")
             if warnings:
                 result["warnings"] = warnings
             return result
@@ -548,6 +578,7 @@ async def index_repo(
                 )
 
         # Shared pipeline: parse all files, enrich, summarize, extract metadata
+        unparsed: list[str] = []
         all_symbols, file_summaries, languages, file_languages, file_imports, no_symbols_files = (
             parse_and_prepare_full(
                 file_contents=current_files,
@@ -555,8 +586,17 @@ async def index_repo(
                 warnings=warnings,
                 existing_summaries=_existing_summaries,
                 unchanged_files=_unchanged_files,
+                deadline=deadline,
+                unparsed=unparsed,
             )
         )
+        if unparsed:
+            # Saved without a blob SHA, the skipped files come back as new
+            # on the next (incremental) run.
+            _skipped = set(unparsed)
+            current_files = {p: c for p, c in current_files.items() if p not in _skipped}
+            file_hashes = {p: h for p, h in file_hashes.items() if p not in _skipped}
+            blob_shas = {p: sha for p, sha in blob_shas.items() if p not in _skipped}
         source_file_list = sorted(current_files)
         index = store.save_index(
             owner=owner,
@@ -588,6 +628,8 @@ async def index_repo(
             "no_symbols_count": len(no_symbols_files),
             "no_symbols_files": no_symbols_files[:50],
         }
+        if unparsed:
+            mark_timed_out(result, warnings, deadline, len(unparsed), "index_repo")
 
         logger.info(
             "index_repo complete — repo: %s/%s, files: %d, symbols: %d",
@@ -0,0 +1,207 @@
+"""List TODO/FIXME/HACK/XXX markers in comments, attributed to their enclosing symbol.
+
+Comments are found with the same tree-sitter comment nodes ``search_ast``
+matches (``comment``, ``line_comment``, ``block_comment``, doc comments), so
+a marker inside a string literal is not reported and every language the
+parser supports is covered.  Runs of single-line comments on consecutive
+lines are read as one comment, so a TODO wrapped over several ``//`` lines
+comes back as one entry with its full text.
+
+Each marker is attributed to the innermost indexed symbol whose line range
+contains it — the function or method where the work is pending.  Files are
+read from the index's stored content, so remote (index_repo) repos work too.
+"""
+
+from __future__ import annotations
+
+import fnmatch
+import os
+import re
+import time
+from typing import Optional
+
+from ..parser.extractor import _clean_comment_markers
+from ..parser.languages import LANGUAGE_EXTENSIONS, LANGUAGE_REGISTRY
+from ..storage import IndexStore
+from ._utils import index_status_to_tool_error, resolve_repo
+from .search_ast import _COMMENT_NODES
+
+_DEFAULT_MARKERS = ("TODO", "FIXME", "HACK", "XXX")
+_DEFAULT_MAX_RESULTS = 200
+_MAX_RESULTS_CAP = 1000
+_TEXT_CAP = 500
+_MARKER_NAME_RE = re.compile(r"^\w+$")
+
+
+def _comment_groups(root, source_bytes: bytes) -> list[tuple[int, list[str]]]:
+    """Comment text as (first line, raw lines).
+
+    Single-line comments that sit alone on consecutive lines at the same
+    column are merged into one group; trailing comments after code never are.
+    """
+    nodes = []
+    stack = [root]
+    while stack:
+        node = stack.pop()
+        if node.type in _COMMENT_NODES:
+            nodes.append(node)
+            continue
+        stack.extend(reversed(node.children))
+
+    groups: list[tuple[int, list[str]]] = []
+    prev = None  # (row, column) of the last mergeable comment
+    for node in nodes:
+        text = source_bytes[node.start_byte:node.end_byte].decode("utf-8", errors="replace")
+        lines = text.rstrip("\n").split("\n")
+        row, col = node.start_point
+        line_start = source_bytes.rfind(b"\n", 0, node.start_byte) + 1
+        mergeable = len(lines) == 1 and not source_bytes[line_start:node.start_byte].strip()
+        if mergeable and prev == (row - 1, col):
+            groups[-1][1].extend(lines)
+        else:
+            groups.append((row + 1, lines))
+        prev = (row, col) if mergeable else None
+    return groups
+
+
+def _find_markers(groups, marker_re: re.Pattern, canonical: dict[str, str]) -> list[dict]:
+    """One entry per marker: its line and the comment text from the marker on.
+
+    The text runs to the next marker, a blank comment line, or the end of
+    the comment, whichever comes first.
+    """
+    found: list[dict] = []
+    for first_line, raw in groups:
+        cleaned = [_clean_comment_markers(line) for line in raw]
+        hits = [(i, marker_re.search(line)) for i, line in enumerate(cleaned)]
+        hits = [(i, m) for i, m in hits if m]
+        for n, (i, m) in enumerate(hits):
+            stop = hits[n + 1][0] if n + 1 < len(hits) else len(cleaned)
+            parts = [cleaned[i][m.start():]]
+            for line in cleaned[i + 1:stop]:
+                if not line.strip():
+                    break
+                parts.append(line.strip())
+            found.append({
+                "line": first_line + i,
+                "marker": canonical[m.group(1).lower()],
+                "text": "\n".join(parts).strip()[:_TEXT_CAP],
+            })
+    return found
+
+
+def _enclosing_symbol(file_syms: list[dict], line: int) -> Optional[dict]:
+    """Innermost symbol whose line range contains *line*."""
+    best = None
+    for sym in file_syms:
+        start, end = sym.get("line", 0), sym.get("end_line", 0) or sym.get("line", 0)
+        if start <= line <= end and (best is None or end - start < best[0]):
+            best = (end - start, sym)
+    return best[1] if best else None
+
+
+def list_todos(
+    repo: str,
+    markers: Optional[list[str]] = None,
+    file_pattern: Optional[str] = None,
+    language: Optional[str] = None,
+    max_results: int = _DEFAULT_MAX_RESULTS,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """List tech-debt markers in comments with the symbol each one lives in.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        markers: Marker words to look for, matched case-insensitively as
+            whole words (default TODO, FIXME, HACK, XXX).
+        file_pattern: Optional glob on file paths (e.g. ``src/**/*.go``).
+        language: Optional language filter (e.g. ``go``, ``python``).
+        max_results: Cap on markers returned (default 200, max 1000).
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with ``todos`` (file, line, marker, text, and ``symbol``/
+        ``symbol_id``/``symbol_kind`` when the marker is inside an indexed
+        symbol), ``by_marker`` counts, ``truncated``, and _meta.
+    """
+    start = time.perf_counter()
+
+    markers = list(markers) if markers else list(_DEFAULT_MARKERS)
+    bad = [m for m in markers if not _MARKER_NAME_RE.match(m)]
+    if bad:
+        return {"error": f"Invalid marker(s): {', '.join(bad)}. Markers must be single words."}
+    canonical = {m.lower(): m.upper() for m in markers}
+    marker_re = re.compile(
+        r"\b(" + "|".join(re.escape(m) for m in sorted(canonical, key=len, reverse=True)) + r")\b",
+        re.IGNORECASE,
+    )
+    max_results = max(1, min(int(max_results), _MAX_RESULTS_CAP))
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    from tree_sitter_language_pack import get_parser
+
+    symbols_by_file: dict[str, list[dict]] = {}
+    for sym in index.symbols:
+        symbols_by_file.setdefault(sym.get("file", ""), []).append(sym)
+
+    file_langs = getattr(index, "file_languages", {}) or {}
+    todos: list[dict] = []
+    by_marker: dict[str, int] = {}
+    files_scanned = 0
+    truncated = False
+
+    for file_path in sorted(index.source_files):
+        lang = file_langs.get(file_path) or LANGUAGE_EXTENSIONS.get(os.path.splitext(file_path)[1].lower(), "")
+        spec = LANGUAGE_REGISTRY.get(lang)
+        if spec is None:
+            continue
+        if language and lang != language.lower():
+            continue
+        if file_pattern and not fnmatch.fnmatch(file_path, file_pattern):
+            continue
+        content = store.get_file_content(owner, name, file_path, _index=index)
+        if not content:
+            continue
+        source_bytes = content.encode("utf-8")
+        try:
+            tree = get_parser(spec.ts_language).parse(source_bytes)
+        except Exception:
+            continue
+        files_scanned += 1
+
+        for hit in _find_markers(_comment_groups(tree.root_node, source_bytes), marker_re, canonical):
+            if len(todos) >= max_results:
+                truncated = True
+                break
+            entry = {"file": file_path, **hit}
+            sym = _enclosing_symbol(symbols_by_file.get(file_path, []), hit["line"])
+            if sym is not None:
+                entry["symbol"] = sym.get("qualified_name") or sym.get("name", "")
+                entry["symbol_id"] = sym.get("id", "")
+                entry["symbol_kind"] = sym.get("kind", "")
+            todos.append(entry)
+            by_marker[hit["marker"]] = by_marker.get(hit["marker"], 0) + 1
+        if truncated:
+            break
+
+    return {
+        "repo": f"{owner}/{name}",
+        "markers": sorted(canonical.values()),
+        "total": len(todos),
+        "by_marker": dict(sorted(by_marker.items())),
+        "truncated": truncated,
+        "todos": todos,
+        "_meta": {
+            "timing_ms": round((time.perf_counter() - start) * 1000, 1),
+            "files_scanned": files_scanned,
+        },
+    }
@@ -0,0 +1,849 @@
+"""locate_definition — jump from a reference to the declaration it refers to.
+
+Given ``file_path:line:column`` of an identifier, return the site that
+declares it.  Resolution for Go, in order of preference:
+
+1. ``gopls definition`` when ``gopls`` is on PATH, the repo was indexed
+   from a local folder, and the file on disk matches the index.  That is
+   go/types resolution, exact across packages, for method calls, embedded
+   (promoted) members and struct fields.
+2. A syntactic resolver built on rename_preview's scope binder:
+
+   * locals, parameters and receivers resolve through Go's block scoping;
+   * package-level names resolve to their declaration in any file of the
+     package;
+   * ``pkg.Name`` resolves through the file's imports to the package's
+     directory in the index;
+   * ``x.Method`` and ``x.field`` infer the type of ``x`` from its
+     declaration (parameter and variable types, composite literals,
+     ``&T{}``, function results, field types) and look the member up on
+     that type, following embedded fields.
+
+Identifiers declared outside the index — the standard library, module
+dependencies, Go's predeclared names — resolve to ``external`` with their
+import path (``builtin`` for predeclared names) instead of failing.
+
+Columns are 1-based and count characters, not bytes.
+"""
+
+from __future__ import annotations
+
+import os
+import posixpath
+import re
+import shutil
+import time
+from typing import Optional
+
+from ..storage import IndexStore
+from . import _gopls
+from ._utils import index_status_to_tool_error, resolve_repo
+from .get_dependencies import _Resolver, _is_stdlib
+from .rename_preview import (
+    _GoBinder,
+    _GoPackageView,
+    _IMPORT,
+    _MEMBER,
+    _PKG,
+    _char_column,
+    _import_local_names,
+    _import_specs,
+    _package_name,
+    _text,
+    _top_level_names,
+)
+
+_SUPPORTED_LANGUAGES = ("go",)
+
+_GO_BUILTINS = frozenset({
+    "any", "bool", "byte", "comparable", "complex64", "complex128", "error",
+    "float32", "float64", "int", "int8", "int16", "int32", "int64", "rune",
+    "string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+    "true", "false", "iota", "nil",
+    "append", "cap", "clear", "close", "complex", "copy", "delete", "imag",
+    "len", "make", "max", "min", "new", "panic", "print", "println", "real", "recover",
+})
+_NAME_NODE_TYPES = frozenset({"identifier", "type_identifier", "field_identifier", "package_identifier"})
+_MAX_INFER_DEPTH = 8
+_MAX_CANDIDATES = 20
+
+class _External(Exception):
+    """A type resolved to a package outside the index."""
+
+    def __init__(self, import_path: str, name: str):
+        super().__init__(import_path)
+        self.import_path = import_path
+        self.name = name
+
+
+def _byte_offset(source: bytes, line: int, column: int) -> Optional[int]:
+    lines = source.split(b"\n")
+    if line > len(lines):
+        return None
+    row = lines[line - 1].decode("utf-8", errors="replace")
+    if column > len(row) + 1:
+        return None
+    return sum(len(r) + 1 for r in lines[: line - 1]) + len(row[: column - 1].encode("utf-8"))
+
+
+def _name_node_at(root, offset: int):
+    """The identifier covering *offset*, or ending right before it."""
+    for start in (offset, offset - 1):
+        if start < 0:
+            continue
+        node = root.descendant_for_byte_range(start, start + 1)
+        if node is not None and node.type in _NAME_NODE_TYPES:
+            return node
+    return None
+
+
+def _default_name(import_path: str) -> str:
+    """Conventional package name of an import path: ``.../yaml.v3`` -> yaml, ``.../chi/v5`` -> chi."""
+    parts = import_path.split("/")
+    last = parts[-1]
+    if re.match(r"^v\d+$", last) and len(parts) > 1:
+        last = parts[-2]
+    return re.sub(r"\.v\d+$", "", last)
+
+
+def _base_type(type_text: str) -> str:
+    """``*pkg.List[T]`` -> ``pkg.List``; composite types come back unchanged."""
+    text = type_text.strip()
+    while text.startswith(("*", "(")):
+        text = text.lstrip("*(").rstrip(")").strip()
+    if not text.startswith("[") and "[" in text:
+        text = text.split("[", 1)[0]
+    return text
+
+
+class _GoLocator:
+    """Syntactic definition lookup over the Go files of one index."""
+
+    def __init__(self, index, store: IndexStore, owner: str, name: str):
+        self.index = index
+        self.view = _GoPackageView(store, owner, name, index)
+        self.resolver = _Resolver(index)
+
+    # -- packages ---------------------------------------------------------
+
+    def package_files(self, directory: str, pkg_name: str) -> list[str]:
+        out = []
+        for f in self.view.go_files_in(directory):
+            _, froot = self.view.parse(f)
+            if froot is not None and _package_name(froot) == pkg_name:
+                out.append(f)
+        return out
+
+    def package_of(self, path: str) -> tuple[str, list[str]]:
+        _, root = self.view.parse(path)
+        directory = posixpath.dirname(path)
+        return directory, self.package_files(directory, _package_name(root))
+
+    def import_dir(self, importer: str, import_path: str) -> Optional[str]:
+        directory = self.resolver.resolve(import_path, importer, "go")
+        if directory is not None and self.view.go_files_in(directory):
+            return directory
+        return None
+
+    def import_for(self, path: str, local: str) -> Optional[str]:
+        """Import path bound to *local* in *path* (the last element for unaliased imports)."""
+        _, root = self.view.parse(path)
+        specs = list(_import_specs(root))
+        for alias, kind, import_path in specs:
+            if kind == "name" and alias == local:
+                return import_path
+            if kind == "default" and local in (posixpath.basename(import_path), _default_name(import_path)):
+                return import_path
+        # Otherwise the imported package's own clause decides the name.
+        for _, kind, import_path in specs:
+            if kind != "default":
+                continue
+            directory = self.import_dir(path, import_path)
+            if directory is not None and self.dir_package_name(directory) == local:
+                return import_path
+        return None
+
+    def dir_package_name(self, directory: str) -> str:
+        files = [f for f in self.view.go_files_in(directory) if not f.endswith("_test.go")]
+        return _package_name(self.view.parse(files[0])[1]) if files else ""
+
+    # -- bindings ---------------------------------------------------------
+
+    def bind(self, path: str, node) -> tuple[Optional[tuple], str]:
+        """``(binding, role)`` of identifier *node* in *path*."""
+        _, root = self.view.parse(path)
+        _, files = self.package_of(path)
+        name = _text(node)
+        if any(name in _top_level_names(self.view.parse(f)[1]) for f in files):
+            top = _PKG
+        elif name in _import_local_names(root) or self.import_for(path, name) is not None:
+            top = _IMPORT
+        else:
+            top = None
+        for occ_node, binding, role in _GoBinder(name, path, top).run(root):
+            if occ_node.start_byte == node.start_byte:
+                return binding, role
+        return top, "reference"
+
+    def package_decl(self, files: list[str], name: str) -> Optional[tuple[str, object]]:
+        """``(file, name node)`` declaring *name* at package scope."""
+        for f in files:
+            _, froot = self.view.parse(f)
+            if name not in _top_level_names(froot):
+                continue
+            for occ_node, binding, role in _GoBinder(name, f, _PKG).run(froot):
+                if binding == _PKG and role == "definition":
+                    return f, occ_node
+        return None
+
+    # -- types ------------------------------------------------------------
+
+    def named_type(self, type_text: str, path: str) -> Optional[tuple[str, str]]:
+        """``(package dir, TypeName)`` of *type_text* as written in *path*.
+
+        Raises _External for a type from a package outside the index.
+        """
+        base = _base_type(type_text)
+        if not base or not re.match(r"^[\w.]+$", base):
+            return None
+        if "." in base:
+            qualifier, tname = base.split(".", 1)
+            import_path = self.import_for(path, qualifier)
+            if import_path is None:
+                return None
+            directory = self.import_dir(path, import_path)
+            if directory is None:
+                raise _External(import_path, tname)
+            return directory, tname
+        if base in _GO_BUILTINS:
+            raise _External("builtin", base)
+        return posixpath.dirname(path), base
+
+    def decl_type(self, path: str, name_node, depth: int) -> Optional[tuple[str, str]]:
+        """``(type text, file it is written in)`` for the declaration *name_node*."""
+        parent = name_node.parent
+        if parent is None:
+            return None
+        if parent.type in ("parameter_declaration", "variadic_parameter_declaration"):
+            type_node = parent.child_by_field_name("type")
+            if type_node is None:
+                return None
+            prefix = "[]" if parent.type == "variadic_parameter_declaration" else ""
+            return prefix + _text(type_node), path
+        if parent.type in ("var_spec", "const_spec"):
+            type_node = parent.child_by_field_name("type")
+            if type_node is not None:
+                return _text(type_node), path
+            names = parent.children_by_field_name("name")
+            values = parent.child_by_field_name("value")
+            return self._assigned_type(path, name_node, names, values, depth)
+        if parent.type == "expression_list" and parent.parent is not None:
+            stmt = parent.parent
+            if stmt.type == "short_var_declaration":
+                return self._assigned_type(
+                    path, name_node, parent.named_children, stmt.child_by_field_name("right"), depth,
+                )
+            if stmt.type == "range_clause":
+                right = stmt.child_by_field_name("right")
+                if right is None or parent.named_children.index(name_node) != 1:
+                    return None
+                container = self.expr_type(path, right, depth + 1)
+                return self._element_type(container)
+        return None
+
+    def _element_type(self, container: Optional[tuple[str, str]]) -> Optional[tuple[str, str]]:
+        if container is None:
+            return None
+        text, path = container
+        text = text.strip()
+        if text.startswith("[]"):
+            return text[2:], path
+        if text.startswith("map["):
+            depth = 0
+            for i, ch in enumerate(text[3:], start=3):
+                depth += ch == "["
+                depth -= ch == "]"
+                if depth == 0:
+                    return text[i + 1:], path
+        m = re.match(r"^\[\d*\]", text)
+        if m:
+            return text[m.end():], path
+        return None
+
+    def _assigned_type(self, path, name_node, names, values, depth) -> Optional[tuple[str, str]]:
+        if values is None:
+            return None
+        exprs = values.named_children if values.type == "expression_list" else [values]
+        position = next((i for i, n in enumerate(names) if n.start_byte == name_node.start_byte), None)
+        if position is None:
+            return None
+        if len(exprs) == len(names):
+            return self.expr_type(path, exprs[position], depth + 1)
+        if len(exprs) == 1 and exprs[0].type == "call_expression":
+            results = self.call_results(path, exprs[0], depth + 1)
+            if results and position < len(results[0]):
+                return results[0][position], results[1]
+        return None
+
+    def expr_type(self, path: str, expr, depth: int) -> Optional[tuple[str, str]]:
+        """``(type text, file it is written in)`` of expression *expr*, or None."""
+        if expr is None or depth > _MAX_INFER_DEPTH:
+            return None
+        t = expr.type
+        if t == "parenthesized_expression" and expr.named_children:
+            return self.expr_type(path, expr.named_children[0], depth + 1)
+        if t == "unary_expression":
+            # &T{} and *p: pointers make no difference to member lookup.
+            return self.expr_type(path, expr.child_by_field_name("operand"), depth + 1)
+        if t == "composite_literal":
+            type_node = expr.child_by_field_name("type")
+            return (_text(type_node), path) if type_node is not None else None
+        if t == "call_expression":
+            results = self.call_results(path, expr, depth + 1)
+            if results and results[0]:
+                return results[0][0], results[1]
+            return None
+        if t == "identifier":
+            try:
+                site = self.resolve_identifier(path, expr, depth + 1)
+            except _External:
+                return None
+            if site is None or site["_node"] is None or site.get("kind") in ("function", "type", "package"):
+                return None
+            return self.decl_type(site["_file"], site["_node"], depth + 1)
+        if t == "selector_expression":
+            try:
+                found = self.resolve_selector(path, expr, depth + 1)
+            except _External:
+                return None
+            if found is None:
+                return None
+            if found["kind"] == "field":
+                return found["_type"], found["_file"]
+            if found["kind"] in ("variable", "constant"):
+                return self.decl_type(found["_file"], found["_node"], depth + 1)
+        return None
+
+    def call_results(self, path: str, call, depth: int) -> Optional[tuple[list[str], str]]:
+        """``([result type texts], file)`` of a call, or a conversion's target type."""
+        fn = call.child_by_field_name("function")
+        if fn is None:
+            return None
+        if fn.type == "parenthesized_expression" and fn.named_children:
+            fn = fn.named_children[0]
+        site = None
+        if fn.type == "identifier":
+            if _text(fn) == "new":
+                args = call.child_by_field_name("arguments")
+                if args is not None and args.named_children:
+                    return ["*" + _text(args.named_children[0])], path
+            try:
+                site = self.resolve_identifier(path, fn, depth + 1)
+            except _External:
+                return None
+        elif fn.type == "selector_expression":
+            try:
+                site = self.resolve_selector(path, fn, depth + 1)
+            except _External:
+                return None
+        elif fn.type in ("generic_type", "type_identifier", "qualified_type"):
+            return [_text(fn)], path
+        if site is None:
+            return None
+        if site.get("kind") == "type":
+            return [site["name"]], site["_file"]
+        decl = site["_node"].parent
+        if decl is None or decl.type not in ("function_declaration", "method_declaration", "method_elem", "method_spec"):
+            return None
+        result = decl.child_by_field_name("result")
+        if result is None:
+            return [], site["_file"]
+        if result.type != "parameter_list":
+            return [_text(result)], site["_file"]
+        types = []
+        for p in result.named_children:
+            type_node = p.child_by_field_name("type")
+            if type_node is not None:
+                types.extend([_text(type_node)] * max(1, len(p.children_by_field_name("name"))))
+        return types, site["_file"]
+
+    # -- members ----------------------------------------------------------
+
+    def type_spec(self, directory: str, tname: str) -> Optional[tuple[str, object]]:
+        for f in self.view.go_files_in(directory):
+            _, froot = self.view.parse(f)
+            stack = [c for c in froot.named_children if c.type == "type_declaration"]
+            while stack:
+                node = stack.pop()
+                if node.type in ("type_spec", "type_alias"):
+                    name = node.child_by_field_name("name")
+                    if name is not None and _text(name) == tname:
+                        return f, node
+                elif node.named_children:
+                    stack.extend(node.named_children)
+        return None
+
+    def find_member(self, directory: str, tname: str, member: str, seen: Optional[set] = None) -> Optional[dict]:
+        """Method or field *member* of type *tname*, promoted members included."""
+        seen = seen if seen is not None else set()
+        if (directory, tname) in seen:
+            return None
+        seen.add((directory, tname))
+        for f in self.view.go_files_in(directory):
+            _, froot = self.view.parse(f)
+            for decl in froot.named_children if froot is not None else []:
+                if decl.type != "method_declaration":
+                    continue
+                name_node = decl.child_by_field_name("name")
+                if name_node is None or _text(name_node) != member:
+                    continue
+                receiver = decl.child_by_field_name("receiver")
+                param = next((c for c in receiver.named_children if c.type == "parameter_declaration"), None) \
+                    if receiver is not None else None
+                type_node = param.child_by_field_name("type") if param is not None else None
+                if type_node is not None and _base_type(_text(type_node)) == tname:
+                    return {"kind": "method", "name": member, "_file": f, "_node": name_node}
+
+        spec = self.type_spec(directory, tname)
+        if spec is None:
+            return None
+        path, node = spec
+        body = node.child_by_field_name("type")
+        while body is not None and body.type in ("pointer_type", "parenthesized_type") and body.named_children:
+            body = body.named_children[0]
+        if body is None:
+            return None
+        embedded: list[str] = []
+        if body.type == "struct_type":
+            field_list = next((c for c in body.named_children if c.type == "field_declaration_list"), None)
+            for decl in field_list.named_children if field_list is not None else []:
+                if decl.type != "field_declaration":
+                    continue
+                type_node = decl.child_by_field_name("type")
+                names = decl.children_by_field_name("name")
+                if not names and type_node is not None:
+                    if _base_type(_text(type_node)).rsplit(".", 1)[-1] == member:
+                        return {"kind": "field", "name": member, "_file": path,
+                                "_node": type_node, "_type": _text(type_node)}
+                    embedded.append(_text(type_node))
+                for n in names:
+                    if _text(n) == member:
+                        return {"kind": "field", "name": member, "_file": path, "_node": n,
+                                "_type": _text(type_node) if type_node is not None else ""}
+        elif body.type == "interface_type":
+            for elem in body.named_children:
+                if elem.type in ("method_elem", "method_spec"):
+                    n = elem.child_by_field_name("name")
+                    if n is not None and _text(n) == member:
+                        return {"kind": "method", "name": member, "_file": path, "_node": n}
+                elif elem.type in ("type_elem", "constraint_elem", "interface_type_name") or elem.type.endswith("_type"):
+                    embedded.append(_text(elem))
+        elif body.type in ("type_identifier", "qualified_type", "generic_type"):
+            # A defined type keeps its underlying type's fields (not its methods).
+            embedded.append(_text(body))
+        for type_text in embedded:
+            try:
+                target = self.named_type(type_text, path)
+            except _External:
+                continue
+            if target is not None:
+                found = self.find_member(target[0], target[1], member, seen)
+                if found is not None:
+                    return found
+        return None
+
+    # -- resolution -------------------------------------------------------
+
+    def resolve_identifier(self, path: str, node, depth: int = 0) -> Optional[dict]:
+        """Declaration site of a plain identifier; raises _External outside the index."""
+        binding, role = self.bind(path, node)
+        name = _text(node)
+        if binding is not None and binding[0] == "local":
+            _, root = self.view.parse(path)
+            decl = root.descendant_for_byte_range(binding[2], binding[2] + len(name.encode("utf-8")))
+            if decl is None:
+                return None
+            return {"kind": _local_kind(decl), "name": name, "_file": path, "_node": decl, "binding": "local"}
+        if binding == _PKG:
+            _, files = self.package_of(path)
+            found = self.package_decl(files, name)
+            if found is None:
+                return None
+            f, decl = found
+            return {"kind": _decl_kind(decl), "name": name, "_file": f, "_node": decl, "binding": "package"}
+        if binding == _IMPORT:
+            import_path = self.import_for(path, name) or name
+            _, root = self.view.parse(path)
+            return {"kind": "package", "name": name, "_file": path,
+                    "_node": _import_spec_node(root, import_path) or node,
+                    "binding": "import", "_import_path": import_path}
+        if binding == _MEMBER:
+            return None
+        # Not declared in this file or package: dot-imports, then the universe block.
+        _, root = self.view.parse(path)
+        for _, kind, import_path in _import_specs(root):
+            if kind != "dot":
+                continue
+            directory = self.import_dir(path, import_path)
+            if directory is None:
+                continue
+            found = self.package_decl(self.package_files(directory, self.dir_package_name(directory)), name)
+            if found is not None:
+                return {"kind": _decl_kind(found[1]), "name": name, "_file": found[0],
+                        "_node": found[1], "binding": "package"}
+        if name in _GO_BUILTINS:
+            raise _External("builtin", name)
+        dots = [p for _, kind, p in _import_specs(root) if kind == "dot"]
+        if dots:
+            raise _External(dots[0], name)
+        return None
+
+    def resolve_selector(self, path: str, selector, depth: int = 0) -> Optional[dict]:
+        """Declaration site of ``x.name``: a package member or a method/field of x's type."""
+        operand = selector.child_by_field_name("operand")
+        field = selector.child_by_field_name("field")
+        if operand is None or field is None:
+            return None
+        member = _text(field)
+        if operand.type == "identifier":
+            binding, _ = self.bind(path, operand)
+            if binding == _IMPORT:
+                return self.qualified(path, _text(operand), member)
+        owner = self.expr_type(path, operand, depth + 1)
+        if owner is None:
+            return None
+        try:
+            target = self.named_type(owner[0], owner[1])
+        except _External as ext:
+            raise _External(ext.import_path, f"{ext.name}.{member}") from None
+        if target is None:
+            return None
+        return self.find_member(target[0], target[1], member)
+
+    def qualified(self, path: str, qualifier: str, name: str) -> Optional[dict]:
+        import_path = self.import_for(path, qualifier)
+        if import_path is None:
+            return None
+        directory = self.import_dir(path, import_path)
+        if directory is None:
+            raise _External(import_path, name)
+        found = self.package_decl(self.package_files(directory, self.dir_package_name(directory)), name)
+        if found is None:
+            return None
+        return {"kind": _decl_kind(found[1]), "name": name, "_file": found[0], "_node": found[1],
+                "binding": "package"}
+
+    def key_owner(self, node) -> Optional[str]:
+        """Type text of the composite literal whose struct key is *node*."""
+        lit = node.parent
+        while lit is not None and lit.type != "literal_value":
+            lit = lit.parent
+        if lit is None or lit.parent is None or lit.parent.type != "composite_literal":
+            return None
+        type_node = lit.parent.child_by_field_name("type")
+        return _text(type_node) if type_node is not None else None
+
+    def candidates(self, member: str) -> list[dict]:
+        """Every method or struct field named *member* in the index."""
+        out = []
+        for sym in self.index.symbols:
+            if sym.get("language") != "go":
+                continue
+            if sym.get("kind") == "method" and sym.get("name") == member:
+                out.append({"file": sym["file"], "line": sym["line"], "name": member, "kind": "method",
+                            "receiver_type": sym.get("receiver_type", ""), "symbol_id": sym["id"]})
+            for f in sym.get("fields") or []:
+                if f.get("name") == member:
+                    out.append({"file": sym["file"], "line": f.get("line", sym["line"]), "name": member,
+                                "kind": "field", "receiver_type": sym["name"], "symbol_id": sym["id"]})
+        out.sort(key=lambda c: (c["file"], c["line"]))
+        return out[:_MAX_CANDIDATES]
+
+
+def _import_spec_node(root, import_path: str):
+    """The alias (or, unaliased, the path string) of the import of *import_path*."""
+    stack = list(root.named_children)
+    while stack:
+        node = stack.pop()
+        if node.type in ("import_declaration", "import_spec_list"):
+            stack.extend(node.named_children)
+        elif node.type == "import_spec":
+            path_node = node.child_by_field_name("path")
+            if path_node is not None and _text(path_node).strip("\"`") == import_path:
+                return node.child_by_field_name("name") or path_node
+    return None
+
+
+def _local_kind(decl) -> str:
+    parent = decl.parent if decl is not None else None
+    if parent is None:
+        return "variable"
+    if parent.type in ("parameter_declaration", "variadic_parameter_declaration"):
+        owner = parent.parent
+        if owner is not None and owner.parent is not None and owner.parent.type == "method_declaration" \
+                and owner.parent.child_by_field_name("receiver") == owner:
+            return "receiver"
+        return "parameter"
+    if parent.type == "type_parameter_declaration":
+        return "type_parameter"
+    return _decl_kind(decl)
+
+
+def _decl_kind(decl) -> str:
+    parent = decl.parent if decl is not None else None
+    kinds = {
+        "function_declaration": "function",
+        "method_declaration": "method",
+        "type_spec": "type",
+        "type_alias": "type",
+        "const_spec": "constant",
+        "var_spec": "variable",
+    }
+    return kinds.get(parent.type, "variable") if parent is not None else "variable"
+
+
+def _classify(decl) -> tuple[str, str]:
+    """``(resolution, kind)`` of a declaring identifier found by position."""
+    node = decl.parent
+    while node is not None and node.type in ("pointer_type", "qualified_type", "generic_type"):
+        node = node.parent  # embedded fields: `*Base`, `pkg.Base`
+    if node is not None and node.type in ("method_declaration", "method_elem", "method_spec"):
+        return "method", "method"
+    if node is not None and node.type == "field_declaration":
+        return "field", "field"
+    scope = decl.parent
+    while scope is not None and scope.type != "source_file":
+        if scope.type in ("block", "func_literal", "parameter_list", "type_parameter_list"):
+            return "local", _local_kind(decl)
+        scope = scope.parent
+    return "package", _decl_kind(decl)
+
+
+def _site(index, view: _GoPackageView, found: dict) -> dict:
+    path, node = found["_file"], found["_node"]
+    source, _ = view.parse(path)
+    line = node.start_point[0] + 1
+    site = {
+        "file": path,
+        "line": line,
+        "column": _char_column(source, node.start_byte),
+        "name": found["name"],
+        "kind": found["kind"],
+    }
+    best = None
+    for sym in index.symbols:
+        if (sym.get("file") == path and sym.get("name") == found["name"]
+                and sym.get("line", 0) <= line <= sym.get("end_line", 0)):
+            if best is None or sym["end_line"] - sym["line"] < best["end_line"] - best["line"]:
+                best = sym
+    if best is not None:
+        site.update({"symbol_id": best["id"], "signature": best.get("signature", ""), "end_line": best["end_line"]})
+    return site
+
+
+def _external(import_path: str, name: str) -> dict:
+    return {
+        "import_path": import_path,
+        "name": name,
+        "stdlib": import_path == "builtin" or _is_stdlib(import_path, "go"),
+    }
+
+
+def _import_path_of_dir(directory: str) -> str:
+    """Import path of a package directory outside the repo (GOROOT or module cache)."""
+    directory = directory.replace(os.sep, "/")
+    if "/pkg/mod/" in directory:
+        rest = directory.split("/pkg/mod/", 1)[1]
+        rest = re.sub(r"@[^/]+", "", rest)
+        # The module cache escapes capitals as "!x".
+        return re.sub(r"!([a-z])", lambda m: m.group(1).upper(), rest)
+    go = shutil.which("go")
+    goroot = os.environ.get("GOROOT") or (
+        os.path.dirname(os.path.dirname(os.path.realpath(go))) if go else ""
+    )
+    src = goroot.replace(os.sep, "/").rstrip("/") + "/src/"
+    if goroot and directory.startswith(src):
+        rel = directory[len(src):]
+        return rel.split("vendor/", 1)[1] if rel.startswith("vendor/") else rel
+    if "/vendor/" in directory:
+        return directory.rsplit("/vendor/", 1)[1]
+    return ""
+
+
+def _gopls_locate(index, locator: _GoLocator, file_path: str, node, syntactic: dict) -> tuple[Optional[dict], str]:
+    """The gopls answer in this tool's result shape, or ``(None, reason)`` to fall back."""
+    source_root = getattr(index, "source_root", "") or ""
+    if not _gopls.available(source_root):
+        return None, "gopls not available"
+    source, _ = locator.view.parse(file_path)
+    pos, err = _gopls.position(source_root, file_path, source, node)
+    if pos is None:
+        return None, err
+    spans, err = _gopls.query(source_root, "definition", pos)
+    if spans is None:
+        return None, err
+    if not spans:
+        return None, "gopls returned no definition"
+    span_path, line, byte_col = spans[0]
+    name = _text(node)
+    rel = _gopls.relative_path(span_path, source_root)
+    if rel.startswith("../") or not index.has_source_file(rel):
+        external = syntactic.get("external")
+        if external is None:
+            external = _external(_import_path_of_dir(os.path.dirname(os.path.realpath(span_path))), name)
+        external = dict(external, file=span_path, line=line)
+        return {"resolution": "external", "external": external}, ""
+    file_source, froot = locator.view.parse(rel)
+    if froot is None:
+        return None, "definition file not cached"
+    offset = _gopls.byte_offset(file_source, line, byte_col)
+    if offset is None:
+        return None, "gopls position out of range"
+    target = _name_node_at(froot, offset)
+    if target is None:
+        return None, "gopls position is not an identifier"
+    resolution, kind = _classify(target)
+    found = {"kind": kind, "name": _text(target), "_file": rel, "_node": target}
+    return {"resolution": resolution, "definition": _site(index, locator.view, found)}, ""
+
+
+def _go_locate(locator: _GoLocator, file_path: str, node) -> dict:
+    """Syntactic resolution of identifier *node*: ``{resolution, definition | external | candidates}``."""
+    name = _text(node)
+    parent = node.parent
+    try:
+        if node.type == "field_identifier" and parent is not None and parent.type == "selector_expression":
+            operand = parent.child_by_field_name("operand")
+            if operand is not None and operand.type == "identifier" \
+                    and locator.bind(file_path, operand)[0] == _IMPORT:
+                found = locator.qualified(file_path, _text(operand), name)
+                return {"resolution": "package", "found": found} if found else {"resolution": "unresolved"}
+            found = locator.resolve_selector(file_path, parent)
+            if found is None:
+                candidates = locator.candidates(name)
+                return {"resolution": "ambiguous" if candidates else "unresolved", "candidates": candidates}
+            return {"resolution": "package" if found.get("binding") == "package" else found["kind"], "found": found}
+        if node.type == "type_identifier" and parent is not None and parent.type == "qualified_type":
+            pkg = parent.child_by_field_name("package")
+            found = locator.qualified(file_path, _text(pkg), name) if pkg is not None else None
+            return {"resolution": "package", "found": found} if found else {"resolution": "unresolved"}
+
+        binding, role = locator.bind(file_path, node)
+        if binding == _MEMBER:
+            if role == "definition":
+                resolution, kind = _classify(node)
+                return {"resolution": resolution, "found": {"kind": kind, "name": name, "_file": file_path, "_node": node}}
+            owner = locator.key_owner(node)
+            target = locator.named_type(owner, file_path) if owner else None
+            found = locator.find_member(target[0], target[1], name) if target else None
+            if found is None:
+                candidates = locator.candidates(name)
+                return {"resolution": "ambiguous" if candidates else "unresolved", "candidates": candidates}
+            return {"resolution": "field", "found": found}
+
+        found = locator.resolve_identifier(file_path, node)
+    except _External as ext:
+        return {"resolution": "builtin" if ext.import_path == "builtin" else "external",
+                "external": _external(ext.import_path, ext.name)}
+    if found is None:
+        return {"resolution": "unresolved"}
+    if found.get("binding") == "import":
+        import_path = found["_import_path"]
+        return {"resolution": "import", "found": found,
+                "package_dir": locator.import_dir(file_path, import_path), "import_path": import_path}
+    return {"resolution": found["binding"], "found": found}
+
+
+def locate_definition(
+    repo: str,
+    file_path: str,
+    line: int,
+    column: int,
+    use_type_checker: bool = True,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Resolve the identifier at ``file_path:line:column`` to its declaration.
+
+    Args:
+        repo:             Repository identifier (owner/repo or bare name).
+        file_path:        File holding the reference.
+        line:             1-based line of the reference.
+        column:           1-based character column inside the identifier.
+        use_type_checker: Try ``gopls definition`` (go/types) before the
+                          syntactic resolver (default True).
+        storage_path:     Optional index storage path override.
+
+    Returns:
+        ``{repo, file, line, column, identifier, resolution, resolver,
+        definition | external | candidates, _meta}``.  ``resolution`` is
+        ``local``, ``package``, ``method``, ``field``, ``import``,
+        ``external``, ``builtin``, ``ambiguous``, or ``unresolved``;
+        ``definition`` is ``{file, line, column, name, kind}`` plus
+        ``symbol_id``/``signature``/``end_line`` when the site is an indexed
+        symbol, and ``external`` is ``{import_path, name, stdlib}``.
+    """
+    t0 = time.perf_counter()
+    if line < 1 or column < 1:
+        return {"error": "line and column are 1-based."}
+
+    try:
+        owner, repo_name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, repo_name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, repo_name))
+    if not index.has_source_file(file_path):
+        return {"error": f"File not found: {file_path}"}
+
+    language = index.file_languages.get(file_path, "")
+    if language not in _SUPPORTED_LANGUAGES:
+        return {
+            "error": (
+                f"locate_definition does not support {language or 'this'} files yet "
+                f"(supported: {', '.join(_SUPPORTED_LANGUAGES)}). "
+                "search_symbols or find_references can find the declaration by name."
+            )
+        }
+
+    locator = _GoLocator(index, store, owner, repo_name)
+    source, root = locator.view.parse(file_path)
+    if source is None:
+        return {"error": f"File content not cached: {file_path}. Re-index to populate it."}
+    offset = _byte_offset(source, line, column)
+    node = _name_node_at(root, offset) if offset is not None else None
+    if node is None:
+        return {"error": f"No identifier at {file_path}:{line}:{column}."}
+
+    result = _go_locate(locator, file_path, node)
+    found = result.pop("found", None)
+    if found is not None:
+        result["definition"] = _site(index, locator.view, found)
+
+    resolver_used = "syntactic"
+    type_checker_note = ""
+    if use_type_checker and result["resolution"] not in ("import", "builtin"):
+        typed, type_checker_note = _gopls_locate(index, locator, file_path, node, result)
+        if typed is not None:
+            result = typed
+            resolver_used = "gopls"
+
+    meta = {"timing_ms": round((time.perf_counter() - t0) * 1000, 1)}
+    if type_checker_note and resolver_used == "syntactic":
+        meta["type_checker_skipped"] = type_checker_note
+    if result["resolution"] == "ambiguous":
+        meta["note"] = (
+            f"The type of the receiver could not be inferred; every method and field named "
+            f"'{_text(node)}' in the index is listed."
+        )
+    return {
+        "repo": f"{owner}/{repo_name}",
+        "file": file_path,
+        "line": line,
+        "column": column,
+        "identifier": _text(node),
+        "resolver": resolver_used,
+        **result,
+        "_meta": meta,
+    }
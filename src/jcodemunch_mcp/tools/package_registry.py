@@ -7,9 +7,10 @@ import logging
 import os
 import re
 import time
-from pathlib import Path
 from typing import Optional
 
+from ..config import default_storage_path
+
 logger = logging.getLogger(__name__)
 
 # ---------------------------------------------------------------------------
@@ -295,7 +296,7 @@ def extract_root_package_from_specifier(specifier: str, language: str) -> str:
 
 def _get_newest_index_mtime(all_repos: list[dict]) -> float:
     """Return the maximum mtime of all index .db files for cache invalidation."""
-    storage = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
+    storage = str(default_storage_path())
     newest: float = 0.0
     for repo in all_repos:
         repo_id = repo.get("repo", "")
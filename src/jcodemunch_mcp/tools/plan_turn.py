@@ -7,9 +7,8 @@ from typing import Optional
 from ._utils import load_repo_index_or_error
 from .search_symbols import (
     _tokenize,
-    _compute_bm25,
+    _ensure_bm25_corpus,
     _bm25_score,
-    _compute_centrality,
 )
 
 # Confidence thresholds
@@ -55,12 +54,7 @@ def plan_turn(
     owner, name = index.owner, index.name
 
     # Get BM25 cache
-    cache = index._bm25_cache
-    if "idf" not in cache:
-        cache["idf"], cache["avgdl"], cache["inverted"] = _compute_bm25(index.symbols)
-        cache["centrality"] = _compute_centrality(
-            index.symbols, index.imports, index.alias_map, getattr(index, "psr4_map", None)
-        )
+    cache = _ensure_bm25_corpus(index)
     idf = cache["idf"]
     avgdl = cache["avgdl"]
     centrality = cache["centrality"]
@@ -0,0 +1,174 @@
+"""project_stats — whole-repo metrics in one call.
+
+Aggregates what an agent would otherwise probe for with several calls when
+it first meets a codebase: files, lines and bytes per language, symbol
+counts by kind, the largest files, the most-referenced symbols and the
+number of packages.  Everything is read from the index and the content
+cache; nothing is re-parsed.
+
+"Most-referenced" counts, per called name, the distinct symbols whose
+AST ``call_references`` mention it.  Like ``get_call_hierarchy`` it matches
+by name, so it is approximate: same-named definitions share one count
+(each is listed under the name).  Indexes that predate call_references
+have no counts, and the section is omitted with a note in ``_meta``.
+"""
+
+from __future__ import annotations
+
+import json
+import posixpath
+import time
+from collections import Counter
+from typing import Optional
+
+from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
+from ._utils import load_repo_index_or_error
+
+_DEFAULT_TOP_N = 10
+_TOP_N_CAP = 100
+_MAX_IDS_PER_NAME = 3
+_REFERENCED_KINDS = ("function", "method", "class", "type")
+
+
+def _cached_stats(store: IndexStore, content_dir, file_path: str) -> tuple[Optional[int], int]:
+    """``(lines, bytes)`` of one cached file; lines is None when the cache has no copy."""
+    path = store._safe_content_path(content_dir, file_path)
+    if path is None:
+        return None, 0
+    try:
+        data = path.read_bytes()
+    except OSError:
+        return None, 0
+    if not data:
+        return 0, 0
+    return data.count(b"\n") + (0 if data.endswith(b"\n") else 1), len(data)
+
+
+def _most_referenced(index, top_n: int) -> Optional[list[dict]]:
+    """Top defined names by distinct referencing symbols (name-matched).
+
+    None when the index carries no call references at all.
+    """
+    callers: dict[str, set[str]] = {}
+    for (_file, called), ids in index.get_callers_by_name().items():
+        callers.setdefault(called, set()).update(ids)
+    if not callers:
+        return None
+    defined: dict[str, list[dict]] = {}
+    for sym in index.symbols:
+        if sym.get("kind") in _REFERENCED_KINDS and sym.get("name") in callers:
+            defined.setdefault(sym["name"], []).append(sym)
+    ranked = sorted(
+        defined.items(),
+        key=lambda item: (-len(callers[item[0]] - {s["id"] for s in item[1]}), item[0]),
+    )
+    out: list[dict] = []
+    for ref_name, syms in ranked[:top_n]:
+        # A recursive call is not a reference from elsewhere.
+        count = len(callers[ref_name] - {s["id"] for s in syms})
+        if count == 0:
+            break
+        entry: dict = {"name": ref_name, "references": count, "kind": syms[0]["kind"]}
+        entry["symbol_ids"] = [s["id"] for s in syms[:_MAX_IDS_PER_NAME]]
+        if len(syms) > _MAX_IDS_PER_NAME:
+            entry["more_definitions"] = len(syms) - _MAX_IDS_PER_NAME
+        out.append(entry)
+    return out
+
+
+def project_stats(
+    repo: str,
+    top_n: int = _DEFAULT_TOP_N,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Whole-repo metrics: languages, symbol kinds, largest files, hot symbols.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        top_n: Entries in the ``largest_files`` and ``most_referenced_symbols``
+            lists (default 10, max 100).
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with ``totals`` (files, lines, bytes, symbols, packages),
+        ``languages`` (per language: files, lines, bytes, symbols),
+        ``symbol_kinds``, ``largest_files``, ``most_referenced_symbols``
+        (approximate; omitted when the index has no call references)
+        and _meta.
+    """
+    start = time.perf_counter()
+    top_n = max(1, min(int(top_n), _TOP_N_CAP))
+
+    index, error, _status = load_repo_index_or_error(repo, storage_path)
+    if error:
+        return error
+    owner, name = index.owner, index.name
+    store = IndexStore(base_path=storage_path)
+    content_dir = store._content_dir(owner, name)
+
+    symbols_per_file: Counter = Counter(sym.get("file", "") for sym in index.symbols)
+    languages: dict[str, dict] = {}
+    files: list[dict] = []
+    packages: set[str] = set()
+    lines_known = True
+    for file_path in index.source_files:
+        language = index.file_languages.get(file_path) or "unknown"
+        lines, cached_bytes = _cached_stats(store, content_dir, file_path)
+        size = index.file_sizes.get(file_path, cached_bytes)
+        if lines is None:
+            lines_known = False
+        stats = languages.setdefault(language, {"files": 0, "lines": 0, "bytes": 0, "symbols": 0})
+        stats["files"] += 1
+        stats["lines"] += lines or 0
+        stats["bytes"] += size
+        stats["symbols"] += symbols_per_file.get(file_path, 0)
+        files.append({"file": file_path, "language": language, "bytes": size, "lines": lines})
+        # A package is a directory holding source files (a Go package, a
+        # Python package or module directory, a JS folder).
+        packages.add(posixpath.dirname(file_path))
+
+    kind_counts: Counter = Counter(sym.get("kind", "unknown") for sym in index.symbols)
+    largest = sorted(files, key=lambda f: (-f["bytes"], f["file"]))[:top_n]
+    for entry in largest:
+        entry["symbols"] = symbols_per_file.get(entry["file"], 0)
+        if entry["lines"] is None:
+            del entry["lines"]
+    most_referenced = _most_referenced(index, top_n)
+
+    total_bytes = sum(s["bytes"] for s in languages.values())
+    payload = {
+        "repo": f"{owner}/{name}",
+        "indexed_at": index.indexed_at,
+        "totals": {
+            "files": len(index.source_files),
+            "lines": sum(s["lines"] for s in languages.values()),
+            "bytes": total_bytes,
+            "symbols": len(index.symbols),
+            "packages": len(packages),
+        },
+        "languages": dict(sorted(languages.items(), key=lambda item: (-item[1]["files"], item[0]))),
+        "symbol_kinds": dict(kind_counts.most_common()),
+        "largest_files": largest,
+    }
+    if most_referenced is not None:
+        payload["most_referenced_symbols"] = most_referenced
+
+    response_bytes = len(json.dumps(payload).encode("utf-8"))
+    tokens_saved = estimate_savings(total_bytes, response_bytes)
+    total_saved = record_savings(tokens_saved, tool_name="project_stats")
+    meta: dict = {
+        "timing_ms": round((time.perf_counter() - start) * 1000, 1),
+        "tokens_saved": tokens_saved,
+        "total_tokens_saved": total_saved,
+        **cost_avoided(tokens_saved, total_saved),
+    }
+    if most_referenced is not None:
+        meta["references_approximate"] = True
+    else:
+        meta["note"] = (
+            "most_referenced_symbols omitted: the index has no call references. "
+            "Re-index to build them."
+        )
+    if not lines_known:
+        meta["lines_partial"] = True
+    return {**payload, "_meta": meta}
@@ -0,0 +1,70 @@
+"""read_file_range: a line slice of a cached file, each line prefixed by its number."""
+
+from typing import Optional
+
+from .get_file_content import get_file_content
+
+
+def number_lines(text: str, first_line: int) -> str:
+    """Prefix each line of *text* with its 1-based number, right-aligned.
+
+    The gutter width fits the last number, so columns stay aligned:
+    ``" 9\\tfoo"`` / ``"10\\tbar"``.
+    """
+    if not text:
+        return ""
+    lines = text.split("\n")
+    width = len(str(first_line + len(lines) - 1))
+    return "\n".join(f"{first_line + i:>{width}}\t{line}" for i, line in enumerate(lines))
+
+
+def read_file_range(
+    repo: str,
+    file_path: str,
+    start_line: int = 1,
+    end_line: Optional[int] = None,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Return lines *start_line*..*end_line* (1-based, inclusive) with line numbers.
+
+    Out-of-range bounds are clamped to the file rather than rejected; the
+    response's ``start_line`` / ``end_line`` give the range actually
+    returned and ``clamped`` says whether it differs from the request.
+    Each line is formatted ``<number>\\t<text>``.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        file_path: Path to the file within the repository.
+        start_line: First line to return (default 1).
+        end_line: Last line to return (default: end of file).
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with file, language, start_line, end_line, line_count,
+        requested ({start_line, end_line}), clamped, content, and _meta.
+    """
+    result = get_file_content(
+        repo=repo,
+        file_path=file_path,
+        start_line=start_line,
+        end_line=end_line,
+        storage_path=storage_path,
+    )
+    if "error" in result:
+        return result
+
+    actual_start = result["start_line"]
+    actual_end = result["end_line"]
+    requested_end = end_line if end_line is not None else result["line_count"]
+    return {
+        "repo": result["repo"],
+        "file": result["file"],
+        "language": result["language"],
+        "start_line": actual_start,
+        "end_line": actual_end,
+        "line_count": result["line_count"],
+        "requested": {"start_line": start_line, "end_line": requested_end},
+        "clamped": (start_line, requested_end) != (actual_start, actual_end),
+        "content": number_lines(result["content"], actual_start),
+        "_meta": result["_meta"],
+    }
@@ -67,8 +67,9 @@ def register_edit(
             sym.pop("_dl", None)
             invalidated_symbols += 1
 
-    # Clear BM25 cache
-    index._bm25_cache.clear()
+    # Drop the BM25 cache.  Rebind rather than clear() so a search running
+    # concurrently (HTTP transports) keeps reading the dict it already holds.
+    index._bm25_cache = {}
     bm25_cleared = True
 
     # Clear import name index
@@ -0,0 +1,657 @@
+"""rename_preview — the exact edits a scope-aware rename would make, without making them.
+
+Given the old name, the new name, and the location of the definition (or of
+any occurrence of it), the identifier is resolved to its binding and every
+occurrence of that binding becomes one ``{file, line, column, end_column}``
+edit.  Nothing is written to disk.
+
+Resolution for Go, in order of preference:
+
+1. ``gopls references -declaration`` when ``gopls`` is on PATH, the repo was
+   indexed from a local folder, and the file on disk matches the index.
+   That is go/types resolution, so methods and struct fields are exact.
+   A package that does not type-check makes gopls fail, and the syntactic
+   resolver answers instead.
+2. A syntactic resolver over the tree-sitter AST that applies Go's block
+   scoping rules: function, block, ``if``/``for``/``switch``/``select`` and
+   case-clause scopes, ``:=`` redeclaration, type-switch aliases, and
+   parameters and results.  A local ``user`` therefore never touches a
+   package-level ``user``.  A package-level name is renamed across every
+   file of the package.  An exported one is also renamed at the
+   ``pkg.Name`` selectors of files that import the package.  Methods and
+   struct fields cannot be tied to a receiver type without type
+   information, so every ``.Name`` selector in the package and its
+   importers is returned with ``certain: false``.
+
+Columns are 1-based and count characters, not bytes.
+"""
+
+from __future__ import annotations
+
+import os
+import posixpath
+import re
+import time
+from typing import Optional
+
+from ..storage import IndexStore
+from . import _gopls
+from ._utils import resolve_repo, index_status_to_tool_error
+from .get_dependencies import _Resolver
+
+_SUPPORTED_LANGUAGES = ("go",)
+
+_GO_KEYWORDS = frozenset({
+    "break", "case", "chan", "const", "continue", "default", "defer", "else",
+    "fallthrough", "for", "func", "go", "goto", "if", "import", "interface",
+    "map", "package", "range", "return", "select", "struct", "switch", "type", "var",
+})
+_GO_IDENT_RE = re.compile(r"^[^\W\d]\w*$")
+
+# Bindings an occurrence can resolve to, besides ("local", file, byte).
+_PKG = ("package",)
+_MEMBER = ("member",)
+_IMPORT = ("import",)
+
+_IDENT_TYPES = frozenset({"identifier", "type_identifier", "package_identifier"})
+_FUNC_TYPES = frozenset({"function_declaration", "method_declaration", "func_literal"})
+# Nodes that open a scope.  Function types are here so their parameter names
+# bind nothing outside them.
+_SCOPE_TYPES = frozenset({
+    "block", "if_statement", "for_statement", "expression_switch_statement",
+    "select_statement", "expression_case", "default_case", "communication_case",
+    "type_case", "function_type",
+})
+_PARAM_TYPES = frozenset({
+    "parameter_declaration", "variadic_parameter_declaration", "type_parameter_declaration",
+})
+_NON_STRUCT_LITERAL_TYPES = frozenset({
+    "map_type", "slice_type", "array_type", "implicit_length_array_type",
+})
+
+def _text(node) -> str:
+    return node.text.decode("utf-8", errors="replace")
+
+
+def _has_define(node) -> bool:
+    return any(c.type == ":=" for c in node.children)
+
+
+def _literal_is_struct(literal_value) -> bool:
+    """True when the keys of *literal_value* are struct field names.
+
+    Handles an explicit type (``User{name: x}``) and one level of elided
+    element type (``[]User{{name: x}}``); map, slice and array literals key
+    by value.
+    """
+    parent = literal_value.parent
+    if parent is None:
+        return False
+    if parent.type == "composite_literal":
+        t = parent.child_by_field_name("type")
+        return t is not None and t.type not in _NON_STRUCT_LITERAL_TYPES
+    if parent.type != "literal_element":
+        return False
+    outer = parent.parent
+    if outer is not None and outer.type == "keyed_element":
+        outer = outer.parent
+    if outer is None or outer.type != "literal_value" or outer.parent is None:
+        return False
+    if outer.parent.type != "composite_literal":
+        return False
+    t = outer.parent.child_by_field_name("type")
+    if t is None:
+        return False
+    elem = t.child_by_field_name("value" if t.type == "map_type" else "element")
+    return elem is not None and elem.type not in _NON_STRUCT_LITERAL_TYPES
+
+
+class _GoBinder:
+    """Resolve every occurrence of one name in one Go file to its binding.
+
+    Only *name* is tracked, so the environment is a single ``(binding,
+    scope)`` pair threaded through the walk: declarations return the
+    updated pair for the statements after them, and scope nodes discard
+    whatever was declared inside.
+    """
+
+    def __init__(self, name: str, path: str, top_binding: Optional[tuple]):
+        self.name = name
+        self.path = path
+        self.top = top_binding
+        self.pkg_scope = object()
+        self.occurrences: list[tuple] = []  # (node, binding, role)
+
+    def run(self, root) -> list[tuple]:
+        self._seq(root.children, (self.top, self.pkg_scope), self.pkg_scope)
+        return self.occurrences
+
+    def _record(self, node, binding, role: str) -> None:
+        self.occurrences.append((node, binding, role))
+
+    def _seq(self, children, env, scope):
+        for child in children:
+            env = self._visit(child, env, scope)
+        return env
+
+    def _declare(self, nodes, env, scope, redeclare: bool = False):
+        for node in nodes:
+            if node.type == "expression_list":
+                env = self._declare(node.named_children, env, scope, redeclare)
+                continue
+            if node.type not in _IDENT_TYPES or _text(node) != self.name:
+                continue
+            if redeclare and env[1] is scope:
+                # `a, err := ...` reuses an `err` already declared in this scope.
+                self._record(node, env[0], "reference")
+                continue
+            binding = _PKG if scope is self.pkg_scope else ("local", self.path, node.start_byte)
+            self._record(node, binding, "definition")
+            env = (binding, scope)
+        return env
+
+    def _visit(self, node, env, scope):
+        t = node.type
+        if t in _IDENT_TYPES:
+            if _text(node) == self.name:
+                self._record(node, env[0], "reference")
+            return env
+        if t == "field_identifier":
+            if _text(node) == self.name:
+                self._record(node, _MEMBER, "reference")
+            return env
+        if t in _FUNC_TYPES:
+            self._function(node, env, scope)
+            return env
+        if t in _SCOPE_TYPES:
+            self._seq(node.children, env, object())
+            return env
+        if t in _PARAM_TYPES:
+            type_node = node.child_by_field_name("type")
+            if type_node is not None:
+                self._visit(type_node, env, scope)
+            return self._declare(node.children_by_field_name("name"), env, scope)
+        if t in ("field_declaration", "method_elem", "method_spec"):
+            # Struct fields and interface methods: member definitions.
+            names = node.children_by_field_name("name")
+            for n in names:
+                if _text(n) == self.name:
+                    self._record(n, _MEMBER, "definition")
+            skip = {(n.start_byte, n.end_byte) for n in names}
+            inner = object()
+            for child in node.children:
+                if (child.start_byte, child.end_byte) not in skip:
+                    self._visit(child, env, inner)
+            return env
+        if t in ("var_spec", "const_spec"):
+            for field in ("type", "value"):
+                child = node.child_by_field_name(field)
+                if child is not None:
+                    self._visit(child, env, scope)
+            return self._declare(node.children_by_field_name("name"), env, scope)
+        if t in ("type_spec", "type_alias"):
+            # A type's scope starts at its own name, so recursive types resolve.
+            env = self._declare(node.children_by_field_name("name"), env, scope)
+            inner = object()
+            type_env = env
+            params = node.child_by_field_name("type_parameters")
+            if params is not None:
+                type_env = self._visit(params, env, inner)
+            body = node.child_by_field_name("type")
+            if body is not None:
+                self._visit(body, type_env, inner)
+            return env
+        if t == "short_var_declaration" or (t in ("range_clause", "receive_statement") and _has_define(node)):
+            right = node.child_by_field_name("right")
+            if right is not None:
+                self._visit(right, env, scope)
+            return self._declare(node.children_by_field_name("left"), env, scope, redeclare=True)
+        if t == "type_switch_statement":
+            self._type_switch(node, env)
+            return env
+        if t == "keyed_element":
+            return self._keyed_element(node, env, scope)
+        return self._seq(node.children, env, scope)
+
+    def _function(self, node, env, scope) -> None:
+        name_node = node.child_by_field_name("name")
+        if name_node is not None and _text(name_node) == self.name:
+            binding = _MEMBER if node.type == "method_declaration" else _PKG
+            self._record(name_node, binding, "definition")
+        inner = object()
+        fn_env = env
+        for field in ("receiver", "type_parameters", "parameters", "result"):
+            child = node.child_by_field_name(field)
+            if child is not None:
+                fn_env = self._visit(child, fn_env, inner)
+        body = node.child_by_field_name("body")
+        if body is not None:
+            # The body shares the parameters' scope.
+            self._seq(body.children, fn_env, inner)
+
+    def _type_switch(self, node, env) -> None:
+        inner = object()
+        init = node.child_by_field_name("initializer")
+        if init is not None:
+            env = self._visit(init, env, inner)
+        value = node.child_by_field_name("value")
+        if value is not None:
+            self._visit(value, env, inner)
+        # `switch v := x.(type)` declares v afresh in every clause; one
+        # binding stands for all of them, as a rename must change them together.
+        case_env = self._declare(node.children_by_field_name("alias"), env, object())
+        for clause in node.named_children:
+            if clause.type not in ("type_case", "default_case"):
+                continue
+            types = {(c.start_byte, c.end_byte) for c in clause.children_by_field_name("type")}
+            clause_scope = object()
+            stmt_env = case_env
+            for child in clause.children:
+                if (child.start_byte, child.end_byte) in types:
+                    self._visit(child, env, clause_scope)
+                else:
+                    stmt_env = self._visit(child, stmt_env, clause_scope)
+
+    def _keyed_element(self, node, env, scope):
+        named = node.named_children
+        if not named:
+            return env
+        key = named[0]
+        bare = key.named_children[0] if key.type == "literal_element" and key.named_child_count == 1 else key
+        if bare.type in ("identifier", "field_identifier") and _literal_is_struct(node.parent):
+            if _text(bare) == self.name:
+                self._record(bare, _MEMBER, "reference")
+            named = named[1:]
+        for child in named:
+            env = self._visit(child, env, scope)
+        return env
+
+
+def _top_level_names(root) -> set[str]:
+    """Names a Go file declares at package scope (methods excluded)."""
+    names: set[str] = set()
+    for decl in root.named_children:
+        if decl.type == "function_declaration":
+            n = decl.child_by_field_name("name")
+            if n is not None:
+                names.add(_text(n))
+        elif decl.type in ("var_declaration", "const_declaration", "type_declaration"):
+            stack = list(decl.named_children)
+            while stack:
+                spec = stack.pop()
+                if spec.type in ("var_spec", "const_spec", "type_spec", "type_alias"):
+                    names.update(_text(n) for n in spec.children_by_field_name("name"))
+                elif spec.type.endswith("_spec_list"):
+                    stack.extend(spec.named_children)
+    return names
+
+
+def _package_name(root) -> str:
+    for child in root.named_children:
+        if child.type == "package_clause":
+            ident = next((c for c in child.named_children if c.type == "package_identifier"), None)
+            return _text(ident) if ident is not None else ""
+    return ""
+
+
+def _import_specs(root):
+    """``(local name or None, kind, path)`` for each import; kind is name/dot/blank/default."""
+    stack = list(root.named_children)
+    while stack:
+        node = stack.pop()
+        if node.type == "import_declaration" or node.type == "import_spec_list":
+            stack.extend(node.named_children)
+            continue
+        if node.type != "import_spec":
+            continue
+        path_node = node.child_by_field_name("path")
+        path = _text(path_node).strip("\"`") if path_node is not None else ""
+        name_node = node.child_by_field_name("name")
+        if name_node is None:
+            yield None, "default", path
+        elif name_node.type == "dot":
+            yield None, "dot", path
+        elif name_node.type == "blank_identifier":
+            yield None, "blank", path
+        else:
+            yield _text(name_node), "name", path
+
+
+def _import_local_names(root) -> set[str]:
+    """Names a file's imports bind (an unaliased import is assumed to bind its last path element)."""
+    return {
+        local if kind == "name" else posixpath.basename(path)
+        for local, kind, path in _import_specs(root)
+        if kind in ("name", "default")
+    }
+
+
+def _qualified_refs(root, qualifier: str, name: str) -> list:
+    """``qualifier.name`` selector fields and qualified type names under *root*."""
+    out = []
+    stack = [root]
+    while stack:
+        n = stack.pop()
+        if n.type == "selector_expression":
+            operand = n.child_by_field_name("operand")
+            field = n.child_by_field_name("field")
+            if (operand is not None and field is not None and operand.type == "identifier"
+                    and _text(operand) == qualifier and _text(field) == name):
+                out.append(field)
+        elif n.type == "qualified_type":
+            pkg = n.child_by_field_name("package")
+            tname = n.child_by_field_name("name")
+            if pkg is not None and tname is not None and _text(pkg) == qualifier and _text(tname) == name:
+                out.append(tname)
+        stack.extend(n.children)
+    return out
+
+
+def _char_column(source: bytes, byte_offset: int) -> int:
+    line_start = source.rfind(b"\n", 0, byte_offset) + 1
+    return len(source[line_start:byte_offset].decode("utf-8", errors="replace")) + 1
+
+
+def _edit(path: str, source: bytes, node, old: str, new: str, role: str, certain: bool) -> dict:
+    column = _char_column(source, node.start_byte)
+    return {
+        "file": path,
+        "line": node.start_point[0] + 1,
+        "column": column,
+        "end_column": column + len(old),
+        "old_text": old,
+        "new_text": new,
+        "role": role,
+        "certain": certain,
+    }
+
+
+class _GoPackageView:
+    """Parsed Go files of one repo, loaded lazily from the index content cache."""
+
+    def __init__(self, store: IndexStore, owner: str, name: str, index):
+        from tree_sitter_language_pack import get_parser
+        self._parser = get_parser("go")
+        self.store, self.owner, self.name, self.index = store, owner, name, index
+        self._cache: dict[str, tuple[bytes, object]] = {}
+
+    def parse(self, path: str):
+        if path not in self._cache:
+            # Raw: callers return positions only, and withheld (blanked)
+            # bodies would hide references and never match the file on disk.
+            content = self.store.get_file_content(self.owner, self.name, path, _index=self.index, raw=True)
+            if content is None:
+                return None, None
+            source = content.encode("utf-8")
+            self._cache[path] = (source, self._parser.parse(source).root_node)
+        return self._cache[path]
+
+    def go_files_in(self, directory: str) -> list[str]:
+        return sorted(
+            f for f, lang in self.index.file_languages.items()
+            if lang == "go" and posixpath.dirname(f) == directory
+        )
+
+
+def _gopls_edits(
+    index, view: _GoPackageView, file_path: str, node, old: str, new: str, definitions: set,
+) -> tuple[Optional[list[dict]], str]:
+    """Type-checked edits via gopls, or ``(None, reason)`` to fall back."""
+    source_root = getattr(index, "source_root", "") or ""
+    if not _gopls.available(source_root):
+        return None, "gopls not available"
+    source, _ = view.parse(file_path)
+    pos, err = _gopls.position(source_root, file_path, source, node)
+    if pos is None:
+        return None, err
+    spans, err = _gopls.query(source_root, "references", "-declaration", pos)
+    if spans is None:
+        return None, err
+    edits = []
+    for span_path, line, byte_col in spans:
+        rel = _gopls.relative_path(span_path, source_root)
+        if rel.startswith("../"):
+            continue  # outside the indexed tree (module cache, vendored copies elsewhere)
+        file_source, _ = view.parse(rel)
+        if file_source is None:
+            continue
+        offset = _gopls.byte_offset(file_source, line, byte_col)
+        if offset is None:
+            continue
+        column = _char_column(file_source, offset)
+        edits.append({
+            "file": rel,
+            "line": line,
+            "column": column,
+            "end_column": column + len(old),
+            "old_text": old,
+            "new_text": new,
+            "role": "definition" if (rel, line, column) in definitions else "reference",
+            "certain": True,
+        })
+    return edits, ""
+
+
+def _go_rename(
+    index, store: IndexStore, owner: str, name: str,
+    file_path: str, line: int, column: Optional[int], old: str, new: str,
+    use_type_checker: bool,
+) -> dict:
+    view = _GoPackageView(store, owner, name, index)
+    source, root = view.parse(file_path)
+    if source is None:
+        return {"error": f"File content not cached: {file_path}. Re-index to populate it."}
+
+    directory = posixpath.dirname(file_path)
+    pkg_name = _package_name(root)
+    package_files = []
+    for f in view.go_files_in(directory):
+        _, froot = view.parse(f)
+        if froot is not None and _package_name(froot) == pkg_name:
+            package_files.append(f)
+    declared = any(old in _top_level_names(view.parse(f)[1]) for f in package_files)
+
+    def binder_for(path: str, froot) -> _GoBinder:
+        if declared:
+            top = _PKG
+        else:
+            top = _IMPORT if old in _import_local_names(froot) else None
+        return _GoBinder(old, path, top)
+
+    occurrences = binder_for(file_path, root).run(root)
+    here = [
+        occ for occ in occurrences
+        if occ[0].start_point[0] + 1 == line
+        and (column is None or _char_column(source, occ[0].start_byte) <= column
+             < _char_column(source, occ[0].start_byte) + len(old))
+    ]
+    if not here:
+        where = f"{file_path}:{line}" + (f":{column}" if column else "")
+        return {"error": f"No identifier '{old}' at {where}."}
+    target_node, binding, _ = here[0]
+    if binding is None or binding == _IMPORT:
+        what = "an imported package name" if binding == _IMPORT else "a predeclared identifier"
+        return {"error": f"'{old}' at {file_path}:{line} refers to {what}; nothing in this repo defines it."}
+
+    warnings: list[str] = []
+    edits: list[dict] = []
+    if binding[0] == "local":
+        kind = "local"
+        edits = [_edit(file_path, source, n, old, new, role, True) for n, b, role in occurrences if b == binding]
+        enclosing = target_node
+        while enclosing is not None and enclosing.type not in _FUNC_TYPES:
+            enclosing = enclosing.parent
+        if enclosing is not None:
+            clashes = [
+                n for n, _, _ in _GoBinder(new, file_path, None).run(root)
+                if enclosing.start_byte <= n.start_byte < enclosing.end_byte
+            ]
+            if clashes:
+                warnings.append(
+                    f"'{new}' is already used in the enclosing function (line "
+                    f"{clashes[0].start_point[0] + 1}); the rename may shadow it or be shadowed by it."
+                )
+    else:
+        kind = "package" if binding == _PKG else "member"
+        certain = binding == _PKG
+        for f in package_files:
+            fsource, froot = view.parse(f)
+            occ = occurrences if f == file_path else binder_for(f, froot).run(froot)
+            edits.extend(_edit(f, fsource, n, old, new, role, certain) for n, b, role in occ if b == binding)
+
+        if binding == _PKG and any(new in _top_level_names(view.parse(f)[1]) for f in package_files):
+            warnings.append(f"'{new}' is already declared in package {pkg_name}.")
+
+        if old[:1].isupper():
+            resolver = _Resolver(index)
+            pkg_set = set(package_files)
+            for importer in sorted(index.imports or {}):
+                if importer in pkg_set or index.file_languages.get(importer) != "go":
+                    continue
+                specs = [e.get("specifier", "") for e in index.imports.get(importer) or []]
+                if not any(resolver.resolve(s, importer, "go") == directory for s in specs):
+                    continue
+                isource, iroot = view.parse(importer)
+                if iroot is None:
+                    continue
+                for local, ikind, path in _import_specs(iroot):
+                    if resolver.resolve(path, importer, "go") != directory:
+                        continue
+                    if ikind == "dot":
+                        warnings.append(f"{importer} dot-imports {path}; its unqualified uses of '{old}' are not included.")
+                        continue
+                    if ikind == "blank":
+                        continue
+                    if binding == _MEMBER:
+                        nodes = [n for n, b, _ in binder_for(importer, iroot).run(iroot) if b == _MEMBER]
+                    else:
+                        nodes = _qualified_refs(iroot, local or pkg_name, old)
+                    edits.extend(_edit(importer, isource, n, old, new, "reference", certain) for n in nodes)
+            if binding == _PKG and not new[:1].isupper() and any(e["file"] not in pkg_set for e in edits):
+                warnings.append(f"'{new}' is unexported: the uses of '{old}' in other packages will no longer compile.")
+
+    resolver_used = "syntactic"
+    type_checker_note = ""
+    if use_type_checker:
+        definitions = {(e["file"], e["line"], e["column"]) for e in edits if e["role"] == "definition"}
+        typed, type_checker_note = _gopls_edits(index, view, file_path, target_node, old, new, definitions)
+        if typed:
+            edits = typed
+            resolver_used = "gopls"
+    if binding == _MEMBER and resolver_used == "syntactic":
+        warnings.append(
+            f"'{old}' is a method or field. Without type information every '.{old}' selector in "
+            "the package and its importers is included, whatever its receiver type; review "
+            "the edits marked certain=false."
+        )
+
+    seen = set()
+    unique = []
+    for e in sorted(edits, key=lambda e: (e["file"], e["line"], e["column"])):
+        key = (e["file"], e["line"], e["column"])
+        if key not in seen:
+            seen.add(key)
+            unique.append(e)
+    return {
+        "binding": kind,
+        "package": pkg_name,
+        "resolver": resolver_used,
+        "edits": unique,
+        "warnings": warnings,
+        "type_checker_note": type_checker_note if resolver_used == "syntactic" else "",
+    }
+
+
+def rename_preview(
+    repo: str,
+    name: str,
+    new_name: str,
+    file_path: str,
+    line: int,
+    column: Optional[int] = None,
+    use_type_checker: bool = True,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """List every edit a rename of *name* to *new_name* would make.  Read-only.
+
+    Args:
+        repo:             Repository identifier (owner/repo or bare name).
+        name:             Current identifier.
+        new_name:         Replacement identifier.
+        file_path:        File holding the definition (any occurrence works).
+        line:             1-based line of that occurrence.
+        column:           1-based character column, to pick between several
+                          occurrences of *name* on the line (default: first).
+        use_type_checker: Try ``gopls`` (go/types) before the syntactic
+                          resolver (default True).
+        storage_path:     Optional index storage path override.
+
+    Returns:
+        ``{repo, name, new_name, definition, binding, resolver, edit_count,
+        file_count, files, edits, warnings, _meta}``.  ``binding`` is
+        ``local``, ``package``, or ``member``; each edit is ``{file, line,
+        column, end_column, old_text, new_text, role, certain}``.
+    """
+    t0 = time.perf_counter()
+    if name == new_name:
+        return {"error": "new_name is the same as name."}
+    for label, ident in (("name", name), ("new_name", new_name)):
+        if not _GO_IDENT_RE.match(ident or "") or ident in _GO_KEYWORDS:
+            return {"error": f"{label} '{ident}' is not a valid identifier."}
+    if line < 1 or (column is not None and column < 1):
+        return {"error": "line and column are 1-based."}
+
+    try:
+        owner, repo_name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, repo_name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, repo_name))
+    if not index.has_source_file(file_path):
+        return {"error": f"File not found: {file_path}"}
+
+    language = index.file_languages.get(file_path, "")
+    if language not in _SUPPORTED_LANGUAGES:
+        return {
+            "error": (
+                f"rename_preview does not support {language or 'this'} files yet "
+                f"(supported: {', '.join(_SUPPORTED_LANGUAGES)}). "
+                "plan_refactoring(refactor_type='rename') gives a word-level plan."
+            )
+        }
+
+    result = _go_rename(index, store, owner, repo_name, file_path, line, column, name, new_name, use_type_checker)
+    if "error" in result:
+        return result
+
+    edits = result["edits"]
+    files: dict[str, int] = {}
+    for e in edits:
+        files[e["file"]] = files.get(e["file"], 0) + 1
+    definition = next((e for e in edits if e["role"] == "definition"), None)
+    meta = {
+        "timing_ms": round((time.perf_counter() - t0) * 1000, 1),
+        "read_only": True,
+    }
+    if result["type_checker_note"]:
+        meta["type_checker_skipped"] = result["type_checker_note"]
+    return {
+        "repo": f"{owner}/{repo_name}",
+        "name": name,
+        "new_name": new_name,
+        "definition": (
+            {"file": definition["file"], "line": definition["line"], "column": definition["column"]}
+            if definition else None
+        ),
+        "binding": result["binding"],
+        "package": result["package"],
+        "resolver": result["resolver"],
+        "edit_count": len(edits),
+        "file_count": len(files),
+        "files": files,
+        "edits": edits,
+        "warnings": result["warnings"],
+        "_meta": meta,
+    }
@@ -25,6 +25,7 @@ import time
 from pathlib import Path
 from typing import Any, Optional
 
+from .. import body_policy
 from ..storage import IndexStore
 from ..parser.languages import LANGUAGE_EXTENSIONS, LANGUAGE_REGISTRY
 from ._utils import resolve_repo
@@ -1116,6 +1117,7 @@ def search_ast(
     files_scanned = 0
     files_with_matches = 0
     languages_seen: set[str] = set()
+    withhold = body_policy.is_enabled()
     severity_counts: dict[str, int] = {"error": 0, "warning": 0, "info": 0}
 
     for fpath, lang_name in files_to_scan:
@@ -1144,6 +1146,7 @@ def search_ast(
         files_scanned += 1
         languages_seen.add(lang_name)
         file_had_matches = False
+        file_start = len(all_matches)
 
         for det_name, det_func, det_meta in detectors:
             if len(all_matches) >= max_results:
@@ -1181,6 +1184,11 @@ def search_ast(
 
         if file_had_matches:
             files_with_matches += 1
+            if withhold:
+                body_policy.mask_snippets(
+                    all_matches[file_start:], index, fpath,
+                    source_bytes.decode("utf-8", errors="replace"), key="snippet",
+                )
 
     # Sort: errors first, then warnings, then info; within severity by file+line
     severity_order = {"error": 0, "warning": 1, "info": 2}
@@ -0,0 +1,256 @@
+"""grep-style regex search over indexed file contents.
+
+Unlike ``search_text`` (case-insensitive, grouped by file, paged by offset)
+this takes a Go ``regexp`` (RE2) pattern, case-sensitive unless it starts
+with ``(?i)``, and returns a flat, ordered list of matches with a column and
+``context_lines`` of surroundings.  Scanning stops at the first match past
+``max_results``, so a broad pattern on a large repo costs little more than
+the page it returns.
+
+Only indexed files are searched, which means the indexer's ignore rules
+(``.gitignore``, ``vendor/``, ``node_modules/``, ``extra_ignore_patterns``,
+binary and oversized files) already apply.
+
+RE2 syntax is translated to Python ``re`` where the two differ (``\\z``,
+``\\Q...\\E``, ``[[:alpha:]]``); RE2-only constructs Python cannot express
+(``\\pL``, ``(?U)``) are rejected, and Python-only ones RE2 forbids
+(backreferences, lookaround) are rejected too, so a pattern that works
+here also works in Go code and vice versa.
+"""
+
+import fnmatch as _fnmatch
+import re
+import time
+from typing import Optional
+
+from .. import body_policy
+from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
+from ._utils import index_status_to_tool_error, resolve_repo
+from .search_text import _MAX_REGEX_LEN, _NESTED_QUANTIFIER_RE, _REGEX_BUDGET_SEC
+
+_DEFAULT_MAX_RESULTS = 50
+_MAX_RESULTS_CAP = 500
+_MAX_CONTEXT_LINES = 10
+_LINE_CAP = 200
+
+_POSIX_CLASSES = {
+    "alnum": "0-9A-Za-z", "alpha": "A-Za-z", "ascii": "\\x00-\\x7F",
+    "blank": "\\t ", "cntrl": "\\x00-\\x1F\\x7F", "digit": "0-9",
+    "graph": "!-~", "lower": "a-z", "print": " -~",
+    "punct": "!-/:-@\\[-`{-~", "space": "\\t\\n\\v\\f\\r ",
+    "upper": "A-Z", "word": "0-9A-Za-z_", "xdigit": "0-9A-Fa-f",
+}
+_POSIX_CLASS_RE = re.compile(r"\[:(\^?)([a-z]+):\]")
+_QUOTE_RE = re.compile(r"\\Q(.*?)(?:\\E|$)", re.S)
+_UNSUPPORTED_RE = re.compile(
+    r"\\[pP]"           # Unicode classes: RE2 only
+    r"|\(\?[a-zA-Z]*U"  # ungreedy flag: RE2 only
+    r"|\\[1-9]"         # backreferences: Python only
+    r"|\(\?P="          # named backreference
+    r"|\(\?<?[=!]"      # lookaround
+)
+
+
+def _translate_re2(pattern: str) -> str:
+    """Rewrite a Go regexp into an equivalent Python pattern.
+
+    Raises:
+        ValueError: for constructs one engine supports and the other does not.
+    """
+    pattern = _QUOTE_RE.sub(lambda m: re.escape(m.group(1)), pattern)
+    bad = _UNSUPPORTED_RE.search(pattern)
+    if bad:
+        raise ValueError(f"'{bad.group(0)}' is not supported (must be valid in both Go regexp and Python re)")
+
+    def _posix(m: re.Match) -> str:
+        chars = _POSIX_CLASSES.get(m.group(2))
+        if chars is None:
+            raise ValueError(f"unknown POSIX class [:{m.group(2)}:]")
+        if m.group(1):
+            raise ValueError("negated POSIX classes ([:^name:]) are not supported")
+        return chars
+
+    pattern = _POSIX_CLASS_RE.sub(_posix, pattern)
+    return pattern.replace("\\z", "\\Z")
+
+
+def _path_filter(paths: Optional[list[str]]):
+    """Match a file against any of *paths*: globs, or directory/file prefixes."""
+    if not paths:
+        return None
+    globs: list[str] = []
+    prefixes: list[str] = []
+    for p in paths:
+        p = p.strip()
+        if p.startswith("./"):
+            p = p[2:]
+        if not p:
+            continue
+        if any(ch in p for ch in "*?["):
+            globs.append(_fnmatch.translate(p))
+            # ``**/`` also matches zero directories: ``**/*.go`` covers root
+            # files and ``store/**/*.go`` covers ``store/store.go``.
+            if "/**/" in p:
+                globs.append(_fnmatch.translate(p.replace("/**/", "/")))
+            if p.startswith("**/"):
+                globs.append(_fnmatch.translate(p[3:]))
+            elif not p.startswith("*"):
+                globs.append(_fnmatch.translate(f"*/{p}"))
+        else:
+            prefixes.append(p.rstrip("/"))
+    glob_re = re.compile("|".join(globs)) if globs else None
+
+    def _keep(file_path: str) -> bool:
+        if glob_re is not None and glob_re.match(file_path):
+            return True
+        return any(file_path == pre or file_path.startswith(pre + "/") for pre in prefixes)
+
+    return _keep
+
+
+def search_content(
+    repo: str,
+    pattern: str,
+    paths: Optional[list[str]] = None,
+    context_lines: int = 0,
+    max_results: int = _DEFAULT_MAX_RESULTS,
+    max_per_file: Optional[int] = None,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Search indexed file contents for a Go-syntax regular expression.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        pattern: Go ``regexp`` (RE2) pattern, matched per line.  Prefix with
+            ``(?i)`` for case-insensitive matching.
+        paths: Optional scope: globs (``*.go``, ``internal/**/*_test.go``) or
+            directory/file paths (``cmd/server``).  A file matching any entry
+            is searched.
+        context_lines: Lines before and after each match (0-10).
+        max_results: Stop after this many matches (default 50, max 500).
+        max_per_file: Optional cap on matches taken from any one file, so a
+            single generated file cannot fill the result.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with ``matches`` (file, line, column, text, and ``before``/
+        ``after`` when context is requested), ``truncated`` when more
+        matches exist past ``max_results``, ``files_capped`` (files cut
+        short by ``max_per_file``), and _meta with ``files_searched``.
+    """
+    if len(pattern) > _MAX_REGEX_LEN:
+        return {"error": f"Regex too long ({len(pattern)} chars, max {_MAX_REGEX_LEN})"}
+    if _NESTED_QUANTIFIER_RE.search(pattern):
+        return {"error": "Regex rejected: nested quantifiers can cause catastrophic backtracking"}
+    try:
+        regex = re.compile(_translate_re2(pattern))
+    except (ValueError, re.error) as e:
+        return {"error": f"Invalid regex: {e}"}
+
+    start = time.perf_counter()
+    max_results = max(1, min(int(max_results), _MAX_RESULTS_CAP))
+    context_lines = max(0, min(int(context_lines), _MAX_CONTEXT_LINES))
+    if max_per_file is not None:
+        max_per_file = max(1, int(max_per_file))
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    files = sorted(index.source_files)
+    keep = _path_filter(paths)
+    if keep is not None:
+        files = [f for f in files if keep(f)]
+
+    content_dir = store._content_dir(owner, name)
+    withhold = body_policy.is_enabled()
+    deadline = start + _REGEX_BUDGET_SEC
+    matches: list[dict] = []
+    files_searched = 0
+    files_matched = 0
+    files_capped = 0
+    truncated = False
+    timed_out = False
+    raw_bytes = 0
+
+    for file_path in files:
+        if time.perf_counter() > deadline:
+            timed_out = True
+            break
+        full_path = store._safe_content_path(content_dir, file_path)
+        if not full_path:
+            continue
+        try:
+            with open(full_path, "r", encoding="utf-8", errors="replace") as f:
+                lines = f.read().splitlines()
+        except OSError:
+            continue
+        if withhold:
+            lines = body_policy.blank_hidden_lines(lines, index, file_path)
+
+        files_searched += 1
+        in_file = 0
+        for line_index, line in enumerate(lines):
+            if (line_index & 0xFF) == 0 and time.perf_counter() > deadline:
+                timed_out = True
+                break
+            m = regex.search(line)
+            if not m:
+                continue
+            # One match past a limit is enough to know the output is partial.
+            if len(matches) >= max_results:
+                truncated = True
+                break
+            if max_per_file is not None and in_file >= max_per_file:
+                files_capped += 1
+                break
+            match = {
+                "file": file_path,
+                "line": line_index + 1,
+                "column": m.start() + 1,
+                "text": line.rstrip()[:_LINE_CAP],
+            }
+            if context_lines:
+                match["before"] = [v.rstrip()[:_LINE_CAP] for v in lines[max(0, line_index - context_lines):line_index]]
+                match["after"] = [v.rstrip()[:_LINE_CAP] for v in lines[line_index + 1:line_index + 1 + context_lines]]
+            matches.append(match)
+            in_file += 1
+
+        if in_file:
+            files_matched += 1
+            raw_bytes += index.file_sizes.get(file_path, 0)
+        if truncated or timed_out:
+            break
+
+    response_bytes = sum(
+        len(m["text"]) + sum(len(v) for v in m.get("before", ()) + m.get("after", ())) + len(m["file"]) + 30
+        for m in matches
+    )
+    tokens_saved = estimate_savings(raw_bytes, response_bytes)
+    total_saved = record_savings(tokens_saved, tool_name="search_content")
+    elapsed = (time.perf_counter() - start) * 1000
+
+    return {
+        "repo": f"{owner}/{name}",
+        "pattern": pattern,
+        "match_count": len(matches),
+        "files_matched": files_matched,
+        "truncated": truncated,
+        **({"files_capped": files_capped} if files_capped else {}),
+        "matches": matches,
+        "_meta": {
+            "timing_ms": round(elapsed, 1),
+            "files_searched": files_searched,
+            "files_in_scope": len(files),
+            "timed_out": timed_out,
+            "tokens_saved": tokens_saved,
+            "total_tokens_saved": total_saved,
+            **cost_avoided(tokens_saved, total_saved),
+        },
+    }
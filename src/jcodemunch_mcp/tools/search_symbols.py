@@ -5,7 +5,7 @@ import math
 import re
 import time
 from fnmatch import fnmatch
-from typing import Optional
+from typing import Any, Optional
 
 from ..storage import IndexStore, CodeIndex, record_savings, estimate_savings, cost_avoided
 from ..parser.imports import resolve_specifier
@@ -32,6 +32,14 @@ _CENTRALITY_WEIGHT = 0.3
 # PageRank weight for sort_by="combined" (scales PR scores to be meaningful vs BM25 range)
 _PR_COMBINED_WEIGHT = 100.0
 
+# sort_by="name" tiers, editor "go to symbol" style: exact > prefix >
+# substring > subsequence.  Within a tier a tighter match scores higher,
+# but never reaches the next tier up.
+_NAME_TIER_PREFIX = 0.75
+_NAME_TIER_SUBSTRING = 0.5
+_NAME_TIER_SUBSEQUENCE = 0.25
+_NAME_TIER_SPREAD = 0.24
+
 # Pre-compiled regexes for _tokenize (called ~9000× on cold BM25 build)
 _CAMEL_RE = re.compile(r"([a-z])([A-Z])")
 _TOKEN_RE = re.compile(r"[a-zA-Z0-9]{2,}")
@@ -266,6 +274,31 @@ def _compute_centrality(
     return {f: math.log(1 + c) * _CENTRALITY_WEIGHT for f, c in counts.items()}
 
 
+_bm25_build_lock = threading.Lock()
+
+
+def _ensure_bm25_corpus(index) -> dict:
+    """Return ``index._bm25_cache`` with idf/avgdl/inverted/centrality populated.
+
+    Built once per loaded index.  HTTP transports run tool calls for many
+    sessions concurrently against the same cached CodeIndex, so the build
+    is serialised and the keys land in one ``update`` — a reader never sees
+    ``idf`` without ``centrality``.
+    """
+    cache = index._bm25_cache
+    if "idf" not in cache:
+        with _bm25_build_lock:
+            if "idf" not in cache:
+                idf, avgdl, inverted = _compute_bm25(index.symbols)
+                centrality = cache.get("centrality")
+                if centrality is None:
+                    centrality = _compute_centrality(
+                        index.symbols, index.imports, index.alias_map, getattr(index, "psr4_map", None)
+                    )
+                cache.update(idf=idf, avgdl=avgdl, inverted=inverted, centrality=centrality)
+    return cache
+
+
 def _identity_score(sym: dict, query_joined: str, raw_query: str = "") -> float:
     """Identity channel: exact or prefix match on symbol name/ID.
 
@@ -407,6 +440,36 @@ def _edit_distance(a: str, b: str) -> int:
     return row[la]
 
 
+def _name_match_score(name: str, query: str, case_sensitive: bool = False) -> tuple[float, str]:
+    """Score a symbol name against a query for sort_by="name".
+
+    Returns ``(score, match_type)`` where match_type is ``exact``,
+    ``prefix``, ``substring`` or ``subsequence``; ``(0.0, "")`` means the
+    name does not match.  A case-insensitive exact match whose case differs
+    ranks just below a verbatim one.
+    """
+    if not name or not query:
+        return 0.0, ""
+    n, q = (name, query) if case_sensitive else (name.lower(), query.lower())
+    if n == q:
+        return (1.0 if name == query else 0.95), "exact"
+    coverage = len(q) / len(n)
+    if n.startswith(q):
+        return _NAME_TIER_PREFIX + _NAME_TIER_SPREAD * coverage, "prefix"
+    if q in n:
+        return _NAME_TIER_SUBSTRING + _NAME_TIER_SPREAD * coverage, "substring"
+    # Greedy leftmost subsequence; a shorter matched span is a tighter match.
+    first = pos = -1
+    for ch in q:
+        pos = n.find(ch, pos + 1)
+        if pos < 0:
+            return 0.0, ""
+        if first < 0:
+            first = pos
+    density = len(q) / (pos - first + 1)
+    return _NAME_TIER_SUBSEQUENCE + _NAME_TIER_SPREAD * (density + coverage) / 2, "subsequence"
+
+
 def _cosine_similarity(a: list[float], b: list[float]) -> float:
     """Cosine similarity in pure Python (no numpy).
 
@@ -423,6 +486,108 @@ def _cosine_similarity(a: list[float], b: list[float]) -> float:
     return dot / (norm_a * norm_b)
 
 
+def _variant_key(sym: dict) -> tuple[str, str]:
+    """Directory plus qualified name and kind — what build variants share."""
+    file = sym.get("file", "")
+    return file.rsplit("/", 1)[0] if "/" in file else "", sym["id"].split("::", 1)[-1]
+
+
+def _variant_keys(index) -> frozenset:
+    """Variant keys of every symbol carrying ``build_tags``, cached per loaded index."""
+    cache = index._bm25_cache
+    keys = cache.get("variant_keys")
+    if keys is None:
+        keys = frozenset(_variant_key(s) for s in index.symbols if s.get("build_tags"))
+        cache["variant_keys"] = keys
+    return keys
+
+
+def _rank(score: float, sym: dict, pos: int) -> tuple:
+    """Heap rank for *sym*: best score first, equal scores by file then line.
+
+    *pos* (scan position) only separates symbols sharing a file and line,
+    keeping ranks unique.
+    """
+    return (-score, sym.get("file", ""), sym.get("line", 0), pos)
+
+
+class _Ranked:
+    """Heap entry ordered worst-first, so ``heap[0]`` is the one to evict."""
+
+    __slots__ = ("rank", "members")
+
+    def __init__(self, rank: tuple, members: list):
+        self.rank = rank
+        self.members = members
+
+    def __lt__(self, other: "_Ranked") -> bool:
+        return self.rank > other.rank
+
+
+class _VariantMerger:
+    """Bounded top-*limit* selection that counts build variants of a symbol once.
+
+    A Go package commonly defines the same function once per platform
+    (``poll_linux.go`` / ``poll_windows.go``).  Symbols in the same directory
+    with the same qualified name and kind, at least one of them carrying
+    ``build_tags``, are one symbol built different ways and count as one
+    result, ranked by their best variant.  Merging happens before paging so
+    ``offset`` and the counts refer to merged results.
+
+    Only matches whose key appears in *variant_keys* are held back for
+    grouping; everything else goes straight into a heap of *limit* entries,
+    so a query costs O(limit + tagged matches) memory, not O(matches).
+    Ranks sort ascending (best first) and must be unique.
+    """
+
+    def __init__(self, limit: int, variant_keys: frozenset):
+        self.limit = limit
+        self.total = 0
+        self._variant_keys = variant_keys
+        self._heap: list[_Ranked] = []
+        self._groups: dict[tuple[str, str], list[tuple[tuple, Any, dict]]] = {}
+
+    def add(self, rank: tuple, item: Any, sym: dict) -> None:
+        if self._variant_keys:
+            key = _variant_key(sym)
+            if key in self._variant_keys:
+                self._groups.setdefault(key, []).append((rank, item, sym))
+                return
+        self._push(rank, [item])
+
+    def _push(self, rank: tuple, members: list) -> None:
+        self.total += 1
+        entry = _Ranked(rank, members)
+        if len(self._heap) < self.limit:
+            heapq.heappush(self._heap, entry)
+        elif self._heap and rank < self._heap[0].rank:
+            heapq.heapreplace(self._heap, entry)
+
+    def ranked(self) -> list[tuple[Any, list]]:
+        """``(best, members)`` pairs in rank order.
+
+        ``members`` is ``[best]`` unless variants were merged.  ``total``
+        holds the number of merged results once this has run.
+        """
+        groups, self._groups = self._groups, {}
+        for members in groups.values():
+            if len(members) > 1 and any(sym.get("build_tags") for _, _, sym in members):
+                members.sort(key=lambda m: m[0])
+                self._push(members[0][0], [item for _, item, _ in members])
+            else:
+                for rank, item, _ in members:
+                    self._push(rank, [item])
+        return [(e.members[0], e.members) for e in sorted(self._heap, key=lambda e: e.rank)]
+
+
+def _variant_list(members: list[dict]) -> list[dict]:
+    """``build_variants`` payload for the symbols of one merged result."""
+    return [
+        {"id": m["id"], "file": m["file"], "line": m["line"], "build_tags": m.get("build_tags", "")}
+        for m in members
+    ]
+
+
 def _materialize_full_entry(entry: dict, index, store, owner: str, name: str) -> None:
     """Inline source/docstring/end_line and update byte_length to reflect full content.
 
@@ -449,6 +614,7 @@ def search_symbols(
     language: Optional[str] = None,
     decorator: Optional[str] = None,
     max_results: int = 10,
+    offset: int = 0,
     token_budget: Optional[int] = None,
     detail_level: str = "auto",
     debug: bool = False,
@@ -462,6 +628,8 @@ def search_symbols(
     fusion: bool = False,
     storage_path: Optional[str] = None,
     fqn: Optional[str] = None,
+    case_sensitive: bool = False,
+    include_tests: bool = True,
 ) -> dict:
     """Search for symbols matching a query.
 
@@ -473,6 +641,9 @@ def search_symbols(
         language: Optional filter by language (e.g., "python", "javascript").
         decorator: Optional filter by decorator (substring match, e.g. 'route', 'property').
         max_results: Maximum results to return (ignored when token_budget is set).
+        offset: Number of ranked results to skip, for paging. Results stay in
+            score order; equal scores are ordered by file then line, so
+            consecutive pages do not overlap.
         token_budget: Maximum tokens to consume. Results are greedily packed by
             score until the budget is exhausted. Overrides max_results.
         detail_level: Controls result verbosity.
@@ -494,6 +665,9 @@ def search_symbols(
         sort_by: Ranking strategy. "relevance" (default) = BM25 + centrality tiebreaker.
             "centrality" = filter by query match, rank by PageRank score.
             "combined" = BM25 + PageRank weighted combination.
+            "name" = rank by name alone, editor "go to symbol" style: exact,
+            then prefix, then substring, then subsequence matches. Every
+            result carries ``score`` and ``match_type``.
         semantic: Enable semantic (embedding-based) search. Requires an embedding
             provider to be configured (JCODEMUNCH_EMBED_MODEL, GOOGLE_API_KEY +
             GOOGLE_EMBED_MODEL, or OPENAI_API_KEY + OPENAI_EMBED_MODEL).
@@ -508,6 +682,13 @@ def search_symbols(
             higher-quality ranking than linear score addition. When True,
             ``sort_by`` is ignored (fusion handles its own ranking).
         storage_path: Custom storage path.
+        case_sensitive: Match names case-sensitively under sort_by="name".
+            Other ranking modes tokenize case-insensitively and ignore it.
+        include_tests: When False, drop symbols defined in test-only files
+            (Go ``*_test.go``).
+
+    Go symbols defined once per build constraint (``foo_linux.go`` /
+    ``foo_windows.go``) come back as one result carrying ``build_variants``.
 
     Returns:
         Dict with search results and _meta envelope.
@@ -515,14 +696,17 @@ def search_symbols(
     if detail_level not in ("auto", "compact", "standard", "full"):
         return {"error": f"Invalid detail_level '{detail_level}'. Must be 'auto', 'compact', 'standard', or 'full'."}
 
-    if sort_by not in ("relevance", "centrality", "combined"):
-        return {"error": f"Invalid sort_by '{sort_by}'. Must be 'relevance', 'centrality', or 'combined'."}
+    if sort_by not in ("relevance", "centrality", "combined", "name"):
+        return {"error": f"Invalid sort_by '{sort_by}'. Must be 'relevance', 'centrality', 'combined', or 'name'."}
 
-    # FQN shortcut: resolve PHP FQN and use class name as query
+    # FQN shortcut: resolve the FQN and use the class (PHP) or symbol name as query
     if fqn:
         _resolved, _ = resolve_fqn(repo, fqn, storage_path)
         if _resolved:
-            query = fqn.rsplit("\\", 1)[-1].split("::")[0]
+            if "\\" in fqn:
+                query = fqn.rsplit("\\", 1)[-1].split("::")[0]
+            else:
+                query = _resolved.split("::", 1)[-1].rsplit("#", 1)[0].rsplit(".", 1)[-1]
 
     _MAX_QUERY_LEN = 500
     if len(query) > _MAX_QUERY_LEN:
@@ -530,6 +714,7 @@ def search_symbols(
 
     start = time.perf_counter()
     max_results = max(1, min(max_results, 100))
+    offset = max(0, offset)
 
     # §1.1: Resolve "auto" to a concrete level BEFORE cache_key build so cache
     # keys reflect what we'll actually materialize. Explicit values pass through.
@@ -577,13 +762,16 @@ def search_symbols(
             language,
             decorator,
             max_results,
+            offset,
             fuzzy,
             fuzzy_threshold,
             max_edit_distance,
             sort_by,
+            case_sensitive,
             semantic_weight,
             token_budget,
             fusion,
+            include_tests,
         )
         _cached = _result_cache_get(_cache_key)
         if _cached is not None:
@@ -613,10 +801,7 @@ def search_symbols(
     query_terms = _tokenize(query) or [query.lower()]
     # Guard: empty string in query_terms causes "" to match every filename
     query_terms = [t for t in query_terms if t]
-    cache = index._bm25_cache
-    if "idf" not in cache:
-        cache["idf"], cache["avgdl"], cache["inverted"] = _compute_bm25(index.symbols)
-        cache["centrality"] = _compute_centrality(index.symbols, index.imports, index.alias_map, getattr(index, "psr4_map", None))
+    cache = _ensure_bm25_corpus(index)
     idf = cache["idf"]
     avgdl = cache["avgdl"]
     centrality = cache["centrality"]
@@ -633,7 +818,7 @@ def search_symbols(
             cache["pagerank"] = pr_scores
         pagerank = cache["pagerank"]
 
-    has_filters = bool(kind or file_pattern or language or decorator)
+    has_filters = bool(kind or file_pattern or language or decorator or not include_tests)
 
     # Bound the heap size in both modes.
     # token_budget mode: estimate ceiling as budget_bytes / min_symbol_size so the
@@ -646,6 +831,8 @@ def search_symbols(
     else:
         budget_bytes = 0
         effective_limit = max_results
+    # The heap must hold every skipped result too; the page is sliced below.
+    effective_limit += offset
 
     # ── Semantic / hybrid search path ──────────────────────────────────────
     # Diverges here when semantic=True; pure BM25 path continues below.
@@ -665,7 +852,9 @@ def search_symbols(
             file_pattern=file_pattern,
             language=language,
             decorator=decorator,
+            include_tests=include_tests,
             max_results=max_results,
+            offset=offset,
             effective_limit=effective_limit,
             token_budget=token_budget,
             budget_bytes=budget_bytes,
@@ -697,7 +886,9 @@ def search_symbols(
             file_pattern=file_pattern,
             language=language,
             decorator=decorator,
+            include_tests=include_tests,
             max_results=max_results,
+            offset=offset,
             effective_limit=effective_limit,
             token_budget=token_budget,
             budget_bytes=budget_bytes,
@@ -718,11 +909,15 @@ def search_symbols(
         posting = inverted.get(term)
         if posting:
             candidate_indices.update(posting)
-    if candidate_indices:
+    if candidate_indices and sort_by != "name":
         candidates = [index.symbols[i] for i in sorted(candidate_indices)]
     else:
+        # Name ranking needs a full scan: subsequence matches ("gcg" →
+        # get_call_graph) have no posting list.
         candidates = index.symbols
-    heap: list[tuple[float, int, dict]] = []  # (score, candidates_scored, entry)
+    # Ranked by score descending, ties by file then line, so every page size
+    # keeps the same prefix.  Bounded heap: O(N log K) instead of O(N log N).
+    merger = _VariantMerger(effective_limit, _variant_keys(index))
     candidates_scored = 0
     max_bm25_score = 0.0
 
@@ -736,8 +931,14 @@ def search_symbols(
                 continue
             if decorator and not any(decorator.lower() in d.lower() for d in (sym.get("decorators") or [])):
                 continue
+            if not include_tests and sym.get("is_test"):
+                continue
 
-        score = _bm25_score(sym, query_terms, idf, avgdl, centrality, raw_query=query)
+        name_match = ""
+        if sort_by == "name":
+            score, name_match = _name_match_score(sym.get("name", ""), query, case_sensitive)
+        else:
+            score = _bm25_score(sym, query_terms, idf, avgdl, centrality, raw_query=query)
         if score <= 0:
             continue
 
@@ -753,6 +954,13 @@ def search_symbols(
         else:
             heap_score = score
 
+        merger.add(_rank(heap_score, sym, candidates_scored), (sym, score, name_match), sym)
+
+    ranked = merger.ranked()
+    total_count = merger.total
+    heap_count = len(ranked)  # save before budget packing
+    scored_results = []
+    for (sym, score, name_match), members in ranked[offset:]:
         if detail_level == "compact":
             entry = {
                 "id": sym["id"],
@@ -776,19 +984,17 @@ def search_symbols(
         decs = sym.get("decorators") or []
         if decs:
             entry["decorators"] = decs
-        if debug:
+        if sym.get("build_tags"):
+            entry["build_tags"] = sym["build_tags"]
+        if len(members) > 1:
+            entry["build_variants"] = _variant_list([m[0] for m in members])
+        if name_match:
+            entry["score"] = round(score, 3)
+            entry["match_type"] = name_match
+        elif debug:
             entry["score"] = round(score, 3)
             entry["score_breakdown"] = _bm25_breakdown(sym, query_terms, idf, avgdl, raw_query=query)
-
-        # Bounded heap: O(N log K) instead of O(N log N)
-        if len(heap) < effective_limit:
-            heapq.heappush(heap, (heap_score, candidates_scored, entry))
-        elif heap_score > heap[0][0]:
-            heapq.heapreplace(heap, (heap_score, candidates_scored, entry))
-
-    # Extract results sorted by score descending
-    scored_results = [entry for _, _, entry in sorted(heap, key=lambda x: x[0], reverse=True)]
-    heap_count = len(scored_results)  # save before budget packing
+        scored_results.append(entry)
 
     # §1.2: Materialize full-detail payload BEFORE packing so byte_length reflects
     # what will actually be returned. Prior to this fix, the packer saw the pre-full
@@ -805,18 +1011,21 @@ def search_symbols(
             if used_bytes + b <= budget_bytes:
                 packed.append(entry)
                 used_bytes += b
-        budget_truncated = len(packed) < heap_count
+        budget_truncated = len(packed) < heap_count - offset
         scored_results = packed
 
-    # Fuzzy pass: runs when explicitly requested OR when BM25 found nothing useful
-    run_fuzzy = fuzzy or (max_bm25_score < _FUZZY_NEAR_MISS_THRESHOLD)
+    # Fuzzy pass: runs when explicitly requested OR when BM25 found nothing useful.
+    # Fuzzy hits are appended after the ranked results, so only the first page
+    # carries them.
+    run_fuzzy = (fuzzy or (max_bm25_score < _FUZZY_NEAR_MISS_THRESHOLD)) and offset == 0
     if run_fuzzy:
         for entry in scored_results:
-            entry["match_type"] = "exact"
+            entry.setdefault("match_type", "exact")
 
         query_lower = query.lower()
         query_tris = _trigrams(query_lower)
         existing_ids = {e["id"] for e in scored_results}
+        existing_ids.update(v["id"] for e in scored_results for v in e.get("build_variants", ()))
         fuzzy_hits: list[tuple[dict, float, int]] = []
 
         # Cap fuzzy candidates to avoid O(N) scan on very large repos.
@@ -834,6 +1043,8 @@ def search_symbols(
                     continue
                 if decorator and not any(decorator.lower() in d.lower() for d in (sym.get("decorators") or [])):
                     continue
+                if not include_tests and sym.get("is_test"):
+                    continue
             name_lower = sym.get("name", "").lower()
             name_tris = _trigrams(name_lower)
             union_size = len(query_tris | name_tris)
@@ -872,10 +1083,15 @@ def search_symbols(
             decs = sym.get("decorators") or []
             if decs:
                 entry["decorators"] = decs
+            if sym.get("build_tags"):
+                entry["build_tags"] = sym["build_tags"]
             entry["match_type"] = "fuzzy"
             entry["fuzzy_similarity"] = round(jac, 3)
             entry["edit_distance"] = ed
-            if debug:
+            if sort_by == "name":
+                # Below every name tier.
+                entry["score"] = round(_NAME_TIER_SUBSEQUENCE * min(jac, 0.99), 3)
+            elif debug:
                 entry["score"] = 0.0
             if detail_level == "full":
                 _materialize_full_entry(entry, index, store, owner, name)
@@ -929,7 +1145,7 @@ def search_symbols(
     meta = {
         "timing_ms": round(elapsed, 1),
         "total_symbols": len(index.symbols),
-        "truncated": candidates_scored > heap_count or budget_truncated,
+        "truncated": total_count > heap_count or budget_truncated,
         "tokens_saved": tokens_saved,
         "total_tokens_saved": total_saved,
         **cost_avoided(tokens_saved, total_saved),
@@ -944,8 +1160,13 @@ def search_symbols(
     if scored_results:
         meta["hint"] = "Use get_context_bundle(symbol_id) to retrieve source + imports in one call"
 
+    ranked_returned = len(scored_results) - sum(
+        1 for e in scored_results if e.get("match_type") == "fuzzy"
+    )
     result = {
         "result_count": len(scored_results),
+        "total_count": total_count,
+        "has_more": offset + ranked_returned < total_count,
         "results": scored_results,
         "_meta": meta,
     }
@@ -1022,7 +1243,9 @@ def _search_symbols_semantic(
     file_pattern: Optional[str],
     language: Optional[str],
     decorator: Optional[str],
+    include_tests: bool,
     max_results: int,
+    offset: int,
     effective_limit: int,
     token_budget: Optional[int],
     budget_bytes: int,
@@ -1114,6 +1337,8 @@ def _search_symbols_semantic(
                 continue
             if decorator and not any(decorator.lower() in d.lower() for d in (sym.get("decorators") or [])):
                 continue
+            if not include_tests and sym.get("is_test"):
+                continue
 
         bm25 = 0.0 if semantic_only else _bm25_score(sym, query_terms, idf, avgdl, centrality, raw_query=query)
         if bm25 > max_bm25:
@@ -1127,20 +1352,20 @@ def _search_symbols_semantic(
         raw.append((sym, bm25, cos))
 
     # Pass 2: normalise BM25 and compute combined score
-    scored: list[tuple[float, dict]] = []
-    for sym, bm25, cos in raw:
+    merger = _VariantMerger(effective_limit, _variant_keys(index))
+    for pos, (sym, bm25, cos) in enumerate(raw):
         bm25_norm = (bm25 / max_bm25) if max_bm25 > 0.0 else 0.0
         score = cos if semantic_only else (1.0 - semantic_weight) * bm25_norm + semantic_weight * cos
         if score <= 0.0:
             continue
-        scored.append((score, sym))
+        merger.add(_rank(score, sym, pos), (score, sym), sym)
 
-    scored.sort(key=lambda x: x[0], reverse=True)
-    top = scored[:effective_limit]
+    top = merger.ranked()
+    total_count = merger.total
 
     # ── Build result entries ───────────────────────────────────────────────
     scored_results: list[dict] = []
-    for score, sym in top:
+    for (score, sym), members in top[offset:]:
         if detail_level == "compact":
             entry: dict = {
                 "id": sym["id"],
@@ -1164,6 +1389,10 @@ def _search_symbols_semantic(
         decs = sym.get("decorators") or []
         if decs:
             entry["decorators"] = decs
+        if sym.get("build_tags"):
+            entry["build_tags"] = sym["build_tags"]
+        if len(members) > 1:
+            entry["build_variants"] = _variant_list([m[1] for m in members])
         if debug:
             entry["score"] = round(score, 4)
         scored_results.append(entry)
@@ -1202,7 +1431,7 @@ def _search_symbols_semantic(
     meta: dict = {
         "timing_ms": round(elapsed, 1),
         "total_symbols": len(index.symbols),
-        "truncated": len(scored) > len(scored_results),
+        "truncated": total_count > len(scored_results),
         "tokens_saved": tokens_saved,
         "total_tokens_saved": total_saved,
         "search_mode": "semantic_only" if semantic_only else "hybrid",
@@ -1219,6 +1448,8 @@ def _search_symbols_semantic(
     # Feature 1: Negative evidence for semantic search
     result = {
         "result_count": len(scored_results),
+        "total_count": total_count,
+        "has_more": offset + len(scored_results) < total_count,
         "results": scored_results,
         "_meta": meta,
     }
@@ -1311,7 +1542,9 @@ def _search_symbols_fusion(
     file_pattern,
     language,
     decorator,
+    include_tests: bool,
     max_results: int,
+    offset: int,
     effective_limit: int,
     token_budget,
     budget_bytes: int,
@@ -1339,6 +1572,7 @@ def _search_symbols_fusion(
             and (not file_pattern or _fnmatch(sym.get("file", ""), file_pattern))
             and (not language or sym.get("language") == language)
             and (not decorator or any(decorator.lower() in d.lower() for d in (sym.get("decorators") or [])))
+            and (include_tests or not sym.get("is_test"))
         ]
     else:
         candidates = index.symbols
@@ -1404,12 +1638,16 @@ def _search_symbols_fusion(
 
     # Build result list
     sym_by_id = {sym["id"]: sym for sym in candidates}
+    merger = _VariantMerger(effective_limit, _variant_keys(index))
+    for pos, fr in enumerate(fused):
+        sym = sym_by_id.get(fr.symbol_id)
+        if sym:
+            merger.add(_rank(fr.score, sym, pos), (fr, sym), sym)
+    top = merger.ranked()
+    total_count = merger.total
     scored_results = []
 
-    for fr in fused[:effective_limit]:
-        sym = sym_by_id.get(fr.symbol_id)
-        if not sym:
-            continue
+    for (fr, sym), members in top[offset:]:
 
         if detail_level == "compact":
             entry = {
@@ -1434,6 +1672,10 @@ def _search_symbols_fusion(
         decs = sym.get("decorators") or []
         if decs:
             entry["decorators"] = decs
+        if sym.get("build_tags"):
+            entry["build_tags"] = sym["build_tags"]
+        if len(members) > 1:
+            entry["build_variants"] = _variant_list([m[1] for m in members])
         if debug:
             entry["fusion_score"] = round(fr.score, 6)
             entry["channel_contributions"] = {
@@ -1477,7 +1719,7 @@ def _search_symbols_fusion(
     meta = {
         "timing_ms": round(elapsed, 1),
         "total_symbols": len(index.symbols),
-        "truncated": len(fused) > len(scored_results) or budget_truncated,
+        "truncated": total_count > len(scored_results) or budget_truncated,
         "tokens_saved": tokens_saved,
         "total_tokens_saved": total_saved,
         **cost_avoided(tokens_saved, total_saved),
@@ -1497,6 +1739,8 @@ def _search_symbols_fusion(
 
     result = {
         "result_count": len(scored_results),
+        "total_count": total_count,
+        "has_more": offset + len(scored_results) < total_count,
         "results": scored_results,
         "_meta": meta,
     }
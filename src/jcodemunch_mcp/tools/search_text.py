@@ -5,6 +5,8 @@ import re
 import time
 from typing import Optional
 
+from .. import body_policy
+from ..deadline import current_deadline
 from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
 from ._utils import index_status_to_tool_error, resolve_repo
 
@@ -33,6 +35,8 @@ def search_text(
     context_lines: int = 0,
     is_regex: bool = False,
     storage_path: Optional[str] = None,
+    offset: int = 0,
+    count_all: bool = False,
 ) -> dict:
     """Search for text across all indexed files in a repository.
 
@@ -44,13 +48,21 @@ def search_text(
         query: Text to search for. Case-insensitive substring by default;
                set is_regex=True for full regex (e.g. 'estimateToken|tokenEstimat').
         file_pattern: Optional glob pattern to filter files.
-        max_results: Maximum number of matching lines to return.
+        max_results: Maximum number of matching lines to return (page size).
         context_lines: Lines of context before/after each match (like grep -C).
         is_regex: When True, treat query as a Python regex (re.search, IGNORECASE).
         storage_path: Custom storage path.
+        offset: Number of matching lines to skip, for paging. Matches are
+            ordered by file path then line, so pages are stable across calls.
+        count_all: Keep scanning past the page to count every match.  By
+            default the scan stops at the first match after the page.
 
     Returns:
-        Dict with matching lines grouped by file, plus _meta envelope.
+        Dict with matching lines grouped by file, ``total_count``,
+        ``total_count_exact``, ``has_more``, plus _meta envelope.
+        ``total_count`` counts every match only when ``total_count_exact``
+        is true; otherwise it is a lower bound (the scan stopped one match
+        past the page, or timed out).
     """
     _MAX_QUERY_LEN = 500
     if len(query) > _MAX_QUERY_LEN:
@@ -58,6 +70,7 @@ def search_text(
 
     start = time.perf_counter()
     max_results = max(1, min(max_results, 100))
+    offset = max(0, offset)
     context_lines = max(0, min(context_lines, 10))
 
     # For regex mode, compile the user pattern. For substring mode, use
@@ -88,7 +101,7 @@ def search_text(
         return index_status_to_tool_error(store.inspect_index(owner, name))
 
     # Pre-compile file pattern into a single regex (avoids double fnmatch per file)
-    files = index.source_files
+    files = sorted(index.source_files)
     if file_pattern:
         pat_re = re.compile(
             _fnmatch.translate(file_pattern) + "|" + _fnmatch.translate(f"*/{file_pattern}")
@@ -96,17 +109,24 @@ def search_text(
         files = [f for f in files if pat_re.match(f)]
 
     content_dir = store._content_dir(owner, name)
+    withhold = body_policy.is_enabled()
     results = []
     result_count = 0
+    total_count = 0
     files_searched = 0
     truncated = False
+    capped = False
     timed_out = False
     raw_bytes = 0
     response_bytes = 0
 
     # Only enforce the wall-clock budget for regex mode — substring (`in`)
-    # is linear and not a ReDoS vector.
+    # is linear and not a ReDoS vector.  The per-call deadline applies to both.
     _budget_deadline = (start + _REGEX_BUDGET_SEC) if pattern is not None else None
+    _call_deadline = current_deadline()
+    if _call_deadline is not None:
+        _call_end = time.perf_counter() + _call_deadline.remaining()
+        _budget_deadline = min(_budget_deadline, _call_end) if _budget_deadline is not None else _call_end
 
     for file_path in files:
         if _budget_deadline is not None and time.perf_counter() > _budget_deadline:
@@ -120,6 +140,8 @@ def search_text(
                 lines = f.readlines()
         except OSError:
             continue
+        if withhold:
+            lines = body_policy.blank_hidden_lines(lines, index, file_path, keepends=True)
 
         files_searched += 1
         file_matches = []
@@ -137,6 +159,17 @@ def search_text(
             hit = pattern.search(line) if pattern else (query_lower in line.lower())
             if not hit:
                 continue
+            total_count += 1
+            # Before the requested page: count the match but don't
+            # materialise it.  Past it, one more match proves has_more.
+            if total_count <= offset:
+                continue
+            if result_count >= max_results:
+                truncated = True
+                if not count_all:
+                    capped = True
+                    break
+                continue
             text = line.rstrip()[:200]
             match = {
                 "line": line_index + 1,
@@ -153,16 +186,13 @@ def search_text(
                 response_bytes += sum(len(v) for v in before) + sum(len(v) for v in after)
             file_matches.append(match)
             result_count += 1
-            if result_count >= max_results:
-                truncated = True
-                break
 
         if file_matches:
             results.append({"file": file_path, "matches": file_matches})
             response_bytes += len(file_path) + 20
             raw_bytes += index.file_sizes.get(file_path, 0)
 
-        if truncated or timed_out:
+        if timed_out or capped:
             break
 
     elapsed = (time.perf_counter() - start) * 1000
@@ -172,6 +202,9 @@ def search_text(
 
     return {
         "result_count": result_count,
+        "total_count": total_count,
+        "total_count_exact": not (capped or timed_out),
+        "has_more": offset + result_count < total_count,
         "results": results,
         "_meta": {
             "timing_ms": round(elapsed, 1),
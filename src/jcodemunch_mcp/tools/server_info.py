@@ -0,0 +1,96 @@
+"""Describe this server build: version, languages, tools, and index statistics.
+
+Lets a client discover at runtime what it can ask for instead of hardcoding
+it — a Rust query is only worth offering when ``rust`` is in ``languages``,
+and a tool is only callable when it is in ``tools`` (which reflects the
+active tier and ``disabled_tools``, exactly as ``tools/list`` does).
+``parse_cache`` shows the in-process parse cache staying under its budget
+over a long session.
+"""
+
+import time
+from typing import Optional
+
+from .. import __version__, body_policy
+from ..parser import parse_cache
+from ..parser.languages import LANGUAGE_EXTENSIONS, LANGUAGE_REGISTRY
+from ..storage import IndexStore
+from ..storage.index_store import INDEX_VERSION
+from ._utils import resolve_repo
+
+
+def _languages() -> list[dict]:
+    """Parseable languages with the file extensions routed to each."""
+    by_language: dict[str, list[str]] = {}
+    for ext, language in LANGUAGE_EXTENSIONS.items():
+        if language in LANGUAGE_REGISTRY:
+            by_language.setdefault(language, []).append(ext)
+    return [
+        {"language": language, "extensions": sorted(by_language.get(language, []))}
+        for language in sorted(LANGUAGE_REGISTRY)
+    ]
+
+
+def server_info(
+    tool_names: list[str],
+    tier: str = "full",
+    repo: Optional[str] = None,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Report server capabilities and what is currently indexed.
+
+    Args:
+        tool_names: Tools currently registered (as listed to the client).
+        tier: Active tool tier (``core``, ``standard`` or ``full``).
+        repo: Optional repository to restrict the index statistics to.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with ``version``, ``index_version``, ``languages`` (each with
+        its ``extensions``), ``extensions`` (flat, sorted), ``tools``,
+        ``tier``, and ``index`` totals — repos, files, symbols, and the
+        most recent ``last_indexed_at`` — with a per-repo breakdown, and
+        ``parse_cache`` (entries, estimated bytes, caps, and hit/miss/
+        eviction counters), and ``withhold_unexported_bodies``, the active
+        body policy.
+    """
+    start = time.perf_counter()
+
+    repos = IndexStore(base_path=storage_path).list_repos()
+    if repo is not None:
+        try:
+            owner, name = resolve_repo(repo, storage_path)
+        except ValueError as e:
+            return {"error": str(e)}
+        repos = [r for r in repos if r.get("repo") == f"{owner}/{name}"]
+
+    per_repo = [
+        {
+            "repo": r.get("repo", ""),
+            "file_count": r.get("file_count", 0),
+            "symbol_count": r.get("symbol_count", 0),
+            "indexed_at": r.get("indexed_at", ""),
+        }
+        for r in repos
+    ]
+    languages = _languages()
+
+    return {
+        "version": __version__,
+        "index_version": INDEX_VERSION,
+        "languages": languages,
+        "extensions": sorted(ext for entry in languages for ext in entry["extensions"]),
+        "tier": tier,
+        "tool_count": len(tool_names),
+        "tools": sorted(tool_names),
+        "index": {
+            "repo_count": len(per_repo),
+            "file_count": sum(r["file_count"] for r in per_repo),
+            "symbol_count": sum(r["symbol_count"] for r in per_repo),
+            "last_indexed_at": max((r["indexed_at"] for r in per_repo if r["indexed_at"]), default=""),
+            "repos": per_repo,
+        },
+        "parse_cache": parse_cache.stats(),
+        "withhold_unexported_bodies": body_policy.is_enabled(),
+        "_meta": {"timing_ms": round((time.perf_counter() - start) * 1000, 1)},
+    }
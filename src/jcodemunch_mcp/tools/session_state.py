@@ -8,13 +8,14 @@ This module provides SessionState class for persisting and restoring:
 Storage location: ~/.code-index/_session_state.json
 """
 import json
-import os
 import threading
 from collections import OrderedDict
 from datetime import datetime, timezone, timedelta
 from pathlib import Path
 from typing import Any, Optional
 
+from ..config import default_config_dir
+
 
 class SessionState:
     """Persist and restore session state across server restarts."""
@@ -26,7 +27,7 @@ class SessionState:
             base_path: Storage directory. Defaults to CODE_INDEX_PATH env var.
         """
         if base_path is None:
-            base_path = os.environ.get("CODE_INDEX_PATH", str(Path.home() / ".code-index"))
+            base_path = str(default_config_dir())
         self._path = Path(base_path) / "_session_state.json"
         self._lock = threading.Lock()
         self._flush_counter = 0
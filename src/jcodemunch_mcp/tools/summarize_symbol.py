@@ -0,0 +1,149 @@
+"""summarize_symbol — a compact preview of one symbol for planning steps.
+
+One call returns what ``get_symbol_source`` and ``get_file_outline`` would
+otherwise be combined for: the doc summary, the signature with structured
+``params``/``returns``, the enclosing type and package, and the first
+``max_lines`` lines of source.  A symbol no longer than that comes back
+whole, so short functions need no follow-up call.
+"""
+
+from __future__ import annotations
+
+import posixpath
+import time
+from typing import Optional
+
+from ..parser.docstring import parse_docstring
+from ..parser.fqn import fqn_package
+from ..storage import IndexStore, record_savings, estimate_savings, cost_avoided
+from ._utils import index_status_to_tool_error, resolve_fqn, resolve_repo
+
+_DEFAULT_MAX_LINES = 10
+_MAX_LINES_CAP = 200
+_TYPE_KINDS = ("class", "type")
+
+
+def _enclosing(index, symbol: dict) -> Optional[dict]:
+    """The type a symbol belongs to: its parent, or a Go method's receiver type."""
+    parent = index.get_symbol(symbol["parent"]) if symbol.get("parent") else None
+    if parent is None and symbol.get("receiver_type"):
+        directory = posixpath.dirname(symbol["file"])
+        parent = next(
+            (
+                s for s in index.symbols
+                if s.get("name") == symbol["receiver_type"] and s.get("kind") in _TYPE_KINDS
+                and posixpath.dirname(s.get("file", "")) == directory
+            ),
+            None,
+        )
+        if parent is None:
+            return {"name": symbol["receiver_type"]}
+    if parent is None:
+        return None
+    return {"id": parent["id"], "name": parent["name"], "kind": parent["kind"]}
+
+
+def _package(symbol: dict) -> str:
+    """Import path / module from the stored fqn, else the file's directory."""
+    return fqn_package(symbol) or posixpath.dirname(symbol["file"])
+
+
+def summarize_symbol(
+    repo: str,
+    symbol_id: Optional[str] = None,
+    fqn: Optional[str] = None,
+    max_lines: int = _DEFAULT_MAX_LINES,
+    storage_path: Optional[str] = None,
+) -> dict:
+    """Preview a symbol: doc summary, signature parts, container, first lines.
+
+    Args:
+        repo: Repository identifier (owner/repo or just repo name).
+        symbol_id: Symbol ID from search_symbols or get_file_outline.
+        fqn: Qualified ID (``example.com/app/store.Config.Load``) or PHP FQN,
+            instead of ``symbol_id``.
+        max_lines: Source lines to include (default 10, max 200).  A symbol
+            of at most this many lines is returned whole.
+        storage_path: Custom storage path.
+
+    Returns:
+        Dict with id, name, kind, file, line, end_line, signature, ``summary``
+        (the doc comment's first sentence, else the indexed summary),
+        ``params``/``returns`` when extracted, ``package``, ``enclosing``
+        (the containing type, when there is one), ``source`` (the first
+        ``max_lines`` lines), ``total_lines``, ``truncated``, and _meta.
+        ``body_withheld`` is set when the withhold policy hid the body.
+    """
+    start = time.perf_counter()
+
+    if fqn and not symbol_id:
+        symbol_id, fqn_error = resolve_fqn(repo, fqn, storage_path)
+        if symbol_id is None:
+            return {"error": fqn_error or f"Could not resolve FQN '{fqn}'."}
+    if not symbol_id:
+        return {"error": "Provide symbol_id or fqn."}
+    max_lines = max(1, min(int(max_lines), _MAX_LINES_CAP))
+
+    try:
+        owner, name = resolve_repo(repo, storage_path)
+    except ValueError as e:
+        return {"error": str(e)}
+
+    store = IndexStore(base_path=storage_path)
+    index = store.load_index(owner, name)
+    if not index:
+        return index_status_to_tool_error(store.inspect_index(owner, name))
+
+    symbol = index.get_symbol(symbol_id)
+    if not symbol:
+        return {"error": f"Symbol not found: {symbol_id}. Try search_symbols first."}
+
+    source = store.get_symbol_content(owner, name, symbol_id, _index=index) or ""
+    lines = source.split("\n")
+    if lines and lines[-1] == "":
+        lines.pop()
+    truncated = len(lines) > max_lines
+    preview = "\n".join(lines[:max_lines])
+
+    doc = parse_docstring(symbol.get("docstring", ""), symbol.get("decorators", []))
+    result: dict = {
+        "id": symbol["id"],
+        "name": symbol["name"],
+        "kind": symbol["kind"],
+        "language": symbol.get("language", ""),
+        "file": symbol["file"],
+        "line": symbol["line"],
+        "end_line": symbol["end_line"],
+        "signature": symbol["signature"],
+        "summary": doc["summary"] or symbol.get("summary", ""),
+    }
+    if symbol.get("params"):
+        result["params"] = symbol["params"]
+    if symbol.get("returns"):
+        result["returns"] = symbol["returns"]
+    if symbol.get("type_params"):
+        result["type_params"] = symbol["type_params"]
+    if doc["deprecated"] is not None:
+        result["deprecated"] = doc["deprecated"]
+    result["package"] = _package(symbol)
+    enclosing = _enclosing(index, symbol)
+    if enclosing:
+        result["enclosing"] = enclosing
+    result["source"] = preview
+    result["total_lines"] = len(lines)
+    result["truncated"] = truncated
+    if store.body_withheld(owner, name, symbol_id, _index=index):
+        result["body_withheld"] = True
+
+    raw_bytes = symbol.get("byte_length", 0)
+    tokens_saved = estimate_savings(raw_bytes, len(preview.encode("utf-8")))
+    total_saved = record_savings(tokens_saved, tool_name="summarize_symbol")
+    result["_meta"] = {
+        "timing_ms": round((time.perf_counter() - start) * 1000, 1),
+        "tokens_saved": tokens_saved,
+        "total_tokens_saved": total_saved,
+        **cost_avoided(tokens_saved, total_saved),
+    }
+    if truncated:
+        result["_meta"]["hint"] = "Use get_symbol_source for the full body"
+    return result
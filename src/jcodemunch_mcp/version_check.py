@@ -19,6 +19,8 @@ import os
 import sys
 from pathlib import Path
 
+from .config import default_config_dir
+
 logger = logging.getLogger(__name__)
 
 _LAST_SEEN_FILENAME = "last_seen_version"
@@ -26,11 +28,8 @@ _RELEASE_URL_FMT = "https://github.com/jgravelle/jcodemunch-mcp/releases/tag/v{v
 
 
 def _storage_root() -> Path:
-    """Same root the rest of the package uses for cached state."""
-    env = os.environ.get("CODE_INDEX_PATH")
-    if env:
-        return Path(env)
-    return Path.home() / ".code-index"
+    """Same root the rest of the package uses for user state."""
+    return default_config_dir()
 
 
 def _current_version() -> str | None:
@@ -18,12 +18,12 @@ from __future__ import annotations
 
 import asyncio
 import logging
-import os
 import signal
 import sys
 from pathlib import Path
 from typing import IO, Optional
 
+from .config import default_storage_path
 from .storage import IndexStore
 from .watcher import DEFAULT_DEBOUNCE_MS, WatcherManager
 
@@ -76,7 +76,7 @@ async def watch_all(
     use_ai_summaries: bool = True,
     storage_path: Optional[str] = None,
     extra_ignore_patterns: Optional[list[str]] = None,
-    follow_symlinks: bool = False,
+    follow_symlinks: Optional[bool] = None,
     rediscover_interval_s: float = DEFAULT_REDISCOVER_INTERVAL_S,
     quiet: bool = False,
     log_file_handle: Optional[IO] = None,
@@ -154,4 +154,4 @@ async def watch_all(
 
 
 def storage_path_default() -> str:
-    return os.environ.get("CODE_INDEX_PATH") or str(Path.home() / ".code-index")
+    return str(default_storage_path())
@@ -13,8 +13,9 @@ import tempfile
 import time
 from datetime import datetime, timezone
 from pathlib import Path
-from typing import Callable, IO, Optional
+from typing import Any, Callable, IO, Optional
 
+from .config import default_storage_path
 from .hook_event import default_manifest_path, read_manifest
 from .tools.index_folder import index_folder
 from .tools.invalidate_cache import invalidate_cache
@@ -33,6 +34,9 @@ logger = logging.getLogger(__name__)
 # Default debounce in milliseconds
 DEFAULT_DEBOUNCE_MS = 200
 
+# Cap on per-file entries in an index-update payload
+_MAX_UPDATE_FILES = 50
+
 
 class WatcherError(Exception):
     """Base exception for watcher errors that should not kill the embedding process."""
@@ -74,7 +78,7 @@ def _lock_dir(storage_path: Optional[str]) -> Path:
     if storage_path:
         d = Path(storage_path)
     else:
-        d = Path.home() / ".code-index"
+        d = default_storage_path()
     d.mkdir(parents=True, exist_ok=True)
     return d
 
@@ -157,18 +161,56 @@ async def _idle_timeout_watchdog(
 # Core watching
 # ---------------------------------------------------------------------------
 
+def _index_update_payload(
+    repo_id: str,
+    folder_path: str,
+    changes: list[tuple[str, str]],
+    result: dict,
+) -> dict:
+    """Describe one completed re-index for ``on_index_update`` listeners.
+
+    ``changes`` is ``[(kind, rel_path)]``.  A rename arrives from watchfiles
+    as a ``deleted`` plus an ``added`` entry.
+    """
+    return {
+        "repo": repo_id,
+        "folder": folder_path,
+        "changed": result.get("changed", 0),
+        "new": result.get("new", 0),
+        "deleted": result.get("deleted", 0),
+        "files": [{"path": p, "change": c} for c, p in changes[:_MAX_UPDATE_FILES]],
+        "files_truncated": len(changes) > _MAX_UPDATE_FILES,
+    }
+
+
+async def _call_update_listener(listener: Callable[[dict], Any], payload: dict) -> None:
+    """Invoke a sync or async listener; a failing listener never stops the watcher."""
+    try:
+        maybe_awaitable = listener(payload)
+        if asyncio.iscoroutine(maybe_awaitable):
+            await maybe_awaitable
+    except Exception:
+        logger.debug("Index update listener failed for %s", payload.get("repo"), exc_info=True)
+
+
 async def _watch_single(
     folder_path: str,
     debounce_ms: int,
     use_ai_summaries: bool,
     storage_path: Optional[str],
     extra_ignore_patterns: Optional[list[str]],
-    follow_symlinks: bool,
+    follow_symlinks: Optional[bool],
     on_reindex: Optional[Callable[[], None]] = None,
     quiet: bool = False,
     log_file_handle: Optional[IO] = None,
+    on_index_update: Optional[Callable[[dict], Any]] = None,
 ) -> None:
-    """Watch a single folder and re-index on changes."""
+    """Watch a single folder and re-index on changes.
+
+    When *on_index_update* is given it is called (and awaited, if it returns
+    a coroutine) after every re-index that changed the index, with the
+    payload from :func:`_index_update_payload`.
+    """
     _watcher_output(f"Watching {folder_path} (debounce={debounce_ms}ms)", quiet=quiet, log_file_handle=log_file_handle)
 
     # Compute repo identifier for memory hash cache and reindex state.
@@ -316,6 +358,15 @@ async def _watch_single(
                     # Report re-index activity (only if it actually did work)
                     if on_reindex is not None:
                         on_reindex()
+                    if on_index_update is not None:
+                        rel_changes = [
+                            (_change_map[ct], Path(p).relative_to(folder_path).as_posix())
+                            for ct, p in relevant
+                        ]
+                        await _call_update_listener(
+                            on_index_update,
+                            _index_update_payload(repo_id, folder_path, rel_changes, result),
+                        )
             else:
                 _watcher_output(
                     f"  WARNING: re-index failed for {folder_path}: {result.get('error')}",
@@ -349,10 +400,11 @@ class WatcherManager:
         use_ai_summaries: bool = True,
         storage_path: Optional[str] = None,
         extra_ignore_patterns: Optional[list[str]] = None,
-        follow_symlinks: bool = False,
+        follow_symlinks: Optional[bool] = None,
         quiet: bool = False,
         log_file_handle: Optional[IO] = None,
         on_reindex: Optional[Callable[[], None]] = None,
+        on_index_update: Optional[Callable[[dict], Any]] = None,
     ) -> None:
         self._active: dict[str, asyncio.Task] = {}
         self._watched: set[str] = set()
@@ -369,6 +421,7 @@ class WatcherManager:
         self._quiet = quiet
         self._log_file_handle = log_file_handle
         self._on_reindex = on_reindex
+        self._on_index_update = on_index_update
         self._stop_event: Optional[asyncio.Event] = None
         # Standby tracking for failover
         self._standby: set[str] = set()
@@ -447,6 +500,7 @@ class WatcherManager:
                 on_reindex=self._on_reindex,
                 quiet=self._quiet,
                 log_file_handle=self._log_file_handle,
+                on_index_update=self._on_index_update,
             ),
             name=f"watch:{folder}",
         )
@@ -703,7 +757,7 @@ async def watch_folders(
     use_ai_summaries: bool = True,
     storage_path: Optional[str] = None,
     extra_ignore_patterns: Optional[list[str]] = None,
-    follow_symlinks: bool = False,
+    follow_symlinks: Optional[bool] = None,
     idle_timeout_minutes: Optional[int] = None,
     stop_event: Optional[asyncio.Event] = None,
     quiet: bool = False,
@@ -883,7 +937,7 @@ async def sync_folders(
     use_ai_summaries: bool = True,
     storage_path: Optional[str] = None,
     extra_ignore_patterns: Optional[list[str]] = None,
-    follow_symlinks: bool = False,
+    follow_symlinks: Optional[bool] = None,
 ) -> None:
     """Index all paths once (incremental) and return immediately — no file watching."""
     resolved = []
@@ -1009,7 +1063,7 @@ async def watch_claude_worktrees(
     use_ai_summaries: bool = True,
     storage_path: Optional[str] = None,
     extra_ignore_patterns: Optional[list[str]] = None,
-    follow_symlinks: bool = False,
+    follow_symlinks: Optional[bool] = None,
 ) -> None:
     """Watch agent worktrees via JSONL manifest and/or git repo polling."""
     manifest_path = default_manifest_path()
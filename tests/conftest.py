@@ -5,7 +5,7 @@ import pytest
 
 @pytest.fixture(autouse=True)
 def _clear_index_cache():
-    """Clear the in-memory SQLite index cache before and after each test.
+    """Clear the in-memory SQLite index and parse caches before and after each test.
 
     Tests that modify the SQLite DB directly (e.g. changing index_version)
     can leave stale entries in the module-level cache.  SQLite WAL mode does
@@ -16,13 +16,17 @@ def _clear_index_cache():
     """
     try:
         from jcodemunch_mcp.storage.sqlite_store import _cache_clear
+        from jcodemunch_mcp.parser import parse_cache
         _cache_clear()
+        parse_cache.clear()
     except ImportError:
         pass
     yield
     try:
         from jcodemunch_mcp.storage.sqlite_store import _cache_clear
+        from jcodemunch_mcp.parser import parse_cache
         _cache_clear()
+        parse_cache.clear()
     except ImportError:
         pass
 
@@ -1,4 +1,4 @@
-"""Shared test helpers for session-aware routing features."""
+"""Shared test helpers: small indexed repos and source-position lookups."""
 from pathlib import Path
 
 
@@ -54,6 +54,34 @@ def create_exact_match_index(tmp_path: Path, filename: str = "ui_builder.py") ->
     return result["repo"], sp
 
 
+def create_go_index(
+    tmp_path: Path, files: dict[str, str], module: str | None = None
+) -> tuple[str, str]:
+    """Index *files* (relative path -> source) under ``tmp_path/src``.
+
+    Any mix of languages works; with *module*, a ``go.mod`` declaring it is
+    written at the root first.
+    Returns (repo_id, storage_path_str).
+    """
+    from jcodemunch_mcp.tools.index_folder import index_folder
+
+    src = tmp_path / "src"
+    src.mkdir(parents=True, exist_ok=True)
+    if module:
+        _write(src / "go.mod", f"module {module}\n\ngo 1.21\n")
+    for rel, content in files.items():
+        _write(src / rel, content)
+    sp = str(tmp_path / "idx")
+    result = index_folder(path=str(src), use_ai_summaries=False, storage_path=sp)
+    assert result["success"] is True
+    return result["repo"], sp
+
+
+def line_of(src: str, needle: str) -> int:
+    """1-based number of the first line of *src* containing *needle*."""
+    return next(i for i, text in enumerate(src.split("\n"), 1) if needle in text)
+
+
 def get_index(repo: str, storage_path: str):
     """Load the CodeIndex for a test repo."""
     from jcodemunch_mcp.storage.sqlite_store import SQLiteIndexStore
@@ -81,6 +81,38 @@ def test_find_references_empty_matches_round_trip():
     assert out["references"][1]["matches"][0]["match_type"] == "named"
 
 
+def test_find_references_usages_round_trip():
+    resp = {
+        "repo": "acme/app",
+        "identifier": "get_user",
+        "reference_count": 0,
+        "references": [],
+        "usage_count": 2,
+        "usages": [
+            {"file": "src/a.py", "line": 3, "text": "def get_user(uid):", "role": "definition", "symbol": "src/a.py::get_user#function"},
+            {"file": "src/b.py", "line": 9, "text": "u = get_user(1)", "role": "reference"},
+        ],
+        "_meta": {"timing_ms": 1.0, "truncated": False},
+    }
+    out = _rt("find_references", resp)
+    assert out["usage_count"] == 2
+    assert [u["line"] for u in out["usages"]] == [3, 9]
+    assert out["usages"][0]["role"] == "definition"
+    assert out["usages"][1]["text"] == "u = get_user(1)"
+
+
+def test_find_references_without_usages_omits_key():
+    resp = {
+        "repo": "acme/app",
+        "identifier": "get_user",
+        "reference_count": 1,
+        "references": [{"file": "src/a.py", "matches": [{"specifier": "m", "match_type": "named"}]}],
+        "_meta": {"timing_ms": 1.0, "truncated": False},
+    }
+    out = _rt("find_references", resp)
+    assert "usages" not in out
+
+
 def test_find_references_batch_round_trip():
     resp = {
         "repo": "acme/app",
@@ -400,6 +432,23 @@ def test_get_file_outline_round_trip():
     assert by_id["s2"]["signature"] == "def __init__(self)"
 
 
+def test_get_file_outline_exported_round_trip():
+    resp = {
+        "repo": "acme/app",
+        "file": "src/models/user.py",
+        "symbols": [
+            {"id": "s1", "name": "User", "kind": "class", "signature": "class User", "line": 1, "end_line": 20, "parent": None, "summary": "", "exported": True},
+            {"id": "s2", "name": "_cache", "kind": "constant", "signature": "_cache = {}", "line": 22, "end_line": 22, "parent": None, "summary": "", "exported": False},
+            {"id": "s3", "name": "Thing", "kind": "class", "signature": "class Thing", "line": 30, "end_line": 31, "parent": None, "summary": "", "exported": True},
+        ],
+        "_meta": {"timing_ms": 0.3},
+    }
+    out = _rt("get_file_outline", resp)
+    by_id = {s["id"]: s for s in out["symbols"]}
+    assert by_id["s1"]["exported"] is True
+    assert by_id["s2"]["exported"] is False
+
+
 def test_get_repo_outline_round_trip():
     resp = {
         "repo": "acme/app",
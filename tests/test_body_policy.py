@@ -0,0 +1,99 @@
+"""Tests for the withhold_unexported_bodies policy (body_policy)."""
+
+import pytest
+
+from jcodemunch_mcp.body_policy import BODY_WITHHELD, withhold_source
+from jcodemunch_mcp.tools.get_call_hierarchy import get_call_hierarchy
+from jcodemunch_mcp.tools.get_file_content import get_file_content
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from jcodemunch_mcp.tools.search_text import search_text
+from jcodemunch_mcp.tools.summarize_symbol import summarize_symbol
+from tests.conftest_helpers import create_go_index
+
+STORE_GO = '''package store
+
+// Load returns the value for key.
+func Load(key string) string {
+\treturn decode(key)
+}
+
+// decode unwraps a stored value.
+func decode(key string) string {
+\tseed := "hunter2-secret"
+\treturn seed + key
+}
+'''
+
+JOBS_PY = '''def run(job):
+    return _helper(job)
+
+
+def _helper(job):
+    """Prepare one job."""
+    token = "hunter2-secret"
+    return token + job
+'''
+
+
+@pytest.fixture
+def repo(tmp_path, monkeypatch):
+    indexed = create_go_index(tmp_path, {"store/store.go": STORE_GO, "jobs.py": JOBS_PY})
+    monkeypatch.setenv("JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES", "1")
+    return indexed
+
+
+def test_symbol_source_keeps_signature_and_doc(repo):
+    repo_id, store = repo
+    go = get_symbol_source(repo_id, symbol_id="store/store.go::decode#function", storage_path=store)
+    assert go["body_withheld"] is True
+    assert go["source"].startswith("func decode(key string) string {")
+    assert BODY_WITHHELD in go["source"]
+    assert "hunter2" not in go["source"]
+
+    py = get_symbol_source(repo_id, symbol_id="jobs.py::_helper#function", storage_path=store)
+    assert py["body_withheld"] is True
+    assert '"""Prepare one job."""' in py["source"]
+    assert "hunter2" not in py["source"]
+
+
+def test_exported_symbols_untouched(repo):
+    repo_id, store = repo
+    result = get_symbol_source(repo_id, symbol_id="store/store.go::Load#function", storage_path=store)
+    assert "body_withheld" not in result
+    assert "return decode(key)" in result["source"]
+
+
+def test_other_tools_cannot_bypass(repo):
+    repo_id, store = repo
+    content = get_file_content(repo_id, "store/store.go", storage_path=store)
+    assert "hunter2" not in content["content"]
+    assert content["line_count"] == STORE_GO.count("\n")
+    assert search_text(repo_id, "hunter2", storage_path=store)["results"] == []
+    summary = summarize_symbol(repo_id, symbol_id="jobs.py::_helper#function", storage_path=store)
+    assert summary["body_withheld"] is True
+    assert "hunter2" not in summary["source"]
+
+
+def test_structure_and_call_graph_still_visible(repo):
+    repo_id, store = repo
+    outline = get_file_outline(repo_id, "store/store.go", storage_path=store)
+    assert {s["name"] for s in outline["symbols"]} == {"Load", "decode"}
+    callers = get_call_hierarchy(repo_id, "jobs.py::_helper#function", direction="callers",
+                                 depth=1, storage_path=store)
+    assert "run" in [c["name"] for c in callers["callers"]]
+
+
+def test_policy_off(repo, monkeypatch):
+    monkeypatch.setenv("JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES", "0")
+    repo_id, store = repo
+    result = get_symbol_source(repo_id, symbol_id="store/store.go::decode#function", storage_path=store)
+    assert "body_withheld" not in result
+    assert "hunter2" in result["source"]
+
+
+def test_one_line_definitions():
+    go = withhold_source("func (c *cache) get() {}", {"language": "go", "signature": "func (c *cache) get()"})
+    assert go == f"func (c *cache) get() // {BODY_WITHHELD} (1 line)"
+    py = withhold_source("def _f(): return 1", {"language": "python", "signature": "def _f()"})
+    assert py == f"def _f(): ...  # {BODY_WITHHELD} (1 line)"
@@ -0,0 +1,186 @@
+"""Tests for Go build constraints / test-file flags and search_symbols variant merging."""
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.parser.build_constraints import go_build_constraint, is_go_test_file
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.search_symbols import (
+    _VariantMerger,
+    _variant_key,
+    _variant_list,
+    search_symbols,
+)
+from tests.conftest_helpers import create_go_index
+
+
+class TestGoBuildConstraint:
+    def test_go_build_line(self):
+        src = "// Copyright 2024\n\n//go:build linux || darwin\n\npackage poll\n"
+        assert go_build_constraint("poll/fd.go", src) == "linux || darwin"
+
+    def test_legacy_plus_build_lines(self):
+        src = "// +build linux,386 darwin\n// +build !cgo\n\npackage poll\n"
+        assert go_build_constraint("poll/fd.go", src) == "(linux && 386 || darwin) && !cgo"
+
+    def test_go_build_wins_over_plus_build(self):
+        src = "//go:build windows\n// +build windows\n\npackage poll\n"
+        assert go_build_constraint("fd.go", src) == "windows"
+
+    def test_filename_suffixes(self):
+        assert go_build_constraint("poll/fd_linux.go", "package poll") == "linux"
+        assert go_build_constraint("poll/fd_arm64.go", "package poll") == "arm64"
+        assert go_build_constraint("poll/fd_windows_amd64_test.go", "package poll") == "windows && amd64"
+
+    def test_bare_os_name_is_unconstrained(self):
+        assert go_build_constraint("linux.go", "package poll") == ""
+        assert go_build_constraint("fd.go", "package poll") == ""
+
+    def test_header_and_filename_combine(self):
+        src = "//go:build linux || darwin\n\npackage poll\n"
+        assert go_build_constraint("fd_amd64.go", src) == "(linux || darwin) && amd64"
+
+    def test_directive_after_package_is_ignored(self):
+        assert go_build_constraint("fd.go", "package poll\n\n//go:build linux\n") == ""
+
+    def test_test_file(self):
+        assert is_go_test_file("poll/fd_test.go")
+        assert not is_go_test_file("poll/fd.go")
+        assert not is_go_test_file("testdata/fd.go")
+
+
+class TestParseFile:
+    def test_symbols_carry_tags(self):
+        symbols = parse_file("//go:build linux\n\npackage poll\n\nfunc Open() {}\n", "poll/fd.go", "go")
+        assert [(s.name, s.build_tags, s.is_test) for s in symbols] == [("Open", "linux", False)]
+
+    def test_test_file_symbols(self):
+        symbols = parse_file("package poll\n\nfunc helper() {}\n", "poll/fd_test.go", "go")
+        assert symbols[0].is_test is True
+        assert symbols[0].build_tags == ""
+
+
+def _seed_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "poll/fd_linux.go": "package poll\n\n// Open opens an fd.\nfunc Open() int { return 1 }\n",
+        "poll/fd_windows.go": "package poll\n\n// Open opens a handle.\nfunc Open() int { return 2 }\n",
+        "poll/fd_test.go": "package poll\n\nfunc openHelper() int { return Open() }\n",
+        "other/open.go": "package other\n\nfunc Open() int { return 3 }\n",
+    })
+
+
+class TestSearchSymbols:
+    def test_build_variants_merge(self, tmp_path):
+        repo, store = _seed_repo(tmp_path)
+        result = search_symbols(repo, "Open", kind="function", storage_path=store)
+        poll = [r for r in result["results"] if r["file"].startswith("poll/") and r["name"] == "Open"]
+        assert len(poll) == 1
+        variants = {v["file"]: v["build_tags"] for v in poll[0]["build_variants"]}
+        assert variants == {"poll/fd_linux.go": "linux", "poll/fd_windows.go": "windows"}
+
+    def test_other_package_not_merged(self, tmp_path):
+        repo, store = _seed_repo(tmp_path)
+        result = search_symbols(repo, "Open", kind="function", storage_path=store)
+        other = [r for r in result["results"] if r["file"] == "other/open.go"]
+        assert len(other) == 1
+        assert "build_variants" not in other[0]
+
+    def test_paging_counts_merged_results(self, tmp_path):
+        repo, store = _seed_repo(tmp_path)
+        full = search_symbols(repo, "Open", kind="function", storage_path=store)
+        ranked = [r["id"] for r in full["results"] if r.get("match_type") != "fuzzy"]
+        assert full["total_count"] == len(ranked)
+        paged = []
+        for offset in range(len(ranked)):
+            page = search_symbols(repo, "Open", kind="function", max_results=1, offset=offset, storage_path=store)
+            assert page["total_count"] == len(ranked)
+            assert page["has_more"] is (offset + 1 < len(ranked))
+            paged.extend(r["id"] for r in page["results"] if r.get("match_type") != "fuzzy")
+        assert paged == ranked
+
+    def test_exclude_tests(self, tmp_path):
+        repo, store = _seed_repo(tmp_path)
+        with_tests = search_symbols(repo, "openHelper", storage_path=store)
+        assert any(r["name"] == "openHelper" for r in with_tests["results"])
+        without = search_symbols(repo, "openHelper", include_tests=False, storage_path=store)
+        assert not any(r["name"] == "openHelper" for r in without["results"])
+
+    def test_outline_exposes_flags(self, tmp_path):
+        repo, store = _seed_repo(tmp_path)
+        linux = get_file_outline(repo, "poll/fd_linux.go", storage_path=store)
+        assert linux["symbols"][0]["build_tags"] == "linux"
+        test = get_file_outline(repo, "poll/fd_test.go", storage_path=store)
+        assert test["symbols"][0]["is_test"] is True
+        assert "build_tags" not in test["symbols"][0]
+
+    def test_outline_exclude_tests(self, tmp_path):
+        repo, store = _seed_repo(tmp_path)
+        single = get_file_outline(repo, "poll/fd_test.go", include_tests=False, storage_path=store)
+        assert single["symbols"] == []
+        assert single["_meta"]["filtered_out"] == 1
+        for path in ("poll", "poll/*.go"):
+            result = get_file_outline(repo, path, include_tests=False, storage_path=store)
+            assert [r["file"] for r in result["results"]] == ["poll/fd_linux.go", "poll/fd_windows.go"]
+        with_tests = get_file_outline(repo, "poll", storage_path=store)
+        assert "poll/fd_test.go" in [r["file"] for r in with_tests["results"]]
+
+
+def _merge(entries, limit=10):
+    keys = frozenset(_variant_key(e) for e in entries if e.get("build_tags"))
+    merger = _VariantMerger(limit, keys)
+    for pos, e in enumerate(entries):
+        merger.add((pos,), e, e)
+    return merger.ranked(), merger.total
+
+
+class TestVariantMerger:
+    def test_untagged_duplicates_left_alone(self):
+        entries = [
+            {"id": "a/x.go::F#function", "file": "a/x.go", "line": 1},
+            {"id": "a/y.go::F#function", "file": "a/y.go", "line": 1},
+        ]
+        merged, total = _merge(entries)
+        assert merged == [(e, [e]) for e in entries]
+        assert total == 2
+
+    def test_default_variant_is_listed(self):
+        entries = [
+            {"id": "a/x_windows.go::F#function", "file": "a/x_windows.go", "line": 3, "build_tags": "windows"},
+            {"id": "a/x.go::F#function", "file": "a/x.go", "line": 5},
+        ]
+        merged, total = _merge(entries)
+        assert total == 1
+        best, members = merged[0]
+        assert best is entries[0]
+        assert [v["build_tags"] for v in _variant_list(members)] == ["windows", ""]
+
+    def test_limit_counts_merged_results(self):
+        entries = [
+            {"id": "a/x_linux.go::F#function", "file": "a/x_linux.go", "line": 1, "build_tags": "linux"},
+            {"id": "a/x_windows.go::F#function", "file": "a/x_windows.go", "line": 1, "build_tags": "windows"},
+            {"id": "a/y.go::G#function", "file": "a/y.go", "line": 1},
+            {"id": "a/z.go::H#function", "file": "a/z.go", "line": 1},
+        ]
+        merged, total = _merge(entries, limit=2)
+        assert total == 3
+        assert [best["id"] for best, _ in merged] == ["a/x_linux.go::F#function", "a/y.go::G#function"]
+
+    def test_heap_stays_bounded(self):
+        entries = [{"id": f"a/f{i}.go::F{i}#function", "file": f"a/f{i}.go", "line": 1} for i in range(50)]
+        merger = _VariantMerger(3, frozenset())
+        for pos, e in enumerate(entries):
+            merger.add((-pos,), e, e)
+            assert len(merger._heap) <= 3
+        assert [best["id"] for best, _ in merger.ranked()] == [e["id"] for e in entries[:-4:-1]]
+        assert merger.total == 50
+
+    def test_best_variant_ranks_the_group(self):
+        entries = [
+            {"id": "a/x_linux.go::F#function", "file": "a/x_linux.go", "line": 1, "build_tags": "linux"},
+            {"id": "a/y.go::G#function", "file": "a/y.go", "line": 1},
+            {"id": "a/x_windows.go::F#function", "file": "a/x_windows.go", "line": 1, "build_tags": "windows"},
+        ]
+        merger = _VariantMerger(10, frozenset(_variant_key(e) for e in entries if e.get("build_tags")))
+        for rank, e in zip((2, 0, 1), entries):
+            merger.add((rank,), e, e)
+        merged = merger.ranked()
+        assert [best["id"] for best, _ in merged] == ["a/y.go::G#function", "a/x_windows.go::F#function"]
+        assert [m["build_tags"] for m in merged[1][1]] == ["windows", "linux"]
@@ -0,0 +1,164 @@
+"""Tests for C/C++ prototypes, declarator scopes, macros, and declaration links."""
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+C_SOURCE = '''#ifndef UART_H
+#define UART_H
+
+#define UART_BAUD 115200
+#define UART_MIN(a, b) ((a) < (b) ? (a) : (b))
+
+/** Open the UART at the given baud rate. */
+int uart_open(int baud);
+
+/// Write one byte.
+void uart_putc(char c);
+
+extern int uart_errors;
+
+#ifdef UART_FAST
+static int scale(int x) { return x << 1; }
+#else
+static int scale(int x) { return x * 2; }
+#endif
+
+int uart_open(int baud) {
+    int helper(int);
+    return helper(baud);
+}
+
+#endif
+'''
+
+WIDGET_HPP = '''namespace ui::core {
+
+/// A drawable widget.
+class Widget {
+public:
+    Widget();
+    /** Current value. */
+    int Get() const;
+    virtual void Draw() = 0;
+};
+
+/// Free helper.
+int clamp(int v);
+
+}
+'''
+
+WIDGET_CPP = '''#include "widget.hpp"
+
+namespace ui::core {
+
+Widget::Widget() {}
+
+int Widget::Get() const {
+    return 1;
+}
+
+int clamp(int v) { return v; }
+
+}
+
+extern "C" {
+int c_entry(void) { return 0; }
+}
+'''
+
+
+def _by_name(symbols):
+    out: dict = {}
+    for s in symbols:
+        out.setdefault(s.name, []).append(s)
+    return out
+
+
+class TestC:
+    def test_prototypes_and_definitions(self):
+        syms = _by_name(parse_file(C_SOURCE, "uart.c", "c"))
+        assert [s.is_declaration for s in syms["uart_open"]] == [True, False]
+        assert syms["uart_putc"][0].is_declaration is True
+        assert syms["uart_putc"][0].kind == "function"
+
+    def test_doc_comments(self):
+        syms = _by_name(parse_file(C_SOURCE, "uart.c", "c"))
+        assert syms["uart_open"][0].docstring == "Open the UART at the given baud rate."
+        assert syms["uart_putc"][0].docstring == "Write one byte."
+
+    def test_variables_and_local_prototypes_skipped(self):
+        syms = _by_name(parse_file(C_SOURCE, "uart.c", "c"))
+        assert "uart_errors" not in syms
+        assert "helper" not in syms
+
+    def test_macros(self):
+        syms = _by_name(parse_file(C_SOURCE, "uart.c", "c"))
+        assert syms["UART_BAUD"][0].kind == "constant"
+        assert syms["UART_MIN"][0].kind == "constant"
+        assert syms["UART_MIN"][0].signature.startswith("#define UART_MIN(a, b)")
+        assert "UART_H" not in syms  # include guard has no value
+
+    def test_preprocessor_branches_do_not_derail(self):
+        syms = _by_name(parse_file(C_SOURCE, "uart.c", "c"))
+        assert len(syms["scale"]) == 2
+        assert syms["uart_open"][-1].is_declaration is False
+
+
+class TestCpp:
+    def test_nested_namespace_qualifies(self):
+        syms = _by_name(parse_file(WIDGET_HPP, "widget.hpp", "cpp"))
+        assert syms["Widget"][0].qualified_name == "ui.core.Widget"
+        assert syms["clamp"][0].qualified_name == "ui.core.clamp"
+        assert syms["clamp"][0].is_declaration is True
+
+    def test_in_class_declarations(self):
+        syms = _by_name(parse_file(WIDGET_HPP, "widget.hpp", "cpp"))
+        get = syms["Get"][0]
+        assert (get.kind, get.qualified_name, get.is_declaration) == ("method", "ui.core.Widget.Get", True)
+        assert get.docstring == "Current value."
+        assert syms["Draw"][0].is_declaration is True
+        assert syms["Widget"][0].docstring == "A drawable widget."
+
+    def test_out_of_line_definitions_share_qualified_name(self):
+        syms = _by_name(parse_file(WIDGET_CPP, "widget.cpp", "cpp"))
+        get = syms["Get"][0]
+        assert (get.kind, get.qualified_name, get.is_declaration) == ("method", "ui.core.Widget.Get", False)
+        ctor = next(s for s in syms["Widget"] if s.kind == "method")
+        assert ctor.qualified_name == "ui.core.Widget.Widget"
+        assert syms["clamp"][0].kind == "function"
+
+    def test_extern_c_block(self):
+        syms = _by_name(parse_file(WIDGET_CPP, "widget.cpp", "cpp"))
+        assert syms["c_entry"][0].qualified_name == "c_entry"
+
+
+class TestLinks:
+    def _build(self, tmp_path):
+        return create_go_index(tmp_path, {"widget.hpp": WIDGET_HPP, "widget.cpp": WIDGET_CPP})
+
+    def _id(self, repo, store, file_path, name):
+        outline = get_file_outline(repo, file_path, storage_path=store)
+        return next(s for s in outline["symbols"] if s["name"] == name and s["kind"] == "method")
+
+    def test_outline_flags_declarations(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        assert self._id(repo, store, "widget.hpp", "Get")["is_declaration"] is True
+        assert "is_declaration" not in self._id(repo, store, "widget.cpp", "Get")
+
+    def test_declaration_lists_definition(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        decl = self._id(repo, store, "widget.hpp", "Get")
+        result = get_symbol_source(repo, symbol_id=decl["id"], storage_path=store)
+        assert result["is_declaration"] is True
+        assert [d["file"] for d in result["definitions"]] == ["widget.cpp"]
+
+    def test_definition_borrows_header_doc(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        defn = self._id(repo, store, "widget.cpp", "Get")
+        result = get_symbol_source(repo, symbol_id=defn["id"], storage_path=store)
+        assert [d["file"] for d in result["declarations"]] == ["widget.hpp"]
+        assert result["docstring"] == "Current value."
+        assert result["docstring_from"] == result["declarations"][0]["id"]
@@ -59,10 +59,10 @@ class TestIndexVersionBump:
     """INDEX_VERSION is bumped to 9."""
 
     def test_index_version_is_9(self):
-        """v1.98.0 bumped INDEX_VERSION to 16 for the runtime_stack_events
-        table (Phase 5 stack-log ingest). Test name kept for git-blame
-        stability; assertion tracks the current value."""
-        assert INDEX_VERSION == 16
+        """v23 bumped INDEX_VERSION for the symbols.type_params column. Test
+        name kept for git-blame stability; assertion tracks the current
+        value."""
+        assert INDEX_VERSION == 17
 
 
 class TestCallersByNameIndex:
@@ -167,7 +167,9 @@ class TestSQLiteCallReferencesRoundTrip:
                 "INSERT INTO symbols (id, file, name, kind, signature, summary, docstring, "
                 "line, end_line, byte_offset, byte_length, parent, qualified_name, language, "
                 "decorators, keywords, content_hash, ecosystem_context, data, cyclomatic, "
-                "max_nesting, param_count) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+                "max_nesting, param_count, fields, build_tags, is_test, receiver_type, "
+                "pointer_receiver, is_declaration, fqn, params, returns, type_params) "
+                "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
                 row,
             )
         conn.commit()
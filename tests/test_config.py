@@ -2188,3 +2188,79 @@ class TestConfigInit:
         assert "Refusing to overwrite" in captured.out
 
         assert json.loads(config_path.read_text()) == {"existing": True}
+
+
+class TestDefaultStoragePath:
+    """CODE_INDEX_PATH, then a ~/.code-index holding this role's files, then XDG."""
+
+    @pytest.fixture
+    def home(self, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp import config as config_module
+
+        home = tmp_path / "home"
+        home.mkdir()
+        monkeypatch.setattr(config_module.Path, "home", classmethod(lambda cls: home))
+        monkeypatch.delenv("CODE_INDEX_PATH", raising=False)
+        monkeypatch.delenv("XDG_CACHE_HOME", raising=False)
+        monkeypatch.delenv("XDG_CONFIG_HOME", raising=False)
+        return home
+
+    def test_env_override_wins(self, home, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp.config import default_storage_path
+
+        monkeypatch.setenv("CODE_INDEX_PATH", str(tmp_path / "explicit"))
+        monkeypatch.setenv("XDG_CACHE_HOME", str(tmp_path / "cache"))
+        assert default_storage_path() == tmp_path / "explicit"
+
+    def test_xdg_cache_for_fresh_install(self, home, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp.config import default_storage_path
+
+        monkeypatch.setenv("XDG_CACHE_HOME", str(tmp_path / "cache"))
+        assert default_storage_path() == tmp_path / "cache" / "jcodemunch-mcp"
+
+    def test_existing_legacy_indexes_are_kept(self, home, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp.config import default_storage_path
+
+        (home / ".code-index").mkdir()
+        monkeypatch.setenv("XDG_CACHE_HOME", str(tmp_path / "cache"))
+        assert default_storage_path() == tmp_path / "cache" / "jcodemunch-mcp"
+        (home / ".code-index" / "local-app-1234abcd.db").write_bytes(b"")
+        assert default_storage_path() == home / ".code-index"
+
+    def test_one_role_creating_legacy_dir_leaves_the_other_alone(self, home, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp.config import default_config_dir, default_storage_path
+
+        monkeypatch.setenv("XDG_CACHE_HOME", str(tmp_path / "cache"))
+        monkeypatch.setenv("XDG_CONFIG_HOME", str(tmp_path / "config"))
+        legacy = home / ".code-index"
+        legacy.mkdir()
+        (legacy / "last_seen_version").write_text("1.0.0")
+        (legacy / "config.jsonc").write_text("{}")
+        assert default_config_dir() == legacy
+        assert default_storage_path() == tmp_path / "cache" / "jcodemunch-mcp"
+
+    def test_relative_xdg_is_ignored(self, home, monkeypatch):
+        from src.jcodemunch_mcp.config import default_storage_path
+
+        monkeypatch.setenv("XDG_CACHE_HOME", "relative/cache")
+        assert default_storage_path() == home / ".code-index"
+
+    def test_config_stays_out_of_cache_dir(self, home, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp.config import _global_config_path, default_config_dir
+
+        monkeypatch.setenv("XDG_CACHE_HOME", str(tmp_path / "cache"))
+        assert default_config_dir() == home / ".code-index"
+        monkeypatch.setenv("XDG_CONFIG_HOME", str(tmp_path / "config"))
+        assert _global_config_path() == tmp_path / "config" / "jcodemunch-mcp" / "config.jsonc"
+
+    def test_config_dir_follows_legacy_and_env(self, home, tmp_path, monkeypatch):
+        from src.jcodemunch_mcp.config import default_config_dir
+
+        monkeypatch.setenv("XDG_CONFIG_HOME", str(tmp_path / "config"))
+        (home / ".code-index").mkdir()
+        (home / ".code-index" / "demo.db").write_bytes(b"")
+        assert default_config_dir() == tmp_path / "config" / "jcodemunch-mcp"
+        (home / ".code-index" / "tuning.jsonc").write_text("{}")
+        assert default_config_dir() == home / ".code-index"
+        monkeypatch.setenv("CODE_INDEX_PATH", str(tmp_path / "explicit"))
+        assert default_config_dir() == tmp_path / "explicit"
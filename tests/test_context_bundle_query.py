@@ -0,0 +1,96 @@
+"""Tests for get_context_bundle query mode (relevance-ranked budget packing)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.get_context_bundle import _query_terms, get_context_bundle
+from tests.conftest_helpers import create_go_index
+
+AUTH_PY = '''"""Authentication helpers."""
+
+SESSION_TTL = 3600
+
+
+def login(user, password):
+    """Check the password and open a session."""
+    token = issue_token(user)
+    return token
+
+
+def issue_token(user):
+    return f"session-{user}"
+
+
+class RateLimiter:
+    def allow(self, user):
+        return True
+'''
+
+NOTES_PY = '''# Session handling overview.
+# A login issues a token; sessions expire after SESSION_TTL seconds.
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {"auth.py": AUTH_PY, "notes.py": NOTES_PY})
+
+
+def _bundle(repo, query, **kw):
+    repo_id, store = repo
+    return get_context_bundle(repo_id, query=query, storage_path=store, **kw)
+
+
+def test_query_terms():
+    assert _query_terms("Fix the login() session, LOGIN again") == ["fix", "the", "login", "session", "again"]
+
+
+def test_ranked_symbols_and_snippets(repo):
+    result = _bundle(repo, "login session")
+    assert "error" not in result
+    items = result["items"]
+    assert items[0]["type"] == "symbol"
+    assert items[0]["name"] == "login"
+    assert items[0]["score"] == 1.0
+    assert set(items[0]["channels"]) == {"symbol", "text"}
+    snippets = [it for it in items if it["type"] == "snippet"]
+    assert any(s["file"] == "notes.py" and "SESSION_TTL" in s["source"] for s in snippets)
+    scores = [it["score"] for it in items]
+    assert scores == sorted(scores, reverse=True)
+
+
+def test_no_overlapping_items(repo):
+    result = _bundle(repo, "login session token")
+    spans: dict = {}
+    for it in result["items"]:
+        for lo, hi in spans.get(it["file"], []):
+            assert it["end_line"] < lo or hi < it["line"]
+        spans.setdefault(it["file"], []).append((it["line"], it["end_line"]))
+
+
+def test_ordering_is_stable(repo):
+    first = _bundle(repo, "login session")["items"]
+    second = _bundle(repo, "login session")["items"]
+    assert [(i["file"], i["line"]) for i in first] == [(i["file"], i["line"]) for i in second]
+
+
+@pytest.mark.parametrize("limits", [{"token_budget": 40}, {"max_bytes": 120}, {"token_budget": 30, "max_bytes": 90}])
+def test_budget_never_exceeded(repo, limits):
+    result = _bundle(repo, "login session token user", **limits)
+    budget = result["budget"]
+    if "token_budget" in limits:
+        assert budget["used_tokens"] <= limits["token_budget"]
+    if "max_bytes" in limits:
+        assert budget["used_bytes"] <= limits["max_bytes"]
+    assert budget["used_bytes"] == sum(it["bytes"] for it in result["items"])
+    assert budget["included"] + budget["excluded_by_budget"] + budget["merged_overlaps"] == budget["candidates"]
+
+
+def test_default_budget(repo):
+    assert _bundle(repo, "login")["budget"]["token_budget"] == 4000
+
+
+def test_query_exclusive_with_symbol_ids(repo):
+    assert "error" in _bundle(repo, "login", symbol_id="auth.py::login#function")
+    assert "error" in _bundle(repo, "   ")
+    assert "error" in _bundle(repo, "login", output_format="markdown")
+    assert "error" in _bundle(repo, "login", max_bytes=0)
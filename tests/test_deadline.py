@@ -0,0 +1,221 @@
+"""Tests for per-call deadlines (deadline.py) and the partial results they produce."""
+
+import json
+import time
+from unittest.mock import patch
+
+import pytest
+
+from jcodemunch_mcp import config as config_module
+from jcodemunch_mcp import deadline as deadline_module
+from jcodemunch_mcp.deadline import Deadline, current_deadline, deadline_scope
+from jcodemunch_mcp.parser import parse_pool
+from jcodemunch_mcp.parser.parse_pool import ParseJob, parse_files
+from jcodemunch_mcp.parser.symbols import Symbol
+from jcodemunch_mcp.storage import IndexStore
+from jcodemunch_mcp.summarizer.batch_summarize import BaseSummarizer, signature_fallback
+from jcodemunch_mcp.tools.index_folder import discover_local_files, index_folder
+from jcodemunch_mcp.tools.search_text import search_text
+
+
+def _expire(deadline: Deadline) -> None:
+    deadline._expires_at = time.monotonic() - 1
+
+
+def _jobs(n):
+    return [ParseJob(f"m{i:03d}.py", f"def f{i}():\n    return {i}\n", "python") for i in range(n)]
+
+
+@pytest.fixture
+def sequential():
+    orig = config_module._GLOBAL_CONFIG.copy()
+    config_module._GLOBAL_CONFIG["parse_workers"] = 1
+    yield
+    config_module._GLOBAL_CONFIG.clear()
+    config_module._GLOBAL_CONFIG.update(orig)
+
+
+@pytest.fixture
+def src(tmp_path):
+    root = tmp_path / "src"
+    (root / "pkg").mkdir(parents=True)
+    for i in range(6):
+        (root / "pkg" / f"mod{i}.py").write_text(f"def f{i}():\n    return 'needle {i}'\n")
+    return root
+
+
+class TestDeadline:
+    def test_scope_sets_and_resets(self):
+        assert current_deadline() is None
+        with deadline_scope(30) as dl:
+            assert current_deadline() is dl
+            assert 29 < dl.remaining() <= 30
+            assert not deadline_module.expired(dl)
+            with deadline_scope(None):
+                assert current_deadline() is None
+            assert current_deadline() is dl
+        assert current_deadline() is None
+
+    def test_zero_means_no_limit(self):
+        with deadline_scope(0) as dl:
+            assert dl is None
+        assert deadline_module.expired(None) is False
+
+    def test_expired(self):
+        dl = Deadline(30)
+        _expire(dl)
+        assert dl.expired() and dl.remaining() == 0.0
+
+
+class TestParseFiles:
+    @pytest.mark.parametrize("workers", [1, 4])
+    def test_expired_deadline_parses_nothing(self, workers):
+        dl = Deadline(30)
+        _expire(dl)
+        assert list(parse_files(_jobs(10), max_workers=workers, deadline=dl)) == []
+
+    @pytest.mark.parametrize("workers", [1, 4])
+    def test_stops_midway_and_keeps_finished_results(self, workers, monkeypatch):
+        dl = Deadline(30)
+        real = parse_pool.parse_file
+
+        def slow_after_three(content, filename, language, **kwargs):
+            if filename == "m002.py":
+                _expire(dl)
+            elif filename > "m002.py":
+                time.sleep(0.05)
+            return real(content, filename, language, **kwargs)
+
+        monkeypatch.setattr(parse_pool, "parse_file", slow_after_three)
+        paths = [r.job.rel_path for r in parse_files(_jobs(40), max_workers=workers, deadline=dl)]
+        assert paths[:3] == ["m000.py", "m001.py", "m002.py"]
+        assert len(paths) < 40
+        assert paths == sorted(paths)
+
+
+class TestIndexFolder:
+    def test_discovery_timeout_saves_nothing(self, src, tmp_path):
+        store = str(tmp_path / "store")
+        with deadline_scope(30) as dl:
+            _expire(dl)
+            files, _, skip_counts = discover_local_files(src, deadline=dl)
+            assert files == [] and skip_counts["deadline"] >= 1
+            result = index_folder(str(src), use_ai_summaries=False, storage_path=store)
+        assert result["success"] is False
+        assert result["timed_out"] is True
+        assert "nothing was saved" in result["error"]
+        assert IndexStore(base_path=store).list_repos() == []
+
+    def test_parse_timeout_saves_partial_index_then_resumes(self, src, tmp_path, sequential, monkeypatch):
+        store = str(tmp_path / "store")
+        real = parse_pool.parse_file
+
+        with deadline_scope(30) as dl:
+            def expire_after_two(content, filename, language, **kwargs):
+                if filename == "pkg/mod1.py":
+                    _expire(dl)
+                return real(content, filename, language, **kwargs)
+
+            monkeypatch.setattr(parse_pool, "parse_file", expire_after_two)
+            result = index_folder(str(src), use_ai_summaries=False, storage_path=store)
+        monkeypatch.setattr(parse_pool, "parse_file", real)
+
+        assert result["success"] is True
+        assert result["timed_out"] is True
+        assert result["file_count"] == 2
+        assert result["files_remaining"] == 4
+        assert any("Re-run index_folder" in w for w in result["warnings"])
+        owner, name = result["repo"].split("/", 1)
+        index = IndexStore(base_path=store).load_index(owner, name)
+        assert sorted(index.source_files) == ["pkg/mod0.py", "pkg/mod1.py"]
+
+        again = index_folder(str(src), use_ai_summaries=False, storage_path=store)
+        assert again["success"] is True
+        assert again["incremental"] is True
+        assert again["new"] == 4 and again["changed"] == 0
+        assert "timed_out" not in again
+        index = IndexStore(base_path=store).load_index(owner, name)
+        assert len(index.source_files) == 6
+
+
+def test_search_text_returns_partial_matches(src, tmp_path):
+    store = str(tmp_path / "store")
+    repo = index_folder(str(src), use_ai_summaries=False, storage_path=store)["repo"]
+    with deadline_scope(30) as dl:
+        _expire(dl)
+        result = search_text(repo, "needle", storage_path=store)
+    assert result["_meta"]["timed_out"] is True
+    assert result["result_count"] == 0
+    assert search_text(repo, "needle", storage_path=store)["result_count"] == 6
+
+
+class _SlowSummarizer(BaseSummarizer):
+    def _summarize_one_batch(self, batch):
+        time.sleep(0.1)
+        for sym in batch:
+            sym.summary = f"AI summary of {sym.name}"
+
+
+def test_summarizer_falls_back_after_deadline():
+    symbols = [
+        Symbol(
+            id=f"m.py::f{i}", file="m.py", name=f"f{i}", qualified_name=f"f{i}",
+            kind="function", language="python", signature=f"def f{i}():",
+        )
+        for i in range(4)
+    ]
+    orig = config_module._GLOBAL_CONFIG.copy()
+    config_module._GLOBAL_CONFIG["summarizer_concurrency"] = 1
+    try:
+        with deadline_scope(0.05):
+            _SlowSummarizer(client=object()).summarize_batch(symbols, batch_size=1)
+    finally:
+        config_module._GLOBAL_CONFIG.clear()
+        config_module._GLOBAL_CONFIG.update(orig)
+    # The first batch starts before the deadline; the rest are never sent.
+    assert symbols[0].summary == "AI summary of f0"
+    assert [s.summary for s in symbols[1:]] == [signature_fallback(s) for s in symbols[1:]]
+
+
+@pytest.mark.asyncio
+async def test_indexing_tools_ignore_default_timeout(tmp_path):
+    from jcodemunch_mcp import server
+
+    seen = {}
+
+    def capture(**kwargs):
+        seen["deadline"] = current_deadline()
+        return {"success": True}
+
+    with patch("jcodemunch_mcp.tools.index_folder.index_folder", side_effect=capture):
+        await server.call_tool("index_folder", {"path": str(tmp_path)})
+        assert seen["deadline"] is None
+        await server.call_tool("index_folder", {"path": str(tmp_path), "timeout_seconds": 30})
+        assert seen["deadline"] is not None
+
+
+@pytest.mark.asyncio
+async def test_call_tool_hard_timeout(monkeypatch):
+    from jcodemunch_mcp import server
+
+    def slow_outline(**kwargs):
+        time.sleep(0.5)
+        return {"symbols": []}
+
+    monkeypatch.setattr(server, "_HARD_TIMEOUT_GRACE_SEC", 0.0)
+    with patch("jcodemunch_mcp.tools.get_file_outline.get_file_outline", side_effect=slow_outline) as mock_outline:
+        result = await server.call_tool(
+            "get_file_outline", {"repo": "local/app", "file_path": "a.py", "timeout_seconds": 0.05},
+        )
+    payload = json.loads(result[0].text)
+    assert payload["timed_out"] is True
+    assert "get_file_outline timed out after 0.05s" in payload["error"]
+    assert "timeout_seconds" not in mock_outline.call_args[1]
+
+
+@pytest.mark.asyncio
+async def test_call_tool_rejects_bad_timeout():
+    from jcodemunch_mcp.server import call_tool
+
+    result = await call_tool("get_file_outline", {"repo": "local/app", "file_path": "a.py", "timeout_seconds": -1})
+    assert "timeout_seconds must be a non-negative number" in json.loads(result[0].text)["error"]
@@ -0,0 +1,67 @@
+"""Tests for describe_package: per-directory package summaries."""
+
+from jcodemunch_mcp.tools.describe_package import _resolve_directory, describe_package
+from tests.conftest_helpers import create_go_index
+
+
+def _build_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "internal/store/doc.go": (
+            "// Package store persists key-value pairs.\n// It is safe for concurrent use.\npackage store\n"
+        ),
+        "internal/store/store.go": (
+            "// Not the package doc.\npackage store\n\n"
+            "type Store struct{}\n\n"
+            "func New() *Store { return &Store{} }\n\n"
+            "func helper() {}\n"
+        ),
+        "app/util/__init__.py": '"""Utility helpers."""\n',
+        "app/util/text.py": (
+            "def slugify(s):\n    return s\n\n"
+            "def _strip(s):\n    return s\n"
+        ),
+    }, module="github.com/acme/svc")
+
+
+class TestDescribePackage:
+    def test_go_package(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = describe_package(repo, "internal/store", storage_path=store)
+        assert "error" not in result
+        assert result["package"] == "store"
+        assert result["import_path"] == "github.com/acme/svc/internal/store"
+        assert result["doc"].startswith("Package store persists key-value pairs.")
+        assert result["summary"] == "Package store persists key-value pairs."
+        assert result["files"] == ["internal/store/doc.go", "internal/store/store.go"]
+        assert result["symbols"]["exported"] == {"function": 1, "type": 1}
+        assert result["symbols"]["unexported"] == {"function": 1}
+
+    def test_go_import_path_resolves(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = describe_package(repo, "github.com/acme/svc/internal/store", storage_path=store)
+        assert result["directory"] == "internal/store"
+
+    def test_python_package(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = describe_package(repo, "app.util", storage_path=store)
+        assert result["directory"] == "app/util"
+        assert result["import_path"] == "app.util"
+        assert result["doc"] == "Utility helpers."
+        assert result["symbols"]["exported"] == {"function": 1}
+        assert result["symbols"]["unexported"] == {"function": 1}
+        assert result["file_count"] == 2
+
+    def test_unknown_package(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = describe_package(repo, "nope/store", storage_path=store)
+        assert "error" in result
+        assert result["candidates"] == ["internal/store"]
+
+
+def test_resolve_directory_forms():
+    dirs = {"", "pkg/api", "src/app/core"}
+    assert _resolve_directory("./pkg/api/", dirs) == "pkg/api"
+    assert _resolve_directory("example.com/mod/pkg/api", dirs) == "pkg/api"
+    assert _resolve_directory("app.core", dirs) == "src/app/core"
+    assert _resolve_directory(".", dirs) == ""
+    assert _resolve_directory("missing", dirs) is None
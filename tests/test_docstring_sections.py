@@ -0,0 +1,64 @@
+"""Tests for structured doc-comment sections (parser/docstring.py)."""
+
+from jcodemunch_mcp.parser.docstring import parse_docstring
+from jcodemunch_mcp.parser.extractor import _clean_comment_markers
+
+
+class TestParseDocstring:
+    def test_summary_is_first_sentence(self):
+        doc = parse_docstring("Open opens the file. It returns an error on failure.\n\nMore detail.")
+        assert doc["summary"] == "Open opens the file."
+        assert doc["body"] == "It returns an error on failure.\n\nMore detail."
+        assert doc["deprecated"] is None
+        assert doc["examples"] == []
+
+    def test_abbreviation_does_not_split_summary(self):
+        doc = parse_docstring("Run tasks, e.g. builds and tests.")
+        assert doc["summary"] == "Run tasks, e.g. builds and tests."
+
+    def test_go_deprecated_paragraph(self):
+        doc = parse_docstring("Get fetches a value.\n\nDeprecated: use Fetch instead.")
+        assert doc["deprecated"] == "use Fetch instead."
+        assert doc["body"] == ""
+
+    def test_jsdoc_deprecated_and_example(self):
+        raw = "Sum numbers.\n@example\nsum(1, 2) // 3\n@deprecated since 2.0"
+        doc = parse_docstring(raw)
+        assert doc["summary"] == "Sum numbers."
+        assert doc["examples"] == ["sum(1, 2) // 3"]
+        assert doc["deprecated"] == "since 2.0"
+
+    def test_sphinx_deprecated_directive(self):
+        doc = parse_docstring("Old helper.\n\n.. deprecated:: 1.4\n   Use new_helper.")
+        assert doc["deprecated"] == "1.4 Use new_helper."
+
+    def test_decorator_marks_deprecated(self):
+        doc = parse_docstring("Old.", decorators=['@deprecated("use new")'])
+        assert doc["deprecated"] == "use new"
+        doc = parse_docstring("Old.", decorators=["@Deprecated"])
+        assert doc["deprecated"] == ""
+        doc = parse_docstring("", decorators=["#[deprecated]"])
+        assert doc["deprecated"] == ""
+
+    def test_go_indented_example(self):
+        raw = "Println prints.\n\n\tfmt.Println(\"hi\")\n\tfmt.Println(\"bye\")\n\nTrailing note."
+        doc = parse_docstring(raw)
+        assert doc["examples"] == ['fmt.Println("hi")\nfmt.Println("bye")']
+        assert doc["body"] == "Trailing note."
+
+    def test_fenced_and_doctest_examples(self):
+        raw = "Add.\n\n```python\nadd(1, 2)\n```\n\n>>> add(1, 2)\n3"
+        doc = parse_docstring(raw)
+        assert doc["examples"] == ["add(1, 2)", ">>> add(1, 2)\n3"]
+
+    def test_empty(self):
+        assert parse_docstring("") == {"summary": "", "body": "", "deprecated": None, "examples": []}
+
+
+class TestCleanCommentMarkers:
+    def test_block_comment_has_no_trailing_slash(self):
+        assert _clean_comment_markers("/**\n * Doc\n * more\n */") == "Doc\nmore"
+
+    def test_indentation_after_marker_is_kept(self):
+        raw = "// Foo does x.\n//\n//\tfmt.Println(x)"
+        assert _clean_comment_markers(raw) == "Foo does x.\n\n\tfmt.Println(x)"
@@ -140,6 +140,68 @@ class TestFindImplementationsMeta:
         assert isinstance(result["relationship_counts"], dict)
 
 
+_GO_REPO = {
+    "store/store.go": (
+        "package store\n\n"
+        "import \"io\"\n\n"
+        "type Named interface {\n\tName() string\n}\n\n"
+        "// Store is a key-value store.\n"
+        "type Store interface {\n"
+        "\tGet(key string) ([]byte, error)\n"
+        "\tio.Closer\n"
+        "\tNamed\n"
+        "}\n\n"
+        "type Anything interface{}\n"
+    ),
+    "mem/mem.go": (
+        "package mem\n\n"
+        "type Mem struct {\n\tdata map[string][]byte\n}\n\n"
+        "func (m *Mem) Get(k string) ([]byte, error) { return m.data[k], nil }\n\n"
+        "func (m *Mem) Close() error { return nil }\n\n"
+        "func (m Mem) Name() string { return \"mem\" }\n\n"
+        "type Wrapped struct {\n\t*Mem\n}\n"
+    ),
+    "disk/disk.go": (
+        "package disk\n\n"
+        "type Disk struct {\n\tpath string\n}\n\n"
+        "func (d Disk) Get(key string) (b []byte, err error) { return nil, nil }\n\n"
+        "func (d Disk) Close() error { return nil }\n\n"
+        "func (d Disk) Name() string { return d.path }\n\n"
+        "type Partial struct{}\n\n"
+        "func (p Partial) Get(key string) ([]byte, error) { return nil, nil }\n\n"
+        "func (p Partial) Name() int { return 0 }\n"
+    ),
+}
+
+
+class TestFindImplementationsGo:
+    def test_method_set_satisfaction(self, tmp_path):
+        repo, storage = _make_repo(tmp_path, _GO_REPO)
+        result = find_implementations(repo, symbol="Store", storage_path=storage)
+        assert "error" not in result
+        impls = {r["name"]: r for r in result["implementations"]}
+        assert set(impls) >= {"Mem", "Disk", "Wrapped"}
+        assert "Partial" not in impls
+        assert impls["Disk"]["receiver"] == "value"
+        assert impls["Mem"]["receiver"] == "pointer"
+        # Embedded *Mem promotes every Mem method to Wrapped's value set.
+        assert impls["Wrapped"]["receiver"] == "value"
+        assert impls["Disk"]["source"] == "method_set"
+        assert impls["Disk"]["file"] == "disk/disk.go"
+        assert impls["Disk"]["line"] > 0
+
+    def test_embedded_interfaces_expand_method_set(self, tmp_path):
+        repo, storage = _make_repo(tmp_path, _GO_REPO)
+        result = find_implementations(repo, symbol="Store", storage_path=storage)
+        assert result["method_set"] == ["Close()error", "Get(string)([]byte,error)", "Name()string"]
+
+    def test_empty_interface_is_not_enumerated(self, tmp_path):
+        repo, storage = _make_repo(tmp_path, _GO_REPO)
+        result = find_implementations(repo, symbol="Anything", storage_path=storage)
+        assert result["implementations"] == []
+        assert "Empty interface" in result["note"]
+
+
 class TestFindImplementationsErrors:
     def test_unindexed_repo(self, tmp_path):
         storage = str(tmp_path / ".index")
@@ -0,0 +1,117 @@
+"""find_references(include_usages=True): whole-word usage sites across the tree."""
+from __future__ import annotations
+
+import textwrap
+
+import pytest
+
+from jcodemunch_mcp.tools.find_references import find_references
+from tests.conftest_helpers import create_go_index
+
+
+@pytest.fixture
+def py_repo(tmp_path):
+    repo, store = create_go_index(tmp_path, {
+        "users.py": textwrap.dedent("""\
+            def GetUser(uid):
+                return {"id": uid}
+
+
+            def GetUserProfile(uid):
+                return GetUser(uid)
+        """),
+        "app.py": textwrap.dedent("""\
+            from users import GetUser
+
+
+            def handler(uid):
+                return GetUser(uid)
+        """),
+    })
+    return {"repo": repo, "store": store}
+
+
+def test_usages_off_by_default(py_repo):
+    out = find_references(py_repo["repo"], identifier="GetUser", storage_path=py_repo["store"])
+    assert "usages" not in out
+
+
+def test_usages_classify_definition_and_references(py_repo):
+    out = find_references(
+        py_repo["repo"], identifier="GetUser",
+        storage_path=py_repo["store"], include_usages=True,
+    )
+    hits = [(u["file"], u["line"], u["role"]) for u in out["usages"]]
+    assert hits == [
+        ("app.py", 1, "reference"),
+        ("app.py", 5, "reference"),
+        ("users.py", 1, "definition"),
+        ("users.py", 6, "reference"),
+    ]
+    assert out["usage_count"] == 4
+
+
+def test_usages_do_not_match_substrings(py_repo):
+    out = find_references(
+        py_repo["repo"], identifier="GetUser",
+        storage_path=py_repo["store"], include_usages=True,
+    )
+    assert all("GetUserProfile" not in u["text"] or u["line"] == 6 for u in out["usages"])
+    assert not any(u["line"] == 5 and u["file"] == "users.py" for u in out["usages"])
+
+
+def test_usages_carry_enclosing_symbol_and_text(py_repo):
+    out = find_references(
+        py_repo["repo"], identifier="GetUser",
+        storage_path=py_repo["store"], include_usages=True,
+    )
+    call = next(u for u in out["usages"] if u["file"] == "app.py" and u["line"] == 5)
+    assert call["text"] == "return GetUser(uid)"
+    assert call["symbol"].endswith("::handler#function")
+
+
+def test_usages_respect_max_results(py_repo):
+    out = find_references(
+        py_repo["repo"], identifier="GetUser",
+        storage_path=py_repo["store"], include_usages=True, max_results=2,
+    )
+    assert len(out["usages"]) == 2
+    assert out["usage_count"] == 4
+    assert out["_meta"]["truncated"] is True
+
+
+@pytest.fixture
+def twin_repo(tmp_path):
+    repo, store = create_go_index(tmp_path, {
+        "users.py": "def GetUser(uid):\n    return uid\n",
+        "legacy/users.py": "def GetUser(uid):\n    return None\n",
+        "app.py": "from users import GetUser\n\n\ndef handler(uid):\n    return GetUser(uid)\n",
+        "old.py": "from legacy.users import GetUser\n",
+    })
+    return {"repo": repo, "store": store}
+
+
+def test_file_path_picks_one_definition(twin_repo):
+    current = find_references(
+        twin_repo["repo"], identifier="GetUser", file_path="users.py",
+        storage_path=twin_repo["store"], include_usages=True,
+    )
+    assert current["symbol_id"] == "users.py::GetUser#function"
+    assert [r["file"] for r in current["references"]] == ["app.py"]
+    assert {u["file"] for u in current["usages"]} == {"app.py", "users.py"}
+
+    legacy = find_references(
+        twin_repo["repo"], identifier="GetUser", file_path="legacy",
+        storage_path=twin_repo["store"],
+    )
+    assert [r["file"] for r in legacy["references"]] == ["old.py"]
+
+
+def test_file_path_errors(twin_repo):
+    missing = find_references(
+        twin_repo["repo"], identifier="GetUser", file_path="app.py", storage_path=twin_repo["store"],
+    )
+    assert "error" in missing
+    with pytest.raises(ValueError):
+        find_references(twin_repo["repo"], identifiers=["GetUser"], file_path="users.py",
+                        storage_path=twin_repo["store"])
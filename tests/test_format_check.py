@@ -0,0 +1,100 @@
+"""Tests for format_check (gofmt/goimports deviations, read-only)."""
+
+import shutil
+
+import pytest
+
+from jcodemunch_mcp.tools.format_check import format_check
+from tests.conftest_helpers import create_go_index
+
+CLEAN_GO = '''package store
+
+func Open() error {
+\treturn nil
+}
+'''
+
+MESSY_GO = '''package store
+
+func   Close( ) error {
+    return nil
+}
+'''
+
+BROKEN_GO = '''package api
+
+func Handler( {
+'''
+
+needs_gofmt = pytest.mark.skipif(shutil.which("gofmt") is None, reason="gofmt not on PATH")
+
+
+@pytest.fixture
+def repo(tmp_path):
+    repo_id, store = create_go_index(tmp_path, {
+        "store/open.go": CLEAN_GO,
+        "store/close.go": MESSY_GO,
+        "api/handler.go": BROKEN_GO,
+        "tool.py": "def  f():\n    pass\n",
+    })
+    return repo_id, store, tmp_path / "src"
+
+
+def _check(repo, **kw):
+    repo_id, store, _ = repo
+    return format_check(repo_id, storage_path=store, **kw)
+
+
+@needs_gofmt
+def test_reports_changed_files_with_diff(repo):
+    result = _check(repo, path="store")
+    assert result["formatter"] == "gofmt"
+    assert result["checked"] == 2
+    assert result["changed_files"] == ["store/close.go"]
+    by_file = {f["file"]: f for f in result["files"]}
+    assert by_file["store/open.go"] == {"file": "store/open.go", "formatted": True}
+    diff = by_file["store/close.go"]["diff"]
+    assert diff.startswith("--- a/store/close.go\n+++ b/store/close.go\n")
+    assert "-func   Close( ) error {" in diff
+    assert "+func Close() error {" in diff
+
+
+@needs_gofmt
+def test_single_file_and_parse_error(repo):
+    assert _check(repo, path="store/open.go")["files"] == [{"file": "store/open.go", "formatted": True}]
+    result = _check(repo, path="api/handler.go")
+    assert result["error_count"] == 1
+    assert result["files"][0]["formatted"] is False
+    assert result["files"][0]["error"]
+
+
+@needs_gofmt
+def test_whole_repo_skips_non_go_and_writes_nothing(repo):
+    result = _check(repo)
+    assert sorted(f["file"] for f in result["files"]) == ["api/handler.go", "store/close.go", "store/open.go"]
+    assert (repo[2] / "store" / "close.go").read_text() == MESSY_GO
+
+
+@needs_gofmt
+def test_max_files_truncates(repo):
+    result = _check(repo, max_files=1)
+    assert result["checked"] == 1
+    assert result["truncated"] is True
+
+
+@needs_gofmt
+def test_goimports_falls_back_to_gofmt(repo, monkeypatch):
+    real_which = shutil.which
+    monkeypatch.setattr(
+        "jcodemunch_mcp.tools.format_check.shutil.which",
+        lambda cmd: None if cmd == "goimports" else real_which(cmd),
+    )
+    result = _check(repo, path="store", use_goimports=True)
+    assert result["formatter"] == "gofmt"
+    assert "goimports" in result["_meta"]["fallback"]
+
+
+def test_unknown_path_and_missing_gofmt(repo, monkeypatch):
+    assert "error" in _check(repo, path="nowhere")
+    monkeypatch.setattr("jcodemunch_mcp.tools.format_check.shutil.which", lambda cmd: None)
+    assert "gofmt not found" in _check(repo)["error"]
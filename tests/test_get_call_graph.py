@@ -0,0 +1,85 @@
+"""Tests for get_call_graph: directed caller → callee edges."""
+
+from jcodemunch_mcp.tools.get_call_graph import get_call_graph
+from tests.conftest_helpers import create_go_index
+
+
+def _build_repo(tmp_path):
+    """Chain run → handle → process → helper, plus a mutual-recursion pair."""
+    return create_go_index(tmp_path, {
+        "utils.py": "def helper():\n    return 42\n",
+        "services.py": (
+            "from utils import helper\n\n"
+            "def process():\n    return helper() + 1\n"
+        ),
+        "controllers.py": (
+            "from services import process\n\n"
+            "def handle(req):\n    return process()\n"
+        ),
+        "main.py": (
+            "from controllers import handle\n\n"
+            "def run():\n    return handle(None)\n"
+        ),
+        "loop.py": (
+            "def ping(n):\n    return pong(n - 1) if n else 0\n\n"
+            "def pong(n):\n    return ping(n - 1) if n else 0\n"
+        ),
+    })
+
+
+def _edge_names(result):
+    names = {n["id"]: n["name"] for n in result["nodes"]}
+    return {(names[e["from"]], names[e["to"]]) for e in result["edges"]}
+
+
+class TestGetCallGraph:
+    def test_callees_chain(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="run", depth=3, storage_path=store)
+        assert "error" not in result
+        edges = _edge_names(result)
+        assert ("run", "handle") in edges
+        assert ("handle", "process") in edges
+        assert ("process", "helper") in edges
+        assert result["depth_reached"] == 3
+
+    def test_depth_limits_edges(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="run", depth=1, storage_path=store)
+        assert _edge_names(result) == {("run", "handle")}
+
+    def test_callers_edges_point_caller_to_callee(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="helper", direction="callers",
+                                depth=3, storage_path=store)
+        edges = _edge_names(result)
+        assert ("process", "helper") in edges
+        assert ("handle", "process") in edges
+
+    def test_cycle_terminates(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="ping", depth=5, storage_path=store)
+        edges = _edge_names(result)
+        assert ("ping", "pong") in edges
+        assert ("pong", "ping") in edges
+        assert result["edge_count"] == len(result["edges"]) == 2
+
+    def test_edge_shape(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="run", storage_path=store)
+        for edge in result["edges"]:
+            assert set(edge) == {"from", "to", "resolution", "approximate"}
+            assert isinstance(edge["approximate"], bool)
+        assert result["nodes"][0]["name"] == "run"
+        assert result["nodes"][0]["depth"] == 0
+
+    def test_max_edges_truncates(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="run", max_edges=1, storage_path=store)
+        assert result["edge_count"] == 1
+        assert result["_meta"]["truncated"] is True
+
+    def test_unknown_symbol(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_call_graph(repo=repo, symbol_id="nope_xyz", storage_path=store)
+        assert "error" in result
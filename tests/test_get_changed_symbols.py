@@ -296,3 +296,116 @@ class TestSymbolDetection:
         result = get_changed_symbols(repo_id, since_sha=sha, until_sha=sha,
                                      suppress_meta=True, storage_path=storage)
         assert "_meta" not in result
+
+
+# ---------------------------------------------------------------------------
+# Tests: change classification and working-tree head
+# ---------------------------------------------------------------------------
+
+MODELS_GO = '''package models
+
+type User struct {
+\tID   int
+\tName string
+}
+
+func NewUser(name string) *User {
+\treturn &User{Name: name}
+}
+
+func Greet(u *User) string {
+\treturn "hi " + u.Name
+}
+
+func Legacy() {}
+'''
+
+MODELS_GO_V2 = '''package models
+
+type User struct {
+\tID    int64
+\tName  string
+\tEmail string
+}
+
+func NewUser(name, email string) (*User, error) {
+\treturn &User{Name: name, Email: email}, nil
+}
+
+func Greet(u *User) string {
+\treturn "hello " + u.Name
+}
+
+func Validate(u *User) error { return nil }
+'''
+
+UTIL_PY = '''def slugify(text):
+    return text.lower()
+'''
+
+
+def _by_name(entries):
+    return {e["name"]: e for e in entries}
+
+
+class TestChangeClassification:
+    def _repo(self, tmp_path):
+        repo_path, repo_id, storage = _make_git_repo(tmp_path, {
+            "models/user.go": MODELS_GO,
+            "util.py": UTIL_PY,
+        })
+        base = subprocess.run(
+            ["git", "rev-parse", "HEAD"],
+            cwd=str(repo_path), capture_output=True, text=True, check=True,
+        ).stdout.strip()
+        return repo_path, repo_id, storage, base
+
+    def test_signature_fields_and_body(self, tmp_path):
+        repo_path, repo_id, storage, base = self._repo(tmp_path)
+        _commit_changes(repo_path, {"models/user.go": MODELS_GO_V2})
+
+        result = get_changed_symbols(repo_id, since_sha=base, storage_path=storage)
+        assert "error" not in result, result
+        assert [e["name"] for e in result["added_symbols"]] == ["Validate"]
+        assert [e["name"] for e in result["removed_symbols"]] == ["Legacy"]
+
+        changed = _by_name(result["changed_symbols"])
+        assert changed["NewUser"]["changes"] == ["signature"]
+        assert changed["NewUser"]["previous_signature"].startswith("func NewUser(name string)")
+        assert changed["Greet"]["changes"] == ["body"]
+        fields = changed["User"]["fields"]
+        assert fields["added"] == ["Email"]
+        assert fields["changed"] == [{"name": "ID", "type": {"before": "int", "after": "int64"}}]
+        assert result["signature_changed_count"] == 2
+
+    def test_moved_symbol(self, tmp_path):
+        repo_path, repo_id, storage, base = self._repo(tmp_path)
+        _commit_changes(repo_path, {"util.py": None, "text/slug.py": UTIL_PY})
+
+        result = get_changed_symbols(repo_id, since_sha=base, storage_path=storage)
+        assert result["added_symbols"] == [] and result["removed_symbols"] == []
+        moved = _by_name(result["changed_symbols"])["slugify"]
+        assert moved["change_type"] == "moved"
+        assert moved["changes"] == ["moved"]
+        assert (moved["previous_file"], moved["file"]) == ("util.py", "text/slug.py")
+
+    def test_working_tree_without_checkout(self, tmp_path):
+        repo_path, repo_id, storage, base = self._repo(tmp_path)
+        (repo_path / "util.py").write_text(UTIL_PY + "\n\ndef unslug(text):\n    return text\n")
+        (repo_path / "extra.py").write_text("def fresh():\n    pass\n")
+
+        result = get_changed_symbols(
+            repo_id, since_sha="HEAD", until_sha="WORKTREE", storage_path=storage,
+        )
+        assert "error" not in result, result
+        assert result["to_sha"] == "WORKTREE"
+        assert sorted(e["name"] for e in result["added_symbols"]) == ["fresh", "unslug"]
+        status = subprocess.run(
+            ["git", "status", "--porcelain"],
+            cwd=str(repo_path), capture_output=True, text=True, check=True,
+        ).stdout.splitlines()
+        assert " M util.py" in status and "?? extra.py" in status
+        assert subprocess.run(
+            ["git", "rev-parse", "HEAD"],
+            cwd=str(repo_path), capture_output=True, text=True, check=True,
+        ).stdout.strip() == base
@@ -0,0 +1,99 @@
+"""Tests for get_complexity (AST-counted per-function complexity)."""
+
+from jcodemunch_mcp.tools.get_complexity import get_complexity
+from tests.conftest_helpers import create_go_index
+
+GO_SRC = '''package calc
+
+func Simple() int { return 1 }
+
+// Classify has one of everything.
+func Classify(x int, ok bool) string {
+\tif x > 0 && ok {
+\t\treturn "pos"
+\t} else if x < 0 || !ok {
+\t\treturn "neg"
+\t} else {
+\t\treturn "zero"
+\t}
+}
+
+func Loop(xs []int) (n int) {
+\tfor _, x := range xs {
+\t\tswitch {
+\t\tcase x > 10:
+\t\t\tn += 2
+\t\tcase x > 5:
+\t\t\tn++
+\t\tdefault:
+\t\t}
+\t}
+\tf := func() {
+\t\tif n > 100 {
+\t\t\tn = 100
+\t\t}
+\t}
+\tf()
+\treturn n
+}
+
+type Box struct{ v any }
+
+func (b *Box) Kind() string {
+\tswitch b.v.(type) {
+\tcase int:
+\t\treturn "int"
+\tcase string:
+\t\treturn "string"
+\t}
+\treturn ""
+}
+'''
+
+
+def _build_repo(tmp_path):
+    return create_go_index(tmp_path, {"calc.go": GO_SRC, "util.py": "def f():\n    return 1\n"})
+
+
+def _by_name(result):
+    return {f["name"]: f for f in result["functions"]}
+
+
+class TestGetComplexity:
+    def test_counting_rules(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        fns = _by_name(get_complexity(repo, "calc.go", storage_path=store))
+        assert fns["Simple"]["complexity"] == 1
+        # if + else-if + && + ||
+        assert fns["Classify"]["complexity"] == 5
+        assert fns["Classify"]["decisions"] == {"if": 2, "for": 0, "case": 0, "logical": 2}
+        # for + 2 cases (default free) + closure's if
+        assert fns["Loop"]["complexity"] == 5
+        # type switch cases
+        assert fns["Kind"]["complexity"] == 3
+        assert fns["Kind"]["kind"] == "method"
+
+    def test_sorted_worst_first_with_line_counts(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_complexity(repo, "calc.go", storage_path=store)
+        assert [f["name"] for f in result["functions"]] == ["Classify", "Loop", "Kind", "Simple"]
+        classify = result["functions"][0]
+        assert classify["lines"] == classify["end_line"] - classify["line"] + 1 == 9
+        assert classify["id"].endswith("::Classify#function")
+
+    def test_threshold(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_complexity(repo, "calc.go", threshold=3, storage_path=store)
+        assert [f["name"] for f in result["functions"]] == ["Classify", "Loop"]
+        assert result["function_count"] == 4
+        assert result["returned"] == 2
+
+    def test_unsupported_language(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_complexity(repo, "util.py", storage_path=store)
+        assert "error" in result
+        assert "go" in result["error"]
+
+    def test_missing_file(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        assert "error" in get_complexity(repo, "nope.go", storage_path=store)
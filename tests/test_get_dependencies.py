@@ -0,0 +1,99 @@
+"""Tests for get_dependencies: forward imports and reverse dependencies."""
+
+from jcodemunch_mcp.parser.imports import extract_imports
+from jcodemunch_mcp.tools.get_dependencies import _is_stdlib, get_dependencies
+from tests.conftest_helpers import create_go_index
+
+
+def _build_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "internal/store/store.go": (
+            'package store\n\nimport (\n\t"sync"\n\n\t"github.com/google/uuid"\n)\n\n'
+            "type Store struct{ mu sync.Mutex }\n\n"
+            "func NewID() string { return uuid.NewString() }\n"
+        ),
+        "internal/store/kv.go": 'package store\n\nimport "sync"\n\nvar _ sync.Locker\n',
+        "cmd/app/main.go": (
+            'package main\n\nimport (\n\t"fmt"\n\tst "github.com/acme/svc/internal/store"\n\t. "strings"\n)\n\n'
+            "func main() { fmt.Println(st.NewID(), ToUpper(\"x\")) }\n"
+        ),
+        "pyapp/__init__.py": "",
+        "pyapp/core.py": "import os\nimport requests\n\ndef run():\n    pass\n",
+        "pyapp/cli.py": "from pyapp.core import run\nimport json\n",
+    }, module="github.com/acme/svc")
+
+
+class TestGoImportExtraction:
+    def test_aliases_recorded(self):
+        content = (
+            'package a\n\nimport (\n\t"fmt"\n\tst "example.com/x/store"\n'
+            '\t. "strings"\n\t_ "embed"\n)\n\nimport . "io"\n'
+        )
+        edges = {e["specifier"]: e.get("alias") for e in extract_imports(content, "a.go", "go")}
+        assert edges == {
+            "fmt": None,
+            "example.com/x/store": "st",
+            "strings": ".",
+            "embed": "_",
+            "io": ".",
+        }
+
+
+class TestStdlibDetection:
+    def test_go(self):
+        assert _is_stdlib("net/http", "go")
+        assert not _is_stdlib("github.com/acme/x", "go")
+
+    def test_python(self):
+        assert _is_stdlib("os.path", "python")
+        assert not _is_stdlib("requests", "python")
+
+    def test_node(self):
+        assert _is_stdlib("node:fs", "typescript")
+        assert _is_stdlib("path", "javascript")
+        assert not _is_stdlib("react", "javascript")
+
+
+class TestGetDependencies:
+    def test_go_package_imports(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_dependencies(repo, "internal/store", storage_path=store)
+        assert "error" not in result
+        assert result["kind"] == "package"
+        imports = result["imports"]
+        assert [e["import"] for e in imports["stdlib"]] == ["sync"]
+        assert sorted(imports["stdlib"][0]["files"]) == ["internal/store/kv.go", "internal/store/store.go"]
+        assert [e["import"] for e in imports["third_party"]] == ["github.com/google/uuid"]
+        assert imports["internal"] == []
+
+    def test_go_reverse_dependencies(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_dependencies(repo, "github.com/acme/svc/internal/store", storage_path=store)
+        assert result["target"] == "internal/store"
+        assert [i["file"] for i in result["imported_by"]] == ["cmd/app/main.go"]
+        assert result["imported_by_packages"] == ["cmd/app"]
+
+    def test_go_alias_and_internal(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_dependencies(repo, "cmd/app/main.go", storage_path=store)
+        assert result["kind"] == "file"
+        internal = result["imports"]["internal"]
+        assert internal == [{
+            "import": "github.com/acme/svc/internal/store",
+            "target": "internal/store",
+            "alias": "st",
+        }]
+        stdlib = {e["import"]: e.get("alias") for e in result["imports"]["stdlib"]}
+        assert stdlib == {"fmt": None, "strings": "."}
+
+    def test_python_file(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_dependencies(repo, "pyapp/core.py", storage_path=store)
+        assert [e["import"] for e in result["imports"]["stdlib"]] == ["os"]
+        assert [e["import"] for e in result["imports"]["third_party"]] == ["requests"]
+        assert [i["file"] for i in result["imported_by"]] == ["pyapp/cli.py"]
+
+    def test_not_found(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_dependencies(repo, "nope/missing", storage_path=store)
+        assert "error" in result
@@ -131,3 +131,72 @@ def test_batch_mode_returns_flat_per_file_outlines(class_with_members_index):
     assert all("children" not in s for s in syms)
     names = {s["name"] for s in syms}
     assert {"Repo", "__init__", "count", "top_level_helper"} <= names
+
+
+def test_kinds_filter_drops_other_kinds(class_with_members_index):
+    fx = class_with_members_index
+    out = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"],
+                           kinds=["class", "func"])
+    assert {s["kind"] for s in out["symbols"]} == {"class", "function"}
+    assert out["_meta"]["filtered_out"] == 2
+    assert out["_meta"]["symbol_count"] == 2
+
+
+def test_exported_only_drops_unexported(tmp_path):
+    from jcodemunch_mcp.tools.index_folder import index_folder
+
+    src = tmp_path / "src"
+    store = tmp_path / "store"
+    src.mkdir()
+    store.mkdir()
+    (src / "mod.py").write_text(textwrap.dedent('''\
+        def public():
+            return 1
+
+
+        def _private():
+            return 2
+    '''))
+    r = index_folder(str(src), use_ai_summaries=False, storage_path=str(store))
+    assert r["success"] is True
+
+    out = get_file_outline(repo=r["repo"], file_path="mod.py", storage_path=str(store),
+                           exported_only=True)
+    assert [s["name"] for s in out["symbols"]] == ["public"]
+
+
+def test_no_filters_is_unchanged(class_with_members_index):
+    fx = class_with_members_index
+    plain = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"])
+    empty = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"],
+                             kinds=[], exported_only=False)
+    assert plain["symbols"] == empty["symbols"]
+    assert "filtered_out" not in plain["_meta"]
+
+
+def test_unknown_kind_is_an_error(class_with_members_index):
+    fx = class_with_members_index
+    out = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"],
+                           kinds=["widget"])
+    assert "error" in out
+
+
+def test_outline_paging(class_with_members_index):
+    fx = class_with_members_index
+    first = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"],
+                             max_results=2)
+    rest = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"],
+                            max_results=2, offset=2)
+    assert first["total_count"] == rest["total_count"] == 4
+    assert first["has_more"] is True and rest["has_more"] is False
+    assert [s["name"] for s in first["symbols"] + rest["symbols"]] == [
+        "Repo", "__init__", "count", "top_level_helper",
+    ]
+    # Members on a later page keep pointing at their parent.
+    assert rest["symbols"][0]["parent"] == first["symbols"][0]["id"]
+
+
+def test_outline_without_paging_has_no_total(class_with_members_index):
+    fx = class_with_members_index
+    out = get_file_outline(repo=fx["repo"], file_path="repo.py", storage_path=fx["store"])
+    assert "total_count" not in out and "has_more" not in out
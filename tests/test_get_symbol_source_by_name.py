@@ -0,0 +1,76 @@
+"""Tests for get_symbol_source name lookup and doc-comment capture."""
+
+from jcodemunch_mcp.tools.get_symbol import _leading_doc_start, get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+
+def _build_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "server.go": (
+            "package server\n\n"
+            "// Server serves requests.\n"
+            "// It is safe for concurrent use.\n"
+            "type Server struct {\n"
+            "\taddr string\n"
+            "}\n\n"
+            "// Start listens on addr.\n"
+            "func (s *Server) Start(\n"
+            "\taddr string,\n"
+            ") error {\n"
+            "\ts.addr = addr\n"
+            "\treturn nil\n"
+            "}\n"
+        ),
+        "a.py": "# Helper for a.\ndef helper():\n    return 1\n",
+        "b.py": "def helper():\n    return 2\n",
+    })
+
+
+class TestGetSymbolSourceByName:
+    def test_duplicates_return_all_matches(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_symbol_source(repo, symbol_name="helper", storage_path=store)
+        assert [s["file"] for s in result["symbols"]] == ["a.py", "b.py"]
+        assert result["errors"] == []
+
+    def test_file_path_disambiguates(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_symbol_source(repo, symbol_name="helper", file_path="b.py", storage_path=store)
+        assert len(result["symbols"]) == 1
+        assert "return 2" in result["symbols"][0]["source"]
+
+    def test_go_method_full_body(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_symbol_source(repo, symbol_name="Start", storage_path=store)
+        source = result["symbols"][0]["source"]
+        assert source.startswith("func (s *Server) Start(")
+        assert source.rstrip().endswith("}")
+        assert "doc_line" not in result["symbols"][0]
+
+    def test_include_doc_prepends_comment(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_symbol_source(repo, symbol_name="Server", include_doc=True, storage_path=store)
+        sym = result["symbols"][0]
+        assert sym["source"].startswith("// Server serves requests.\n// It is safe for concurrent use.\ntype Server struct {")
+        assert sym["doc_line"] == 3
+        assert sym["line"] == 5
+
+    def test_unknown_name(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_symbol_source(repo, symbol_name="nope_xyz", storage_path=store)
+        assert "error" in result
+
+
+class TestLeadingDocStart:
+    def test_block_comment(self):
+        lines = ["x := 1", "", "/**", " * Doc.", " */", "func F() {}"]
+        assert _leading_doc_start(lines, 5, "go") == 2
+
+    def test_blank_line_ends_block(self):
+        lines = ["// unrelated", "", "// Doc.", "func F() {}"]
+        assert _leading_doc_start(lines, 3, "go") == 2
+
+    def test_hash_is_not_a_comment_in_c(self):
+        lines = ["#define N 3", "int f(void) {}"]
+        assert _leading_doc_start(lines, 1, "c") == 1
+        assert _leading_doc_start(["# note", "def f(): pass"], 1, "python") == 0
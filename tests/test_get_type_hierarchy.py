@@ -0,0 +1,142 @@
+"""Tests for get_type_hierarchy (Go embedding / interfaces, class inheritance)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.get_type_hierarchy import get_type_hierarchy
+from tests.conftest_helpers import create_go_index, line_of
+
+SHAPES_GO = '''package shapes
+
+import (
+\t"fmt"
+\t"sync"
+)
+
+type Base struct{ ID int }
+
+func (b Base) String() string { return fmt.Sprint(b.ID) }
+
+type Named struct {
+\tBase
+\tName string
+}
+
+type Timed struct {
+\t*Base
+\tAt int
+}
+
+type Record struct {
+\tNamed
+\tTimed
+\tsync.Mutex
+}
+
+type Shape interface {
+\tArea() float64
+\tfmt.Stringer
+}
+
+type Square struct {
+\tBase
+\tSide float64
+}
+
+func (s *Square) Area() float64 { return s.Side * s.Side }
+'''
+
+ANIMALS_PY = '''class Animal:
+    pass
+
+
+class Walker(Animal):
+    pass
+
+
+class Swimmer(Animal):
+    pass
+
+
+class Duck(Walker, Swimmer):
+    pass
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {"shapes/shapes.go": SHAPES_GO, "animals.py": ANIMALS_PY})
+
+
+def _names(nodes):
+    return [(n["name"], n.get("relation"), bool(n.get("repeated"))) for n in nodes]
+
+
+class TestGo:
+    def test_diamond_embedding_expanded_once(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "Record", storage_path=store)
+        assert result["language"] == "go"
+        assert result["type"]["file"] == "shapes/shapes.go"
+        assert result["type"]["line"] == line_of(SHAPES_GO, "type Record")
+        named, timed, mutex = result["supertypes"]
+        assert _names([named, timed, mutex]) == [
+            ("Named", "embeds", False), ("Timed", "embeds", False), ("sync.Mutex", "embeds", False),
+        ]
+        assert _names(named["children"]) == [("Base", "embeds", False)]
+        assert named["children"][0]["line"] == line_of(SHAPES_GO, "type Base")
+        assert _names(timed["children"]) == [("Base", "embeds_pointer", True)]
+        assert "children" not in timed["children"][0]
+        assert mutex["file"] == "(external)"
+        assert result["supertype_count"] == 3
+
+    def test_subtypes_are_embedders(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "shapes.Base", storage_path=store)
+        assert _names(result["subtypes"]) == [
+            ("Named", "embedded_by", False), ("Square", "embedded_by", False), ("Timed", "embedded_by_pointer", False),
+        ]
+        assert _names(result["subtypes"][0]["children"]) == [("Record", "embedded_by", False)]
+        assert _names(result["subtypes"][2]["children"]) == [("Record", "embedded_by", True)]
+
+    def test_interfaces_with_receiver_form(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "Square", storage_path=store)
+        assert [(i["name"], i["form"], i["file"]) for i in result["interfaces"]] == [
+            ("Shape", "pointer", "shapes/shapes.go"),
+            ("fmt.Stringer", "value", "(external)"),
+        ]
+
+    def test_interface_reports_implementers(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "Shape", storage_path=store)
+        assert [(i["name"], i["form"]) for i in result["implementers"]] == [("Square", "pointer")]
+        assert result["supertypes"][0]["name"] == "fmt.Stringer"
+        assert result["supertypes"][0]["kind"] == "interface"
+
+    def test_max_depth_truncates(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "Record", max_depth=1, storage_path=store)
+        assert result["supertypes"][0]["truncated"] is True
+        assert "children" not in result["supertypes"][0]
+
+
+class TestClasses:
+    def test_python_diamond(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "Duck", storage_path=store)
+        assert result["language"] == "python"
+        walker, swimmer = result["supertypes"]
+        assert _names(walker["children"]) == [("Animal", "extends", False)]
+        assert _names(swimmer["children"]) == [("Animal", "extends", True)]
+        assert walker["line"] == line_of(ANIMALS_PY, "class Walker")
+
+    def test_subclasses(self, repo):
+        repo_id, store = repo
+        result = get_type_hierarchy(repo_id, "Animal", storage_path=store)
+        assert [n["name"] for n in result["subtypes"]] == ["Walker", "Swimmer"]
+        assert result["subtype_count"] == 3
+
+
+def test_unknown_type(repo):
+    repo_id, store = repo
+    assert "error" in get_type_hierarchy(repo_id, "Nope", storage_path=store)
@@ -0,0 +1,126 @@
+"""Tests for git_blame: per-line blame, symbol scoping, and untracked files."""
+
+import os
+import subprocess
+
+import pytest
+
+from jcodemunch_mcp.tools.git_blame import _author_date, _parse_porcelain, git_blame
+from jcodemunch_mcp.tools.index_folder import index_folder
+
+V1 = "def login(user):\n    return check(user)\n\n\ndef logout(user):\n    pass\n"
+V2 = "def login(user):\n    audit(user)\n    return check(user)\n\n\ndef logout(user):\n    pass\n"
+
+
+def _git(src, *args, date=None, author="Alice"):
+    env = dict(os.environ)
+    if date:
+        env.update(GIT_AUTHOR_DATE=date, GIT_COMMITTER_DATE=date)
+    env.update(GIT_AUTHOR_NAME=author, GIT_AUTHOR_EMAIL=f"{author.lower()}@example.com",
+               GIT_COMMITTER_NAME=author, GIT_COMMITTER_EMAIL=f"{author.lower()}@example.com")
+    subprocess.run(["git", *args], cwd=str(src), capture_output=True, check=True, env=env)
+
+
+def _build_git_repo(tmp_path):
+    src = tmp_path / "src"
+    store = tmp_path / "store"
+    src.mkdir()
+    store.mkdir()
+    (src / "auth.py").write_text(V1)
+    try:
+        _git(src, "init")
+        _git(src, "add", ".")
+        _git(src, "commit", "-m", "add auth", date="2024-01-01T10:00:00+00:00")
+        (src / "auth.py").write_text(V2)
+        _git(src, "commit", "-am", "audit logins", date="2024-03-05T12:30:00+02:00", author="Bob")
+    except (OSError, subprocess.CalledProcessError):
+        pytest.skip("git not available")
+    (src / "scratch.py").write_text("X = 1\n")
+    r = index_folder(str(src), use_ai_summaries=False, storage_path=str(store))
+    assert r["success"] is True
+    return r["repo"], str(store), src
+
+
+class TestHelpers:
+    def test_author_date_keeps_offset(self):
+        assert _author_date("1709634600", "+0200") == "2024-03-05T12:30:00+02:00"
+
+    def test_parse_porcelain(self):
+        sha = "a" * 40
+        out = (
+            f"{sha} 1 1 2\nauthor Alice\nauthor-time 1704103200\nauthor-tz +0000\nsummary init\n"
+            f"filename f.py\n\tline one\n{sha} 2 2\nfilename f.py\n\tline two\n"
+        )
+        lines, commits = _parse_porcelain(out)
+        assert lines == [{"line": 1, "sha": sha}, {"line": 2, "sha": sha}]
+        assert commits[sha]["author"] == "Alice"
+        assert commits[sha]["summary"] == "init"
+
+
+class TestGitBlame:
+    def test_file_range(self, tmp_path):
+        repo, store, _ = _build_git_repo(tmp_path)
+        result = git_blame(repo, file_path="auth.py", start_line=1, end_line=3, storage_path=store)
+        assert result["status"] == "ok"
+        assert [(l["line"], l["author"]) for l in result["lines"]] == [(1, "Alice"), (2, "Bob"), (3, "Alice")]
+        assert result["lines"][1]["date"] == "2024-03-05T12:30:00+02:00"
+        assert [c["summary"] for c in result["commits"]] == ["audit logins", "add auth"]
+        assert [c["lines"] for c in result["commits"]] == [1, 2]
+
+    def test_range_is_clamped(self, tmp_path):
+        repo, store, _ = _build_git_repo(tmp_path)
+        result = git_blame(repo, file_path="auth.py", start_line=-3, end_line=999, storage_path=store)
+        assert (result["start_line"], result["end_line"]) == (1, 7)
+        assert len(result["lines"]) == 7
+
+    def test_symbol_most_recent(self, tmp_path):
+        repo, store, _ = _build_git_repo(tmp_path)
+        login = git_blame(repo, symbol="login", storage_path=store)
+        assert login["symbol"]["name"] == "login"
+        assert (login["start_line"], login["end_line"]) == (1, 3)
+        assert login["most_recent"]["author"] == "Bob"
+        assert login["most_recent"]["summary"] == "audit logins"
+
+        logout = git_blame(repo, symbol="logout", storage_path=store)
+        assert logout["most_recent"]["author"] == "Alice"
+        assert {c["author"] for c in logout["commits"]} == {"Alice"}
+
+    def test_uncommitted_lines(self, tmp_path):
+        repo, store, src = _build_git_repo(tmp_path)
+        (src / "auth.py").write_text(V2.replace("pass", "return None"))
+        result = git_blame(repo, file_path="auth.py", start_line=7, end_line=7, storage_path=store)
+        assert result["lines"][0]["commit"] is None
+        assert result["most_recent"]["uncommitted"] is True
+
+    def test_max_lines_truncates(self, tmp_path):
+        repo, store, _ = _build_git_repo(tmp_path)
+        result = git_blame(repo, file_path="auth.py", max_lines=2, storage_path=store)
+        assert len(result["lines"]) == 2
+        assert result["_meta"]["truncated"] is True
+        assert sum(c["lines"] for c in result["commits"]) == 7
+
+    def test_untracked_file(self, tmp_path):
+        repo, store, _ = _build_git_repo(tmp_path)
+        result = git_blame(repo, file_path="scratch.py", storage_path=store)
+        assert "error" not in result
+        assert result["status"] == "not_tracked"
+
+
+class TestGitBlameErrors:
+    def test_not_a_git_repo(self, tmp_path):
+        src = tmp_path / "src"
+        store = tmp_path / "store"
+        src.mkdir()
+        store.mkdir()
+        (src / "utils.py").write_text("def helper():\n    return 1\n")
+        r = index_folder(str(src), use_ai_summaries=False, storage_path=str(store))
+        result = git_blame(r["repo"], file_path="utils.py", storage_path=str(store))
+        assert "error" not in result
+        assert result["status"] == "not_tracked"
+
+    def test_requires_target(self, tmp_path):
+        assert "error" in git_blame("whatever", storage_path=str(tmp_path))
+
+    def test_unknown_symbol(self, tmp_path):
+        repo, store, _ = _build_git_repo(tmp_path)
+        assert "error" in git_blame(repo, symbol="nope", storage_path=store)
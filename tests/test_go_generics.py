@@ -0,0 +1,80 @@
+"""Tests for Go generics: type parameters on functions and types (Symbol.type_params)."""
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+SET_GO = '''package set
+
+import "cmp"
+
+type Set[T comparable] struct {
+\tm map[T]struct{}
+}
+
+type Pair[K comparable, V any] struct {
+\tKey K
+\tVal V
+}
+
+type Number interface {
+\t~int | ~int64 | ~float64
+}
+
+func Map[T, U any](xs []T, f func(T) U) []U { return nil }
+
+func Max[S ~[]E, E cmp.Ordered](s S) E { var e E; return e }
+
+func (s *Set[T]) Add(v T) { s.m[v] = struct{}{} }
+
+func Keys() []string { return Map[int, string](nil, nil) }
+'''
+
+
+def _by_name():
+    return {s.name: s for s in parse_file(SET_GO, "set/set.go", "go")}
+
+
+def test_functions_keep_names_and_constraints():
+    syms = _by_name()
+    assert syms["Map"].type_params == [
+        {"name": "T", "constraint": "any"},
+        {"name": "U", "constraint": "any"},
+    ]
+    assert syms["Max"].type_params == [
+        {"name": "S", "constraint": "~[]E"},
+        {"name": "E", "constraint": "cmp.Ordered"},
+    ]
+    assert syms["Map"].params == [{"name": "xs", "type": "[]T"}, {"name": "f", "type": "func(T) U"}]
+
+
+def test_generic_types():
+    syms = _by_name()
+    assert syms["Set"].type_params == [{"name": "T", "constraint": "comparable"}]
+    assert [p["name"] for p in syms["Pair"].type_params] == ["K", "V"]
+    assert syms["Number"].type_params == []
+
+
+def test_methods_on_generic_receivers():
+    add = _by_name()["Add"]
+    assert add.id == "set/set.go::Add#method"
+    assert add.receiver_type == "Set"
+    assert add.pointer_receiver is True
+    assert add.type_params == []
+
+
+def test_instantiated_call_references_base_name():
+    assert "Map" in _by_name()["Keys"].call_references
+
+
+def test_outline_and_source_round_trip(tmp_path):
+    repo, store = create_go_index(tmp_path, {"set/set.go": SET_GO})
+
+    outline = get_file_outline(repo, "set/set.go", storage_path=store)
+    syms = {s["name"]: s for s in outline["symbols"]}
+    assert syms["Set"]["type_params"] == [{"name": "T", "constraint": "comparable"}]
+    assert "type_params" not in syms["Keys"]
+
+    result = get_symbol_source(repo, symbol_id=syms["Max"]["id"], storage_path=store)
+    assert result["type_params"][1] == {"name": "E", "constraint": "cmp.Ordered"}
@@ -0,0 +1,107 @@
+"""Tests for Go method receivers and the grouped get_file_outline view."""
+
+from dataclasses import asdict
+
+from jcodemunch_mcp.parser import build_symbol_tree, parse_file
+from jcodemunch_mcp.parser.go_types import GoTypeIndex
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+LIST_GO = '''package list
+
+type List[T any] struct {
+\titems []T
+}
+
+type counter int
+
+func (l *List[T]) Push(v T) { l.items = append(l.items, v) }
+
+func (l List[T]) Len() int { return len(l.items) }
+
+func (c counter) Value() int { return int(c) }
+
+func (*counter) reset() {}
+
+func (w *Writer) Write(p []byte) (int, error) { return len(p), nil }
+
+func New[T any]() *List[T] { return &List[T]{} }
+'''
+
+
+def _by_name(symbols):
+    return {s.name: s for s in symbols}
+
+
+class TestParseReceivers:
+    def test_pointer_value_and_generic(self):
+        syms = _by_name(parse_file(LIST_GO, "list/list.go", "go"))
+        assert (syms["Push"].receiver_type, syms["Push"].pointer_receiver) == ("List", True)
+        assert (syms["Len"].receiver_type, syms["Len"].pointer_receiver) == ("List", False)
+        assert (syms["Value"].receiver_type, syms["Value"].pointer_receiver) == ("counter", False)
+
+    def test_unnamed_receiver(self):
+        syms = _by_name(parse_file(LIST_GO, "list/list.go", "go"))
+        assert (syms["reset"].receiver_type, syms["reset"].pointer_receiver) == ("counter", True)
+
+    def test_functions_have_no_receiver(self):
+        syms = _by_name(parse_file(LIST_GO, "list/list.go", "go"))
+        assert syms["New"].receiver_type == ""
+        assert syms["List"].receiver_type == ""
+
+    def test_multi_param_generic_receiver(self):
+        src = "package m\n\ntype Map[K comparable, V any] struct{}\n\nfunc (m *Map[K, V]) Get(k K) V { var v V; return v }\n"
+        syms = parse_file(src, "m/m.go", "go")
+        get = _by_name(syms)["Get"]
+        assert (get.receiver_type, get.pointer_receiver) == ("Map", True)
+        go = GoTypeIndex([asdict(s) for s in syms])
+        assert set(go.methods[("m", "Map")]) == {"Get"}
+
+    def test_grouped_tree(self):
+        roots = build_symbol_tree(parse_file(LIST_GO, "list/list.go", "go"), group_methods=True)
+        tree = {n.symbol.name: [c.symbol.name for c in n.children] for n in roots}
+        assert tree == {
+            "List": ["Push", "Len"],
+            "counter": ["Value", "reset"],
+            "Write": [],  # receiver type declared elsewhere
+            "New": [],
+        }
+
+    def test_flat_by_default(self):
+        roots = build_symbol_tree(parse_file(LIST_GO, "list/list.go", "go"))
+        assert all(not n.children for n in roots)
+
+
+class TestOutline:
+    def _build(self, tmp_path):
+        return create_go_index(tmp_path, {"list/list.go": LIST_GO})
+
+    def test_grouped_outline(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        outline = get_file_outline(repo, "list/list.go", storage_path=store, group_methods=True)
+        syms = {s["name"]: s for s in outline["symbols"]}
+        assert syms["Push"]["parent"] == syms["List"]["id"]
+        assert syms["Push"]["receiver_type"] == "List"
+        assert syms["Push"]["pointer_receiver"] is True
+        assert syms["Write"]["parent"] is None
+        # Exported method on an unexported type keeps its own classification.
+        assert syms["Value"]["parent"] == syms["counter"]["id"]
+        assert syms["Value"]["exported"] is True
+        assert syms["reset"]["exported"] is False
+        assert [s["name"] for s in outline["symbols"]][:3] == ["List", "Push", "Len"]
+
+    def test_flat_outline_keeps_receivers(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        outline = get_file_outline(repo, "list/list.go", storage_path=store)
+        syms = {s["name"]: s for s in outline["symbols"]}
+        assert syms["Push"]["parent"] is None
+        assert syms["Len"]["pointer_receiver"] is False
+        assert "receiver_type" not in syms["New"]
+
+    def test_symbol_source(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        outline = get_file_outline(repo, "list/list.go", storage_path=store)
+        push_id = next(s["id"] for s in outline["symbols"] if s["name"] == "Push")
+        result = get_symbol_source(repo, symbol_id=push_id, storage_path=store)
+        assert (result["receiver_type"], result["pointer_receiver"]) == ("List", True)
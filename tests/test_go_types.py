@@ -0,0 +1,109 @@
+"""Unit tests for Go method-set parsing and interface satisfaction (parser/go_types.py)."""
+
+from jcodemunch_mcp.parser.go_types import (
+    GoTypeIndex,
+    parse_method_signature,
+    parse_type_declaration,
+)
+
+
+def _sym(kind, file, signature):
+    return {"language": "go", "kind": kind, "file": file, "signature": signature}
+
+
+class TestParsing:
+    def test_interface_methods_and_embeds(self):
+        (spec,) = parse_type_declaration(
+            "type RW interface {\n\t// Read reads.\n\tRead(p []byte) (n int, err error)\n\tio.Writer\n}"
+        )
+        assert spec.kind == "interface"
+        assert spec.methods == {"Read": "([]byte)(int,error)"}
+        assert spec.embeds == ["io.Writer"]
+
+    def test_grouped_declaration(self):
+        specs = parse_type_declaration("type (\n\tA struct{ x int }\n\tB int\n)")
+        assert [(s.name, s.kind) for s in specs] == [("A", "struct"), ("B", "other")]
+
+    def test_constraint_interface(self):
+        (spec,) = parse_type_declaration("type Number interface { ~int | ~float64 }")
+        assert spec.type_terms is True
+        assert spec.methods == {}
+
+    def test_generic_type(self):
+        (spec,) = parse_type_declaration("type List[T any] struct { items []T }")
+        assert spec.generic is True
+        (arr,) = parse_type_declaration("type Buf [4]byte")
+        assert arr.generic is False
+
+    def test_method_receivers(self):
+        m = parse_method_signature("func (s *Server) Serve(ctx context.Context, addrs ...string) error")
+        assert (m.receiver, m.pointer, m.name) == ("Server", True, "Serve")
+        assert m.key == "(Context,...string)error"
+        m = parse_method_signature("func (l List[T]) Len() int")
+        assert (m.receiver, m.pointer) == ("List", False)
+        m = parse_method_signature("func (m *Map[K, V]) Load(key K) (V, bool)")
+        assert (m.receiver, m.pointer, m.name) == ("Map", True, "Load")
+        m = parse_method_signature("func (Map[K, V]) Len() int")
+        assert (m.receiver, m.pointer, m.name) == ("Map", False, "Len")
+
+    def test_grouped_parameter_names(self):
+        m = parse_method_signature("func (p Pt) Move(dx, dy int, label string)")
+        assert m.key == "(int,int,string)"
+
+    def test_plain_function_is_not_a_method(self):
+        assert parse_method_signature("func main()") is None
+
+
+class TestSatisfaction:
+    def test_pointer_vs_value_receivers(self):
+        idx = GoTypeIndex([
+            _sym("type", "a/a.go", "type Closer interface { Close() error }"),
+            _sym("type", "a/a.go", "type V struct{}"),
+            _sym("method", "a/a.go", "func (V) Close() error"),
+            _sym("type", "a/a.go", "type P struct{}"),
+            _sym("method", "a/a.go", "func (*P) Close() error"),
+        ])
+        assert dict(idx.implementers(("a", "Closer"))) == {("a", "V"): "value", ("a", "P"): "pointer"}
+
+    def test_value_embedding_promotes_only_value_methods(self):
+        idx = GoTypeIndex([
+            _sym("type", "a/a.go", "type Closer interface { Close() error }"),
+            _sym("type", "a/a.go", "type P struct{}"),
+            _sym("method", "a/a.go", "func (*P) Close() error"),
+            _sym("type", "a/a.go", "type Outer struct {\n\tP\n}"),
+        ])
+        assert dict(idx.implementers(("a", "Closer")))[("a", "Outer")] == "pointer"
+
+    def test_signature_mismatch(self):
+        idx = GoTypeIndex([
+            _sym("type", "a/a.go", "type Closer interface { Close() error }"),
+            _sym("type", "a/a.go", "type W struct{}"),
+            _sym("method", "a/a.go", "func (W) Close()"),
+        ])
+        assert idx.implementers(("a", "Closer")) == []
+
+    def test_unexported_methods_stay_in_package(self):
+        idx = GoTypeIndex([
+            _sym("type", "a/a.go", "type sealed interface { seal() }"),
+            _sym("type", "a/a.go", "type In struct{}"),
+            _sym("method", "a/a.go", "func (In) seal()"),
+            _sym("type", "b/b.go", "type Out struct{}"),
+            _sym("method", "b/b.go", "func (Out) seal()"),
+        ])
+        assert [k for k, _ in idx.implementers(("a", "sealed"))] == [("a", "In")]
+
+    def test_builtin_embeds(self):
+        idx = GoTypeIndex([_sym("type", "a/a.go", "type E interface {\n\terror\n\tCode() int\n}")])
+        methods, unresolved, _ = idx.interface_methods(("a", "E"))
+        assert methods == {"Error": "()string", "Code": "()int"}
+        assert unresolved == []
+
+    def test_satisfied_interfaces(self):
+        idx = GoTypeIndex([
+            _sym("type", "a/a.go", "type Sizer interface { Size() int }"),
+            _sym("type", "a/a.go", "type T struct{}"),
+            _sym("method", "a/a.go", "func (t *T) Size() int"),
+            _sym("method", "a/a.go", "func (t T) Error() string"),
+        ])
+        found = {(name, form) for _key, name, form in idx.satisfied_interfaces(("a", "T"))}
+        assert found == {("Sizer", "pointer"), ("error", "value")}
@@ -75,6 +75,7 @@ class TestPerLanguageExtraction:
         # Rust
         ("rust", "sample.rs", "User", "type"),
         ("rust", "sample.rs", "authenticate", "function"),
+        ("rust", "sample.rs", "MAX_RETRIES", "constant"),
         # Java
         ("java", "Sample.java", "Sample", "class"),
         # C#
@@ -257,24 +258,23 @@ class TestPerLanguageExtraction:
     # Special / negative / inline-source tests (keep separate)
     # ------------------------------------------------------------------
 
-    def test_rust_impl_block_not_extracted(self):
-        """impl_item is in symbol_node_types but has no name_fields entry,
-        so the extractor skips it (returns None from _extract_name).
-        Functions inside impl are still extracted as top-level functions."""
+    def test_rust_impl_block_extracted(self):
+        """impl_item is named after the implementing type."""
         content, fname = _fixture("rust", "sample.rs")
         symbols = parse_file(content, fname, "rust")
-        grouped = _kinds(symbols)
-        impl_syms = grouped.get("class", [])
-        assert len(impl_syms) == 0
+        impl_syms = _kinds(symbols).get("class", [])
+        assert [s.name for s in impl_syms] == ["User"]
+        assert impl_syms[0].signature == "impl User"
 
     def test_rust_fn_in_impl(self):
-        """Without the impl parent being extracted, 'new' appears as a
-        top-level function rather than a method."""
+        """Functions inside an impl block are methods of the impl's type."""
         content, fname = _fixture("rust", "sample.rs")
         symbols = parse_file(content, fname, "rust")
         new_sym = _by_name(symbols, "new")
-        assert new_sym.kind == "function"
-        assert new_sym.parent is None
+        impl_sym = next(s for s in symbols if s.kind == "class")
+        assert new_sym.kind == "method"
+        assert new_sym.qualified_name == "User.new"
+        assert new_sym.parent == impl_sym.id
 
     def test_cpp_overload_disambiguation(self):
         content, fname = _fixture("cpp", "sample.cpp")
@@ -533,7 +533,7 @@ class TestIndexVersioning:
         )
 
         assert index.index_version == INDEX_VERSION
-        assert index.index_version == 16
+        assert index.index_version == 17
 
     def test_load_preserves_version(self, tmp_path):
         store = IndexStore(base_path=str(tmp_path))
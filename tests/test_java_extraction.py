@@ -0,0 +1,131 @@
+"""Tests for Java package, annotation, field, and visibility extraction."""
+
+import pytest
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.parser.imports import java_package
+from jcodemunch_mcp.parser.visibility import is_exported, java_visibility
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+JAVA_SRC = '''package com.example.billing;
+
+import java.util.List;
+
+/**
+ * Computes invoice totals.
+ */
+@Deprecated
+public class Invoice {
+    public static final int MAX_LINES = 100;
+    private final List<String> lines;
+    protected int x, y;
+
+    /**
+     * Adds a line.
+     */
+    @Override
+    @SuppressWarnings("unchecked")
+    public int add(String line) {
+        return 0;
+    }
+
+    private void reset() {}
+
+    void touch() {}
+}
+
+interface Priced {
+    int DEFAULT = 0;
+
+    int price();
+}
+
+public record Point(int x, int y) {}
+'''
+
+
+def _by_name(symbols):
+    return {s.name: s for s in symbols}
+
+
+class TestParse:
+    def test_annotations_captured(self):
+        syms = _by_name(parse_file(JAVA_SRC, "Invoice.java", "java"))
+        assert syms["Invoice"].decorators == ["@Deprecated"]
+        assert syms["add"].decorators == ["@Override", '@SuppressWarnings("unchecked")']
+        assert syms["reset"].decorators == []
+
+    def test_javadoc_feeds_docstring(self):
+        syms = _by_name(parse_file(JAVA_SRC, "Invoice.java", "java"))
+        assert syms["Invoice"].docstring == "Computes invoice totals."
+        assert syms["add"].docstring == "Adds a line."
+
+    def test_class_fields(self):
+        fields = _by_name(parse_file(JAVA_SRC, "Invoice.java", "java"))["Invoice"].fields
+        assert [(f["name"], f["type"], f["modifiers"]) for f in fields] == [
+            ("MAX_LINES", "int", "public static final"),
+            ("lines", "List<String>", "private final"),
+            ("x", "int", "protected"),
+            ("y", "int", "protected"),
+        ]
+
+    def test_constants_are_members(self):
+        syms = parse_file(JAVA_SRC, "Invoice.java", "java")
+        consts = {s.qualified_name: s for s in syms if s.kind == "constant"}
+        assert set(consts) == {"Invoice.MAX_LINES", "Priced.DEFAULT"}
+        assert consts["Invoice.MAX_LINES"].parent == "Invoice.java::Invoice#class"
+
+    def test_record_indexed(self):
+        syms = _by_name(parse_file(JAVA_SRC, "Invoice.java", "java"))
+        assert syms["Point"].kind == "class"
+        assert syms["Point"].signature.startswith("public record Point(int x, int y)")
+
+
+class TestVisibility:
+    @pytest.mark.parametrize("signature, parent, expected", [
+        ("public class A", "", "public"),
+        ("class A", "", "package"),
+        ("@Override\n    protected void f(@Public int x)", "", "protected"),
+        ('@SuppressWarnings("x") private static final int X = 1;', "", "private"),
+        ("void f(String publicity)", "", "package"),
+        ("int price()", "interface Priced", "public"),
+        ("String value()", "public @interface Marker", "public"),
+    ])
+    def test_modifier(self, signature, parent, expected):
+        assert java_visibility(signature, parent) == expected
+
+    def test_exported_mapping(self):
+        assert is_exported("f", "java", signature="protected void f()") is True
+        assert is_exported("f", "java", signature="void f()") is False
+        assert is_exported("f", "java", nested=True, parent_exported=False, signature="public void f()") is False
+
+    def test_package_declaration(self):
+        assert java_package(JAVA_SRC) == "com.example.billing"
+        assert java_package('@Deprecated\npackage com.old;\n') == "com.old"
+        assert java_package("class A {}") == ""
+
+
+class TestOutline:
+    def _build(self, tmp_path):
+        return create_go_index(tmp_path, {"Invoice.java": JAVA_SRC})
+
+    def test_package_and_visibility(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        outline = get_file_outline(repo, "Invoice.java", storage_path=store)
+        assert outline["package"] == "com.example.billing"
+        syms = {s["name"]: s for s in outline["symbols"]}
+        assert (syms["Invoice"]["visibility"], syms["Invoice"]["exported"]) == ("public", True)
+        assert (syms["reset"]["visibility"], syms["reset"]["exported"]) == ("private", False)
+        assert (syms["touch"]["visibility"], syms["touch"]["exported"]) == ("package", False)
+        # Priced is package-private, so its implicitly public members are not exported.
+        assert syms["price"]["visibility"] == "public"
+        assert syms["price"]["exported"] is False
+        assert syms["add"]["decorators"] == ["@Override", '@SuppressWarnings("unchecked")']
+
+    def test_symbol_source_visibility_and_deprecation(self, tmp_path):
+        repo, store = self._build(tmp_path)
+        result = get_symbol_source(repo, symbol_id="Invoice.java::Invoice#class", storage_path=store)
+        assert result["visibility"] == "public"
+        assert result["doc"]["deprecated"] == ""
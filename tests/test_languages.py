@@ -137,6 +137,48 @@ def test_parse_tsx():
     assert symbol is not None
     assert symbol.kind == "function"
 
+
+TS_EXPORTS_SOURCE = '''
+/** Load a user. */
+export function loadUser(id: string) {
+    return id;
+}
+
+export default class {
+    render() {
+        return null;
+    }
+}
+'''
+
+
+def test_parse_typescript_exports():
+    """JSDoc before `export function` is kept; anonymous default export is a `default` symbol."""
+    symbols = parse_file(TS_EXPORTS_SOURCE, "exports.ts", "typescript")
+
+    func = next((s for s in symbols if s.name == "loadUser"), None)
+    assert func is not None
+    assert "Load a user" in func.docstring
+
+    default = next((s for s in symbols if s.name == "default"), None)
+    assert default is not None
+    assert default.kind == "class"
+    assert default.signature.startswith("export default class")
+
+    render = next((s for s in symbols if s.name == "render"), None)
+    assert render is not None
+    assert render.kind == "method"
+    assert render.parent == default.id
+
+
+def test_parse_javascript_default_export_function():
+    symbols = parse_file("export default function () {\n  return 1;\n}\n", "index.js", "javascript")
+    default = next((s for s in symbols if s.name == "default"), None)
+    assert default is not None
+    assert default.kind == "function"
+    assert default.id == "index.js::default#function"
+
+
 GO_SOURCE = '''
 package main
 
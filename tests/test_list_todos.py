@@ -0,0 +1,88 @@
+"""Tests for list_todos (comment markers attributed to enclosing symbols)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.list_todos import list_todos
+from tests.conftest_helpers import create_go_index
+
+SERVER_GO = '''package server
+
+// TODO: make the port configurable
+// once the config package lands.
+const port = 8080
+
+type Server struct{}
+
+func (s *Server) Start() error {
+\t// FIXME handle shutdown
+\tmsg := "TODO: not a comment"
+\t_ = msg
+\treturn nil // xxx remove before release
+}
+
+/* HACK: global state until
+   the registry is injected. */
+var registry = map[string]int{}
+'''
+
+JOBS_PY = '''class Worker:
+    # NOTE: ticket 42
+    def run(self):
+        # todo: retry on failure
+        return 1
+
+    # A plain comment.
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {"server/server.go": SERVER_GO, "jobs.py": JOBS_PY})
+
+
+def _todos(repo, **kw):
+    repo_id, store = repo
+    return list_todos(repo_id, storage_path=store, **kw)
+
+
+def test_markers_with_text_and_lines(repo):
+    result = _todos(repo, file_pattern="*.go")
+    got = [(t["line"], t["marker"], t["text"]) for t in result["todos"]]
+    assert got == [
+        (3, "TODO", "TODO: make the port configurable\nonce the config package lands."),
+        (10, "FIXME", "FIXME handle shutdown"),
+        (13, "XXX", "xxx remove before release"),
+        (16, "HACK", "HACK: global state until\nthe registry is injected."),
+    ]
+
+
+def test_string_literals_ignored(repo):
+    texts = [t["text"] for t in _todos(repo)["todos"]]
+    assert not any("not a comment" in t for t in texts)
+
+
+def test_enclosing_symbol(repo):
+    todos = {t["marker"]: t for t in _todos(repo, file_pattern="*.go")["todos"]}
+    assert todos["FIXME"]["symbol"].endswith("Start")
+    assert todos["FIXME"]["symbol_kind"] == "method"
+    assert "symbol" not in todos["TODO"]
+
+
+def test_python_method(repo):
+    todos = _todos(repo, language="python")["todos"]
+    assert [(t["marker"], t["text"]) for t in todos] == [("TODO", "todo: retry on failure")]
+    assert todos[0]["symbol"] == "Worker.run"
+
+
+def test_custom_markers(repo):
+    result = _todos(repo, markers=["note"])
+    assert [t["marker"] for t in result["todos"]] == ["NOTE"]
+    assert result["markers"] == ["NOTE"]
+    assert "error" in _todos(repo, markers=["TO DO"])
+
+
+def test_max_results(repo):
+    result = _todos(repo, max_results=2)
+    assert result["total"] == 2
+    assert result["truncated"] is True
+    assert sum(result["by_marker"].values()) == 2
@@ -0,0 +1,172 @@
+"""Tests for locate_definition (Go go-to-definition)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.locate_definition import locate_definition
+from tests.conftest_helpers import create_go_index, line_of
+
+STORE_GO = '''package store
+
+import "strings"
+
+var Default = &Store{}
+
+type Base struct {
+\tID int
+}
+
+func (b Base) Key() string { return strings.ToUpper("k") }
+
+type Store struct {
+\tBase
+\trows map[string]*Row
+}
+
+type Row struct {
+\tValue string
+}
+
+func New() *Store {
+\treturn &Store{rows: map[string]*Row{}}
+}
+
+func (s *Store) Get(key string) *Row {
+\treturn s.rows[key]
+}
+'''
+
+MAIN_GO = '''package main
+
+import (
+\t"fmt"
+
+\t"example.com/app/store"
+)
+
+func show(s *store.Store) {
+\trow := s.Get("a")
+\tfmt.Println(row.Value, s.ID, s.Key())
+\tfor _, r := range []*store.Row{row} {
+\t\t_ = len(r.Value)
+\t}
+\t_ = store.Default.Get("b")
+}
+
+func anon(v interface{ Get(string) *store.Row }) {
+\t_ = v.Get("c")
+}
+
+func main() {
+\tshow(store.New())
+}
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "store/store.go": STORE_GO,
+        "main.go": MAIN_GO,
+        "util.py": "def f():\n    return 1\n",
+    }, module="example.com/app")
+
+
+def _locate(repo, needle, word, src=MAIN_GO, file_path="main.go", nth=0):
+    repo_id, store = repo
+    line = line_of(src, needle)
+    text = src.split("\n")[line - 1]
+    col = -1
+    for _ in range(nth + 1):
+        col = text.index(word, col + 1)
+    return locate_definition(repo_id, file_path, line, col + 1, use_type_checker=False, storage_path=store)
+
+
+def test_local_variable(repo):
+    result = _locate(repo, "fmt.Println(row.Value", "row")
+    assert result["resolution"] == "local"
+    assert result["definition"]["line"] == line_of(MAIN_GO, "row := s.Get")
+    assert result["definition"]["kind"] == "variable"
+
+
+def test_qualified_function_across_packages(repo):
+    result = _locate(repo, "show(store.New())", "New")
+    assert result["resolution"] == "package"
+    d = result["definition"]
+    assert (d["file"], d["line"], d["kind"]) == ("store/store.go", line_of(STORE_GO, "func New()"), "function")
+    assert d["symbol_id"] == "store/store.go::New#function"
+
+
+def test_method_call_through_parameter_type(repo):
+    result = _locate(repo, 'row := s.Get("a")', "Get")
+    assert result["resolution"] == "method"
+    assert result["definition"]["line"] == line_of(STORE_GO, "func (s *Store) Get")
+
+
+def test_field_of_inferred_result_type(repo):
+    result = _locate(repo, "fmt.Println(row.Value", "Value")
+    assert result["resolution"] == "field"
+    assert result["definition"]["file"] == "store/store.go"
+    assert result["definition"]["line"] == line_of(STORE_GO, "\tValue string")
+
+
+def test_promoted_members(repo):
+    field = _locate(repo, "fmt.Println(row.Value", "ID")
+    assert field["resolution"] == "field"
+    assert field["definition"]["line"] == line_of(STORE_GO, "\tID int")
+    method = _locate(repo, "fmt.Println(row.Value", "Key")
+    assert method["resolution"] == "method"
+    assert method["definition"]["line"] == line_of(STORE_GO, "func (b Base) Key()")
+
+
+def test_range_element_and_package_var(repo):
+    ranged = _locate(repo, "_ = len(r.Value)", "Value")
+    assert ranged["definition"]["line"] == line_of(STORE_GO, "\tValue string")
+    via_var = _locate(repo, 'store.Default.Get("b")', "Get")
+    assert via_var["definition"]["line"] == line_of(STORE_GO, "func (s *Store) Get")
+
+
+def test_external_and_builtin(repo):
+    ext = _locate(repo, "fmt.Println(row.Value", "Println")
+    assert ext["resolution"] == "external"
+    assert ext["external"] == {"import_path": "fmt", "name": "Println", "stdlib": True}
+    assert "definition" not in ext
+    builtin = _locate(repo, "_ = len(r.Value)", "len")
+    assert builtin["resolution"] == "builtin"
+    assert builtin["external"]["import_path"] == "builtin"
+
+
+def test_import_name(repo):
+    result = _locate(repo, 'row := s.Get("a")', "s", nth=0)
+    assert result["resolution"] == "local"
+    assert result["definition"]["kind"] == "parameter"
+    imported = _locate(repo, "show(store.New())", "store")
+    assert imported["resolution"] == "import"
+    assert imported["import_path"] == "example.com/app/store"
+    assert imported["package_dir"] == "store"
+    assert imported["definition"]["line"] == line_of(MAIN_GO, '"example.com/app/store"')
+
+
+def test_ambiguous_receiver(repo):
+    result = _locate(repo, '_ = v.Get("c")', "Get")
+    assert result["resolution"] == "ambiguous"
+    assert [c["symbol_id"] for c in result["candidates"]] == ["store/store.go::Get#method"]
+    assert "could not be inferred" in result["_meta"]["note"]
+
+
+def test_errors(repo):
+    repo_id, store = repo
+    assert "does not support" in locate_definition(repo_id, "util.py", 1, 5, storage_path=store)["error"]
+    assert "No identifier" in locate_definition(repo_id, "main.go", 1, 1, storage_path=store)["error"]
+    assert "1-based" in locate_definition(repo_id, "main.go", 0, 1, storage_path=store)["error"]
+    assert "File not found" in locate_definition(repo_id, "nope.go", 1, 1, storage_path=store)["error"]
+
+
+def test_resolves_inside_withheld_bodies(tmp_path, monkeypatch):
+    repo_id, store = create_go_index(
+        tmp_path, {"count.go": "package app\n\nvar count = 0\n\nfunc bump() {\n\tcount++\n}\n"},
+        module="example.com/app",
+    )
+    monkeypatch.setenv("JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES", "1")
+    result = locate_definition(repo_id, "count.go", 6, 2, use_type_checker=False, storage_path=store)
+    assert result["resolution"] == "package"
+    assert result["definition"]["line"] == 3
@@ -0,0 +1,123 @@
+"""Tests for the output_format serialization layer (compact / pretty / markdown)."""
+
+import json
+from unittest.mock import patch
+
+import pytest
+
+from jcodemunch_mcp.encoding.output_format import normalize_output_format, render, to_markdown
+from jcodemunch_mcp.server import call_tool
+
+OUTLINE = {
+    "repo": "local/app",
+    "file": "src/auth.py",
+    "symbols": [
+        {
+            "id": "src/auth.py::User#class", "name": "User", "kind": "class", "file": "src/auth.py",
+            "line": 3, "summary": "A user | account.",
+            "children": [
+                {"id": "src/auth.py::User.login#method", "name": "login", "kind": "method",
+                 "file": "src/auth.py", "line": 5, "docstring": "Log in.\n\nMore text."},
+            ],
+        },
+    ],
+    "_meta": {"timing_ms": 1.5},
+}
+
+
+def test_symbol_table():
+    md = to_markdown("get_file_outline", OUTLINE)
+    assert md.startswith("## get_file_outline\n")
+    assert "- **file:** src/auth.py" in md
+    assert "| name | kind | line | summary |" in md
+    assert "| User | class | 3 | A user \\| account. |" in md
+    assert "| User.login | method | 5 | Log in. |" in md
+    assert md.rstrip().endswith("_meta: timing_ms=1.5")
+
+
+def test_file_column_when_rows_span_files():
+    result = {"results": [
+        {"name": "a", "kind": "function", "file": "x.py", "line": 1},
+        {"name": "b", "kind": "function", "file": "y.py", "line": 2},
+    ]}
+    md = to_markdown("search_symbols", result)
+    assert "| name | kind | file | line | summary |" in md
+    assert "| b | function | y.py | 2 |  |" in md
+
+
+def test_generic_sections_and_errors():
+    md = to_markdown("project_stats", {
+        "totals": {"files": 2},
+        "largest_files": [{"file": "a.go", "bytes": 10}],
+        "tags": ["x", "y"],
+    })
+    assert "### totals" in md and "- **files:** 2" in md
+    assert "| file | bytes |" in md and "| a.go | 10 |" in md
+    assert "- x" in md
+    assert to_markdown("get_symbol_source", {"error": "Symbol not found"}) == "**Error:** Symbol not found\n"
+
+
+def test_json_formats():
+    data = {"a": [1, 2]}
+    assert render("t", data, "compact") == '{"a":[1,2]}'
+    assert render("t", data, "pretty") == '{\n  "a": [\n    1,\n    2\n  ]\n}'
+    assert normalize_output_format(" Markdown ") == "markdown"
+    assert normalize_output_format("xml") is None
+
+
+@pytest.mark.asyncio
+async def test_call_tool_applies_output_format():
+    outline = json.loads(json.dumps(OUTLINE))
+    with patch("jcodemunch_mcp.tools.get_file_outline.get_file_outline", return_value=outline) as mock_outline:
+        result = await call_tool(
+            "get_file_outline",
+            {"repo": "local/app", "file_path": "src/auth.py", "output_format": "markdown"},
+        )
+    assert "output_format" not in mock_outline.call_args[1]
+    assert "| User.login | method | 5 | Log in. |" in result[0].text
+
+
+@pytest.mark.asyncio
+async def test_call_tool_rejects_unknown_output_format():
+    result = await call_tool("get_file_outline", {"repo": "local/app", "file_path": "a.py", "output_format": "xml"})
+    assert "output_format must be one of" in json.loads(result[0].text)["error"]
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize("fmt", ["markdown", "json"])
+async def test_get_context_bundle_keeps_its_own_output_format(fmt):
+    bundle = {"repo": "local/app", "markdown": "# bundle\n"} if fmt == "markdown" else {"repo": "local/app", "symbols": []}
+    with patch("jcodemunch_mcp.tools.get_context_bundle.get_context_bundle", return_value=bundle) as mock_bundle:
+        result = await call_tool(
+            "get_context_bundle",
+            {"repo": "local/app", "symbol_id": "a.py::f#function", "output_format": fmt},
+        )
+    assert mock_bundle.call_args[1]["output_format"] == fmt
+    payload = json.loads(result[0].text)
+    assert "error" not in payload
+    assert payload["repo"] == "local/app"
+
+
+def test_json_is_a_compact_alias():
+    assert normalize_output_format("json") == "compact"
+
+
+def test_listing_tools_advertise_output_format():
+    from jcodemunch_mcp import config as config_module
+    from jcodemunch_mcp.server import _build_tools_list
+
+    cfg = config_module._GLOBAL_CONFIG
+    original = {k: cfg.get(k) for k in ("tool_profile", "compact_schemas")}
+    try:
+        cfg["tool_profile"] = "full"
+        cfg["compact_schemas"] = False
+        tools = {t.name: t.inputSchema["properties"] for t in _build_tools_list()}
+    finally:
+        for k, v in original.items():
+            if v is None:
+                cfg.pop(k, None)
+            else:
+                cfg[k] = v
+    for name in ("get_file_outline", "search_symbols", "search_text"):
+        assert "markdown" in tools[name]["output_format"]["enum"]
+    assert tools["get_context_bundle"]["output_format"]["enum"] == ["json", "markdown"]
@@ -0,0 +1,116 @@
+"""Tests for the in-process parse_file result cache (parser/parse_cache.py)."""
+
+import pytest
+
+from jcodemunch_mcp.parser import parse_cache, parse_file
+
+
+SOURCE = '''
+def alpha(x):
+    """Alpha."""
+    return beta(x)
+
+
+def beta(y):
+    return y
+'''
+
+
+def test_second_parse_is_served_from_cache(monkeypatch):
+    first = parse_file(SOURCE, "mod.py", "python")
+    assert parse_cache.size() == 1
+
+    import jcodemunch_mcp.parser.extractor as extractor
+
+    def _boom(*a, **k):
+        raise AssertionError("tree-sitter should not run on a cache hit")
+
+    monkeypatch.setattr(extractor, "_parse_with_spec", _boom)
+    second = parse_file(SOURCE, "mod.py", "python")
+    assert [(s.id, s.line, s.end_line) for s in second] == [(s.id, s.line, s.end_line) for s in first]
+
+
+def test_changed_content_misses():
+    parse_file(SOURCE, "mod.py", "python")
+    edited = SOURCE + "\n\ndef gamma():\n    pass\n"
+    names = {s.name for s in parse_file(edited, "mod.py", "python")}
+    assert "gamma" in names
+    assert parse_cache.size() == 2
+
+
+def test_path_is_part_of_the_key():
+    a = parse_file(SOURCE, "a.py", "python")
+    b = parse_file(SOURCE, "b.py", "python")
+    assert all(s.file == "a.py" for s in a)
+    assert all(s.file == "b.py" for s in b)
+
+
+def test_hits_are_isolated_from_caller_mutation():
+    first = parse_file(SOURCE, "mod.py", "python")
+    first[0].summary = "mutated by summarizer"
+    first[0].keywords.append("leaked")
+    first[0].params[0]["type"] = "leaked"
+    second = parse_file(SOURCE, "mod.py", "python")
+    assert second[0].summary == ""
+    assert "leaked" not in second[0].keywords
+    assert second[0].params[0].get("type") != "leaked"
+
+
+def test_lru_eviction_respects_cap(monkeypatch):
+    monkeypatch.setattr(parse_cache, "_max_entries", lambda: 2)
+    for i in range(3):
+        parse_file(SOURCE, f"m{i}.py", "python")
+    assert parse_cache.size() == 2
+    assert parse_cache.get(parse_cache.make_key("python", "m0.py", SOURCE.encode())) is None
+
+
+def test_zero_cap_disables(monkeypatch):
+    monkeypatch.setattr(parse_cache, "_max_entries", lambda: 0)
+    parse_file(SOURCE, "mod.py", "python")
+    assert parse_cache.size() == 0
+
+
+def test_byte_budget_evicts_lru(monkeypatch):
+    one = parse_cache.entry_size(parse_file(SOURCE, "m9.py", "python"))
+    parse_cache.clear()
+    monkeypatch.setattr(parse_cache, "_max_bytes", lambda: one * 2 + one // 2)
+    for i in range(4):
+        parse_file(SOURCE, f"m{i}.py", "python")
+    stats = parse_cache.stats()
+    assert stats["entries"] == 2
+    assert stats["bytes"] <= stats["max_bytes"]
+    assert stats["evictions"] == 2
+    assert parse_cache.get(parse_cache.make_key("python", "m1.py", SOURCE.encode())) is None
+    assert parse_cache.get(parse_cache.make_key("python", "m3.py", SOURCE.encode())) is not None
+
+
+def test_entry_larger_than_budget_is_not_stored(monkeypatch):
+    monkeypatch.setattr(parse_cache, "_max_bytes", lambda: 64)
+    parse_file(SOURCE, "mod.py", "python")
+    assert parse_cache.stats()["entries"] == 0
+
+
+def test_counters():
+    parse_file(SOURCE, "mod.py", "python")
+    parse_file(SOURCE, "mod.py", "python")
+    stats = parse_cache.stats()
+    assert (stats["hits"], stats["misses"]) == (1, 1)
+    assert stats["hit_rate"] == 0.5
+    assert stats["bytes"] == parse_cache.entry_size(parse_file(SOURCE, "mod.py", "python"))
+    parse_cache.clear()
+    assert parse_cache.stats()["hits"] == 0
+
+
+def test_concurrent_puts_stay_under_budget(monkeypatch):
+    from concurrent.futures import ThreadPoolExecutor
+
+    one = parse_cache.entry_size(parse_file(SOURCE, "p99.py", "python"))
+    parse_cache.clear()
+    monkeypatch.setattr(parse_cache, "_max_bytes", lambda: one * 5)
+    with ThreadPoolExecutor(max_workers=8) as pool:
+        list(pool.map(lambda i: parse_file(SOURCE, f"p{i:02d}.py", "python"), range(64)))
+    stats = parse_cache.stats()
+    assert stats["bytes"] <= stats["max_bytes"]
+    assert stats["entries"] == 5
+    assert stats["misses"] == 64
+    assert stats["evictions"] == 59
@@ -0,0 +1,81 @@
+"""Tests for get_parse_errors and partial symbol extraction on broken files."""
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.parser.diagnostics import parse_diagnostics
+from jcodemunch_mcp.tools.get_parse_errors import get_parse_errors
+from tests.conftest_helpers import create_go_index
+
+BROKEN_GO = """package svc
+
+func Good() int { return 1 }
+
+func Broken() {
+\tx := (1 +
+}
+
+func AlsoGood() {}
+"""
+
+CLEAN_PY = "def ok():\n    return 1\n"
+
+
+def _build_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "svc.go": BROKEN_GO,
+        "ok.py": CLEAN_PY,
+    })
+
+
+class TestDiagnostics:
+    def test_clean_source_has_no_diagnostics(self):
+        assert parse_diagnostics(CLEAN_PY, "python") == []
+
+    def test_broken_source_reports_position(self):
+        diags = parse_diagnostics(BROKEN_GO, "go")
+        assert diags
+        first = diags[0]
+        assert first["kind"] in ("error", "missing")
+        assert first["message"].startswith("syntax error")
+        assert first["line"] >= 5
+        assert first["column"] >= 1
+        assert first["end_line"] >= first["line"]
+
+    def test_unknown_language_is_unchecked(self):
+        assert parse_diagnostics("anything", "no-such-language") is None
+
+    def test_limit(self):
+        source = "package p\n" + "func f() { ( }\n" * 10
+        assert len(parse_diagnostics(source, "go", limit=3)) <= 3
+
+
+class TestPartialSymbols:
+    def test_body_error_keeps_symbol(self):
+        names = {s.name for s in parse_file(BROKEN_GO, "svc.go", "go")}
+        assert {"Good", "AlsoGood"} <= names
+        assert "Broken" in names
+
+
+class TestGetParseErrors:
+    def test_reports_broken_file_only(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_parse_errors(repo, storage_path=store)
+        assert "error" not in result
+        assert result["file_count"] == 1
+        assert result["files_checked"] == 2
+        entry = result["files"][0]
+        assert entry["file"] == "svc.go"
+        assert entry["language"] == "go"
+        assert entry["error_count"] == len(entry["diagnostics"]) > 0
+        assert entry["symbol_count"] >= 3
+        assert result["_meta"]["truncated"] is False
+
+    def test_single_clean_file(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_parse_errors(repo, file_path="ok.py", storage_path=store)
+        assert result["files"] == []
+        assert result["files_checked"] == 1
+
+    def test_file_not_found(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        result = get_parse_errors(repo, file_path="missing.go", storage_path=store)
+        assert "error" in result
@@ -0,0 +1,114 @@
+"""Tests for the concurrent parse pool (parser/parse_pool.py)."""
+
+import threading
+
+import pytest
+
+from jcodemunch_mcp.parser import parse_pool
+from jcodemunch_mcp.parser.parse_pool import ParseJob, parse_files, resolve_workers
+from jcodemunch_mcp.tools.index_folder import index_folder
+
+
+def _jobs(n):
+    return [ParseJob(f"m{i:03d}.py", f"def f{i}():\n    return {i}\n", "python") for i in range(n)]
+
+
+class TestParseFiles:
+    @pytest.mark.parametrize("workers", [1, 4])
+    def test_results_in_input_order(self, workers):
+        results = list(parse_files(_jobs(40), max_workers=workers))
+        assert [r.job.rel_path for r in results] == [f"m{i:03d}.py" for i in range(40)]
+        assert [r.symbols[0].name for r in results] == [f"f{i}" for i in range(40)]
+        assert all(r.error is None for r in results)
+
+    def test_errors_are_collected_per_file(self, monkeypatch):
+        real = parse_pool.parse_file
+
+        def flaky(content, filename, language, **kwargs):
+            if filename == "m003.py":
+                raise ValueError("boom")
+            return real(content, filename, language, **kwargs)
+
+        monkeypatch.setattr(parse_pool, "parse_file", flaky)
+        results = list(parse_files(_jobs(8), max_workers=4))
+        assert len(results) == 8
+        failed = [r for r in results if r.error is not None]
+        assert [r.job.rel_path for r in failed] == ["m003.py"]
+        assert str(failed[0].error) == "boom"
+        assert failed[0].symbols == []
+
+    def test_parses_run_off_the_calling_thread(self, monkeypatch):
+        seen = set()
+        real = parse_pool.parse_file
+
+        def spy(*args, **kwargs):
+            seen.add(threading.current_thread().name)
+            return real(*args, **kwargs)
+
+        monkeypatch.setattr(parse_pool, "parse_file", spy)
+        list(parse_files(_jobs(16), max_workers=4))
+        assert seen and all(name.startswith("jcm-parse") for name in seen)
+
+    def test_jobs_consumed_lazily(self):
+        pulled = []
+
+        def gen():
+            for job in _jobs(50):
+                pulled.append(job.rel_path)
+                yield job
+
+        it = parse_files(gen(), max_workers=2)
+        next(it)
+        assert len(pulled) <= 2 * 2 + 1
+
+
+class TestResolveWorkers:
+    def test_explicit_and_auto(self, monkeypatch):
+        assert resolve_workers(3) == 3
+        monkeypatch.setattr(parse_pool.os, "cpu_count", lambda: 64)
+        assert resolve_workers(0) == 32
+
+    def test_config_key(self):
+        from jcodemunch_mcp import config as config_module
+
+        orig = config_module._GLOBAL_CONFIG.copy()
+        try:
+            config_module._GLOBAL_CONFIG.pop("parse_workers", None)
+            assert resolve_workers() == 1  # sequential unless configured
+            config_module._GLOBAL_CONFIG["parse_workers"] = 4
+            assert resolve_workers() == 4
+        finally:
+            config_module._GLOBAL_CONFIG.clear()
+            config_module._GLOBAL_CONFIG.update(orig)
+
+
+def test_index_is_identical_across_worker_counts(tmp_path):
+    """A parallel cold index produces the same symbols, in the same order."""
+    from jcodemunch_mcp import config as config_module
+    from jcodemunch_mcp.parser import parse_cache
+    from jcodemunch_mcp.storage import IndexStore
+
+    src = tmp_path / "src"
+    src.mkdir()
+    for i in range(30):
+        (src / f"mod{i}.py").write_text(f"class C{i}:\n    def m(self):\n        return {i}\n")
+    (src / "extra.py").write_text("def ok():\n    pass\n")
+
+    orig = config_module._GLOBAL_CONFIG.copy()
+    ids = {}
+    try:
+        for workers in (1, 8):
+            config_module._GLOBAL_CONFIG["parse_workers"] = workers
+            parse_cache.clear()
+            store = tmp_path / f"store{workers}"
+            result = index_folder(str(src), use_ai_summaries=False, storage_path=str(store), incremental=False)
+            assert result["success"] is True
+            owner, name = result["repo"].split("/", 1)
+            index = IndexStore(base_path=str(store)).load_index(owner, name)
+            ids[workers] = [s["id"] for s in index.symbols]
+    finally:
+        config_module._GLOBAL_CONFIG.clear()
+        config_module._GLOBAL_CONFIG.update(orig)
+
+    assert ids[1] == ids[8]
+    assert len(ids[1]) == 61
@@ -0,0 +1,93 @@
+"""Tests for project_stats (whole-repo metrics)."""
+
+from unittest.mock import patch
+
+import pytest
+
+from jcodemunch_mcp.storage import IndexStore
+from jcodemunch_mcp.tools.project_stats import project_stats
+from tests.conftest_helpers import create_go_index
+
+STORE_GO = '''package store
+
+type Store struct{}
+
+func Load(key string) string {
+\treturn decode(key)
+}
+
+func decode(key string) string {
+\treturn key
+}
+'''
+
+API_GO = '''package api
+
+func Get() string {
+\treturn Load("a") + Load("b")
+}
+
+func Put() {
+\tLoad("c")
+}
+'''
+
+JOBS_PY = '''def run(job):
+    return run(job)
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "store/store.go": STORE_GO,
+        "api/api.go": API_GO,
+        "jobs.py": JOBS_PY,
+    })
+
+
+def test_totals_and_languages(repo):
+    repo_id, store = repo
+    result = project_stats(repo_id, storage_path=store)
+    assert result["totals"]["files"] == 3
+    assert result["totals"]["packages"] == 3
+    assert result["totals"]["lines"] == STORE_GO.count("\n") + API_GO.count("\n") + JOBS_PY.count("\n")
+    go = result["languages"]["go"]
+    assert go["files"] == 2 and go["symbols"] == 5
+    assert go["lines"] == STORE_GO.count("\n") + API_GO.count("\n")
+    assert result["symbol_kinds"]["function"] == 5
+    assert list(result["languages"]) == ["go", "python"]
+
+
+def test_largest_files(repo):
+    repo_id, store = repo
+    largest = project_stats(repo_id, top_n=2, storage_path=store)["largest_files"]
+    assert [f["file"] for f in largest] == ["store/store.go", "api/api.go"]
+    assert largest[0]["symbols"] == 3
+
+
+def test_most_referenced(repo):
+    repo_id, store = repo
+    result = project_stats(repo_id, storage_path=store)
+    top = result["most_referenced_symbols"]
+    assert top[0]["name"] == "Load"
+    assert top[0]["references"] == 2
+    assert top[0]["symbol_ids"] == ["store/store.go::Load#function"]
+    assert "run" not in {e["name"] for e in top}  # only calls itself
+    assert result["_meta"]["references_approximate"] is True
+
+
+def test_without_call_references(repo):
+    repo_id, store = repo
+    index = IndexStore(base_path=store).load_index(*repo_id.split("/", 1))
+    for sym in index.symbols:
+        sym["call_references"] = []
+    index._callers_by_name = None
+    with patch("jcodemunch_mcp.tools.project_stats.load_repo_index_or_error", return_value=(index, None, None)):
+        result = project_stats(repo_id, storage_path=store)
+    assert "most_referenced_symbols" not in result
+    assert "call references" in result["_meta"]["note"]
+
+
+def test_unknown_repo(tmp_path):
+    assert "error" in project_stats("nope/nope", storage_path=str(tmp_path))
@@ -0,0 +1,116 @@
+"""Tests for import-path-aware symbol fqns (Go and Python) and fqn lookups."""
+
+import pytest
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.parser.fqn import go_import_path, python_module_path
+from jcodemunch_mcp.tools.find_references import find_references
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+STORE_GO = '''package store
+
+type Config struct {
+\tPath string
+}
+
+func (c *Config) Load() error { return nil }
+
+func New() *Config { return &Config{} }
+'''
+
+HTTP_GO = '''package http
+
+type Config struct {
+\tAddr string
+}
+
+func New() *Config { return &Config{} }
+'''
+
+MAIN_GO = '''package main
+
+import "example.com/app/store"
+
+func main() {
+\tcfg := store.New()
+\t_ = cfg.Load()
+\tvar c store.Config
+\t_ = c
+}
+'''
+
+
+@pytest.fixture
+def go_repo(tmp_path):
+    repo, store = create_go_index(tmp_path, {
+        "store/store.go": STORE_GO,
+        "http/http.go": HTTP_GO,
+        "cmd/main.go": MAIN_GO,
+    }, module="example.com/app")
+    return str(tmp_path / "src"), repo, store
+
+
+class TestComputation:
+    def test_go_import_path_from_go_mod(self, go_repo):
+        root, _, _ = go_repo
+        assert go_import_path("store/store.go", STORE_GO, root) == "example.com/app/store"
+        assert go_import_path("main.go", "package main\n", root) == "example.com/app"
+
+    def test_go_import_path_without_go_mod(self):
+        assert go_import_path("internal/store/store.go", STORE_GO) == "internal/store"
+        assert go_import_path("main.go", "package main\n") == "main"
+        assert go_import_path("store/store_test.go", "package store_test\n") == "store_test"
+
+    def test_python_module_path(self):
+        assert python_module_path("src/app/db.py") == "app.db"
+        assert python_module_path("app/store/__init__.py") == "app.store"
+
+    def test_go_symbols(self, go_repo):
+        root, _, _ = go_repo
+        syms = {s.qualified_name: s for s in parse_file(STORE_GO, "store/store.go", "go", repo=root)}
+        assert syms["Config"].fqn == "example.com/app/store.Config"
+        assert syms["Load"].fqn == "example.com/app/store.Config.Load"
+        assert syms["New"].fqn == "example.com/app/store.New"
+
+    def test_python_symbols(self):
+        source = "class Repo:\n    def get(self):\n        return 1\n"
+        syms = {s.qualified_name: s for s in parse_file(source, "src/app/db.py", "python")}
+        assert syms["Repo.get"].fqn == "app.db.Repo.get"
+
+    def test_other_languages_empty(self):
+        syms = parse_file("function f() {}\n", "web/f.js", "javascript")
+        assert all(s.fqn == "" for s in syms)
+
+
+class TestLookups:
+    def test_get_symbol_source_by_fqn(self, go_repo):
+        _, repo, store = go_repo
+        result = get_symbol_source(repo, fqn="example.com/app/http.Config", storage_path=store)
+        assert result["file"] == "http/http.go"
+        assert result["fqn"] == "example.com/app/http.Config"
+        by_name = get_symbol_source(repo, symbol_name="example.com/app/store.Config.Load", storage_path=store)
+        assert [s["name"] for s in by_name["symbols"]] == ["Load"]
+
+    def test_unknown_fqn(self, go_repo):
+        _, repo, store = go_repo
+        result = get_symbol_source(repo, fqn="example.com/app/nope.Config", storage_path=store)
+        assert "error" in result
+
+    def test_find_references_disambiguates(self, go_repo):
+        _, repo, store = go_repo
+        store_refs = find_references(repo, fqn="example.com/app/store.Config", storage_path=store)
+        assert [r["file"] for r in store_refs["references"]] == ["cmd/main.go"]
+        assert store_refs["symbol_id"] == "store/store.go::Config#type"
+        http_refs = find_references(repo, fqn="example.com/app/http.Config", storage_path=store)
+        assert http_refs["references"] == []
+
+    def test_find_references_usages_scoped(self, go_repo):
+        _, repo, store = go_repo
+        result = find_references(repo, fqn="example.com/app/http.New", include_usages=True, storage_path=store)
+        assert {u["file"] for u in result["usages"]} == {"http/http.go"}
+
+    def test_fqn_is_exclusive(self, go_repo):
+        _, repo, store = go_repo
+        with pytest.raises(ValueError):
+            find_references(repo, identifier="Config", fqn="example.com/app/store.Config", storage_path=store)
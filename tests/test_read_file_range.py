@@ -0,0 +1,53 @@
+"""Tests for read_file_range: numbered line slices with clamping."""
+
+from jcodemunch_mcp.tools.read_file_range import number_lines, read_file_range
+from tests.conftest_helpers import create_go_index
+
+
+def _index(tmp_path, lines=12):
+    return create_go_index(tmp_path, {"main.py": "".join(f"x{i} = {i}\n" for i in range(1, lines + 1))})
+
+
+class TestNumberLines:
+    def test_aligns_to_widest_number(self):
+        assert number_lines("a\nb\nc", 8) == " 8\ta\n 9\tb\n10\tc"
+
+    def test_empty(self):
+        assert number_lines("", 1) == ""
+
+
+class TestReadFileRange:
+    def test_in_range(self, tmp_path):
+        repo, store = _index(tmp_path)
+        result = read_file_range(repo, "main.py", 9, 11, storage_path=store)
+        assert result["content"] == " 9\tx9 = 9\n10\tx10 = 10\n11\tx11 = 11"
+        assert (result["start_line"], result["end_line"]) == (9, 11)
+        assert result["clamped"] is False
+        assert result["line_count"] == 12
+
+    def test_defaults_to_whole_file(self, tmp_path):
+        repo, store = _index(tmp_path, lines=3)
+        result = read_file_range(repo, "main.py", storage_path=store)
+        assert result["content"] == "1\tx1 = 1\n2\tx2 = 2\n3\tx3 = 3"
+        assert result["requested"] == {"start_line": 1, "end_line": 3}
+        assert result["clamped"] is False
+
+    def test_out_of_range_is_clamped(self, tmp_path):
+        repo, store = _index(tmp_path)
+        result = read_file_range(repo, "main.py", -4, 500, storage_path=store)
+        assert (result["start_line"], result["end_line"]) == (1, 12)
+        assert result["requested"] == {"start_line": -4, "end_line": 500}
+        assert result["clamped"] is True
+        assert result["content"].splitlines()[-1] == "12\tx12 = 12"
+
+    def test_start_past_end_of_file(self, tmp_path):
+        repo, store = _index(tmp_path)
+        result = read_file_range(repo, "main.py", 40, 50, storage_path=store)
+        assert (result["start_line"], result["end_line"]) == (12, 12)
+        assert result["content"] == "12\tx12 = 12"
+        assert result["clamped"] is True
+
+    def test_file_not_found(self, tmp_path):
+        repo, store = _index(tmp_path)
+        result = read_file_range(repo, "missing.py", storage_path=store)
+        assert "error" in result
@@ -0,0 +1,175 @@
+"""Tests for rename_preview (scope-aware, read-only rename edit plans)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.rename_preview import rename_preview
+from tests.conftest_helpers import create_go_index, line_of
+
+STORE_GO = '''package store
+
+var user = "pkg"
+
+// User is exported.
+type User struct {
+\tName string
+}
+
+func Lookup(id int) User {
+\tuser := User{Name: "local"}
+\tif id > 0 {
+\t\tuser := "shadow"
+\t\t_ = user
+\t}
+\treturn user
+}
+
+func Global() string { return user }
+
+func Two() error {
+\ta, err := first()
+\tb, err := second(a)
+\t_ = b
+\treturn err
+}
+'''
+
+OTHER_GO = '''package store
+
+func Other() string { return user + "x" }
+'''
+
+MAIN_GO = '''package main
+
+import "example.com/app/store"
+
+func main() {
+\tu := store.Lookup(1)
+\tvar v store.User = u
+\t_ = v.Name
+}
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "store/store.go": STORE_GO,
+        "store/other.go": OTHER_GO,
+        "main.go": MAIN_GO,
+        "util.py": "def f():\n    return 1\n",
+    }, module="example.com/app")
+
+
+def _preview(repo, **kw):
+    repo_id, store = repo
+    kw.setdefault("use_type_checker", False)
+    return rename_preview(repo_id, storage_path=store, **kw)
+
+
+def _sites(result):
+    return [(e["file"], e["line"]) for e in result["edits"]]
+
+
+class TestScopes:
+    def test_local_does_not_touch_package_var_or_shadow(self, repo):
+        line = line_of(STORE_GO, 'user := User{')
+        result = _preview(repo, name="user", new_name="account", file_path="store/store.go", line=line)
+        assert result["binding"] == "local"
+        assert result["resolver"] == "syntactic"
+        assert _sites(result) == [
+            ("store/store.go", line),
+            ("store/store.go", line_of(STORE_GO, "\treturn user")),
+        ]
+        assert result["edits"][0]["role"] == "definition"
+        assert result["edits"][0]["column"] == 2
+        assert result["edits"][0]["end_column"] == 6
+
+    def test_package_var_skips_locals(self, repo):
+        result = _preview(repo, name="user", new_name="current", file_path="store/store.go", line=3)
+        assert result["binding"] == "package"
+        assert _sites(result) == [
+            ("store/other.go", 3),
+            ("store/store.go", 3),
+            ("store/store.go", line_of(STORE_GO, "func Global")),
+        ]
+        assert result["definition"] == {"file": "store/store.go", "line": 3, "column": 5}
+
+    def test_reference_location_resolves_to_same_binding(self, repo):
+        by_def = _preview(repo, name="user", new_name="current", file_path="store/store.go", line=3)
+        by_ref = _preview(repo, name="user", new_name="current", file_path="store/other.go", line=3)
+        assert _sites(by_ref) == _sites(by_def)
+
+    def test_colon_equals_redeclaration_reuses_binding(self, repo):
+        result = _preview(repo, name="err", new_name="e", file_path="store/store.go",
+                          line=line_of(STORE_GO, "a, err := first()"))
+        assert [e["line"] for e in result["edits"]] == [
+            line_of(STORE_GO, "a, err := first()"),
+            line_of(STORE_GO, "b, err := second(a)"),
+            line_of(STORE_GO, "return err"),
+        ]
+        assert [e["role"] for e in result["edits"]] == ["definition", "reference", "reference"]
+
+
+class TestCrossPackage:
+    def test_exported_type_renamed_in_importers(self, repo):
+        result = _preview(repo, name="User", new_name="Account", file_path="store/store.go",
+                          line=line_of(STORE_GO, "type User"))
+        assert ("main.go", line_of(MAIN_GO, "store.User")) in _sites(result)
+        assert ("store/store.go", line_of(STORE_GO, "user := User{")) in _sites(result)
+        assert result["files"] == {"main.go": 1, "store/store.go": 3}
+        assert all(e["certain"] for e in result["edits"])
+
+    def test_unexporting_warns(self, repo):
+        result = _preview(repo, name="Lookup", new_name="lookup", file_path="store/store.go",
+                          line=line_of(STORE_GO, "func Lookup"))
+        assert ("main.go", line_of(MAIN_GO, "store.Lookup")) in _sites(result)
+        assert any("unexported" in w for w in result["warnings"])
+
+    def test_member_rename_is_uncertain(self, repo):
+        result = _preview(repo, name="Name", new_name="Title", file_path="store/store.go",
+                          line=line_of(STORE_GO, "\tName string"))
+        assert result["binding"] == "member"
+        assert set(_sites(result)) == {
+            ("store/store.go", line_of(STORE_GO, "\tName string")),
+            ("store/store.go", line_of(STORE_GO, "user := User{")),
+            ("main.go", line_of(MAIN_GO, "v.Name")),
+        }
+        assert not any(e["certain"] for e in result["edits"])
+        assert result["warnings"]
+
+
+class TestValidation:
+    def test_no_identifier_at_location(self, repo):
+        assert "error" in _preview(repo, name="user", new_name="x", file_path="store/store.go", line=1)
+
+    def test_predeclared(self, repo):
+        result = _preview(repo, name="string", new_name="str", file_path="store/store.go",
+                          line=line_of(STORE_GO, "func Global"))
+        assert "predeclared" in result["error"]
+
+    def test_invalid_names(self, repo):
+        assert "error" in _preview(repo, name="user", new_name="func", file_path="store/store.go", line=3)
+        assert "error" in _preview(repo, name="user", new_name="1x", file_path="store/store.go", line=3)
+        assert "error" in _preview(repo, name="user", new_name="user", file_path="store/store.go", line=3)
+
+    def test_unsupported_language(self, repo):
+        result = _preview(repo, name="f", new_name="g", file_path="util.py", line=1)
+        assert "plan_refactoring" in result["error"]
+
+    def test_type_checker_fallback_is_reported(self, repo, monkeypatch):
+        monkeypatch.setattr("jcodemunch_mcp.tools._gopls.shutil.which", lambda _name: None)
+        result = _preview(repo, name="user", new_name="current", file_path="store/store.go", line=3,
+                          use_type_checker=True)
+        assert result["resolver"] == "syntactic"
+        assert result["_meta"]["type_checker_skipped"] == "gopls not available"
+
+
+def test_references_inside_withheld_bodies(tmp_path, monkeypatch):
+    repo_id, store = create_go_index(
+        tmp_path, {"count.go": "package app\n\nvar count = 0\n\nfunc bump() {\n\tcount++\n}\n"},
+        module="example.com/app",
+    )
+    monkeypatch.setenv("JCODEMUNCH_WITHHOLD_UNEXPORTED_BODIES", "1")
+    result = rename_preview(repo_id, name="count", new_name="total", file_path="count.go", line=3,
+                            use_type_checker=False, storage_path=store)
+    assert _sites(result) == [("count.go", 3), ("count.go", 6)]
@@ -0,0 +1,138 @@
+"""Tests for MCP resources: file and package symbol-index URIs."""
+
+import asyncio
+import json
+
+import pytest
+
+from jcodemunch_mcp import server as server_module
+from jcodemunch_mcp.resources import (
+    file_uri,
+    list_resource_entries,
+    parse_uri,
+    read_resource_text,
+    symbols_uri,
+)
+from jcodemunch_mcp.storage import IndexStore
+from jcodemunch_mcp.tools.index_folder import index_folder
+from tests.conftest_helpers import create_go_index
+
+
+def _build_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "main.go": "package main\n\nfunc main() {}\n",
+        "pkg/util.go": "package pkg\n\n// Add adds.\nfunc Add(a, b int) int { return a + b }\n",
+        "pkg/my file.py": "def helper():\n    pass\n",
+    })
+
+
+class TestUris:
+    def test_round_trip_with_escaping(self):
+        uri = file_uri("local/proj-abc", "pkg/my file.py")
+        assert uri == "codemunch://file/local/proj-abc/pkg/my%20file.py"
+        assert parse_uri(uri) == ("file", "local", "proj-abc", "pkg/my file.py")
+
+    def test_symbols_root_package(self):
+        assert symbols_uri("o/n", "") == "codemunch://symbols/o/n/."
+
+    @pytest.mark.parametrize("uri", [
+        "file:///etc/passwd",
+        "codemunch://blob/o/n/x",
+        "codemunch://file/o/n",
+        "codemunch://file/o//x",
+    ])
+    def test_rejects_malformed(self, uri):
+        with pytest.raises(ValueError):
+            parse_uri(uri)
+
+
+class TestListAndRead:
+    def test_lists_packages_then_files(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        entries, next_cursor = list_resource_entries(store)
+        uris = [e["uri"] for e in entries]
+        assert next_cursor is None
+        assert uris == [
+            symbols_uri(repo, "."),
+            symbols_uri(repo, "pkg"),
+            file_uri(repo, "main.go"),
+            file_uri(repo, "pkg/my file.py"),
+            file_uri(repo, "pkg/util.go"),
+        ]
+        by_uri = {e["uri"]: e for e in entries}
+        assert by_uri[file_uri(repo, "pkg/util.go")]["mimeType"] == "text/x-go"
+        assert by_uri[symbols_uri(repo, "pkg")]["mimeType"] == "application/json"
+
+    def test_cursor_pages_cover_the_full_listing(self, tmp_path):
+        _, store = _build_repo(tmp_path)
+        full, _ = list_resource_entries(store)
+        pages, cursor = [], None
+        while True:
+            entries, cursor = list_resource_entries(store, cursor=cursor, page_size=2)
+            assert len(entries) <= 2
+            pages.extend(entries)
+            if cursor is None:
+                break
+        assert pages == full
+
+    def test_page_loads_only_the_repos_it_lists(self, tmp_path, monkeypatch):
+        repo, store = _build_repo(tmp_path)
+        other_src = tmp_path / "other"
+        other_src.mkdir()
+        (other_src / "a.py").write_text("def a():\n    pass\n")
+        other = index_folder(str(other_src), use_ai_summaries=False, storage_path=store)["repo"]
+        first, last = sorted([repo, other])
+
+        loaded = []
+        real_load = IndexStore.load_index
+
+        def _load(self, owner, name, *args, **kwargs):
+            loaded.append(f"{owner}/{name}")
+            return real_load(self, owner, name, *args, **kwargs)
+
+        monkeypatch.setattr(IndexStore, "load_index", _load)
+        entries, cursor = list_resource_entries(store, cursor=f"1:{last}")
+        assert cursor is None
+        assert entries and all(e["uri"].split("/")[3:5] == last.split("/") for e in entries)
+        assert loaded == [last]
+        assert first not in loaded
+
+    def test_invalid_cursor_errors(self, tmp_path):
+        _, store = _build_repo(tmp_path)
+        with pytest.raises(ValueError):
+            list_resource_entries(store, cursor="not-a-cursor")
+
+    def test_read_file(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        text, mime = read_resource_text(file_uri(repo, "pkg/util.go"), store)
+        assert "func Add" in text
+        assert mime == "text/x-go"
+
+    def test_read_package_symbols(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        text, mime = read_resource_text(symbols_uri(repo, "pkg"), store)
+        payload = json.loads(text)
+        assert mime == "application/json"
+        assert payload["package"] == "pkg"
+        assert {s["name"] for s in payload["symbols"]} == {"Add", "helper"}
+        assert [f["path"] for f in payload["files"]] == ["pkg/my file.py", "pkg/util.go"]
+
+    def test_read_unknown_file_errors(self, tmp_path):
+        repo, store = _build_repo(tmp_path)
+        with pytest.raises(ValueError):
+            read_resource_text(file_uri(repo, "../outside.go"), store)
+        with pytest.raises(ValueError):
+            read_resource_text(symbols_uri(repo, "nope"), store)
+
+
+class TestServerHandlers:
+    def test_list_and_read_via_server(self, tmp_path, monkeypatch):
+        repo, store = _build_repo(tmp_path)
+        monkeypatch.setenv("CODE_INDEX_PATH", store)
+        result = asyncio.run(server_module.list_resources())
+        assert file_uri(repo, "main.go") in {str(r.uri) for r in result.resources}
+        assert result.nextCursor is None
+        contents = asyncio.run(server_module.read_resource(file_uri(repo, "main.go")))
+        assert "func main" in contents[0].content
+        templates = asyncio.run(server_module.list_resource_templates())
+        assert {t.uriTemplate.split("://")[1].split("/")[0] for t in templates} == {"file", "symbols"}
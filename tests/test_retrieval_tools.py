@@ -336,6 +336,44 @@ def test_get_file_outline_batch_empty_list(tmp_path):
     assert result["results"] == []
 
 
+def test_get_file_outline_globs_and_directories(tmp_path):
+    """Globs and directories expand to indexed files; overlaps are deduped."""
+    src = tmp_path / "src"
+    (src / "store" / "sql").mkdir(parents=True)
+    (src / "api").mkdir()
+    (src / "main.go").write_text("package main\n\nfunc main() {}\n")
+    (src / "store" / "store.go").write_text("package store\n\nfunc Open() {}\n")
+    (src / "store" / "sql" / "query.go").write_text("package sql\n\nfunc Run() {}\n")
+    (src / "api" / "api.go").write_text("package api\n\nfunc Serve() {}\n")
+    (src / "api" / "notes.py").write_text("def note(): pass\n")
+    store = str(tmp_path / "idx")
+    repo = index_folder(path=str(src), use_ai_summaries=False, storage_path=store)["repo"]
+
+    def files(result):
+        return [r["file"] for r in result["results"]]
+
+    result = get_file_outline(repo=repo, file_path="**/*.go", storage_path=store)
+    assert files(result) == ["api/api.go", "main.go", "store/sql/query.go", "store/store.go"]
+    assert result["results"][1]["symbols"][0]["name"] == "main"
+    assert result["_meta"]["file_count"] == 4
+
+    # A directory walks its subdirectories.
+    assert files(get_file_outline(repo=repo, file_path="store", storage_path=store)) == [
+        "store/sql/query.go", "store/store.go",
+    ]
+
+    result = get_file_outline(
+        repo=repo,
+        file_paths=["store/", "store/**/*.go", "api/api.go", "api/*.py", "cmd/*.go"],
+        storage_path=store,
+    )
+    assert files(result) == ["store/sql/query.go", "store/store.go", "api/api.go", "api/notes.py"]
+    assert result["_meta"]["unmatched"] == ["cmd/*.go"]
+
+    # A plain file path keeps the singular shape.
+    assert get_file_outline(repo=repo, file_path="main.go", storage_path=store)["file"] == "main.go"
+
+
 def test_get_file_outline_both_params_raises(tmp_path):
     """Passing both file_path and file_paths raises ValueError."""
     src = tmp_path / "src"
@@ -0,0 +1,193 @@
+"""Tests for Rust symbol extraction: impl attachment, constants, docs, visibility."""
+
+from jcodemunch_mcp.parser import build_symbol_tree, parse_file
+from jcodemunch_mcp.parser.visibility import is_exported
+from jcodemunch_mcp.tools.describe_package import describe_package
+from jcodemunch_mcp.tools.get_file_outline import _flatten_tree_with_parents
+from tests.conftest_helpers import create_go_index
+
+RUST_SOURCE = '''//! Shape utilities.
+//!
+//! Everything here is allocation-free.
+
+use std::fmt;
+
+/// Upper bound on vertices.
+pub const MAX_VERTICES: usize = 64;
+
+static mut COUNTER: u32 = 0;
+
+/// A 2D point.
+#[derive(Debug, Clone)]
+pub struct Point {
+    pub x: f64,
+    pub y: f64,
+}
+
+/// Something with an area.
+pub trait Shape {
+    /// Area in square units.
+    fn area(&self) -> f64;
+
+    fn name(&self) -> String {
+        String::from("shape")
+    }
+}
+
+impl Point {
+    /// Origin point.
+    pub fn origin() -> Self {
+        Point { x: 0.0, y: 0.0 }
+    }
+
+    fn norm(&self) -> f64 {
+        (self.x * self.x + self.y * self.y).sqrt()
+    }
+
+    pub(crate) fn scale(&mut self, k: f64) {
+        self.x *= k;
+        self.y *= k;
+    }
+}
+
+impl fmt::Display for Point {
+    fn fmt(&self, f: &mut fmt::Formatter) -> fmt::Result {
+        write!(f, "({}, {})", self.x, self.y)
+    }
+}
+
+impl<T: Into<f64>> From<(T, T)> for Point {
+    fn from(p: (T, T)) -> Self {
+        Point { x: p.0.into(), y: p.1.into() }
+    }
+}
+
+macro_rules! square {
+    ($x:expr) => { $x * $x };
+}
+
+enum Kind { Open, Closed }
+
+fn helper() -> u32 {
+    square!(3)
+}
+'''
+
+
+def _symbols():
+    return parse_file(RUST_SOURCE, "src/lib.rs", "rust")
+
+
+def _by_qname(symbols, qname):
+    return next(s for s in symbols if s.qualified_name == qname)
+
+
+class TestRustSymbols:
+    def test_top_level_kinds(self):
+        kinds = {s.name: s.kind for s in _symbols() if s.parent is None and s.kind != "class"}
+        assert kinds == {
+            "MAX_VERTICES": "constant",
+            "COUNTER": "constant",
+            "Point": "type",
+            "Shape": "type",
+            "Kind": "type",
+            "helper": "function",
+        }
+
+    def test_impl_blocks_named_after_type(self):
+        impls = [s for s in _symbols() if s.kind == "class"]
+        assert [s.name for s in impls] == ["Point", "Point", "Point"]
+        assert [s.signature for s in impls] == [
+            "impl Point",
+            "impl fmt::Display for Point",
+            "impl<T: Into<f64>> From<(T, T)> for Point",
+        ]
+        # Same qualified name and kind: disambiguated like overloads.
+        assert len({s.id for s in impls}) == 3
+
+    def test_methods_attach_to_type(self):
+        symbols = _symbols()
+        for qname in ("Point.origin", "Point.norm", "Point.scale", "Point.fmt", "Point.from"):
+            method = _by_qname(symbols, qname)
+            assert method.kind == "method"
+            parent = next(s for s in symbols if s.id == method.parent)
+            assert parent.kind == "class" and parent.name == "Point"
+        # Each impl block keeps its own methods.
+        assert _by_qname(symbols, "Point.fmt").parent != _by_qname(symbols, "Point.origin").parent
+
+    def test_trait_methods(self):
+        symbols = _symbols()
+        area = _by_qname(symbols, "Shape.area")
+        assert area.kind == "method"
+        assert area.signature.startswith("fn area(&self) -> f64")
+        assert area.docstring == "Area in square units."
+        assert _by_qname(symbols, "Shape.name").kind == "method"
+
+    def test_doc_comments(self):
+        symbols = _symbols()
+        assert _by_qname(symbols, "Point").docstring == "A 2D point."
+        assert _by_qname(symbols, "Point").decorators == ["#[derive(Debug, Clone)]"]
+        assert _by_qname(symbols, "Point.origin").docstring == "Origin point."
+        assert _by_qname(symbols, "MAX_VERTICES").docstring == "Upper bound on vertices."
+
+    def test_inner_doc_not_attached_to_first_item(self):
+        source = "//! Crate docs.\nfn first() {}\n"
+        first = parse_file(source, "lib.rs", "rust")[0]
+        assert first.docstring == ""
+
+    def test_macros_skipped(self):
+        names = {s.name for s in _symbols()}
+        assert "square" not in names
+        assert "helper" in names
+
+
+class TestRustVisibility:
+    def _exported(self):
+        symbols = _symbols()
+        entries = _flatten_tree_with_parents(build_symbol_tree(symbols), language="rust")
+        by_id = {s.id: s.qualified_name for s in symbols}
+        # impl blocks share their type's name; they're checked separately.
+        return {by_id[e["id"]]: e.get("exported") for e in entries if e["kind"] != "class"}
+
+    def test_pub_drives_exported(self):
+        exported = self._exported()
+        assert exported["MAX_VERTICES"] is True
+        assert exported["COUNTER"] is False
+        assert exported["Point"] is True
+        assert exported["Kind"] is False
+        assert exported["helper"] is False
+        assert exported["Point.origin"] is True
+        assert exported["Point.norm"] is False
+
+    def test_restricted_pub_is_unexported(self):
+        assert self._exported()["Point.scale"] is False
+
+    def test_trait_and_trait_impl_members(self):
+        exported = self._exported()
+        assert exported["Shape.area"] is True
+        assert exported["Point.fmt"] is True
+        assert exported["Point.from"] is True
+
+    def test_impl_block_unclassified(self):
+        symbols = _symbols()
+        entries = _flatten_tree_with_parents(build_symbol_tree(symbols), language="rust")
+        assert all("exported" not in e for e in entries if e["kind"] == "class")
+        assert is_exported("Point", "rust", signature="impl Point") is None
+        assert is_exported("Point", "rust", signature="unsafe impl Send for Point") is None
+
+    def test_private_trait_members(self):
+        assert is_exported(
+            "run", "rust", nested=True, parent_exported=False,
+            signature="fn run(&self)", parent_signature="trait Job",
+        ) is False
+
+
+class TestRustPackageDoc:
+    def test_inner_doc_is_package_doc(self, tmp_path):
+        repo, store = create_go_index(tmp_path, {
+            "src/lib.rs": RUST_SOURCE,
+            "src/util.rs": "//! Helpers.\npub fn noop() {}\n",
+        })
+
+        pkg = describe_package(repo, "src", storage_path=store)
+        assert pkg["doc"] == "Shape utilities.\n\nEverything here is allocation-free."
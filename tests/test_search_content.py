@@ -0,0 +1,89 @@
+"""Tests for search_content (Go-regexp content search with context)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.search_content import _translate_re2, search_content
+from tests.conftest_helpers import create_go_index
+
+SERVER_GO = '''package server
+
+import "errors"
+
+var ErrTimeout = errors.New("dial timeout")
+
+// TODO: make this configurable
+const maxConns = 1024
+
+func Serve() error {
+\treturn errors.New("read timeout")
+}
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "server/server.go": SERVER_GO,
+        "cmd/main.go": 'package main\n\n// TODO: flags\nfunc main() {}\n',
+        "vendor/lib/lib.go": 'package lib\n\n// TODO: vendored\nfunc F() {}\n',
+        "notes.py": "# TODO one\n# TODO two\n# TODO three\n",
+    })
+
+
+def _search(repo, pattern, **kw):
+    repo_id, store = repo
+    return search_content(repo_id, pattern, storage_path=store, **kw)
+
+
+class TestTranslate:
+    def test_re2_spellings(self):
+        assert _translate_re2(r"\Qa.b\E+") == r"a\.b+"
+        assert _translate_re2(r"[[:digit:]_]+\z") == r"[0-9_]+\Z"
+
+    @pytest.mark.parametrize("pattern", [r"\pL+", r"(?U)a+", r"(a)\1", r"foo(?=bar)", r"(?<!x)y"])
+    def test_rejects_single_engine_constructs(self, pattern):
+        with pytest.raises(ValueError):
+            _translate_re2(pattern)
+
+
+class TestSearch:
+    def test_matches_with_column_and_context(self, repo):
+        result = _search(repo, r'errors\.New\("[^"]*timeout', context_lines=1)
+        assert [(m["file"], m["line"]) for m in result["matches"]] == [
+            ("server/server.go", 5),
+            ("server/server.go", 11),
+        ]
+        first = result["matches"][0]
+        assert first["column"] == SERVER_GO.split("\n")[4].index("errors") + 1
+        assert first["before"] == [""]
+        assert first["after"] == [""]
+        assert result["truncated"] is False
+
+    def test_case_sensitive_unless_flagged(self, repo):
+        assert _search(repo, "todo")["match_count"] == 0
+        assert _search(repo, "(?i)todo")["match_count"] == 5
+
+    def test_ignored_files_not_searched(self, repo):
+        files = {m["file"] for m in _search(repo, "TODO")["matches"]}
+        assert not any(f.startswith("vendor/") for f in files)
+
+    def test_paths_scope(self, repo):
+        result = _search(repo, "TODO", paths=["*.go"])
+        assert {m["file"] for m in result["matches"]} == {"cmd/main.go", "server/server.go"}
+        result = _search(repo, "TODO", paths=["cmd"])
+        assert [m["file"] for m in result["matches"]] == ["cmd/main.go"]
+
+    def test_max_results_truncates(self, repo):
+        result = _search(repo, "TODO", max_results=2)
+        assert result["match_count"] == 2
+        assert result["truncated"] is True
+
+    def test_max_per_file(self, repo):
+        result = _search(repo, "TODO", paths=["notes.py"], max_per_file=1)
+        assert result["match_count"] == 1
+        assert result["files_capped"] == 1
+        assert result["truncated"] is False
+
+    def test_invalid_pattern(self, repo):
+        assert "error" in _search(repo, "(unclosed")
+        assert "error" in _search(repo, r"(a)\1")
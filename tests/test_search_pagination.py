@@ -0,0 +1,88 @@
+"""Tests for offset paging (total_count / has_more) on search_symbols and search_text."""
+
+from jcodemunch_mcp.tools.search_symbols import search_symbols
+from jcodemunch_mcp.tools.search_text import search_text
+from tests.conftest_helpers import create_go_index
+
+
+def _seed_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        f"mod{i}.py": "".join(f"def widget_{i}_{j}():\n    return 'widget'\n\n" for j in range(3))
+        for i in range(3)
+    })
+
+
+def test_search_symbols_pages_do_not_overlap(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    first = search_symbols(repo=repo, query="widget", max_results=4, storage_path=storage)
+    second = search_symbols(repo=repo, query="widget", max_results=4, offset=4, storage_path=storage)
+    third = search_symbols(repo=repo, query="widget", max_results=4, offset=8, storage_path=storage)
+
+    assert first["total_count"] == 9
+    assert first["has_more"] is True and second["has_more"] is True
+    assert third["has_more"] is False
+    ids = [r["id"] for page in (first, second, third) for r in page["results"]]
+    assert len(ids) == len(set(ids)) == 9
+
+
+def test_search_symbols_paging_is_stable(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    a = search_symbols(repo=repo, query="widget", max_results=3, offset=3, storage_path=storage)
+    b = search_symbols(repo=repo, query="widget", max_results=3, offset=3, storage_path=storage)
+
+    assert [r["id"] for r in a["results"]] == [r["id"] for r in b["results"]]
+
+
+def test_search_symbols_ties_ordered_by_file_then_line(tmp_path):
+    repo, storage = create_go_index(tmp_path, {
+        name: "def gadget():\n    pass\n\n\nclass Holder:\n    def gadget(self):\n        pass\n"
+        for name in ("zeta.py", "alpha.py", "mid.py")
+    })
+
+    pages = [
+        search_symbols(repo=repo, query="gadget", kind="function", max_results=1,
+                       offset=i, storage_path=storage)["results"]
+        for i in range(3)
+    ]
+
+    assert [page[0]["file"] for page in pages] == ["alpha.py", "mid.py", "zeta.py"]
+
+
+def test_search_text_offset_pages(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    first = search_text(repo, "return 'widget'", max_results=5, storage_path=storage)
+    second = search_text(repo, "return 'widget'", max_results=5, offset=5, storage_path=storage)
+
+    assert first["total_count"] == 6 and first["total_count_exact"] is False
+    assert second["total_count"] == 9 and second["total_count_exact"] is True
+    assert first["result_count"] == 5 and first["has_more"] is True
+    assert second["result_count"] == 4 and second["has_more"] is False
+    seen = [(g["file"], m["line"]) for page in (first, second)
+            for g in page["results"] for m in g["matches"]]
+    assert seen == sorted(seen)
+    assert len(set(seen)) == 9
+
+
+def test_search_text_offset_past_end(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    result = search_text(repo, "return 'widget'", offset=50, storage_path=storage)
+
+    assert result["result_count"] == 0
+    assert result["total_count"] == 9
+    assert result["total_count_exact"] is True
+    assert result["has_more"] is False
+
+
+def test_search_text_count_all(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    result = search_text(repo, "return 'widget'", max_results=2, count_all=True, storage_path=storage)
+
+    assert result["result_count"] == 2
+    assert result["total_count"] == 9
+    assert result["total_count_exact"] is True
+    assert result["has_more"] is True
@@ -0,0 +1,85 @@
+"""Tests for sort_by="name": editor-style "go to symbol" ranking."""
+
+from jcodemunch_mcp.tools.search_symbols import _name_match_score, search_symbols
+from tests.conftest_helpers import create_go_index
+
+
+def _seed_repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "graph.py": (
+            "def get_call_graph():\n    pass\n\n"
+            "def graph():\n    pass\n\n"
+            "def graph_nodes():\n    pass\n\n"
+            "def call_graph():\n    pass\n"
+        ),
+        "shapes.py": (
+            "class Graph:\n    pass\n\n"
+            "def unrelated():\n    pass\n"
+        ),
+    })
+
+
+class TestNameMatchScore:
+    def test_tiers_are_ordered(self):
+        exact, _ = _name_match_score("graph", "graph")
+        prefix, _ = _name_match_score("graph_nodes", "graph")
+        substring, _ = _name_match_score("call_graph", "graph")
+        subsequence, _ = _name_match_score("get_call_graph", "gcg")
+        assert exact > prefix > substring > subsequence > 0
+
+    def test_match_types(self):
+        assert _name_match_score("graph", "graph")[1] == "exact"
+        assert _name_match_score("graph_nodes", "graph")[1] == "prefix"
+        assert _name_match_score("call_graph", "graph")[1] == "substring"
+        assert _name_match_score("get_call_graph", "gcg")[1] == "subsequence"
+        assert _name_match_score("unrelated", "gcg") == (0.0, "")
+
+    def test_case_handling(self):
+        assert _name_match_score("Graph", "graph")[1] == "exact"
+        assert _name_match_score("Graph", "graph")[0] < _name_match_score("graph", "graph")[0]
+        assert _name_match_score("Graph", "graph", case_sensitive=True) == (0.0, "")
+
+    def test_tighter_prefix_ranks_higher(self):
+        short, _ = _name_match_score("graph_x", "graph")
+        long, _ = _name_match_score("graph_nodes_long", "graph")
+        assert short > long
+
+
+def test_name_ranking_across_files(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    result = search_symbols(repo=repo, query="graph", sort_by="name", max_results=10,
+                            storage_path=storage)
+
+    names = [r["name"] for r in result["results"]]
+    assert names[:2] == ["graph", "Graph"]
+    assert names.index("graph_nodes") < names.index("call_graph")
+    assert "unrelated" not in names
+    for r in result["results"]:
+        assert {"score", "match_type", "file", "line", "kind"} <= set(r)
+
+
+def test_name_ranking_subsequence(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    result = search_symbols(repo=repo, query="gcg", sort_by="name", storage_path=storage)
+
+    assert result["results"][0]["name"] == "get_call_graph"
+    assert result["results"][0]["match_type"] == "subsequence"
+
+
+def test_name_ranking_case_sensitive(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    result = search_symbols(repo=repo, query="Graph", sort_by="name", case_sensitive=True,
+                            storage_path=storage)
+
+    assert [r["name"] for r in result["results"] if r["match_type"] != "fuzzy"] == ["Graph"]
+
+
+def test_invalid_sort_by_lists_name(tmp_path):
+    repo, storage = _seed_repo(tmp_path)
+
+    result = search_symbols(repo=repo, query="graph", sort_by="bogus", storage_path=storage)
+
+    assert "'name'" in result["error"]
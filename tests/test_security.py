@@ -13,6 +13,9 @@ from jcodemunch_mcp.security import (
     is_binary_extension,
     is_binary_content,
     is_binary_file,
+    is_generated_content,
+    is_generated_file,
+    get_exclude_generated,
     safe_decode,
     should_exclude_file,
     SECRET_PATTERNS,
@@ -23,6 +26,8 @@ from jcodemunch_mcp.security import (
     DEFAULT_MAX_FOLDER_FILES,
     MAX_FOLDER_FILES_ENV_VAR,
     get_max_folder_files,
+    DEFAULT_MAX_FILE_SIZE,
+    get_max_file_size,
     EXTRA_IGNORE_PATTERNS_ENV_VAR,
     get_extra_ignore_patterns,
     get_skip_directories,
@@ -207,6 +212,44 @@ class TestBinaryDetection:
         assert reason == "file_too_large"
 
 
+class TestGeneratedDetection:
+    @pytest.mark.parametrize("header", [
+        b"// Code generated by protoc-gen-go. DO NOT EDIT.\n",
+        b"// Code generated by MockGen. DO NOT EDIT.\r\n",
+        b"# Code generated by sqlc. DO NOT EDIT.\n",
+        b"/* Code generated by stringer; DO NOT EDIT. */\n",
+    ])
+    def test_generated_headers_detected(self, header):
+        assert is_generated_content(b"// Copyright 2024\n\n" + header + b"package pb\n") is True
+
+    def test_plain_source_not_generated(self):
+        assert is_generated_content(b"package main\n\nfunc main() {}\n") is False
+
+    def test_marker_outside_comment_not_generated(self):
+        data = b'const msg = "Code generated by x. DO NOT EDIT."\n'
+        assert is_generated_content(data) is False
+
+    def test_generated_file_detection(self, tmp_path):
+        gen = tmp_path / "api.pb.go"
+        gen.write_text("// Code generated by protoc-gen-go. DO NOT EDIT.\npackage api\n")
+        assert is_generated_file(gen) is True
+        assert is_generated_file(tmp_path / "missing.go") is False
+
+    def test_exclude_generated_config(self):
+        from jcodemunch_mcp import config as config_module
+
+        orig_config = config_module._GLOBAL_CONFIG.copy()
+        config_module._GLOBAL_CONFIG.clear()
+        try:
+            assert get_exclude_generated() is False
+            config_module._GLOBAL_CONFIG["exclude_generated"] = True
+            assert get_exclude_generated() is True
+            assert get_exclude_generated(False) is False
+        finally:
+            config_module._GLOBAL_CONFIG.clear()
+            config_module._GLOBAL_CONFIG.update(orig_config)
+
+
 # --- Encoding Safety (S-06) ---
 
 class TestEncodingSafety:
@@ -585,6 +628,156 @@ class TestDiscoverLocalFilesSecure:
         assert "real.py" in names
         assert "link.py" not in names
 
+    @pytest.mark.skipif(sys.platform == "win32", reason="Symlinks unreliable on Windows")
+    def test_symlink_cycle_walked_once(self, tmp_path):
+        """A directory link back to an ancestor is pruned instead of looping."""
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        pkg = tmp_path / "pkg"
+        pkg.mkdir()
+        (pkg / "api.py").write_text("x = 1\n")
+        (pkg / "loop").symlink_to(tmp_path, target_is_directory=True)
+
+        files, _, skip_counts = discover_local_files(tmp_path, follow_symlinks=True)
+        assert [f.resolve().relative_to(tmp_path.resolve()).as_posix() for f in files] == ["pkg/api.py"]
+        assert skip_counts["symlink_cycle"] == 1
+
+    @pytest.mark.skipif(sys.platform == "win32", reason="Symlinks unreliable on Windows")
+    def test_symlinked_dir_indexed_once_under_real_path(self, tmp_path):
+        """Linked in-root directories are followed by default without duplicates."""
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        shared = tmp_path / "shared"
+        shared.mkdir()
+        (shared / "util.py").write_text("x = 1\n")
+        (tmp_path / "app").mkdir()
+        (tmp_path / "app" / "shared").symlink_to(shared, target_is_directory=True)
+        (tmp_path / "alias.py").symlink_to(shared / "util.py")
+
+        files, warnings, _ = discover_local_files(tmp_path)
+        real = [f.resolve().relative_to(tmp_path.resolve()).as_posix() for f in files]
+        assert real == ["shared/util.py"]
+        assert warnings == []
+
+    @pytest.mark.skipif(sys.platform == "win32", reason="Symlinks unreliable on Windows")
+    def test_symlinked_dir_outside_root_reported(self, tmp_path):
+        """Linked directories that leave the root are skipped with a warning."""
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        outside = tmp_path / "outside"
+        outside.mkdir()
+        (outside / "leak.py").write_text("x = 1\n")
+        root = tmp_path / "root"
+        root.mkdir()
+        (root / "main.py").write_text("x = 1\n")
+        (root / "vendor").symlink_to(outside, target_is_directory=True)
+
+        files, warnings, skip_counts = discover_local_files(root)
+        assert [f.name for f in files] == ["main.py"]
+        assert skip_counts["symlink_escape"] == 1
+        assert any("vendor" in w for w in warnings)
+
+    @pytest.mark.skipif(sys.platform == "win32", reason="Symlinks unreliable on Windows")
+    def test_follow_symlinks_config_off(self, tmp_path):
+        """follow_symlinks=false in config stops discovery at symlinks."""
+        from jcodemunch_mcp import config as config_module
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        (tmp_path / "real.py").write_text("x = 1\n")
+        (tmp_path / "link.py").symlink_to(tmp_path / "real.py")
+        orig = dict(config_module._GLOBAL_CONFIG)
+        try:
+            config_module._GLOBAL_CONFIG["follow_symlinks"] = False
+            _, _, skip_counts = discover_local_files(tmp_path)
+        finally:
+            config_module._GLOBAL_CONFIG.clear()
+            config_module._GLOBAL_CONFIG.update(orig)
+        assert skip_counts["symlink"] == 1
+
+    def test_nested_gitignore_scoped_to_its_directory(self, tmp_path):
+        """A nested .gitignore applies only beneath its own directory."""
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        sub = tmp_path / "pkg"
+        sub.mkdir()
+        (sub / ".gitignore").write_text("gen_*.py\n")
+        (sub / "gen_api.py").write_text("x = 1\n")
+        (sub / "api.py").write_text("x = 1\n")
+        (tmp_path / "gen_root.py").write_text("x = 1\n")
+
+        files, _, skip_counts = discover_local_files(tmp_path)
+        rel_paths = {f.relative_to(tmp_path).as_posix() for f in files}
+        assert rel_paths == {"pkg/api.py", "gen_root.py"}
+        assert skip_counts["gitignore"] == 1
+
+    def test_fast_path_sees_nested_gitignore(self, tmp_path):
+        """The watcher fast path collects .gitignore specs along the ancestor chain."""
+        from jcodemunch_mcp.tools.index_folder import _ancestor_gitignore_specs, _is_gitignored_fast
+
+        root = tmp_path.resolve()
+        (root / ".gitignore").write_text("*.log\n")
+        (root / "pkg" / "gen").mkdir(parents=True)
+        (root / "pkg" / ".gitignore").write_text("gen/\n")
+        target = root / "pkg" / "gen" / "api.py"
+
+        cache = {}
+        specs = _ancestor_gitignore_specs(root, target, cache)
+        assert len(specs) == 2
+        assert _is_gitignored_fast(str(target), specs) is True
+        assert _is_gitignored_fast(str(root / "api.py"), specs) is False
+        assert str(root / "pkg" / "gen") in cache  # negative lookups are cached too
+
+    def test_exclude_generated(self, tmp_path):
+        """Files with a generated-code header are skipped only when asked."""
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        (tmp_path / "api.go").write_text("package api\n")
+        (tmp_path / "api.pb.go").write_text(
+            "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n"
+        )
+
+        files, *_ = discover_local_files(tmp_path)
+        assert {f.name for f in files} == {"api.go", "api.pb.go"}
+
+        files, _, skip_counts = discover_local_files(tmp_path, exclude_generated=True)
+        assert {f.name for f in files} == {"api.go"}
+        assert skip_counts["generated"] == 1
+
+    def test_oversize_and_binary_reported(self, tmp_path):
+        """Oversize and NUL-containing files are skipped with a warning each."""
+        from jcodemunch_mcp.tools.index_folder import discover_local_files
+
+        (tmp_path / "app.js").write_text("function ok() {}\n")
+        (tmp_path / "vendor_lib.js").write_text("var a=1;" * 200)
+        (tmp_path / "blob.py").write_bytes(b"x = 1\x00\x01\x02")
+
+        files, warnings, skip_counts = discover_local_files(tmp_path, max_size=1000)
+        assert {f.name for f in files} == {"app.js"}
+        assert skip_counts["too_large"] == 1
+        assert skip_counts["binary"] == 1
+        assert any("oversize" in w and "vendor_lib.js" in w for w in warnings)
+        assert any("binary" in w and "blob.py" in w for w in warnings)
+
+    def test_explicit_paths_skip_binary(self, tmp_path):
+        from jcodemunch_mcp.tools.index_folder import resolve_explicit_paths
+
+        (tmp_path / "blob.py").write_bytes(b"x = 1\x00")
+        files, warnings, skip_counts = resolve_explicit_paths(tmp_path.resolve(), ["blob.py"], max_files=None)
+        assert files == []
+        assert skip_counts["binary"] == 1
+
+    def test_explicit_paths_count_generated(self, tmp_path):
+        from jcodemunch_mcp.tools.index_folder import resolve_explicit_paths
+
+        (tmp_path / "api.go").write_text("package api\n")
+        (tmp_path / "api.pb.go").write_text("// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n")
+        files, warnings, skip_counts = resolve_explicit_paths(
+            tmp_path.resolve(), ["api.go", "api.pb.go"], max_files=None, exclude_generated=True,
+        )
+        assert [f.name for f in files] == ["api.go"]
+        assert skip_counts["generated"] == 1
+        assert warnings == []
+
 
 # --- Index repo secret filtering ---
 
@@ -730,6 +923,24 @@ class TestSecurityConfigIntegration:
         result = get_max_folder_files(max_files=1000)
         assert result == 1000
 
+    def test_get_max_file_size_uses_config(self):
+        """max_file_size config replaces the 500KB default."""
+        from jcodemunch_mcp import config as config_module
+
+        orig_config = config_module._GLOBAL_CONFIG.copy()
+        config_module._GLOBAL_CONFIG.clear()
+
+        try:
+            assert get_max_file_size() == DEFAULT_MAX_FILE_SIZE
+            config_module._GLOBAL_CONFIG["max_file_size"] = 2_000_000
+            assert get_max_file_size() == 2_000_000
+            assert get_max_file_size(4096) == 4096
+            with pytest.raises(ValueError):
+                get_max_file_size(0)
+        finally:
+            config_module._GLOBAL_CONFIG.clear()
+            config_module._GLOBAL_CONFIG.update(orig_config)
+
 
 class TestExcludeSkipDirectories:
     """Tests for the exclude_skip_directories config key."""
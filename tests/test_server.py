@@ -21,30 +21,34 @@ async def test_server_lists_all_tools():
     try:
         tools = await list_tools()
 
-        assert len(tools) == 81  # +1: list_workspaces (monorepo enumeration, v1.108.0)
+        # 81 + 17: read_file_range, search_content, get_parse_errors, describe_package,
+        # summarize_symbol, list_todos, project_stats, server_info, get_dependencies,
+        # get_type_hierarchy, get_call_graph, get_complexity, git_blame,
+        # format_check, get_test_coverage_map, rename_preview, locate_definition
+        assert len(tools) == 98
 
         names = {t.name for t in tools}
         expected = {
-            "index_repo", "index_folder", "index_file", "summarize_repo", "list_repos", "resolve_repo",
-            "get_file_tree", "get_file_outline", "get_file_content", "get_symbol_source",
-            "search_symbols", "invalidate_cache", "search_text", "get_repo_outline",
-            "find_importers", "find_references", "check_references", "search_columns", "get_context_bundle",
+            "index_repo", "index_folder", "index_file", "summarize_repo", "list_repos", "server_info", "resolve_repo",
+            "get_file_tree", "get_file_outline", "describe_package", "get_file_content", "read_file_range", "get_symbol_source", "summarize_symbol",
+            "search_symbols", "invalidate_cache", "search_text", "search_content", "list_todos", "get_repo_outline", "project_stats",
+            "find_importers", "find_references", "locate_definition", "check_references", "search_columns", "get_context_bundle",
             "get_session_stats", "get_session_context", "get_session_snapshot", "plan_turn", "register_edit",
-            "get_dependency_graph", "get_blast_radius",
-            "get_symbol_diff", "get_class_hierarchy", "get_related_symbols", "suggest_queries",
+            "get_dependency_graph", "get_dependencies", "get_blast_radius",
+            "get_symbol_diff", "get_class_hierarchy", "get_type_hierarchy", "get_related_symbols", "suggest_queries",
             "get_symbol_importance", "get_repo_map", "find_similar_symbols", "find_dead_code",
             "get_changed_symbols", "get_ranked_context", "assemble_task_context", "embed_repo",
             "get_cross_repo_map", "get_group_contracts",
-            "get_call_hierarchy", "get_impact_preview",
+            "get_call_hierarchy", "get_call_graph", "get_impact_preview",
             "get_dependency_cycles", "get_coupling_metrics", "get_layer_violations",
-            "check_rename_safe", "check_delete_safe", "find_implementations",
+            "check_rename_safe", "rename_preview", "check_delete_safe", "find_implementations",
             "get_dead_code_v2", "get_extraction_candidates",
             "plan_refactoring",
-            "get_symbol_complexity", "get_churn_rate", "get_hotspots", "get_repo_health",
-            "audit_agent_config", "get_untested_symbols", "search_ast",
+            "get_symbol_complexity", "get_complexity", "get_churn_rate", "get_hotspots", "get_repo_health",
+            "audit_agent_config", "get_untested_symbols", "search_ast", "get_parse_errors", "format_check", "get_test_coverage_map",
             "get_tectonic_map", "get_signal_chains", "render_diagram",
             "get_project_intel", "list_workspaces",
-            "get_symbol_provenance", "get_pr_risk_profile",
+            "get_symbol_provenance", "git_blame", "get_pr_risk_profile",
             "winnow_symbols", "get_watch_status", "analyze_perf", "tune_weights",
             "check_embedding_drift",
             "set_tool_tier", "announce_model", "jcodemunch_guide",
@@ -150,7 +154,7 @@ async def test_call_tool_defaults_index_folder_incremental_true():
         use_ai_summaries=True,
         storage_path=None,
         extra_ignore_patterns=None,
-        follow_symlinks=False,
+        follow_symlinks=None,
         incremental=True,
         paths=None,
         identity_mode="config",
@@ -672,10 +676,10 @@ async def test_disabled_tools_filtered_from_schema(monkeypatch):
         assert "index_repo" not in tool_names
         assert "search_columns" not in tool_names
         assert "get_file_tree" in tool_names  # Not disabled
-        # 81 default tools + test_summarizer (config cleared) - 2 disabled = 80
+        # 98 default tools + test_summarizer (config cleared) - 2 disabled = 97
         # set_tool_tier + announce_model are undisableable; jcodemunch_guide
         # is in _ALWAYS_PRESENT_TOOLS for tier survival but honors disabled_tools.
-        assert len(tools) == 80
+        assert len(tools) == 97
     finally:
         config_module._GLOBAL_CONFIG.clear()
         config_module._GLOBAL_CONFIG.update(orig_config)
@@ -683,7 +687,7 @@ async def test_disabled_tools_filtered_from_schema(monkeypatch):
 
 @pytest.mark.asyncio
 async def test_disabled_tools_empty_all_tools_present(monkeypatch):
-    """When disabled_tools is empty, all 81 tools are present (80 + test_summarizer)."""
+    """When disabled_tools is empty, all 99 tools are present (98 + test_summarizer)."""
     from jcodemunch_mcp import config as config_module
 
     orig_config = config_module._GLOBAL_CONFIG.copy()
@@ -693,7 +697,7 @@ async def test_disabled_tools_empty_all_tools_present(monkeypatch):
         config_module._GLOBAL_CONFIG["disabled_tools"] = []
 
         tools = await list_tools()
-        assert len(tools) == 82  # 81 + test_summarizer (config cleared, so disabled gate off)
+        assert len(tools) == 99  # 98 + test_summarizer (config cleared, so disabled gate off)
     finally:
         config_module._GLOBAL_CONFIG.clear()
         config_module._GLOBAL_CONFIG.update(orig_config)
@@ -0,0 +1,48 @@
+"""Tests for server_info (capabilities, languages, and index statistics)."""
+
+import pytest
+
+from jcodemunch_mcp import __version__
+from jcodemunch_mcp.storage.index_store import INDEX_VERSION
+from jcodemunch_mcp.tools.server_info import server_info
+from tests.conftest_helpers import create_go_index
+
+
+@pytest.fixture
+def store(tmp_path):
+    return create_go_index(tmp_path, {
+        "app.py": "def main():\n    return 1\n\n\ndef helper():\n    return 2\n",
+        "util.go": "package util\n\nfunc Add(a, b int) int { return a + b }\n",
+    })
+
+
+def test_versions_and_tools(tmp_path):
+    result = server_info(["search_symbols", "list_repos"], tier="core", storage_path=str(tmp_path))
+    assert result["version"] == __version__
+    assert result["index_version"] == INDEX_VERSION
+    assert result["tier"] == "core"
+    assert result["tools"] == ["list_repos", "search_symbols"]
+    assert result["tool_count"] == 2
+    assert result["index"]["repo_count"] == 0
+    assert result["index"]["last_indexed_at"] == ""
+    assert set(result["parse_cache"]) >= {"entries", "bytes", "max_bytes", "hits", "misses", "evictions"}
+
+
+def test_languages_and_extensions(tmp_path):
+    result = server_info([], storage_path=str(tmp_path))
+    langs = {entry["language"]: entry["extensions"] for entry in result["languages"]}
+    assert ".py" in langs["python"]
+    assert ".go" in langs["go"]
+    assert ".py" in result["extensions"]
+    assert result["extensions"] == sorted(result["extensions"])
+
+
+def test_index_statistics(store):
+    repo, path = store
+    index = server_info([], storage_path=path)["index"]
+    assert index["repo_count"] == 1
+    assert index["file_count"] == 2
+    assert index["symbol_count"] >= 3
+    assert index["last_indexed_at"] == index["repos"][0]["indexed_at"]
+    assert server_info([], repo=repo, storage_path=path)["index"]["repos"][0]["repo"] == repo
+    assert "error" in server_info([], repo="no-such-repo", storage_path=path)
@@ -0,0 +1,107 @@
+"""Tests for structured function parameters and results (Symbol.params / returns)."""
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from jcodemunch_mcp.tools.get_symbol import get_symbol_source
+from tests.conftest_helpers import create_go_index
+
+SERVER_GO = '''package server
+
+type Server struct{}
+
+func New(addr string, opts ...Option) *Server { return nil }
+
+func Dial(a, b int, _ string, m map[string][]*Conn) (conn *Conn, err error) { return nil, nil }
+
+func (s *Server) Serve(int, bool) error { return nil }
+
+func (s *Server) Close() {}
+
+func Map[T, U any](xs []T, f func(T) U) []U { return nil }
+'''
+
+JOBS_PY = '''class Worker:
+    def run(self, job: "Job", retries: int = 3, *args, timeout=None, **kwargs) -> bool:
+        return True
+
+
+@staticmethod
+def build(name, /, *, strict: bool = False):
+    pass
+'''
+
+
+def _by_name(symbols):
+    return {s.name: s for s in symbols}
+
+
+class TestGo:
+    def setup_method(self):
+        self.syms = _by_name(parse_file(SERVER_GO, "server/server.go", "go"))
+
+    def test_variadic_and_unnamed_result(self):
+        new = self.syms["New"]
+        assert new.params == [
+            {"name": "addr", "type": "string"},
+            {"name": "opts", "type": "Option", "variadic": True},
+        ]
+        assert new.returns == [{"name": "", "type": "*Server"}]
+
+    def test_shared_type_blank_and_named_results(self):
+        dial = self.syms["Dial"]
+        assert dial.params == [
+            {"name": "a", "type": "int"},
+            {"name": "b", "type": "int"},
+            {"name": "_", "type": "string"},
+            {"name": "m", "type": "map[string][]*Conn"},
+        ]
+        assert dial.returns == [{"name": "conn", "type": "*Conn"}, {"name": "err", "type": "error"}]
+
+    def test_methods_exclude_receiver(self):
+        serve = self.syms["Serve"]
+        assert serve.params == [{"name": "", "type": "int"}, {"name": "", "type": "bool"}]
+        assert serve.returns == [{"name": "", "type": "error"}]
+        assert self.syms["Close"].params == [] and self.syms["Close"].returns == []
+
+    def test_generic_function(self):
+        assert self.syms["Map"].params == [
+            {"name": "xs", "type": "[]T"},
+            {"name": "f", "type": "func(T) U"},
+        ]
+
+    def test_types_have_none(self):
+        assert self.syms["Server"].params == []
+
+
+class TestPython:
+    def test_method_params(self):
+        run = _by_name(parse_file(JOBS_PY, "jobs.py", "python"))["run"]
+        assert run.params == [
+            {"name": "self", "type": ""},
+            {"name": "job", "type": '"Job"'},
+            {"name": "retries", "type": "int", "default": "3"},
+            {"name": "args", "type": "", "variadic": True},
+            {"name": "timeout", "type": "", "keyword_only": True, "default": "None"},
+            {"name": "kwargs", "type": "", "keyword_variadic": True},
+        ]
+        assert run.returns == [{"name": "", "type": "bool"}]
+
+    def test_separators_and_decorated(self):
+        build = _by_name(parse_file(JOBS_PY, "jobs.py", "python"))["build"]
+        assert build.params == [
+            {"name": "name", "type": ""},
+            {"name": "strict", "type": "bool", "keyword_only": True, "default": "False"},
+        ]
+        assert build.returns == []
+
+
+def test_outline_and_source_round_trip(tmp_path):
+    repo, store = create_go_index(tmp_path, {"server/server.go": SERVER_GO})
+
+    outline = get_file_outline(repo, "server/server.go", storage_path=store)
+    syms = {s["name"]: s for s in outline["symbols"]}
+    assert syms["Dial"]["returns"][1] == {"name": "err", "type": "error"}
+    assert "params" not in syms["Close"]
+
+    result = get_symbol_source(repo, symbol_id=syms["New"]["id"], storage_path=store)
+    assert result["params"][1] == {"name": "opts", "type": "Option", "variadic": True}
@@ -4,7 +4,9 @@ import sqlite3
 import shutil
 from pathlib import Path
 
-from jcodemunch_mcp.storage.sqlite_store import SQLiteIndexStore
+import pytest
+
+from jcodemunch_mcp.storage.sqlite_store import _V17_SYMBOL_COLUMNS, SQLiteIndexStore, _migrate_v16_to_v17
 from jcodemunch_mcp.parser.symbols import Symbol
 
 
@@ -731,6 +733,39 @@ def test_v4_to_v5_migration(tmp_path):
     conn2.close()
 
 
+@pytest.mark.parametrize("column", [c for c, _ in _V17_SYMBOL_COLUMNS])
+def test_v16_to_v17_migration(tmp_path, column):
+    """v17 adds each parse-time column and forces every file to re-extract."""
+    store = SQLiteIndexStore(base_path=str(tmp_path))
+    store.save_index(
+        owner="local", name="v16-test",
+        source_files=["a.py"], symbols=[_make_symbol("f", "a.py")],
+        raw_files={"a.py": "x"}, file_hashes={"a.py": "ha"}, file_mtimes={"a.py": 100},
+    )
+    db_path = store._db_path("local", "v16-test")
+    conn = sqlite3.connect(str(db_path))
+    for name, _ in _V17_SYMBOL_COLUMNS:
+        conn.execute(f"ALTER TABLE symbols DROP COLUMN {name}")
+    conn.execute("UPDATE meta SET value = '16' WHERE key = 'index_version'")
+    conn.commit()
+    conn.close()
+    SQLiteIndexStore._initialized_dbs.discard(str(db_path))
+
+    # Unchanged mtime and hash would normally skip a.py; after the upgrade it re-extracts.
+    changed, new, deleted, _, _ = store.detect_changes_with_mtimes(
+        "local", "v16-test", current_mtimes={"a.py": 100}, hash_fn=lambda fp: "ha",
+    )
+    assert (changed, new, deleted) == (["a.py"], [], [])
+
+    conn = sqlite3.connect(str(db_path))
+    _migrate_v16_to_v17(conn)  # idempotent
+    cols = {r[1] for r in conn.execute("PRAGMA table_info(symbols)")}
+    version = conn.execute("SELECT value FROM meta WHERE key = 'index_version'").fetchone()[0]
+    conn.close()
+    assert column in cols
+    assert version == "17"
+
+
 def test_v5_schema_no_json_in_data(tmp_path):
     """New indexes written with v5 schema have data=NULL and new columns populated."""
     store = SQLiteIndexStore(base_path=str(tmp_path))
@@ -893,3 +928,48 @@ def test_cross_process_cache_invalidation(tmp_path):
     )
     assert any(s["name"] == "g" for s in idx2.symbols)
 
+
+def test_load_index_discards_corrupt_database(tmp_path):
+    """A DB that is not SQLite is moved aside so the next save rebuilds it."""
+    store = SQLiteIndexStore(base_path=str(tmp_path))
+    db_path = store._db_path("local", "garbage")
+    db_path.write_text("not a sqlite database", encoding="utf-8")
+    Path(str(db_path) + "-wal").write_bytes(b"stale")
+
+    assert store.load_index("local", "garbage") is None
+    assert not db_path.exists()
+    assert not Path(str(db_path) + "-wal").exists()
+    assert Path(str(db_path) + ".corrupt").read_text(encoding="utf-8") == "not a sqlite database"
+
+    store.save_index(
+        owner="local", name="garbage",
+        source_files=["a.py"], symbols=[_make_symbol("f", file="a.py")],
+        raw_files={"a.py": "x"},
+    )
+    index = store.load_index("local", "garbage")
+    assert index is not None
+    assert [s["name"] for s in index.symbols] == ["f"]
+
+
+def test_corrupt_database_connection_is_closed(tmp_path, monkeypatch):
+    """The connection opened on a corrupt file is closed before it is moved aside."""
+    opened = []
+    real_connect = sqlite3.connect
+
+    def tracking_connect(*args, **kwargs):
+        conn = real_connect(*args, **kwargs)
+        opened.append(conn)
+        return conn
+
+    monkeypatch.setattr(sqlite3, "connect", tracking_connect)
+    store = SQLiteIndexStore(base_path=str(tmp_path))
+    store._db_path("local", "garbage").write_text("not a sqlite database", encoding="utf-8")
+
+    assert store.load_index("local", "garbage") is None
+    assert opened
+    for conn in opened:
+        try:
+            conn.execute("SELECT 1")
+        except sqlite3.ProgrammingError:
+            continue
+        raise AssertionError("connection left open")
@@ -0,0 +1,82 @@
+"""Tests for struct field extraction (Symbol.fields) and its storage round-trip."""
+
+from jcodemunch_mcp.parser import parse_file
+from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+from tests.conftest_helpers import create_go_index
+
+GO_STRUCTS = '''package model
+
+type Base struct{ ID int }
+
+type User struct {
+\tBase
+\t*pkg.Audit `json:"-"`
+\tID, Legacy int64
+\tName  string            `json:"name" db:"user_name"`
+\tIndex map[string][]*Foo `json:"index,omitempty"`
+\tMeta  struct {
+\t\tCreated string `json:"created"`
+\t}
+}
+
+type Empty struct{}
+
+type ID int
+'''
+
+
+def _fields(name):
+    symbols = parse_file(GO_STRUCTS, "model.go", "go")
+    return next(s for s in symbols if s.name == name).fields
+
+
+class TestGoStructFields:
+    def test_named_fields_with_tags(self):
+        by_name = {f["name"]: f for f in _fields("User")}
+        assert by_name["Name"]["type"] == "string"
+        assert by_name["Name"]["tag"] == 'json:"name" db:"user_name"'
+        assert by_name["Name"]["embedded"] is False
+
+    def test_type_is_source_text(self):
+        by_name = {f["name"]: f for f in _fields("User")}
+        assert by_name["Index"]["type"] == "map[string][]*Foo"
+
+    def test_multiple_names_share_type(self):
+        by_name = {f["name"]: f for f in _fields("User")}
+        assert by_name["ID"]["type"] == by_name["Legacy"]["type"] == "int64"
+        assert by_name["ID"]["line"] == by_name["Legacy"]["line"]
+
+    def test_embedded_fields(self):
+        fields = _fields("User")
+        embedded = [f for f in fields if f["embedded"]]
+        assert [(f["name"], f["type"], f["tag"]) for f in embedded] == [
+            ("Base", "Base", ""),
+            ("Audit", "*pkg.Audit", 'json:"-"'),
+        ]
+
+    def test_anonymous_struct_nests_fields(self):
+        meta = next(f for f in _fields("User") if f["name"] == "Meta")
+        assert meta["type"].startswith("struct {")
+        assert meta["fields"] == [{
+            "name": "Created", "type": "string", "tag": 'json:"created"',
+            "embedded": False, "line": meta["line"] + 1,
+        }]
+
+    def test_declaration_order(self):
+        names = [f["name"] for f in _fields("User")]
+        assert names == ["Base", "Audit", "ID", "Legacy", "Name", "Index", "Meta"]
+
+    def test_non_struct_types_have_no_fields(self):
+        assert _fields("Empty") == []
+        assert _fields("ID") == []
+
+
+class TestStructFieldsStorage:
+    def test_round_trip_through_index(self, tmp_path):
+        repo, store = create_go_index(tmp_path, {"model.go": GO_STRUCTS})
+
+        outline = get_file_outline(repo, "model.go", storage_path=store)
+        user = next(s for s in outline["symbols"] if s["name"] == "User")
+        assert {f["name"] for f in user["fields"]} >= {"Base", "Name", "Meta"}
+        base = next(s for s in outline["symbols"] if s["name"] == "Base")
+        assert base["fields"] == [{"name": "ID", "type": "int", "tag": "", "embedded": False, "line": 3}]
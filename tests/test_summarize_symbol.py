@@ -0,0 +1,87 @@
+"""Tests for summarize_symbol (doc + signature + first lines of one symbol)."""
+
+import pytest
+
+from jcodemunch_mcp.tools.summarize_symbol import summarize_symbol
+from tests.conftest_helpers import create_go_index
+
+STORE_GO = '''package store
+
+// Store keeps rows in memory.
+type Store struct {
+\trows map[string]string
+}
+
+// Load reads every row for key. It returns an error when key is empty.
+//
+// Deprecated: use LoadContext.
+func (s *Store) Load(key string, limit int) (rows []string, err error) {
+\tif key == "" {
+\t\treturn nil, nil
+\t}
+\tfor k, v := range s.rows {
+\t\tif k == key {
+\t\t\trows = append(rows, v)
+\t\t}
+\t}
+\treturn rows, nil
+}
+
+func (c *Cache) Get() {}
+'''
+
+JOBS_PY = '''class Worker:
+    def run(self, job):
+        """Run one job."""
+        return job
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {"store/store.go": STORE_GO, "jobs.py": JOBS_PY})
+
+
+def _summary(repo, symbol_id, **kw):
+    repo_id, store = repo
+    return summarize_symbol(repo_id, symbol_id=symbol_id, storage_path=store, **kw)
+
+
+def test_go_method_preview(repo):
+    result = _summary(repo, "store/store.go::Load#method", max_lines=4)
+    assert "error" not in result
+    assert result["summary"] == "Load reads every row for key."
+    assert result["deprecated"] == "use LoadContext."
+    assert result["params"] == [{"name": "key", "type": "string"}, {"name": "limit", "type": "int"}]
+    assert result["returns"][1] == {"name": "err", "type": "error"}
+    assert result["enclosing"] == {"id": "store/store.go::Store#type", "name": "Store", "kind": "type"}
+    assert result["package"] == "store"
+    assert result["source"].split("\n")[0].startswith("func (s *Store) Load(")
+    assert len(result["source"].split("\n")) == 4
+    assert result["total_lines"] == 11
+    assert result["truncated"] is True
+
+
+def test_short_symbol_returned_whole(repo):
+    result = _summary(repo, "store/store.go::Load#method", max_lines=11)
+    assert result["truncated"] is False
+    assert result["source"].rstrip().endswith("}")
+
+
+def test_receiver_type_not_indexed(repo):
+    result = _summary(repo, "store/store.go::Get#method")
+    assert result["enclosing"] == {"name": "Cache"}
+
+
+def test_python_method(repo):
+    result = _summary(repo, "jobs.py::Worker.run#method")
+    assert result["summary"] == "Run one job."
+    assert result["enclosing"]["name"] == "Worker"
+    assert result["package"] == "jobs"
+    assert result["truncated"] is False
+
+
+def test_errors(repo):
+    assert "error" in _summary(repo, "nope.go::X#function")
+    repo_id, store = repo
+    assert "error" in summarize_symbol(repo_id, storage_path=store)
@@ -0,0 +1,109 @@
+"""Tests for get_test_coverage_map (Go tests -> production symbols)."""
+
+import shutil
+
+import pytest
+
+from jcodemunch_mcp.tools.get_test_coverage_map import get_test_coverage_map
+from tests.conftest_helpers import create_go_index
+
+STORE_GO = '''package store
+
+type Store struct{ rows map[string]string }
+
+func (s *Store) Load(key string) string {
+\treturn decode(s.rows[key])
+}
+
+func decode(v string) string {
+\treturn v
+}
+
+func Save(s *Store, key, v string) {
+\ts.rows[key] = v
+}
+
+func Unused() {}
+'''
+
+STORE_TEST_GO = '''package store
+
+import "testing"
+
+func newStore() *Store {
+\ts := &Store{rows: map[string]string{}}
+\tSave(s, "a", "1")
+\treturn s
+}
+
+func TestLoad(t *testing.T) {
+\ts := newStore()
+\tif s.Load("a") != "1" {
+\t\tt.Fatal("bad")
+\t}
+}
+
+func testable() {}
+'''
+
+
+@pytest.fixture
+def repo(tmp_path):
+    return create_go_index(tmp_path, {
+        "store/store.go": STORE_GO,
+        "store/store_test.go": STORE_TEST_GO,
+    }, module="example.com/app")
+
+
+def _by_name(result):
+    return {s["name"]: s for s in result["symbols"]}
+
+
+def test_direct_and_helper_calls(repo):
+    repo_id, store = repo
+    result = get_test_coverage_map(repo_id, "store", storage_path=store)
+    assert "error" not in result
+    assert [t["name"] for t in result["tests"]] == ["TestLoad"]
+    syms = _by_name(result)
+    assert syms["Load"]["tests"] == [{"name": "TestLoad", "depth": 1}]
+    assert syms["Save"]["tests"] == [{"name": "TestLoad", "depth": 2}]  # via newStore
+    assert syms["decode"]["covered"] is True
+    assert syms["Unused"]["covered"] is False
+    assert "newStore" not in syms  # test helpers are not production symbols
+    assert result["symbol_count"] == 4
+    assert result["uncovered_count"] == 1
+
+
+def test_depth_one_and_uncovered_only(repo):
+    repo_id, store = repo
+    direct = _by_name(get_test_coverage_map(repo_id, "store", depth=1, storage_path=store))
+    assert direct["Load"]["covered"] is True
+    assert direct["Save"]["covered"] is False
+    uncovered = get_test_coverage_map(repo_id, "store", uncovered_only=True, storage_path=store)
+    assert [s["name"] for s in uncovered["symbols"]] == ["Unused"]
+
+
+def test_import_path_and_unknown_package(repo):
+    repo_id, store = repo
+    result = get_test_coverage_map(repo_id, "example.com/app/store", storage_path=store)
+    assert result["package"] == "store"
+    assert result["import_path"] == "example.com/app/store"
+    assert "error" in get_test_coverage_map(repo_id, "nope", storage_path=store)
+
+
+def test_go_test_without_go(repo, monkeypatch):
+    repo_id, store = repo
+    monkeypatch.setattr("jcodemunch_mcp.tools.get_test_coverage_map.shutil.which", lambda _: None)
+    result = get_test_coverage_map(repo_id, "store", run_go_test=True, storage_path=store)
+    assert result["go_coverage"] == {"error": "go not found on PATH"}
+    assert "coverage_pct" not in _by_name(result)["Load"]
+
+
+@pytest.mark.skipif(shutil.which("go") is None, reason="go toolchain not installed")
+def test_go_test_coverage(repo):
+    repo_id, store = repo
+    result = get_test_coverage_map(repo_id, "store", run_go_test=True, storage_path=store)
+    assert "error" not in result["go_coverage"], result["go_coverage"]
+    syms = _by_name(result)
+    assert syms["Load"]["coverage_pct"] == 100.0
+    assert syms["Unused"]["coverage_pct"] == 0.0
@@ -0,0 +1,100 @@
+"""Tests for serve transport selection and concurrent BM25 corpus builds."""
+
+import argparse
+import threading
+
+import pytest
+
+from jcodemunch_mcp import server as server_module
+from jcodemunch_mcp.server import _parse_listen, _resolve_transport
+from jcodemunch_mcp.tools import search_symbols as search_module
+from jcodemunch_mcp.tools.search_symbols import _ensure_bm25_corpus
+
+
+def _args(transport="stdio", host="127.0.0.1", port=8901, listen=None):
+    return argparse.Namespace(transport=transport, host=host, port=port, listen=listen)
+
+
+class TestParseListen:
+    @pytest.mark.parametrize("addr, expected", [
+        ("0.0.0.0:9000", ("0.0.0.0", 9000)),
+        (":9000", ("127.0.0.1", 9000)),
+        ("9000", ("127.0.0.1", 9000)),
+        ("[::1]:9000", ("::1", 9000)),
+        ("localhost:1", ("localhost", 1)),
+    ])
+    def test_valid(self, addr, expected):
+        assert _parse_listen(addr) == expected
+
+    @pytest.mark.parametrize("addr", ["host", "host:", "host:0", "host:70000", "[::1]9000", "host:abc"])
+    def test_invalid(self, addr):
+        with pytest.raises(ValueError):
+            _parse_listen(addr)
+
+
+class TestResolveTransport:
+    def test_stdio_takes_no_address(self):
+        runner, runner_args = _resolve_transport(_args("stdio", listen="0.0.0.0:1"))
+        assert runner is server_module.run_stdio_server
+        assert runner_args == ()
+
+    def test_http_alias(self):
+        runner, runner_args = _resolve_transport(_args("http"))
+        assert runner is server_module.run_streamable_http_server
+        assert runner_args == ("127.0.0.1", 8901)
+
+    def test_listen_overrides_host_and_port(self):
+        runner, runner_args = _resolve_transport(_args("sse", host="10.0.0.1", port=1, listen="0.0.0.0:9100"))
+        assert runner is server_module.run_sse_server
+        assert runner_args == ("0.0.0.0", 9100)
+
+    def test_every_transport_is_registered(self):
+        for name in ("stdio", "http", "sse", "streamable-http"):
+            runner, _ = _resolve_transport(_args(name))
+            assert callable(runner)
+
+
+class _FakeIndex:
+    def __init__(self):
+        self.symbols = [{"id": "a.py::f#function", "file": "a.py", "name": "f", "kind": "function"}]
+        self.imports = {}
+        self.alias_map = {}
+        self._bm25_cache = {}
+
+
+class TestEnsureBm25Corpus:
+    def test_populates_all_keys(self):
+        cache = _ensure_bm25_corpus(_FakeIndex())
+        assert {"idf", "avgdl", "inverted", "centrality"} <= set(cache)
+
+    def test_concurrent_callers_build_once(self, monkeypatch):
+        calls = []
+        real = search_module._compute_bm25
+
+        def slow_bm25(symbols):
+            calls.append(1)
+            return real(symbols)
+
+        monkeypatch.setattr(search_module, "_compute_bm25", slow_bm25)
+        index = _FakeIndex()
+        errors = []
+
+        def worker():
+            try:
+                cache = _ensure_bm25_corpus(index)
+                cache["centrality"], cache["idf"]
+            except Exception as exc:  # pragma: no cover - failure path
+                errors.append(exc)
+
+        threads = [threading.Thread(target=worker) for _ in range(8)]
+        for t in threads:
+            t.start()
+        for t in threads:
+            t.join()
+        assert errors == []
+        assert len(calls) == 1
+
+    def test_keeps_precomputed_centrality(self):
+        index = _FakeIndex()
+        index._bm25_cache["centrality"] = {"a.py": 1.0}
+        assert _ensure_bm25_corpus(index)["centrality"] == {"a.py": 1.0}
@@ -0,0 +1,192 @@
+"""Tests for exported/unexported classification (parser/visibility.py)."""
+
+import textwrap
+
+import pytest
+
+from jcodemunch_mcp.parser.visibility import is_exported, js_exports, python_all_names
+from tests.conftest_helpers import create_go_index
+
+
+class TestPythonAllNames:
+    def test_undefined_returns_none(self):
+        assert python_all_names("def f():\n    pass\n") is None
+
+    def test_literal_list(self):
+        src = '__all__ = ["alpha", \'beta\']\n'
+        assert python_all_names(src) == frozenset({"alpha", "beta"})
+
+    def test_multiline_tuple_with_annotation(self):
+        src = textwrap.dedent('''\
+            __all__: tuple[str, ...] = (
+                "alpha",
+                "beta",
+            )
+        ''')
+        assert python_all_names(src) == frozenset({"alpha", "beta"})
+
+    def test_augmented_assignment_extends(self):
+        src = '__all__ = ["alpha"]\n__all__ += ["beta"]\n'
+        assert python_all_names(src) == frozenset({"alpha", "beta"})
+
+    def test_empty_all_exports_nothing(self):
+        assert python_all_names("__all__ = []\n") == frozenset()
+
+
+class TestIsExported:
+    def test_python_underscore_convention(self):
+        assert is_exported("public", "python") is True
+        assert is_exported("_private", "python") is False
+        assert is_exported("__init__", "python", nested=True) is True
+
+    def test_python_all_overrides_convention_for_top_level(self):
+        module_all = frozenset({"_reexported"})
+        assert is_exported("_reexported", "python", module_all=module_all) is True
+        assert is_exported("public", "python", module_all=module_all) is False
+
+    def test_python_all_ignored_for_members(self):
+        module_all = frozenset({"Repo"})
+        assert is_exported("count", "python", nested=True, module_all=module_all) is True
+
+    def test_member_of_unexported_parent_is_unexported(self):
+        assert is_exported("count", "python", nested=True, parent_exported=False) is False
+
+    def test_go_capitalisation(self):
+        assert is_exported("GetUser", "go") is True
+        assert is_exported("getUser", "go") is False
+
+    def test_unknown_language_returns_none(self):
+        assert is_exported("anything", "cobol") is None
+
+    def test_js_top_level_uses_module_exports(self):
+        exports = frozenset({"loadUser"})
+        assert is_exported("loadUser", "typescript", module_exports=exports) is True
+        assert is_exported("internal", "typescript", module_exports=exports) is False
+
+    def test_js_script_without_exports_is_unknown(self):
+        assert is_exported("loadUser", "javascript") is None
+
+    def test_js_private_members(self):
+        kw = dict(nested=True, parent_exported=True, module_exports=frozenset())
+        assert is_exported("render", "tsx", signature="render()", **kw) is True
+        assert is_exported("cache", "typescript", signature="private cache()", **kw) is False
+        assert is_exported("#secret", "javascript", signature="#secret()", **kw) is False
+
+
+class TestJsExports:
+    def test_declarations(self):
+        src = (
+            "export function a() {}\n"
+            "export async function b() {}\n"
+            "export class C {}\n"
+            "export interface I {}\n"
+            "export type T = string;\n"
+            "export const enum E { X }\n"
+            "function hidden() {}\n"
+        )
+        exports = js_exports(src)
+        assert set(exports) == {"a", "b", "C", "I", "T", "E"}
+        assert exports["a"] == ("a",)
+
+    def test_aliases_and_defaults(self):
+        src = (
+            "function foo() {}\n"
+            "class Widget {}\n"
+            "export { foo as bar, foo };\n"
+            "export default Widget;\n"
+            "export { other } from './other';\n"
+        )
+        exports = js_exports(src)
+        assert exports["foo"] == ("bar", "foo")
+        assert exports["Widget"] == ("default",)
+        assert "other" not in exports
+
+    def test_destructured_exports(self):
+        exports = js_exports("export const { a, b: c, ...rest } = obj;\nexport const [d] = arr;\n")
+        assert set(exports) == {"a", "c", "rest", "d"}
+
+    def test_anonymous_default(self):
+        assert js_exports("export default function () {}\n") == {"default": ("default",)}
+
+    def test_commonjs(self):
+        exports = js_exports("module.exports = { alpha, beta: gamma };\nexports.delta = epsilon;\n")
+        assert exports == {"alpha": ("alpha",), "gamma": ("beta",), "epsilon": ("delta",)}
+
+    def test_script_returns_none(self):
+        assert js_exports("function f() {}\n") is None
+
+
+@pytest.fixture
+def all_index(tmp_path):
+    repo, store = create_go_index(tmp_path, {"mod.py": textwrap.dedent('''\
+        __all__ = ["Repo"]
+
+
+        class Repo:
+            def count(self):
+                return 0
+
+            def _helper(self):
+                return 1
+
+
+        class Hidden:
+            def visible_name(self):
+                return 2
+
+
+        def helper():
+            return 3
+    ''')})
+    return {"repo": repo, "store": store}
+
+
+def test_outline_reports_exported_from_all(all_index):
+    from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+
+    out = get_file_outline(all_index["repo"], file_path="mod.py", storage_path=all_index["store"])
+    by_name = {s["name"]: s for s in out["symbols"]}
+
+    assert by_name["Repo"]["exported"] is True
+    assert by_name["count"]["exported"] is True
+    assert by_name["_helper"]["exported"] is False
+    assert by_name["Hidden"]["exported"] is False
+    assert by_name["visible_name"]["exported"] is False
+    assert by_name["helper"]["exported"] is False
+
+
+def test_outline_reports_js_exports(tmp_path):
+    from jcodemunch_mcp.tools.get_file_outline import get_file_outline
+
+    repo, store = create_go_index(tmp_path, {"widget.ts": textwrap.dedent('''\
+        export class Widget {
+            render() {
+                return 1;
+            }
+
+            private cache() {
+                return 2;
+            }
+        }
+
+        function helper() {
+            return 3;
+        }
+
+        function internal() {
+            return 4;
+        }
+
+        export { helper as publicHelper };
+    ''')})
+
+    out = get_file_outline(repo, file_path="widget.ts", storage_path=store)
+    by_name = {s["name"]: s for s in out["symbols"]}
+
+    assert by_name["Widget"]["exported"] is True
+    assert "exported_as" not in by_name["Widget"]
+    assert by_name["render"]["exported"] is True
+    assert by_name["cache"]["exported"] is False
+    assert by_name["helper"]["exported"] is True
+    assert by_name["helper"]["exported_as"] == "publicHelper"
+    assert by_name["internal"]["exported"] is False
@@ -397,7 +397,6 @@ class TestFastPathHonorsAllDiscoveryFilters:
         """Medium-risk per #306: a file that exceeds the size cap must be
         skipped on the fast path, not silently re-indexed."""
         from jcodemunch_mcp.tools.index_folder import index_folder
-        from jcodemunch_mcp.tools import index_folder as idx_mod
 
         kept_file = tmp_path / "main.py"
         kept_file.write_text("def kept():\n    return 1\n")
@@ -419,12 +418,13 @@ class TestFastPathHonorsAllDiscoveryFilters:
         store = IndexStore(base_path=storage)
         owner, name = result["repo"].split("/", 1)
 
-        # Grow big.py past the size cap. Patch the module-level constant so
-        # the fast-path filter config sees a low cap (real cap is 500 KB;
+        # Grow big.py past the size cap. Lower max_file_size so the
+        # fast-path filter config sees a low cap (real cap is 500 KB;
         # writing that many bytes per test is wasteful).
-        original_cap = idx_mod.DEFAULT_MAX_FILE_SIZE
+        from jcodemunch_mcp import config as config_mod
+        original_cap = config_mod._GLOBAL_CONFIG.get("max_file_size")
         try:
-            idx_mod.DEFAULT_MAX_FILE_SIZE = 200  # bytes
+            config_mod._GLOBAL_CONFIG["max_file_size"] = 200  # bytes
             big_file.write_text("# padding\n" * 100)  # ~1000 bytes
             watcher_changes = [
                 WatcherChange("modified", str(big_file.resolve()), "__cache_miss__"),
@@ -445,7 +445,10 @@ class TestFastPathHonorsAllDiscoveryFilters:
                 f"result: {result2}"
             )
         finally:
-            idx_mod.DEFAULT_MAX_FILE_SIZE = original_cap
+            if original_cap is None:
+                config_mod._GLOBAL_CONFIG.pop("max_file_size", None)
+            else:
+                config_mod._GLOBAL_CONFIG["max_file_size"] = original_cap
 
     def test_added_file_under_skip_dir_not_indexed(self, tmp_path):
         """Per #306: watchfiles can emit events under build/cache dirs that
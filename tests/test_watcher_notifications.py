@@ -0,0 +1,134 @@
+"""Tests for watcher index-update payloads and their push to MCP sessions."""
+
+import asyncio
+
+from jcodemunch_mcp import config as config_module
+from jcodemunch_mcp import server as server_module
+from jcodemunch_mcp.watcher import _MAX_UPDATE_FILES, _call_update_listener, _index_update_payload
+
+
+class _FakeSession:
+    def __init__(self, fail: bool = False):
+        self.messages: list[dict] = []
+        self.updated: list[str] = []
+        self.list_changed = 0
+        self.fail = fail
+
+    async def send_log_message(self, level, data, logger=None):
+        if self.fail:
+            raise RuntimeError("stream closed")
+        self.messages.append({"level": level, "data": data, "logger": logger})
+
+    async def send_resource_updated(self, uri):
+        self.updated.append(str(uri))
+
+    async def send_resource_list_changed(self):
+        self.list_changed += 1
+
+
+class TestIndexUpdatePayload:
+    def test_counts_and_files(self):
+        payload = _index_update_payload(
+            "local/proj-abc",
+            "/src/proj",
+            [("modified", "a.py"), ("deleted", "old.py"), ("added", "new.py")],
+            {"changed": 1, "new": 1, "deleted": 1},
+        )
+        assert payload["repo"] == "local/proj-abc"
+        assert (payload["changed"], payload["new"], payload["deleted"]) == (1, 1, 1)
+        assert payload["files"] == [
+            {"path": "a.py", "change": "modified"},
+            {"path": "old.py", "change": "deleted"},
+            {"path": "new.py", "change": "added"},
+        ]
+        assert payload["files_truncated"] is False
+
+    def test_files_capped(self):
+        changes = [("modified", f"f{i}.py") for i in range(_MAX_UPDATE_FILES + 5)]
+        payload = _index_update_payload("r", "/r", changes, {})
+        assert len(payload["files"]) == _MAX_UPDATE_FILES
+        assert payload["files_truncated"] is True
+
+
+class TestUpdateListener:
+    def test_sync_and_async_listeners(self):
+        seen = []
+
+        async def async_listener(p):
+            seen.append(("async", p["repo"]))
+
+        async def run():
+            await _call_update_listener(lambda p: seen.append(("sync", p["repo"])), {"repo": "x"})
+            await _call_update_listener(async_listener, {"repo": "y"})
+
+        asyncio.run(run())
+        assert seen == [("sync", "x"), ("async", "y")]
+
+    def test_failing_listener_is_swallowed(self):
+        def boom(_p):
+            raise ValueError("nope")
+
+        asyncio.run(_call_update_listener(boom, {"repo": "x"}))
+
+
+class TestEmitIndexUpdated:
+    def setup_method(self):
+        self._saved = set(server_module._notify_sessions)
+        server_module._notify_sessions.clear()
+
+    def teardown_method(self):
+        server_module._notify_sessions.clear()
+        for s in self._saved:
+            server_module._notify_sessions.add(s)
+
+    def test_sends_log_message_to_sessions(self):
+        session = _FakeSession()
+        server_module._notify_sessions.add(session)
+        asyncio.run(server_module._emit_index_updated({"repo": "local/p", "changed": 2}))
+        assert len(session.messages) == 1
+        msg = session.messages[0]
+        assert msg["logger"] == "jcodemunch.watch"
+        assert msg["data"]["event"] == "index_updated"
+        assert msg["data"]["repo"] == "local/p"
+
+    def test_modified_files_send_resource_updates(self):
+        session = _FakeSession()
+        server_module._notify_sessions.add(session)
+        payload = {"repo": "local/p", "files": [
+            {"path": "pkg/a.go", "change": "modified"},
+            {"path": "pkg/b.go", "change": "modified"},
+        ]}
+        asyncio.run(server_module._emit_index_updated(payload))
+        assert session.updated == [
+            "codemunch://file/local/p/pkg/a.go",
+            "codemunch://symbols/local/p/pkg",
+            "codemunch://file/local/p/pkg/b.go",
+        ]
+        assert session.list_changed == 0
+
+    def test_added_and_deleted_files_change_the_list(self):
+        session = _FakeSession()
+        server_module._notify_sessions.add(session)
+        payload = {"repo": "local/p", "files": [
+            {"path": "new.go", "change": "added"},
+            {"path": "old.go", "change": "deleted"},
+        ]}
+        asyncio.run(server_module._emit_index_updated(payload))
+        assert session.updated == ["codemunch://symbols/local/p/."]
+        assert session.list_changed == 1
+
+    def test_failed_session_is_forgotten(self):
+        bad = _FakeSession(fail=True)
+        good = _FakeSession()
+        server_module._notify_sessions.add(bad)
+        server_module._notify_sessions.add(good)
+        asyncio.run(server_module._emit_index_updated({"repo": "r"}))
+        assert bad not in server_module._notify_sessions
+        assert len(good.messages) == 1
+
+    def test_disabled_by_config(self, monkeypatch):
+        session = _FakeSession()
+        server_module._notify_sessions.add(session)
+        monkeypatch.setitem(config_module._GLOBAL_CONFIG, "watch_notifications", False)
+        asyncio.run(server_module._emit_index_updated({"repo": "r"}))
+        assert session.messages == []